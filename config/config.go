@@ -43,15 +43,16 @@ var (
 
 // Config contains configuration options.
 type Config struct {
-	Host             string `toml:"host" json:"host"`
-	AdvertiseAddress string `toml:"advertise-address" json:"advertise-address"`
-	Port             uint   `toml:"port" json:"port"`
-	Cors             string `toml:"cors" json:"cors"`
-	Store            string `toml:"store" json:"store"`
-	Path             string `toml:"path" json:"path"`
-	Lease            string `toml:"lease" json:"lease"`
-	Log              Log    `toml:"log" json:"log"`
-	Status           Status `toml:"status" json:"status"`
+	Host             string   `toml:"host" json:"host"`
+	AdvertiseAddress string   `toml:"advertise-address" json:"advertise-address"`
+	Port             uint     `toml:"port" json:"port"`
+	Cors             string   `toml:"cors" json:"cors"`
+	Store            string   `toml:"store" json:"store"`
+	Path             string   `toml:"path" json:"path"`
+	Lease            string   `toml:"lease" json:"lease"`
+	Log              Log      `toml:"log" json:"log"`
+	Status           Status   `toml:"status" json:"status"`
+	Security         Security `toml:"security" json:"security"`
 }
 
 // Log is the log section of config.
@@ -84,6 +85,23 @@ type Status struct {
 	ReportStatus bool `toml:"report-status" json:"report-status"`
 }
 
+// Security is the security section of the config.
+type Security struct {
+	// SSLCA, SSLCert and SSLKey are the paths of the PEM files used by the
+	// MySQL protocol listener, TLS is enabled when the certificate and the key are given.
+	SSLCA   string `toml:"ssl-ca" json:"ssl-ca"`
+	SSLCert string `toml:"ssl-cert" json:"ssl-cert"`
+	SSLKey  string `toml:"ssl-key" json:"ssl-key"`
+	// AutoTLS generates a self-signed certificate under AutoTLSDir when
+	// SSLCert and SSLKey are not given.
+	AutoTLS bool `toml:"auto-tls" json:"auto-tls"`
+	// AutoTLSDir is the directory of the self-signed certificate, the system
+	// temporary directory is used if it's empty.
+	AutoTLSDir string `toml:"auto-tls-dir" json:"auto-tls-dir"`
+	// RequireSecureTransport rejects the client connections which don't use TLS.
+	RequireSecureTransport bool `toml:"require-secure-transport" json:"require-secure-transport"`
+}
+
 var defaultConf = Config{
 	Host:             "0.0.0.0",
 	AdvertiseAddress: "",
@@ -101,6 +119,14 @@ var defaultConf = Config{
 		StatusHost:   "0.0.0.0",
 		StatusPort:   10080,
 	},
+	Security: Security{
+		SSLCA:                  "",
+		SSLCert:                "",
+		SSLKey:                 "",
+		AutoTLS:                false,
+		AutoTLSDir:             "",
+		RequireSecureTransport: false,
+	},
 }
 
 var (
@@ -398,6 +398,9 @@ func (w *worker) doModifyColumn(t *meta.Meta, job *model.Job, newCol *model.Colu
 			}
 		}
 	}
+	if newCol.Name.L != oldName.L {
+		renameFKColumns(dbInfo.Name, tblInfo, *oldName, newCol.Name)
+	}
 
 	ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
 	if err != nil {
@@ -87,6 +87,7 @@ var (
 	errTooManyFields            = terror.ClassDDL.New(mysql.ErrTooManyFields, mysql.MySQLErrName[mysql.ErrTooManyFields])
 	errInvalidSplitRegionRanges = terror.ClassDDL.New(mysql.ErrInvalidSplitRegionRanges, mysql.MySQLErrName[mysql.ErrInvalidSplitRegionRanges])
 	errReorgPanic               = terror.ClassDDL.New(mysql.ErrReorgPanic, mysql.MySQLErrName[mysql.ErrReorgPanic])
+	errFileNotFound             = terror.ClassDDL.New(mysql.ErrFileNotFound, "Can't find file: './%s/%s.frm'")
 
 	// errWrongKeyColumn is for table column cannot be indexed.
 	errWrongKeyColumn = terror.ClassDDL.New(mysql.ErrWrongKeyColumn, mysql.MySQLErrName[mysql.ErrWrongKeyColumn])
@@ -102,6 +103,9 @@ var (
 	// ErrGeneratedColumnFunctionIsNotAllowed returns for unsupported functions for generated columns.
 	ErrGeneratedColumnFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrGeneratedColumnFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrGeneratedColumnFunctionIsNotAllowed])
 	errUnsupportedIndexType                = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "index type"))
+	errUnsupportedPartitionColumns         = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "partition by multiple columns"))
+	errUnsupportedAddPartition             = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "add partitions to a HASH partitioned table"))
+	errDependentByPartitionExpr            = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "%s column which is used by the partition expression"))
 
 	// ErrDupKeyName returns for duplicated key name
 	ErrDupKeyName = terror.ClassDDL.New(mysql.ErrDupKeyName, mysql.MySQLErrName[mysql.ErrDupKeyName])
@@ -146,12 +150,84 @@ var (
 
 	// ErrPartitionMgmtOnNonpartitioned returns it's not a partition table.
 	ErrPartitionMgmtOnNonpartitioned = terror.ClassDDL.New(mysql.ErrPartitionMgmtOnNonpartitioned, mysql.MySQLErrName[mysql.ErrPartitionMgmtOnNonpartitioned])
+	// ErrDropPartitionNonExistent returns error in list of partition.
+	ErrDropPartitionNonExistent = terror.ClassDDL.New(mysql.ErrDropPartitionNonExistent, mysql.MySQLErrName[mysql.ErrDropPartitionNonExistent])
+	// ErrSameNamePartition returns duplicate partition name.
+	ErrSameNamePartition = terror.ClassDDL.New(mysql.ErrSameNamePartition, mysql.MySQLErrName[mysql.ErrSameNamePartition])
+	// ErrRangeNotIncreasing returns values less than value must be strictly increasing for each partition.
+	ErrRangeNotIncreasing = terror.ClassDDL.New(mysql.ErrRangeNotIncreasing, mysql.MySQLErrName[mysql.ErrRangeNotIncreasing])
+	// ErrPartitionMaxvalue returns maxvalue can only be used in last partition definition.
+	ErrPartitionMaxvalue = terror.ClassDDL.New(mysql.ErrPartitionMaxvalue, mysql.MySQLErrName[mysql.ErrPartitionMaxvalue])
+	// ErrNullInValuesLessThan returns not allowed to use NULL value in VALUES LESS THAN.
+	ErrNullInValuesLessThan = terror.ClassDDL.New(mysql.ErrNullInValuesLessThan, mysql.MySQLErrName[mysql.ErrNullInValuesLessThan])
+	// ErrMultipleDefConstInListPart returns multiple definition of same constant in list partitioning.
+	ErrMultipleDefConstInListPart = terror.ClassDDL.New(mysql.ErrMultipleDefConstInListPart, mysql.MySQLErrName[mysql.ErrMultipleDefConstInListPart])
+	// ErrDropLastPartition returns cannot remove all partitions, use drop table instead.
+	ErrDropLastPartition = terror.ClassDDL.New(mysql.ErrDropLastPartition, mysql.MySQLErrName[mysql.ErrDropLastPartition])
+	// ErrTooManyPartitions returns too many partitions were defined.
+	ErrTooManyPartitions = terror.ClassDDL.New(mysql.ErrTooManyPartitions, mysql.MySQLErrName[mysql.ErrTooManyPartitions])
+	// ErrNoParts returns the number of partitions is zero.
+	ErrNoParts = terror.ClassDDL.New(mysql.ErrNoParts, mysql.MySQLErrName[mysql.ErrNoParts])
+	// ErrPartitionFunctionIsNotAllowed returns this partition function is not allowed.
+	ErrPartitionFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrPartitionFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrPartitionFunctionIsNotAllowed])
+	// ErrPartitionFuncNotAllowed returns partition function returns the wrong type.
+	ErrPartitionFuncNotAllowed = terror.ClassDDL.New(mysql.ErrPartitionFuncNotAllowed, mysql.MySQLErrName[mysql.ErrPartitionFuncNotAllowed])
+	// ErrUniqueKeyNeedAllFieldsInPf returns must include all columns in the table's partitioning function.
+	ErrUniqueKeyNeedAllFieldsInPf = terror.ClassDDL.New(mysql.ErrUniqueKeyNeedAllFieldsInPf, mysql.MySQLErrName[mysql.ErrUniqueKeyNeedAllFieldsInPf])
+	// ErrWrongExprInPartitionFunc returns constant, random or timezone-dependent expressions in (sub)partitioning function are not permitted.
+	ErrWrongExprInPartitionFunc = terror.ClassDDL.New(mysql.ErrWrongExprInPartitionFunc, mysql.MySQLErrName[mysql.ErrWrongExprInPartitionFunc])
+	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
+	ErrFieldNotFoundPart = terror.ClassDDL.New(mysql.ErrFieldNotFoundPart, mysql.MySQLErrName[mysql.ErrFieldNotFoundPart])
+	// ErrFieldTypeNotAllowedAsPartitionField returns the field type is not allowed as partition field.
+	ErrFieldTypeNotAllowedAsPartitionField = terror.ClassDDL.New(mysql.ErrFieldTypeNotAllowedAsPartitionField, mysql.MySQLErrName[mysql.ErrFieldTypeNotAllowedAsPartitionField])
+	// ErrPartitionRequiresValues returns an error when a partition definition misses its VALUES clause.
+	ErrPartitionRequiresValues = terror.ClassDDL.New(mysql.ErrPartitionRequiresValues, mysql.MySQLErrName[mysql.ErrPartitionRequiresValues])
+	// ErrPartitionWrongValues returns an error when a partition definition uses the VALUES clause of another partition type.
+	ErrPartitionWrongValues = terror.ClassDDL.New(mysql.ErrPartitionWrongValues, mysql.MySQLErrName[mysql.ErrPartitionWrongValues])
+	// ErrPartitionsMustBeDefined returns an error when the partitions of a RANGE/LIST table are not defined.
+	ErrPartitionsMustBeDefined = terror.ClassDDL.New(mysql.ErrPartitionsMustBeDefined, mysql.MySQLErrName[mysql.ErrPartitionsMustBeDefined])
+	// ErrPartitionWrongNoPart returns an error when the PARTITIONS number mismatches the partition definitions.
+	ErrPartitionWrongNoPart = terror.ClassDDL.New(mysql.ErrPartitionWrongNoPart, mysql.MySQLErrName[mysql.ErrPartitionWrongNoPart])
+	// ErrPartitionColumnList returns an error when the column list of a partition definition is inconsistent.
+	ErrPartitionColumnList = terror.ClassDDL.New(mysql.ErrPartitionColumnList, mysql.MySQLErrName[mysql.ErrPartitionColumnList])
+	// ErrValuesIsNotIntType returns an error when a partition value is not an integer for an integer partition function.
+	ErrValuesIsNotIntType = terror.ClassDDL.New(mysql.ErrValuesIsNotIntType, mysql.MySQLErrName[mysql.ErrValuesIsNotIntType])
+	// ErrWrongTypeColumnValue returns an error when a partition value can't be converted to the type of the partition column.
+	ErrWrongTypeColumnValue = terror.ClassDDL.New(mysql.ErrWrongTypeColumnValue, mysql.MySQLErrName[mysql.ErrWrongTypeColumnValue])
+	// ErrOnlyOnRangeListPartition returns an error when a partition management is only supported by RANGE/LIST partitioning.
+	ErrOnlyOnRangeListPartition = terror.ClassDDL.New(mysql.ErrOnlyOnRangeListPartition, mysql.MySQLErrName[mysql.ErrOnlyOnRangeListPartition])
 	// ErrWarnDataTruncated returns data truncated error.
 	ErrWarnDataTruncated = terror.ClassDDL.New(mysql.WarnDataTruncated, mysql.MySQLErrName[mysql.WarnDataTruncated])
 	// ErrAlterOperationNotSupported returns when alter operations is not supported.
 	ErrAlterOperationNotSupported = terror.ClassDDL.New(mysql.ErrAlterOperationNotSupportedReason, mysql.MySQLErrName[mysql.ErrAlterOperationNotSupportedReason])
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = terror.ClassDDL.New(mysql.ErrTableCantHandleFt, mysql.MySQLErrName[mysql.ErrTableCantHandleFt])
+	// ErrWrongFkDef returns for a foreign key whose columns don't match the referenced columns.
+	ErrWrongFkDef = terror.ClassDDL.New(mysql.ErrWrongFkDef, mysql.MySQLErrName[mysql.ErrWrongFkDef])
+	// ErrFkNoIndexChild returns when the referencing columns aren't the leading columns of an index.
+	ErrFkNoIndexChild = terror.ClassDDL.New(mysql.ErrFkNoIndexChild, mysql.MySQLErrName[mysql.ErrFkNoIndexChild])
+	// ErrFkNoIndexParent returns when the referenced columns aren't the primary key or the leading columns of an index.
+	ErrFkNoIndexParent = terror.ClassDDL.New(mysql.ErrFkNoIndexParent, mysql.MySQLErrName[mysql.ErrFkNoIndexParent])
+	// ErrFkCannotOpenParent returns when the referenced table doesn't exist.
+	ErrFkCannotOpenParent = terror.ClassDDL.New(mysql.ErrFkCannotOpenParent, mysql.MySQLErrName[mysql.ErrFkCannotOpenParent])
+	// ErrFkDupName returns for a duplicate foreign key name.
+	ErrFkDupName = terror.ClassDDL.New(mysql.ErrFkDupName, mysql.MySQLErrName[mysql.ErrFkDupName])
+	// ErrFkColumnNotNull returns when a SET NULL foreign key is defined on a NOT NULL column.
+	ErrFkColumnNotNull = terror.ClassDDL.New(mysql.ErrFkColumnNotNull, mysql.MySQLErrName[mysql.ErrFkColumnNotNull])
+	// ErrFkColumnCannotDrop returns when dropping a column used by a foreign key.
+	ErrFkColumnCannotDrop = terror.ClassDDL.New(mysql.ErrFkColumnCannotDrop, mysql.MySQLErrName[mysql.ErrFkColumnCannotDrop])
+	// ErrFKIncompatibleColumns returns when the referencing and the referenced columns have incompatible types.
+	ErrFKIncompatibleColumns = terror.ClassDDL.New(mysql.ErrFKIncompatibleColumns, mysql.MySQLErrName[mysql.ErrFKIncompatibleColumns])
+	// ErrForeignKeyOnPartitioned returns when a foreign key references or is defined on a partitioned table.
+	ErrForeignKeyOnPartitioned = terror.ClassDDL.New(mysql.ErrForeignKeyOnPartitioned, mysql.MySQLErrName[mysql.ErrForeignKeyOnPartitioned])
+	// ErrDropIndexFk returns when dropping an index needed by a foreign key.
+	ErrDropIndexFk = terror.ClassDDL.New(mysql.ErrDropIndexFk, mysql.MySQLErrName[mysql.ErrDropIndexFk])
+	// ErrTruncateIllegalFk returns when truncating a table referenced by a foreign key.
+	ErrTruncateIllegalFk = terror.ClassDDL.New(mysql.ErrTruncateIllegalFk, mysql.MySQLErrName[mysql.ErrTruncateIllegalFk])
+	// ErrRowIsReferenced returns when dropping a table referenced by a foreign key.
+	ErrRowIsReferenced = terror.ClassDDL.New(mysql.ErrRowIsReferenced, mysql.MySQLErrName[mysql.ErrRowIsReferenced])
+	// ErrNoReferencedRow2 returns when adding a foreign key which an existing row doesn't satisfy.
+	ErrNoReferencedRow2 = terror.ClassDDL.New(mysql.ErrNoReferencedRow2, mysql.MySQLErrName[mysql.ErrNoReferencedRow2])
 )
 
 // DDL is responsible for updating schema in data store and maintaining in-memory InfoSchema cache.
@@ -160,6 +236,9 @@ type DDL interface {
 	DropSchema(ctx sessionctx.Context, schema model.CIStr) error
 	CreateTable(ctx sessionctx.Context, stmt *ast.CreateTableStmt) error
 	DropTable(ctx sessionctx.Context, tableIdent ast.Ident) (err error)
+	TruncateTable(ctx sessionctx.Context, tableIdent ast.Ident) error
+	RenameTable(ctx sessionctx.Context, oldTableIdent, newTableIdent ast.Ident, isAlterTable bool) error
+	RenameTables(ctx sessionctx.Context, oldTableIdents, newTableIdents []ast.Ident) error
 	CreateIndex(ctx sessionctx.Context, tableIdent ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 		columnNames []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error
 	DropIndex(ctx sessionctx.Context, tableIdent ast.Ident, indexName model.CIStr, ifExists bool) error
@@ -291,8 +370,14 @@ func (d *ddl) start(ctx context.Context, ctxPool *pools.ResourcePool) {
 
 	d.workers = make(map[workerType]*worker, 2)
 	d.sessPool = newSessionPool(ctxPool)
-	d.workers[generalWorker] = newWorker(generalWorker, d.sessPool)
-	d.workers[addIdxWorker] = newWorker(addIdxWorker, d.sessPool)
+	var delRangeMgr delRangeManager
+	if ctxPool != nil {
+		delRangeMgr = newDelRangeManager(d.sessPool)
+	} else {
+		delRangeMgr = newMockDelRangeManager()
+	}
+	d.workers[generalWorker] = newWorker(generalWorker, d.sessPool, delRangeMgr)
+	d.workers[addIdxWorker] = newWorker(addIdxWorker, d.sessPool, delRangeMgr)
 	for _, worker := range d.workers {
 		worker.wg.Add(1)
 		w := worker
@@ -329,6 +414,7 @@ func (d *ddl) close() {
 
 	startTime := time.Now()
 	close(d.quitCh)
+	variable.UnregisterStatistics(d)
 	d.ownerManager.Cancel()
 	d.schemaSyncer.CloseCleanWork()
 	err := d.schemaSyncer.RemoveSelfVersionPath()
@@ -499,6 +585,7 @@ func init() {
 		mysql.ErrCollationCharsetMismatch:             mysql.ErrCollationCharsetMismatch,
 		mysql.ErrConflictingDeclarations:              mysql.ErrConflictingDeclarations,
 		mysql.ErrDependentByGeneratedColumn:           mysql.ErrDependentByGeneratedColumn,
+		mysql.ErrDropIndexFk:                          mysql.ErrDropIndexFk,
 		mysql.ErrDropLastPartition:                    mysql.ErrDropLastPartition,
 		mysql.ErrDropPartitionNonExistent:             mysql.ErrDropPartitionNonExistent,
 		mysql.ErrDupKeyName:                           mysql.ErrDupKeyName,
@@ -506,6 +593,14 @@ func init() {
 		mysql.ErrFieldNotFoundPart:                    mysql.ErrFieldNotFoundPart,
 		mysql.ErrFieldTypeNotAllowedAsPartitionField:  mysql.ErrFieldTypeNotAllowedAsPartitionField,
 		mysql.ErrFileNotFound:                         mysql.ErrFileNotFound,
+		mysql.ErrFkCannotOpenParent:                   mysql.ErrFkCannotOpenParent,
+		mysql.ErrFkColumnCannotDrop:                   mysql.ErrFkColumnCannotDrop,
+		mysql.ErrFkColumnNotNull:                      mysql.ErrFkColumnNotNull,
+		mysql.ErrFkDupName:                            mysql.ErrFkDupName,
+		mysql.ErrFKIncompatibleColumns:                mysql.ErrFKIncompatibleColumns,
+		mysql.ErrFkNoIndexChild:                       mysql.ErrFkNoIndexChild,
+		mysql.ErrFkNoIndexParent:                      mysql.ErrFkNoIndexParent,
+		mysql.ErrForeignKeyOnPartitioned:              mysql.ErrForeignKeyOnPartitioned,
 		mysql.ErrGeneratedColumnFunctionIsNotAllowed:  mysql.ErrGeneratedColumnFunctionIsNotAllowed,
 		mysql.ErrGeneratedColumnNonPrior:              mysql.ErrGeneratedColumnNonPrior,
 		mysql.ErrGeneratedColumnRefAutoInc:            mysql.ErrGeneratedColumnRefAutoInc,
@@ -523,8 +618,11 @@ func init() {
 		mysql.ErrJSONUsedAsKey:                        mysql.ErrJSONUsedAsKey,
 		mysql.ErrKeyColumnDoesNotExits:                mysql.ErrKeyColumnDoesNotExits,
 		mysql.ErrLockWaitTimeout:                      mysql.ErrLockWaitTimeout,
+		mysql.ErrMultipleDefConstInListPart:           mysql.ErrMultipleDefConstInListPart,
 		mysql.ErrNoParts:                              mysql.ErrNoParts,
+		mysql.ErrNoReferencedRow2:                     mysql.ErrNoReferencedRow2,
 		mysql.ErrNotOwner:                             mysql.ErrNotOwner,
+		mysql.ErrNullInValuesLessThan:                 mysql.ErrNullInValuesLessThan,
 		mysql.ErrOnlyOnRangeListPartition:             mysql.ErrOnlyOnRangeListPartition,
 		mysql.ErrPartitionColumnList:                  mysql.ErrPartitionColumnList,
 		mysql.ErrPartitionFuncNotAllowed:              mysql.ErrPartitionFuncNotAllowed,
@@ -538,6 +636,7 @@ func init() {
 		mysql.ErrPartitionsMustBeDefined:              mysql.ErrPartitionsMustBeDefined,
 		mysql.ErrPrimaryCantHaveNull:                  mysql.ErrPrimaryCantHaveNull,
 		mysql.ErrRangeNotIncreasing:                   mysql.ErrRangeNotIncreasing,
+		mysql.ErrRowIsReferenced:                      mysql.ErrRowIsReferenced,
 		mysql.ErrRowSinglePartitionField:              mysql.ErrRowSinglePartitionField,
 		mysql.ErrSameNamePartition:                    mysql.ErrSameNamePartition,
 		mysql.ErrSubpartition:                         mysql.ErrSubpartition,
@@ -550,16 +649,19 @@ func init() {
 		mysql.ErrTooManyFields:                        mysql.ErrTooManyFields,
 		mysql.ErrTooManyPartitions:                    mysql.ErrTooManyPartitions,
 		mysql.ErrTooManyValues:                        mysql.ErrTooManyValues,
+		mysql.ErrTruncateIllegalFk:                    mysql.ErrTruncateIllegalFk,
 		mysql.ErrUniqueKeyNeedAllFieldsInPf:           mysql.ErrUniqueKeyNeedAllFieldsInPf,
 		mysql.ErrUnknownCharacterSet:                  mysql.ErrUnknownCharacterSet,
 		mysql.ErrUnknownCollation:                     mysql.ErrUnknownCollation,
 		mysql.ErrUnknownPartition:                     mysql.ErrUnknownPartition,
 		mysql.ErrUnsupportedDDLOperation:              mysql.ErrUnsupportedDDLOperation,
 		mysql.ErrUnsupportedOnGeneratedColumn:         mysql.ErrUnsupportedOnGeneratedColumn,
+		mysql.ErrValuesIsNotIntType:                   mysql.ErrValuesIsNotIntType,
 		mysql.ErrViewWrongList:                        mysql.ErrViewWrongList,
 		mysql.ErrWrongColumnName:                      mysql.ErrWrongColumnName,
 		mysql.ErrWrongDBName:                          mysql.ErrWrongDBName,
 		mysql.ErrWrongExprInPartitionFunc:             mysql.ErrWrongExprInPartitionFunc,
+		mysql.ErrWrongFkDef:                           mysql.ErrWrongFkDef,
 		mysql.ErrWrongFKOptionForGeneratedColumn:      mysql.ErrWrongFKOptionForGeneratedColumn,
 		mysql.ErrWrongKeyColumn:                       mysql.ErrWrongKeyColumn,
 		mysql.ErrWrongNameForIndex:                    mysql.ErrWrongNameForIndex,
@@ -827,6 +827,17 @@ func buildTableInfoWithCheck(ctx sessionctx.Context, d *ddl, s *ast.CreateTableS
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Foreign keys are built after the indices, they don't share the names with indices.
+	var fkConstraints []*ast.Constraint
+	idxConstraints := newConstraints[:0]
+	for _, constr := range newConstraints {
+		if constr.Tp == ast.ConstraintForeignKey {
+			fkConstraints = append(fkConstraints, constr)
+		} else {
+			idxConstraints = append(idxConstraints, constr)
+		}
+	}
+	newConstraints = idxConstraints
 
 	err = checkConstraintNames(newConstraints)
 	if err != nil {
@@ -840,6 +851,16 @@ func buildTableInfoWithCheck(ctx sessionctx.Context, d *ddl, s *ast.CreateTableS
 	}
 	tbInfo.Charset, tbInfo.Collate = charset.GetDefaultCharsetAndCollate()
 
+	if err = buildTablePartitionInfo(d, s.Partition, tbInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = checkTablePartitionInfo(ctx, tbInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = buildTableForeignKeys(ident.Schema, tbInfo, fkConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	return tbInfo, nil
 }
 
@@ -863,6 +884,13 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if ctx.GetSessionVars().ForeignKeyChecks {
+		for _, fkInfo := range tbInfo.ForeignKeys {
+			if err = checkFKReferences(is, ident.Schema, tbInfo, fkInfo); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 	tbInfo.State = model.StatePublic
 	err = checkTableInfoValid(tbInfo)
 	if err != nil {
@@ -943,6 +971,8 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 			err = d.DropIndex(ctx, ident, model.NewCIStr(spec.Name), spec.IfExists)
 		case ast.AlterTableDropPrimaryKey:
 			err = d.dropIndex(ctx, ident, true, model.NewCIStr(mysql.PrimaryKeyName), spec.IfExists)
+		case ast.AlterTableDropForeignKey:
+			err = d.DropForeignKey(ctx, ident, model.NewCIStr(spec.Name), spec.IfExists)
 		case ast.AlterTableAddConstraint:
 			constr := spec.Constraint
 			switch spec.Constraint.Tp {
@@ -956,6 +986,8 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 				err = d.CreatePrimaryKey()
 			case ast.ConstraintFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+			case ast.ConstraintForeignKey:
+				err = d.CreateForeignKey(ctx, ident, constr)
 			default:
 				// Nothing to do now.
 			}
@@ -965,6 +997,20 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 			err = d.ChangeColumn(ctx, ident, spec)
 		case ast.AlterTableAlterColumn:
 			err = d.AlterColumn(ctx, ident, spec)
+		case ast.AlterTableRenameColumn:
+			err = d.RenameColumn(ctx, ident, spec)
+		case ast.AlterTableRenameIndex:
+			err = d.RenameIndex(ctx, ident, spec)
+		case ast.AlterTableRenameTable:
+			newIdent := ast.Ident{Schema: spec.NewTable.Schema, Name: spec.NewTable.Name}
+			isAlterTable := true
+			err = d.RenameTable(ctx, ident, newIdent, isAlterTable)
+		case ast.AlterTableAddPartitions:
+			err = d.AddTablePartitions(ctx, ident, spec)
+		case ast.AlterTableDropPartition:
+			err = d.DropTablePartition(ctx, ident, spec)
+		case ast.AlterTableTruncatePartition:
+			err = d.TruncateTablePartition(ctx, ident, spec)
 		case ast.AlterTablePartition:
 			// Prevent silent succeed if user executes ALTER TABLE x PARTITION BY ...
 			err = errors.New("alter table partition is unsupported")
@@ -1133,6 +1179,12 @@ func (d *ddl) DropColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTa
 	if col.IsPKHandleColumn(tblInfo) {
 		return errUnsupportedPKHandle
 	}
+	if in, err := isColumnInPartitionExpr(ctx, tblInfo, colName); err != nil || in {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errDependentByPartitionExpr.GenWithStackByArgs("drop")
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -1345,6 +1397,9 @@ func (d *ddl) getModifiableColumnJob(ctx sessionctx.Context, ident ast.Ident, or
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if err = checkRenameReferredColumn(ctx, is, schema.Name, t.Meta(), col.Name, "CHANGE COLUMN"); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -1433,6 +1488,9 @@ func (d *ddl) getModifiableColumnJob(ctx sessionctx.Context, ident ast.Ident, or
 	if err = checkColumnWithIndexConstraint(t.Meta(), col.ColumnInfo, newCol.ColumnInfo); err != nil {
 		return nil, err
 	}
+	if err = checkModifyPartitionColumn(ctx, t.Meta(), col.ColumnInfo, newCol.ColumnInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -1519,6 +1577,57 @@ func (d *ddl) ChangeColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 	return errors.Trace(err)
 }
 
+// RenameColumn renames an existing column without changing its definition,
+// so it only updates the table info.
+func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	oldColName := spec.OldColumnName.Name
+	newColName := spec.NewColumnName.Name
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	oldCol := table.FindCol(t.Cols(), oldColName.L)
+	if oldCol == nil {
+		return infoschema.ErrColumnNotExists.GenWithStackByArgs(oldColName, ident.Name)
+	}
+	if oldCol.Name.O == newColName.O {
+		// Nothing to do.
+		return nil
+	}
+	// Renaming a column to the same name with another letter case is allowed.
+	if newColName.L != oldColName.L && table.FindCol(t.Cols(), newColName.L) != nil {
+		return infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
+	}
+	if err = checkTooLongColumn([]interface{}{newColName}); err != nil {
+		return errors.Trace(err)
+	}
+	if newColName.L != oldColName.L {
+		err = checkRenameReferredColumn(ctx, d.GetInfoSchemaWithInterceptor(ctx), schema.Name, t.Meta(), oldCol.Name, "RENAME COLUMN")
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	newCol := oldCol.ColumnInfo.Clone()
+	newCol.Name = newColName
+	if err = checkModifyPartitionColumn(ctx, t.Meta(), oldCol.ColumnInfo, newCol); err != nil {
+		return errors.Trace(err)
+	}
+	var modifyColumnTp byte
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionModifyColumn,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{newCol, oldColName, modifyColumnTp},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 // ModifyColumn does modification on an existing column, currently we only support limited kind of changes
 // that do not need to change or check data on the table.
 func (d *ddl) ModifyColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
@@ -1743,6 +1852,12 @@ func (d *ddl) DropTable(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	err = checkTableNotReferred(ctx, d.GetInfoSchemaWithInterceptor(ctx), schema.Name, tb.Meta(), func(*infoschema.ReferredFKInfo) error {
+		return ErrRowIsReferenced.GenWithStackByArgs()
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -1757,6 +1872,187 @@ func (d *ddl) DropTable(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	return errors.Trace(err)
 }
 
+// TruncateTable empties the table by replacing it with a new table ID.
+func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = checkTableNotReferred(ctx, d.GetInfoSchemaWithInterceptor(ctx), schema.Name, tb.Meta(), func(referredFK *infoschema.ReferredFKInfo) error {
+		return ErrTruncateIllegalFk.GenWithStackByArgs(referredFK.String())
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Partitions of the table get new IDs as well.
+	genIDs, err := d.genGlobalIDs(1 + len(getPartitionIDs(tb.Meta())))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newTableID := genIDs[0]
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionTruncateTable,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{newTableID, genIDs[1:]},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// RenameIndex renames an index of the table, it only updates the table info.
+func (d *ddl) RenameIndex(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	duplicate, err := validateRenameIndex(spec.FromKey, spec.ToKey, t.Meta())
+	if duplicate {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionRenameIndex,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{spec.FromKey, spec.ToKey},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// RenameTable renames oldIdent to newIdent, newIdent may be in another database.
+func (d *ddl) RenameTable(ctx sessionctx.Context, oldIdent, newIdent ast.Ident, isAlterTable bool) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	if isAlterTable && oldIdent.Schema.L == newIdent.Schema.L && oldIdent.Name.L == newIdent.Name.L {
+		if !is.TableExists(oldIdent.Schema, oldIdent.Name) {
+			return infoschema.ErrTableNotExists.GenWithStackByArgs(oldIdent.Schema, oldIdent.Name)
+		}
+		// oldIdent is equal to newIdent, do nothing.
+		return nil
+	}
+	oldSchemaID, newSchemaID, tableID, err := checkRenameTable(ctx, is, oldIdent, newIdent, isAlterTable, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   newSchemaID,
+		TableID:    tableID,
+		SchemaName: newIdent.Schema.L,
+		Type:       model.ActionRenameTable,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{oldSchemaID, newIdent.Name},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// RenameTables renames all the tables of a RENAME TABLE statement in one DDL job,
+// the renames are applied from left to right so that they can swap table names.
+func (d *ddl) RenameTables(ctx sessionctx.Context, oldIdents, newIdents []ast.Ident) error {
+	if len(oldIdents) == 1 {
+		return d.RenameTable(ctx, oldIdents[0], newIdents[0], false)
+	}
+
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	oldSchemaIDs := make([]int64, 0, len(oldIdents))
+	newSchemaIDs := make([]int64, 0, len(oldIdents))
+	tableNames := make([]model.CIStr, 0, len(oldIdents))
+	tableIDs := make([]int64, 0, len(oldIdents))
+	renamed := make(map[string]int64, 2*len(oldIdents))
+	for i := range oldIdents {
+		oldSchemaID, newSchemaID, tableID, err := checkRenameTable(ctx, is, oldIdents[i], newIdents[i], false, renamed)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		renamed[renameKey(oldIdents[i])] = 0
+		renamed[renameKey(newIdents[i])] = tableID
+		oldSchemaIDs = append(oldSchemaIDs, oldSchemaID)
+		newSchemaIDs = append(newSchemaIDs, newSchemaID)
+		tableNames = append(tableNames, newIdents[i].Name)
+		tableIDs = append(tableIDs, tableID)
+	}
+
+	job := &model.Job{
+		SchemaID:   newSchemaIDs[0],
+		TableID:    tableIDs[0],
+		SchemaName: newIdents[0].Schema.L,
+		Type:       model.ActionRenameTables,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{oldSchemaIDs, newSchemaIDs, tableNames, tableIDs},
+	}
+	err := d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func renameKey(ident ast.Ident) string {
+	return ident.Schema.L + "." + ident.Name.L
+}
+
+// checkRenameTable checks whether oldIdent can be renamed to newIdent, and returns the
+// old schema ID, the new schema ID and the table ID. renamed holds the tables renamed by
+// the earlier part of the same statement, a name mapped to 0 has been renamed away.
+func checkRenameTable(ctx sessionctx.Context, is infoschema.InfoSchema, oldIdent, newIdent ast.Ident, isAlterTable bool,
+	renamed map[string]int64) (oldSchemaID, newSchemaID, tableID int64, err error) {
+	tableExists := func(ident ast.Ident) (int64, bool) {
+		if id, ok := renamed[renameKey(ident)]; ok {
+			return id, id != 0
+		}
+		tbl, err := is.TableByName(ident.Schema, ident.Name)
+		if err != nil {
+			return 0, false
+		}
+		return tbl.Meta().ID, true
+	}
+
+	oldSchema, ok := is.SchemaByName(oldIdent.Schema)
+	if ok {
+		tableID, ok = tableExists(oldIdent)
+	}
+	if !ok {
+		if isAlterTable {
+			return 0, 0, 0, infoschema.ErrTableNotExists.GenWithStackByArgs(oldIdent.Schema, oldIdent.Name)
+		}
+		if _, ok := tableExists(newIdent); ok {
+			return 0, 0, 0, infoschema.ErrTableExists.GenWithStackByArgs(newIdent)
+		}
+		return 0, 0, 0, errFileNotFound.GenWithStackByArgs(oldIdent.Schema, oldIdent.Name)
+	}
+	newSchema, ok := is.SchemaByName(newIdent.Schema)
+	if !ok {
+		return 0, 0, 0, ErrErrorOnRename.GenWithStackByArgs(
+			fmt.Sprintf("%s.%s", oldIdent.Schema, oldIdent.Name),
+			fmt.Sprintf("%s.%s", newIdent.Schema, newIdent.Name),
+			168,
+			fmt.Sprintf("Database `%s` doesn't exist", newIdent.Schema))
+	}
+	if _, ok := tableExists(newIdent); ok {
+		return 0, 0, 0, infoschema.ErrTableExists.GenWithStackByArgs(newIdent)
+	}
+	if err = checkTooLongTable(newIdent.Name); err != nil {
+		return 0, 0, 0, errors.Trace(err)
+	}
+	if tbl, ok := is.TableByID(tableID); ok {
+		if err = checkRenameReferredTable(ctx, is, oldSchema.Name, tbl.Meta()); err != nil {
+			return 0, 0, 0, errors.Trace(err)
+		}
+	}
+	return oldSchema.ID, newSchema.ID, tableID, nil
+}
+
 func getAnonymousIndex(t table.Table, colName model.CIStr) model.CIStr {
 	id := 2
 	l := len(t.Indices())
@@ -1812,10 +2108,19 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// to job queue, the fail path logic is super fast.
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
-	_, err = buildIndexColumns(tblInfo.Columns, idxColNames)
+	idxColumns, err := buildIndexColumns(tblInfo.Columns, idxColNames)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if unique && tblInfo.GetPartitionInfo() != nil {
+		partCols, _, err := checkPartitionFunc(ctx, tblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = checkPartitionKeysConstraint(&model.IndexInfo{Unique: true, Columns: idxColumns}, partCols); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// May be truncate comment here, when index comment too long and sql_mode is't strict.
 	if _, err = validateCommentLength(ctx.GetSessionVars(), indexName.String(), indexOption); err != nil {
 		return errors.Trace(err)
@@ -1874,6 +2179,11 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, isPK bool, indexNa
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Check for drop index needed by foreign keys.
+	err = checkDropIndexForFK(is, schema.Name, t.Meta(), indexInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	jobTp := model.ActionDropIndex
 	if isPK {
@@ -1899,11 +2209,87 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, isPK bool, indexNa
 	return errors.Trace(err)
 }
 
+// CreateForeignKey adds a foreign key to the table. Unlike CREATE TABLE, the referencing columns
+// must be indexed already, and the existing rows are checked if foreign_key_checks is on.
+func (d *ddl) CreateForeignKey(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	if tblInfo.Partition != nil {
+		return errors.Trace(ErrForeignKeyOnPartitioned)
+	}
+	fkInfo, err := buildFKInfo(schema.Name, tblInfo, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !tblInfo.IsHandleColumns(fkInfo.Cols) && tblInfo.FindIndexByColumns(fkInfo.Cols) == nil {
+		return ErrFkNoIndexChild.GenWithStackByArgs(fkInfo.Name.O, tblInfo.Name.O)
+	}
+	if ctx.GetSessionVars().ForeignKeyChecks {
+		is := d.GetInfoSchemaWithInterceptor(ctx)
+		if err = checkFKReferences(is, schema.Name, tblInfo, fkInfo); err != nil {
+			return errors.Trace(err)
+		}
+		if err = checkFKExistingRows(ctx, is, schema.Name, t, fkInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddForeignKey,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{fkInfo},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// DropForeignKey drops a foreign key of the table, the index used by the foreign key is kept.
+func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.CIStr, ifExists bool) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindForeignKeyByName(fkName.L) == nil {
+		err = ErrCantDropFieldOrKey.GenWithStackByArgs(fkName.O)
+		if ifExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropForeignKey,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{fkName},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
 	if len(tblInfo.Columns) == 1 {
 		return ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
 			colName, tblInfo.Name)
 	}
+	for _, fkInfo := range tblInfo.ForeignKeys {
+		for _, col := range fkInfo.Cols {
+			if col.L == colName.L {
+				return ErrFkColumnCannotDrop.GenWithStackByArgs(colName, fkInfo.Name)
+			}
+		}
+	}
 	// We don't support dropping column with index covered now.
 	// We must drop the index first, then drop the column.
 	if isColumnWithIndex(colName.L, tblInfo.Indices) {
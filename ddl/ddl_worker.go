@@ -64,19 +64,21 @@ type worker struct {
 	quitCh   chan struct{}
 	wg       sync.WaitGroup
 
-	sessPool *sessionPool // sessPool is used to new sessions to execute SQL in ddl package.
-	reorgCtx *reorgCtx    // reorgCtx is used for reorganization.
-	logCtx   context.Context
+	sessPool    *sessionPool    // sessPool is used to new sessions to execute SQL in ddl package.
+	reorgCtx    *reorgCtx       // reorgCtx is used for reorganization.
+	delRangeMgr delRangeManager // delRangeMgr is used for recording the key ranges of the dropped data.
+	logCtx      context.Context
 }
 
-func newWorker(tp workerType, sessPool *sessionPool) *worker {
+func newWorker(tp workerType, sessPool *sessionPool, delRangeMgr delRangeManager) *worker {
 	worker := &worker{
-		id:       atomic.AddInt32(&ddlWorkerID, 1),
-		tp:       tp,
-		ddlJobCh: make(chan struct{}, 1),
-		quitCh:   make(chan struct{}),
-		reorgCtx: &reorgCtx{notifyCancelReorgJob: 0},
-		sessPool: sessPool,
+		id:          atomic.AddInt32(&ddlWorkerID, 1),
+		tp:          tp,
+		ddlJobCh:    make(chan struct{}, 1),
+		quitCh:      make(chan struct{}),
+		reorgCtx:    &reorgCtx{notifyCancelReorgJob: 0},
+		sessPool:    sessPool,
+		delRangeMgr: delRangeMgr,
 	}
 
 	worker.logCtx = logutil.WithKeyValue(context.Background(), "worker", worker.String())
@@ -261,6 +263,9 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 	}
 
 	job.BinlogInfo.FinishedTS = t.StartTS
+	if err = w.delRangeMgr.addDelRangeJob(job, t.StartTS); err != nil {
+		return errors.Trace(err)
+	}
 	logutil.Logger(w.logCtx).Info("[ddl] finish DDL job", zap.String("job", job.String()))
 	updateRawArgs := true
 	if job.Type == model.ActionAddPrimaryKey && !job.IsCancelled() {
@@ -452,6 +457,12 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onCreateTable(d, t, job)
 	case model.ActionDropTable:
 		ver, err = onDropTableOrView(t, job)
+	case model.ActionTruncateTable:
+		ver, err = onTruncateTable(d, t, job)
+	case model.ActionRenameTable:
+		ver, err = onRenameTable(d, t, job)
+	case model.ActionRenameTables:
+		ver, err = onRenameTables(d, t, job)
 	case model.ActionAddColumn:
 		ver, err = onAddColumn(d, t, job)
 	case model.ActionDropColumn:
@@ -464,14 +475,26 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = w.onCreateIndex(d, t, job, false)
 	case model.ActionAddPrimaryKey:
 		ver, err = w.onCreateIndex(d, t, job, true)
+	case model.ActionRenameIndex:
+		ver, err = onRenameIndex(t, job)
 	case model.ActionDropIndex, model.ActionDropPrimaryKey:
 		ver, err = onDropIndex(t, job)
+	case model.ActionAddForeignKey:
+		ver, err = onCreateForeignKey(t, job)
+	case model.ActionDropForeignKey:
+		ver, err = onDropForeignKey(t, job)
 	case model.ActionShardRowID:
 		ver, err = w.onShardRowID(d, t, job)
 	case model.ActionModifyTableComment:
 		ver, err = onModifyTableComment(t, job)
 	case model.ActionModifyTableCharsetAndCollate:
 		ver, err = onModifyTableCharsetAndCollate(t, job)
+	case model.ActionAddTablePartition:
+		ver, err = onAddTablePartition(t, job)
+	case model.ActionDropTablePartition:
+		ver, err = onDropTablePartition(t, job)
+	case model.ActionTruncateTablePartition:
+		ver, err = onTruncateTablePartition(t, job)
 	default:
 		// Invalid job, cancel it.
 		job.State = model.JobStateCancelled
@@ -615,6 +638,35 @@ func updateSchemaVersion(t *meta.Meta, job *model.Job) (int64, error) {
 		SchemaID: job.SchemaID,
 		TableID:  job.TableID,
 	}
+	switch job.Type {
+	case model.ActionTruncateTable:
+		// Truncate table replaces the table with a new table ID.
+		if err = job.DecodeArgs(&diff.TableID); err != nil {
+			return 0, errors.Trace(err)
+		}
+		diff.OldTableID = job.TableID
+	case model.ActionRenameTable:
+		if err = job.DecodeArgs(&diff.OldSchemaID); err != nil {
+			return 0, errors.Trace(err)
+		}
+	case model.ActionRenameTables:
+		var oldSchemaIDs, newSchemaIDs []int64
+		var tableNames []model.CIStr
+		var tableIDs []int64
+		if err = job.DecodeArgs(&oldSchemaIDs, &newSchemaIDs, &tableNames, &tableIDs); err != nil {
+			return 0, errors.Trace(err)
+		}
+		diff.OldSchemaID = oldSchemaIDs[0]
+		diff.AffectedOpts = make([]*model.AffectedOption, 0, len(tableIDs))
+		for i, tableID := range tableIDs {
+			diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+				SchemaID:    newSchemaIDs[i],
+				TableID:     tableID,
+				OldTableID:  tableID,
+				OldSchemaID: oldSchemaIDs[i],
+			})
+		}
+	}
 	err = t.SetSchemaDiff(diff)
 	return schemaVersion, errors.Trace(err)
 }
@@ -0,0 +1,119 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+const (
+	insertDeleteRangeSQLPrefix = "REPLACE INTO mysql.gc_delete_range VALUES "
+	insertDeleteRangeSQLValue  = `(%d, %d, "%s", "%s", %d)`
+)
+
+// delRangeManager records the key ranges of the data which is no longer
+// reachable after the DDL jobs, so that they can be deleted by GC.
+type delRangeManager interface {
+	// addDelRangeJob inserts the key ranges of the finished job into mysql.gc_delete_range.
+	addDelRangeJob(job *model.Job, ts uint64) error
+}
+
+type delRange struct {
+	sessPool *sessionPool
+}
+
+// newDelRangeManager returns a delRangeManager which writes the key ranges
+// through the sessions of sessPool.
+func newDelRangeManager(sessPool *sessionPool) delRangeManager {
+	return &delRange{sessPool: sessPool}
+}
+
+// addDelRangeJob implements delRangeManager interface.
+// All the ranges of the job are written by one statement, so they are
+// recorded either all or none. It's called before the transaction which
+// finishes the job is committed, the rows are keyed by the job ID and the
+// element ID, so writing them again when the job is retried is harmless.
+func (dr *delRange) addDelRangeJob(job *model.Job, ts uint64) error {
+	physicalTableIDs, err := getDroppedPhysicalTableIDs(job)
+	if err != nil || len(physicalTableIDs) == 0 {
+		return errors.Trace(err)
+	}
+	ctx, err := dr.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer dr.sessPool.put(ctx)
+	exec, ok := ctx.(sqlexec.SQLExecutor)
+	if !ok {
+		return errors.Errorf("the session of the delete-range manager can't execute SQL")
+	}
+
+	var buf strings.Builder
+	buf.WriteString(insertDeleteRangeSQLPrefix)
+	for i, physicalTableID := range physicalTableIDs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		startKey := tablecodec.EncodeTablePrefix(physicalTableID)
+		endKey := tablecodec.EncodeTablePrefix(physicalTableID + 1)
+		fmt.Fprintf(&buf, insertDeleteRangeSQLValue, job.ID, physicalTableID, hex.EncodeToString(startKey), hex.EncodeToString(endKey), ts)
+	}
+	_, err = exec.Execute(context.Background(), buf.String())
+	return errors.Trace(err)
+}
+
+type mockDelRange struct{}
+
+// newMockDelRangeManager returns a delRangeManager which records nothing, it's
+// used when the DDL has no session pool to execute SQL, such as in the tests.
+func newMockDelRangeManager() delRangeManager {
+	return &mockDelRange{}
+}
+
+// addDelRangeJob implements delRangeManager interface.
+func (dr *mockDelRange) addDelRangeJob(job *model.Job, ts uint64) error {
+	return nil
+}
+
+// getDroppedPhysicalTableIDs returns the IDs of the tables and the partitions
+// whose data is dropped by the job. Only the jobs which drop the data of whole
+// tables or partitions are handled.
+func getDroppedPhysicalTableIDs(job *model.Job) ([]int64, error) {
+	if job.IsCancelled() || job.IsRollbackDone() {
+		return nil, nil
+	}
+	var physicalTableIDs []int64
+	switch job.Type {
+	case model.ActionDropTable, model.ActionTruncateTable:
+		var startKey kv.Key
+		if err := job.DecodeArgs(&startKey, &physicalTableIDs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		physicalTableIDs = append([]int64{job.TableID}, physicalTableIDs...)
+	case model.ActionDropTablePartition, model.ActionTruncateTablePartition:
+		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return physicalTableIDs, nil
+}
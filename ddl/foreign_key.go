@@ -0,0 +1,409 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+)
+
+// buildFKInfo builds the foreign key defined by constr on tbInfo. Only the columns of tbInfo are
+// checked here, the referenced table is checked by checkFKReferences.
+func buildFKInfo(schema model.CIStr, tbInfo *model.TableInfo, constr *ast.Constraint) (*model.FKInfo, error) {
+	refer := constr.Refer
+	fkName := model.NewCIStr(constr.Name)
+	if fkName.L == "" {
+		fkName = genForeignKeyName(tbInfo)
+	}
+	if tbInfo.FindForeignKeyByName(fkName.L) != nil {
+		return nil, ErrFkDupName.GenWithStackByArgs(fkName.O)
+	}
+	if len(constr.Keys) != len(refer.IndexPartSpecifications) {
+		return nil, ErrWrongFkDef.GenWithStackByArgs(fkName.O, "Key reference and table reference don't match")
+	}
+
+	fkInfo := &model.FKInfo{
+		Name:      fkName,
+		RefSchema: refer.Table.Schema,
+		RefTable:  refer.Table.Name,
+		Cols:      make([]model.CIStr, 0, len(constr.Keys)),
+		RefCols:   make([]model.CIStr, 0, len(refer.IndexPartSpecifications)),
+	}
+	if fkInfo.RefSchema.L == "" {
+		fkInfo.RefSchema = schema
+	}
+	if refer.OnDelete != nil {
+		fkInfo.OnDelete = int(refer.OnDelete.ReferOpt)
+	}
+	if refer.OnUpdate != nil {
+		fkInfo.OnUpdate = int(refer.OnUpdate.ReferOpt)
+	}
+	for _, key := range constr.Keys {
+		col := model.FindColumnInfo(tbInfo.Columns, key.Column.Name.L)
+		if col == nil {
+			return nil, errKeyColumnDoesNotExits.GenWithStackByArgs(key.Column.Name)
+		}
+		isSetNull := ast.ReferOptionType(fkInfo.OnDelete) == ast.ReferOptionSetNull ||
+			ast.ReferOptionType(fkInfo.OnUpdate) == ast.ReferOptionSetNull
+		if isSetNull && mysql.HasNotNullFlag(col.Flag) {
+			return nil, ErrFkColumnNotNull.GenWithStackByArgs(col.Name.O, fkName.O)
+		}
+		fkInfo.Cols = append(fkInfo.Cols, col.Name)
+	}
+	for _, key := range refer.IndexPartSpecifications {
+		fkInfo.RefCols = append(fkInfo.RefCols, key.Column.Name)
+	}
+	return fkInfo, nil
+}
+
+// genForeignKeyName generates a foreign key name like MySQL, e.g. `t_ibfk_1`.
+func genForeignKeyName(tbInfo *model.TableInfo) model.CIStr {
+	for i := len(tbInfo.ForeignKeys) + 1; ; i++ {
+		name := fmt.Sprintf("%s_ibfk_%d", tbInfo.Name.O, i)
+		if tbInfo.FindForeignKeyByName(strings.ToLower(name)) == nil {
+			return model.NewCIStr(name)
+		}
+	}
+}
+
+func allocateFKID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxFKID++
+	return tblInfo.MaxFKID
+}
+
+// buildTableForeignKeys adds the foreign keys to a table being created. If the referencing
+// columns aren't the leading columns of an index, an index is created for them like MySQL.
+func buildTableForeignKeys(schema model.CIStr, tbInfo *model.TableInfo, constraints []*ast.Constraint) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	if tbInfo.Partition != nil {
+		return errors.Trace(ErrForeignKeyOnPartitioned)
+	}
+	for _, constr := range constraints {
+		fkInfo, err := buildFKInfo(schema, tbInfo, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !tbInfo.IsHandleColumns(fkInfo.Cols) && tbInfo.FindIndexByColumns(fkInfo.Cols) == nil {
+			if err = addForeignKeyIndex(tbInfo, constr); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		fkInfo.ID = allocateFKID(tbInfo)
+		fkInfo.State = model.StatePublic
+		tbInfo.ForeignKeys = append(tbInfo.ForeignKeys, fkInfo)
+	}
+	return nil
+}
+
+// addForeignKeyIndex adds an index for the referencing columns, it's named by the constraint
+// name if there is one, otherwise by the first column.
+func addForeignKeyIndex(tbInfo *model.TableInfo, constr *ast.Constraint) error {
+	namesMap := make(map[string]bool, len(tbInfo.Indices))
+	for _, idx := range tbInfo.Indices {
+		namesMap[idx.Name.L] = true
+	}
+	idxConstr := &ast.Constraint{Name: constr.Name, Keys: constr.Keys}
+	if err := checkDuplicateConstraint(namesMap, idxConstr.Name); err != nil {
+		return errors.Trace(err)
+	}
+	setEmptyConstraintName(namesMap, idxConstr)
+	idxInfo, err := buildIndexInfo(tbInfo, model.NewCIStr(idxConstr.Name), idxConstr.Keys, model.StatePublic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	idxInfo.Tp = model.IndexTypeBtree
+	idxInfo.ID = allocateIndexID(tbInfo)
+	tbInfo.Indices = append(tbInfo.Indices, idxInfo)
+	return nil
+}
+
+// checkFKReferences checks the referenced table and columns of the foreign key exist, the referenced
+// columns are indexed and have compatible types with the referencing columns.
+func checkFKReferences(is infoschema.InfoSchema, schema model.CIStr, tbInfo *model.TableInfo, fkInfo *model.FKInfo) error {
+	refTblInfo := tbInfo
+	if fkInfo.RefSchema.L != schema.L || fkInfo.RefTable.L != tbInfo.Name.L {
+		refTbl, err := is.TableByName(fkInfo.RefSchema, fkInfo.RefTable)
+		if err != nil {
+			return ErrFkCannotOpenParent.GenWithStackByArgs(fkInfo.RefTable.O)
+		}
+		refTblInfo = refTbl.Meta()
+	}
+	if refTblInfo.Partition != nil {
+		return errors.Trace(ErrForeignKeyOnPartitioned)
+	}
+	for _, refColName := range fkInfo.RefCols {
+		if model.FindColumnInfo(refTblInfo.Columns, refColName.L) == nil {
+			return ErrFkNoIndexParent.GenWithStackByArgs(fkInfo.Name.O, fkInfo.RefTable.O)
+		}
+	}
+	if !refTblInfo.IsHandleColumns(fkInfo.RefCols) && refTblInfo.FindIndexByColumns(fkInfo.RefCols) == nil {
+		return ErrFkNoIndexParent.GenWithStackByArgs(fkInfo.Name.O, fkInfo.RefTable.O)
+	}
+	for i, refColName := range fkInfo.RefCols {
+		refCol := model.FindColumnInfo(refTblInfo.Columns, refColName.L)
+		col := model.FindColumnInfo(tbInfo.Columns, fkInfo.Cols[i].L)
+		if !isFKColumnCompatible(col, refCol) {
+			return ErrFKIncompatibleColumns.GenWithStackByArgs(col.Name.O, fkInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+// checkFKExistingRows checks the referenced rows of the existing rows of the table exist. The rows are read in
+// the transaction of the DDL statement before the job is submitted.
+func checkFKExistingRows(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tbl table.Table, fkInfo *model.FKInfo) error {
+	refTbl, err := is.TableByName(fkInfo.RefSchema, fkInfo.RefTable)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	refCols, refIdx, ok := tables.FKLookupColumns(refTbl, fkInfo.RefCols)
+	if !ok {
+		return ErrFkNoIndexParent.GenWithStackByArgs(fkInfo.Name.O, fkInfo.RefTable.O)
+	}
+	offsets := make([]int, 0, len(fkInfo.Cols))
+	for _, colName := range fkInfo.Cols {
+		offsets = append(offsets, table.FindCol(tbl.Cols(), colName.L).Offset)
+	}
+	vals := make([]types.Datum, len(offsets))
+	missing := false
+	err = tbl.IterRecords(ctx, tbl.FirstKey(), tbl.Cols(), func(_ int64, row []types.Datum, _ []*table.Column) (bool, error) {
+		// A row with any NULL referencing column always satisfies the foreign key.
+		for i, offset := range offsets {
+			if row[offset].IsNull() {
+				return true, nil
+			}
+			vals[i] = row[offset]
+		}
+		handles, err := tables.LookupFKRows(context.Background(), ctx, refTbl, refCols, refIdx, vals, 1)
+		if err != nil {
+			return false, err
+		}
+		missing = len(handles) == 0
+		return !missing, nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if missing {
+		return ErrNoReferencedRow2.GenWithStackByArgs(infoschema.FormatForeignKey(schema, tbl.Meta().Name, fkInfo))
+	}
+	return nil
+}
+
+// isFKColumnCompatible checks whether the referencing column and the referenced column can be compared
+// without conversion. Like InnoDB, integers must have the same size and sign, and strings must have the
+// same charset, but their lengths can differ.
+func isFKColumnCompatible(col, refCol *model.ColumnInfo) bool {
+	if types.IsString(col.Tp) && types.IsString(refCol.Tp) {
+		return col.Charset == refCol.Charset
+	}
+	if col.Tp != refCol.Tp || mysql.HasUnsignedFlag(col.Flag) != mysql.HasUnsignedFlag(refCol.Flag) {
+		return false
+	}
+	if col.Tp == mysql.TypeNewDecimal {
+		return col.Flen == refCol.Flen && col.Decimal == refCol.Decimal
+	}
+	return true
+}
+
+// checkTableNotReferred returns err if the table is referenced by foreign keys of other tables
+// when foreign_key_checks is on.
+func checkTableNotReferred(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tbInfo *model.TableInfo,
+	fn func(*infoschema.ReferredFKInfo) error) error {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	for _, referredFK := range is.ReferredFKs(schema, tbInfo.Name) {
+		if referredFK.ChildTable.Meta().ID == tbInfo.ID {
+			continue
+		}
+		if err := fn(referredFK); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRenameReferredTable returns err if the table to be renamed is referenced by foreign keys of
+// other tables, their references aren't changed by the rename.
+func checkRenameReferredTable(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tbInfo *model.TableInfo) error {
+	return checkTableNotReferred(ctx, is, schema, tbInfo, func(*infoschema.ReferredFKInfo) error {
+		return ErrAlterOperationNotSupported.GenWithStackByArgs("RENAME TABLE",
+			"Tables referenced by a foreign key are renamed", "dropping the foreign key first")
+	})
+}
+
+// checkRenameReferredColumn returns err if the column to be renamed is referenced by foreign keys of
+// other tables, the self-referencing foreign keys are changed by renameFKColumns.
+func checkRenameReferredColumn(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tbInfo *model.TableInfo,
+	colName model.CIStr, op string) error {
+	return checkTableNotReferred(ctx, is, schema, tbInfo, func(referredFK *infoschema.ReferredFKInfo) error {
+		for _, refCol := range referredFK.FK.RefCols {
+			if refCol.L == colName.L {
+				return ErrAlterOperationNotSupported.GenWithStackByArgs(op,
+					"Columns participating in a foreign key are renamed", "dropping the foreign key first")
+			}
+		}
+		return nil
+	})
+}
+
+// checkDropIndexForFK checks the index isn't the only index which can be used by a foreign key.
+func checkDropIndexForFK(is infoschema.InfoSchema, schema model.CIStr, tbInfo *model.TableInfo, idxInfo *model.IndexInfo) error {
+	remaining := *tbInfo
+	remaining.Indices = make([]*model.IndexInfo, 0, len(tbInfo.Indices))
+	for _, idx := range tbInfo.Indices {
+		if idx.ID != idxInfo.ID {
+			remaining.Indices = append(remaining.Indices, idx)
+		}
+	}
+	needed := func(cols []model.CIStr) bool {
+		return tbInfo.FindIndexByColumns(cols) != nil && !remaining.IsHandleColumns(cols) && remaining.FindIndexByColumns(cols) == nil
+	}
+	for _, fkInfo := range tbInfo.ForeignKeys {
+		if needed(fkInfo.Cols) {
+			return ErrDropIndexFk.GenWithStackByArgs(idxInfo.Name.O)
+		}
+	}
+	for _, referredFK := range is.ReferredFKs(schema, tbInfo.Name) {
+		if needed(referredFK.FK.RefCols) {
+			return ErrDropIndexFk.GenWithStackByArgs(idxInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+// renameFKColumns renames the column in the foreign keys of the table, including the referenced
+// columns of the self-referencing foreign keys.
+func renameFKColumns(schema model.CIStr, tblInfo *model.TableInfo, oldName, newName model.CIStr) {
+	for _, fkInfo := range tblInfo.ForeignKeys {
+		for i, col := range fkInfo.Cols {
+			if col.L == oldName.L {
+				fkInfo.Cols[i] = newName
+			}
+		}
+		if fkInfo.RefSchema.L != schema.L || fkInfo.RefTable.L != tblInfo.Name.L {
+			continue
+		}
+		for i, col := range fkInfo.RefCols {
+			if col.L == oldName.L {
+				fkInfo.RefCols[i] = newName
+			}
+		}
+	}
+}
+
+// renameSelfReferencingFKs points the self-referencing foreign keys of the table being renamed to the
+// new name of the table.
+func renameSelfReferencingFKs(t *meta.Meta, oldSchemaID, newSchemaID int64, tblInfo *model.TableInfo, tableName model.CIStr) error {
+	if len(tblInfo.ForeignKeys) == 0 {
+		return nil
+	}
+	oldDBInfo, err := t.GetDatabase(oldSchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDBInfo := oldDBInfo
+	if newSchemaID != oldSchemaID {
+		if newDBInfo, err = t.GetDatabase(newSchemaID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, fkInfo := range tblInfo.ForeignKeys {
+		if fkInfo.RefSchema.L == oldDBInfo.Name.L && fkInfo.RefTable.L == tblInfo.Name.L {
+			fkInfo.RefSchema, fkInfo.RefTable = newDBInfo.Name, tableName
+		}
+	}
+	return nil
+}
+
+func onCreateForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var fkInfo model.FKInfo
+	if err = job.DecodeArgs(&fkInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	// Double check, the table info may have been changed since the job was submitted.
+	if tblInfo.FindForeignKeyByName(fkInfo.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrFkDupName.GenWithStackByArgs(fkInfo.Name.O)
+	}
+
+	switch fkInfo.State {
+	case model.StateNone:
+		// The existing rows are validated by CreateForeignKey before the job is submitted.
+		// none -> public
+		fkInfo.ID = allocateFKID(tblInfo)
+		fkInfo.State = model.StatePublic
+		tblInfo.ForeignKeys = append(tblInfo.ForeignKeys, &fkInfo)
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	default:
+		return ver, ErrInvalidDDLState.GenWithStackByArgs("foreign key", fkInfo.State)
+	}
+}
+
+func onDropForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var fkName model.CIStr
+	if err = job.DecodeArgs(&fkName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	fks := make([]*model.FKInfo, 0, len(tblInfo.ForeignKeys))
+	for _, fkInfo := range tblInfo.ForeignKeys {
+		if fkInfo.Name.L != fkName.L {
+			fks = append(fks, fkInfo)
+		}
+	}
+	if len(fks) == len(tblInfo.ForeignKeys) {
+		job.State = model.JobStateCancelled
+		return ver, ErrCantDropFieldOrKey.GenWithStackByArgs(fkName.O)
+	}
+	tblInfo.ForeignKeys = fks
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
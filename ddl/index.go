@@ -17,6 +17,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -520,6 +521,59 @@ func onDropIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	return ver, errors.Trace(err)
 }
 
+// validateRenameIndex checks whether the index from can be renamed to to. It returns
+// true if nothing needs to be changed.
+func validateRenameIndex(from, to model.CIStr, tbl *model.TableInfo) (ignore bool, err error) {
+	fromIdx := tbl.FindIndexByName(from.L)
+	if fromIdx == nil {
+		return false, errors.Trace(infoschema.ErrKeyNotExists.GenWithStackByArgs(from.O, tbl.Name))
+	}
+	// The primary key is always named PRIMARY, and no other index can take the name.
+	if fromIdx.Primary || strings.EqualFold(to.O, mysql.PrimaryKeyName) {
+		return false, ErrWrongNameForIndex.GenWithStackByArgs(to.O)
+	}
+	// Take case-sensitivity into account, if from and to are the same, nothing needs to be changed.
+	if fromIdx.Name.O == to.O {
+		return true, nil
+	}
+	// If from.L == to.L, the index only changes the letter case of its name.
+	// Otherwise, the index can't be renamed to the name of another existing index.
+	if toIdx := tbl.FindIndexByName(to.L); toIdx != nil && from.L != to.L {
+		return false, errors.Trace(ErrDupKeyName.GenWithStackByArgs(toIdx.Name.O))
+	}
+	if err = checkTooLongIndex(to); err != nil {
+		return false, errors.Trace(err)
+	}
+	return false, nil
+}
+
+func onRenameIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var from, to model.CIStr
+	if err = job.DecodeArgs(&from, &to); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	// Double check, the table info may have been changed since the job was submitted.
+	if _, err = validateRenameIndex(from, to, tblInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	idx := tblInfo.FindIndexByName(from.L)
+	idx.Name = to
+	if ver, err = updateVersionAndTableInfo(t, job, tblInfo, true); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
 func checkDropIndex(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.IndexInfo, error) {
 	schemaID := job.SchemaID
 	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
@@ -1282,9 +1336,69 @@ func (w *worker) addPhysicalTableIndex(t table.PhysicalTable, indexInfo *model.I
 	return nil
 }
 
-// addTableIndex handles the add index reorganization state for a table.
+// addTableIndex handles the add index reorganization state for a non-partitioned table or a partitioned table.
+// The partitions of a partitioned table are handled one by one.
 func (w *worker) addTableIndex(t table.Table, idx *model.IndexInfo, reorgInfo *reorgInfo) error {
-	return w.addPhysicalTableIndex(t.(table.PhysicalTable), idx, reorgInfo)
+	tbl, ok := t.(table.PartitionedTable)
+	if !ok {
+		return w.addPhysicalTableIndex(t.(table.PhysicalTable), idx, reorgInfo)
+	}
+	for {
+		p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return errors.Errorf("can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+		}
+		if err := w.addPhysicalTableIndex(p, idx, reorgInfo); err != nil {
+			return errors.Trace(err)
+		}
+		finish, err := w.updateReorgInfo(tbl, reorgInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if finish {
+			return nil
+		}
+	}
+}
+
+// updateReorgInfo will find the next partition according to current reorgInfo.
+// If no more partitions, it returns true to indicate that the reorganize work is finished.
+func (w *worker) updateReorgInfo(t table.PartitionedTable, reorg *reorgInfo) (bool, error) {
+	pid, err := findNextPartitionID(reorg.PhysicalTableID, t.Meta().GetPartitionInfo().Definitions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if pid == 0 {
+		// Next partition does not exist, all the job done.
+		return true, nil
+	}
+
+	start, end, err := getTableRange(reorg.d, t.GetPartition(pid), reorg.Job.SnapshotVer, reorg.Job.Priority)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	logutil.BgLogger().Info("[ddl] job update reorgInfo", zap.Int64("jobID", reorg.Job.ID), zap.Int64("partitionTableID", pid), zap.Int64("startHandle", start), zap.Int64("endHandle", end))
+	reorg.StartHandle, reorg.EndHandle, reorg.PhysicalTableID = start, end, pid
+
+	// Write the reorg info to store so the whole reorganize process can recover from panic.
+	err = kv.RunInNewTxn(reorg.d.store, true, func(txn kv.Transaction) error {
+		return errors.Trace(reorg.UpdateReorgMeta(txn, reorg.StartHandle, reorg.EndHandle, reorg.PhysicalTableID))
+	})
+	return false, errors.Trace(err)
+}
+
+// findNextPartitionID finds the next partition ID in the PartitionDefinition array.
+// Returns 0 if current partition is already the last one.
+func findNextPartitionID(currentPartition int64, defs []model.PartitionDefinition) (int64, error) {
+	for i, def := range defs {
+		if currentPartition == def.ID {
+			if i == len(defs)-1 {
+				return 0, nil
+			}
+			return defs[i+1].ID, nil
+		}
+	}
+	return 0, errors.Errorf("partition id not found %d", currentPartition)
 }
 
 func allocateIndexID(tblInfo *model.TableInfo) int64 {
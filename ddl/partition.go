@@ -0,0 +1,695 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+const (
+	partitionMaxValue = "MAXVALUE"
+	partitionNull     = "NULL"
+	// partitionCountLimit is the max number of partitions of a table, it is the same as MySQL.
+	partitionCountLimit = 8192
+)
+
+// buildTablePartitionInfo builds the partition info of the table from the PARTITION BY clause.
+func buildTablePartitionInfo(d *ddl, s *ast.PartitionOptions, tbInfo *model.TableInfo) error {
+	if s == nil {
+		return nil
+	}
+	pi := &model.PartitionInfo{Type: s.Tp}
+	if s.Expr != nil {
+		var buf bytes.Buffer
+		s.Expr.Format(&buf)
+		pi.Expr = buf.String()
+	} else {
+		if len(s.ColumnNames) > 1 {
+			return errors.Trace(errUnsupportedPartitionColumns)
+		}
+		for _, cn := range s.ColumnNames {
+			pi.Columns = append(pi.Columns, cn.Name)
+		}
+	}
+
+	defs, err := buildPartitionDefinitions(s.Tp, s.Definitions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case len(defs) == 0 && s.Tp == model.PartitionTypeHash:
+		num := s.Num
+		if num == 0 {
+			num = 1
+		}
+		if num > partitionCountLimit {
+			return errors.Trace(ErrTooManyPartitions)
+		}
+		for i := uint64(0); i < num; i++ {
+			defs = append(defs, model.PartitionDefinition{Name: model.NewCIStr(fmt.Sprintf("p%d", i))})
+		}
+	case len(defs) == 0:
+		return errors.Trace(ErrPartitionsMustBeDefined.GenWithStackByArgs(s.Tp.String()))
+	case s.Num != 0 && s.Num != uint64(len(defs)):
+		return errors.Trace(ErrPartitionWrongNoPart)
+	}
+	if err = setPartitionIDs(d, defs); err != nil {
+		return errors.Trace(err)
+	}
+	pi.Definitions = defs
+	pi.Num = uint64(len(defs))
+	tbInfo.Partition = pi
+	return nil
+}
+
+// buildPartitionDefinitions builds the partition definitions, the values are
+// kept as strings and checked by checkTablePartitionInfo later.
+func buildPartitionDefinitions(tp model.PartitionType, defs []*ast.PartitionDefinition) ([]model.PartitionDefinition, error) {
+	ret := make([]model.PartitionDefinition, 0, len(defs))
+	for _, def := range defs {
+		switch {
+		case tp != model.PartitionTypeRange && len(def.LessThan) > 0:
+			return nil, ErrPartitionWrongValues.GenWithStackByArgs("RANGE", "LESS THAN")
+		case tp != model.PartitionTypeList && len(def.InValues) > 0:
+			return nil, ErrPartitionWrongValues.GenWithStackByArgs("LIST", "IN")
+		case tp == model.PartitionTypeRange && len(def.LessThan) == 0:
+			return nil, ErrPartitionRequiresValues.GenWithStackByArgs("RANGE", "LESS THAN")
+		case tp == model.PartitionTypeList && len(def.InValues) == 0:
+			return nil, ErrPartitionRequiresValues.GenWithStackByArgs("LIST", "IN")
+		case len(def.LessThan) > 1:
+			return nil, ErrPartitionColumnList
+		}
+		ret = append(ret, model.PartitionDefinition{
+			Name:     def.Name,
+			LessThan: formatPartitionValues(def.LessThan),
+			InValues: formatPartitionValues(def.InValues),
+			Comment:  def.Comment,
+		})
+	}
+	return ret, nil
+}
+
+func formatPartitionValues(exprs []ast.ExprNode) []string {
+	if len(exprs) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(exprs))
+	var buf bytes.Buffer
+	for _, expr := range exprs {
+		expr.Format(&buf)
+		values = append(values, buf.String())
+		buf.Reset()
+	}
+	return values
+}
+
+// setPartitionIDs allocates IDs for the partitions. The ddl is nil for mock table infos.
+func setPartitionIDs(d *ddl, defs []model.PartitionDefinition) error {
+	if d == nil || len(defs) == 0 {
+		return nil
+	}
+	genIDs, err := d.genGlobalIDs(len(defs))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range defs {
+		defs[i].ID = genIDs[i]
+	}
+	return nil
+}
+
+// checkTablePartitionInfo checks the partition info against the table definition.
+func checkTablePartitionInfo(ctx sessionctx.Context, tbInfo *model.TableInfo) error {
+	pi := tbInfo.GetPartitionInfo()
+	if pi == nil {
+		return nil
+	}
+	if len(pi.Definitions) > partitionCountLimit {
+		return errors.Trace(ErrTooManyPartitions)
+	}
+	if err := checkPartitionNameUnique(pi); err != nil {
+		return errors.Trace(err)
+	}
+	partCols, tp, err := checkPartitionFunc(ctx, tbInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isColumns := len(pi.Columns) > 0
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		err = checkRangePartitionValues(ctx, pi, tp, isColumns)
+	case model.PartitionTypeList:
+		err = checkListPartitionValues(ctx, pi, tp, isColumns)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return checkPartitioningKeysConstraints(tbInfo, partCols)
+}
+
+func checkPartitionNameUnique(pi *model.PartitionInfo) error {
+	names := make(map[string]struct{}, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		if _, ok := names[def.Name.L]; ok {
+			return ErrSameNamePartition.GenWithStackByArgs(def.Name.O)
+		}
+		names[def.Name.L] = struct{}{}
+	}
+	return nil
+}
+
+// checkPartitionFunc checks the partition expression or the partition columns,
+// it returns the columns used by the partition function and its result type.
+func checkPartitionFunc(ctx sessionctx.Context, tbInfo *model.TableInfo) ([]*model.ColumnInfo, *types.FieldType, error) {
+	pi := tbInfo.GetPartitionInfo()
+	if len(pi.Columns) > 0 {
+		cols := make([]*model.ColumnInfo, 0, len(pi.Columns))
+		for _, name := range pi.Columns {
+			col := model.FindColumnInfo(tbInfo.Columns, name.L)
+			if col == nil {
+				return nil, nil, ErrFieldNotFoundPart
+			}
+			if !isColumnsPartitionType(col.Tp) {
+				return nil, nil, ErrFieldTypeNotAllowedAsPartitionField.GenWithStackByArgs(col.Name.O)
+			}
+			cols = append(cols, col)
+		}
+		return cols, &cols[0].FieldType, nil
+	}
+
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, pi.Expr, tbInfo)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	exprCols := expression.ExtractColumns(expr)
+	if len(exprCols) == 0 {
+		return nil, nil, ErrWrongExprInPartitionFunc
+	}
+	if expr.GetType().EvalType() != types.ETInt {
+		return nil, nil, ErrPartitionFuncNotAllowed.GenWithStackByArgs("PARTITION")
+	}
+	cols := make([]*model.ColumnInfo, 0, len(exprCols))
+	for _, col := range exprCols {
+		cols = append(cols, tbInfo.Columns[col.Index])
+	}
+	return cols, expr.GetType(), nil
+}
+
+// isColumnsPartitionType checks whether the column type can be used by RANGE/LIST COLUMNS partitioning.
+func isColumnsPartitionType(tp byte) bool {
+	switch tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeDate, mysql.TypeDatetime, mysql.TypeDuration:
+		return true
+	}
+	return false
+}
+
+// evalPartitionValue evaluates the value of a partition definition.
+func evalPartitionValue(ctx sessionctx.Context, def *model.PartitionDefinition, value string, tp *types.FieldType, isColumns bool) (types.Datum, error) {
+	expr, err := expression.ParseSimpleExprWithSchema(ctx, value, nil, nil)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	d, err := expr.Eval(chunk.Row{})
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	if d.IsNull() {
+		return d, nil
+	}
+	if !isColumns {
+		if expr.GetType().EvalType() != types.ETInt {
+			return types.Datum{}, ErrValuesIsNotIntType.GenWithStackByArgs(def.Name.O)
+		}
+		return d, nil
+	}
+	v, err := d.ConvertTo(ctx.GetSessionVars().StmtCtx, tp)
+	if err != nil {
+		return types.Datum{}, ErrWrongTypeColumnValue.GenWithStackByArgs()
+	}
+	return v, nil
+}
+
+// checkRangePartitionValues checks MAXVALUE is only used by the last partition and
+// the values of VALUES LESS THAN are strictly increasing.
+func checkRangePartitionValues(ctx sessionctx.Context, pi *model.PartitionInfo, tp *types.FieldType, isColumns bool) error {
+	sc := ctx.GetSessionVars().StmtCtx
+	var prev *types.Datum
+	for i := range pi.Definitions {
+		def := &pi.Definitions[i]
+		if strings.EqualFold(def.LessThan[0], partitionMaxValue) {
+			if i != len(pi.Definitions)-1 {
+				return ErrPartitionMaxvalue
+			}
+			continue
+		}
+		v, err := evalPartitionValue(ctx, def, def.LessThan[0], tp, isColumns)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if v.IsNull() {
+			return ErrNullInValuesLessThan
+		}
+		if prev != nil {
+			cmp, err := prev.CompareDatum(sc, &v)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if cmp >= 0 {
+				return ErrRangeNotIncreasing
+			}
+		}
+		prev = &v
+	}
+	return nil
+}
+
+// checkListPartitionValues checks that a value is defined in only one partition.
+func checkListPartitionValues(ctx sessionctx.Context, pi *model.PartitionInfo, tp *types.FieldType, isColumns bool) error {
+	seen := make(map[string]struct{})
+	for i := range pi.Definitions {
+		def := &pi.Definitions[i]
+		for _, value := range def.InValues {
+			v, err := evalPartitionValue(ctx, def, value, tp, isColumns)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			key := partitionNull
+			if !v.IsNull() {
+				s, err := v.ToString()
+				if err != nil {
+					return errors.Trace(err)
+				}
+				key = "v:" + s
+			}
+			if _, ok := seen[key]; ok {
+				return ErrMultipleDefConstInListPart
+			}
+			seen[key] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// checkPartitioningKeysConstraints checks that every unique key of the table
+// includes all the columns used by the partition function.
+func checkPartitioningKeysConstraints(tbInfo *model.TableInfo, partCols []*model.ColumnInfo) error {
+	if tbInfo.PKIsHandle {
+		pkCol := tbInfo.GetPkColInfo()
+		for _, col := range partCols {
+			if col.ID != pkCol.ID {
+				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
+			}
+		}
+	}
+	for _, idx := range tbInfo.Indices {
+		if err := checkPartitionKeysConstraint(idx, partCols); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func checkPartitionKeysConstraint(idx *model.IndexInfo, partCols []*model.ColumnInfo) error {
+	if !idx.Unique {
+		return nil
+	}
+	for _, col := range partCols {
+		found := false
+		for _, idxCol := range idx.Columns {
+			// A prefix index can't guarantee the uniqueness of the whole column.
+			if idxCol.Name.L == col.Name.L && (idxCol.Length == types.UnspecifiedLength || idxCol.Length == col.Flen) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			if idx.Primary {
+				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
+			}
+			return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+		}
+	}
+	return nil
+}
+
+// isColumnInPartitionExpr checks whether the column is used by the partition function.
+func isColumnInPartitionExpr(ctx sessionctx.Context, tbInfo *model.TableInfo, colName model.CIStr) (bool, error) {
+	if tbInfo.GetPartitionInfo() == nil {
+		return false, nil
+	}
+	partCols, _, err := checkPartitionFunc(ctx, tbInfo)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, col := range partCols {
+		if col.Name.L == colName.L {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkModifyPartitionColumn forbids renaming the columns used by the partition
+// function or changing their types, since the partition expression depends on them.
+func checkModifyPartitionColumn(ctx sessionctx.Context, tbInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) error {
+	in, err := isColumnInPartitionExpr(ctx, tbInfo, oldCol.Name)
+	if err != nil || !in {
+		return errors.Trace(err)
+	}
+	if oldCol.Name.L != newCol.Name.L {
+		return errDependentByPartitionExpr.GenWithStackByArgs("rename")
+	}
+	if !oldCol.FieldType.Equal(&newCol.FieldType) {
+		return errDependentByPartitionExpr.GenWithStackByArgs("modify")
+	}
+	return nil
+}
+
+// getPartitionIDs returns the IDs of all the partitions, it returns nil for a non-partitioned table.
+func getPartitionIDs(tblInfo *model.TableInfo) []int64 {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return nil
+	}
+	physicalTableIDs := make([]int64, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		physicalTableIDs = append(physicalTableIDs, def.ID)
+	}
+	return physicalTableIDs
+}
+
+// AddTablePartitions adds partitions to a RANGE or LIST partitioned table.
+func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Type == model.PartitionTypeHash {
+		return errors.Trace(errUnsupportedAddPartition)
+	}
+
+	defs, err := buildPartitionDefinitions(pi.Type, spec.PartDefinitions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addingDefs := &model.PartitionInfo{Definitions: defs}
+	if err = checkAddPartitions(ctx, meta, addingDefs); err != nil {
+		return errors.Trace(err)
+	}
+	if err = setPartitionIDs(d, addingDefs.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{addingDefs},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// checkAddPartitions checks the table is still valid with the adding partitions appended.
+func checkAddPartitions(ctx sessionctx.Context, tblInfo *model.TableInfo, addingDefs *model.PartitionInfo) error {
+	newTblInfo := tblInfo.Clone()
+	newTblInfo.Partition.Definitions = append(newTblInfo.Partition.Definitions, addingDefs.Definitions...)
+	return checkTablePartitionInfo(ctx, newTblInfo)
+}
+
+// DropTablePartition drops partitions of a RANGE or LIST partitioned table.
+func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Type == model.PartitionTypeHash {
+		return errors.Trace(ErrOnlyOnRangeListPartition.GenWithStackByArgs("DROP"))
+	}
+
+	partNames := make([]model.CIStr, 0, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		if pi.FindPartitionDefinitionByName(name.L) < 0 {
+			if spec.IfExists {
+				ctx.GetSessionVars().StmtCtx.AppendNote(ErrDropPartitionNonExistent.GenWithStackByArgs("DROP"))
+				continue
+			}
+			return errors.Trace(ErrDropPartitionNonExistent.GenWithStackByArgs("DROP"))
+		}
+		partNames = append(partNames, name)
+	}
+	if len(partNames) == 0 {
+		return nil
+	}
+	if err = checkDropTablePartition(meta, partNames); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// checkDropTablePartition checks the partitions exist and at least one partition is left.
+func checkDropTablePartition(tblInfo *model.TableInfo, partNames []model.CIStr) error {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return ErrPartitionMgmtOnNonpartitioned
+	}
+	dropping := make(map[string]struct{}, len(partNames))
+	for _, name := range partNames {
+		if pi.FindPartitionDefinitionByName(name.L) < 0 {
+			return ErrDropPartitionNonExistent.GenWithStackByArgs("DROP")
+		}
+		dropping[name.L] = struct{}{}
+	}
+	if len(dropping) >= len(pi.Definitions) {
+		return ErrDropLastPartition
+	}
+	return nil
+}
+
+// TruncateTablePartition empties partitions by giving them new partition IDs.
+func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta := t.Meta()
+	if meta.GetPartitionInfo() == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	oldIDs := make([]int64, 0, len(spec.PartitionNames))
+	seen := make(map[int64]struct{}, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		pid, err := tables.FindPartitionByName(meta, name.L)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := seen[pid]; ok {
+			continue
+		}
+		seen[pid] = struct{}{}
+		oldIDs = append(oldIDs, pid)
+	}
+	newIDs, err := d.genGlobalIDs(len(oldIDs))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionTruncateTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{oldIDs, newIDs},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// onAddTablePartition appends the new partitions to the table.
+func onAddTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	addingDefs := &model.PartitionInfo{}
+	if err := job.DecodeArgs(addingDefs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.GetPartitionInfo() == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	// Only check the structure of the partitions here, the values have been
+	// checked when the job was submitted and they are appended in order.
+	newPi := tblInfo.Partition.Clone()
+	newPi.Definitions = append(newPi.Definitions, addingDefs.Definitions...)
+	if err = checkPartitionNameUnique(newPi); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if newPi.Type == model.PartitionTypeRange && len(tblInfo.Partition.Definitions) > 0 {
+		last := tblInfo.Partition.Definitions[len(tblInfo.Partition.Definitions)-1]
+		if strings.EqualFold(last.LessThan[0], partitionMaxValue) {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(ErrPartitionMaxvalue)
+		}
+	}
+	newPi.Num = uint64(len(newPi.Definitions))
+	tblInfo.Partition = newPi
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// onDropTablePartition removes the partitions from the table, the data is left for GC.
+func onDropTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var partNames []model.CIStr
+	if err := job.DecodeArgs(&partNames); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Double check, the table info may have been changed since the job was submitted.
+	if err = checkDropTablePartition(tblInfo, partNames); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dropping := make(map[string]struct{}, len(partNames))
+	for _, name := range partNames {
+		dropping[name.L] = struct{}{}
+	}
+	pi := tblInfo.Partition
+	newDefs := make([]model.PartitionDefinition, 0, len(pi.Definitions))
+	physicalTableIDs := make([]int64, 0, len(partNames))
+	for _, def := range pi.Definitions {
+		if _, ok := dropping[def.Name.L]; ok {
+			physicalTableIDs = append(physicalTableIDs, def.ID)
+			continue
+		}
+		newDefs = append(newDefs, def)
+	}
+	pi.Definitions = newDefs
+	pi.Num = uint64(len(newDefs))
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	// The physical IDs are used to record the delete ranges.
+	job.Args = []interface{}{physicalTableIDs}
+	return ver, nil
+}
+
+// onTruncateTablePartition replaces the IDs of the partitions with new ones,
+// the data of the old IDs is left for GC.
+func onTruncateTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var oldIDs, newIDs []int64
+	if err := job.DecodeArgs(&oldIDs, &newIDs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if len(oldIDs) != len(newIDs) {
+		job.State = model.JobStateCancelled
+		return ver, errInvalidDDLJob.GenWithStack("the number of new partition IDs mismatches")
+	}
+	for i, oldID := range oldIDs {
+		found := false
+		for j := range pi.Definitions {
+			if pi.Definitions[j].ID == oldID {
+				pi.Definitions[j].ID = newIDs[i]
+				found = true
+				break
+			}
+		}
+		if !found {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(fmt.Sprint(oldID), tblInfo.Name.O))
+		}
+	}
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	// The old physical IDs are used to record the delete ranges.
+	job.Args = []interface{}{oldIDs}
+	return ver, nil
+}
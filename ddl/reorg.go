@@ -354,7 +354,14 @@ func getReorgInfo(d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table) (*re
 		}
 		tblInfo := tbl.Meta()
 		pid = tblInfo.ID
-		tb := tbl.(table.PhysicalTable)
+		var tb table.PhysicalTable
+		if pi := tblInfo.GetPartitionInfo(); pi != nil {
+			// The partitions are reorganized one by one, start from the first one.
+			pid = pi.Definitions[0].ID
+			tb = tbl.(table.PartitionedTable).GetPartition(pid)
+		} else {
+			tb = tbl.(table.PhysicalTable)
+		}
 		start, end, err = getTableRange(d, tb, ver.Ver, job.Priority)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -114,7 +114,7 @@ func onDropTableOrView(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		// Finish this job.
 		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
 		startKey := tablecodec.EncodeTablePrefix(job.TableID)
-		job.Args = append(job.Args, startKey)
+		job.Args = append(job.Args, startKey, getPartitionIDs(tblInfo))
 	default:
 		err = ErrInvalidDDLState.GenWithStackByArgs("table", tblInfo.State)
 	}
@@ -122,6 +122,155 @@ func onDropTableOrView(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	return ver, errors.Trace(err)
 }
 
+// onTruncateTable replaces the table with an empty one which has a new table
+// ID, so the auto ID is reset and the data of the old ID is left for GC.
+func onTruncateTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	tableID := job.TableID
+	var newTableID int64
+	var newPartitionIDs []int64
+	err := job.DecodeArgs(&newTableID, &newPartitionIDs)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	oldPartitionIDs := getPartitionIDs(tblInfo)
+	if len(oldPartitionIDs) != len(newPartitionIDs) {
+		job.State = model.JobStateCancelled
+		return ver, errors.Errorf("the number of partitions has changed from %d to %d", len(newPartitionIDs), len(oldPartitionIDs))
+	}
+
+	err = t.DropTableOrView(schemaID, tblInfo.ID, true)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo.ID = newTableID
+	for i := range oldPartitionIDs {
+		tblInfo.Partition.Definitions[i].ID = newPartitionIDs[i]
+	}
+	err = t.CreateTableOrView(schemaID, tblInfo)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	ver, err = updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	startKey := tablecodec.EncodeTablePrefix(tableID)
+	job.Args = []interface{}{startKey, oldPartitionIDs}
+	return ver, nil
+}
+
+// onRenameTable renames a table, and moves it together with its auto ID
+// when the new name is in another database.
+func onRenameTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var oldSchemaID int64
+	var tableName model.CIStr
+	if err := job.DecodeArgs(&oldSchemaID, &tableName); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, oldSchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	newSchemaID := job.SchemaID
+	err = checkTableNotExists(d, t, newSchemaID, tableName.L)
+	if err != nil {
+		if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	if err = renameTable(t, oldSchemaID, newSchemaID, tblInfo, tableName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	ver, err = updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// onRenameTables applies the renames of a RENAME TABLE statement in order within one
+// transaction, so either all the tables are renamed or none of them is.
+func onRenameTables(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var oldSchemaIDs, newSchemaIDs, tableIDs []int64
+	var tableNames []model.CIStr
+	if err := job.DecodeArgs(&oldSchemaIDs, &newSchemaIDs, &tableNames, &tableIDs); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfos := make([]*model.TableInfo, 0, len(tableIDs))
+	for i, tableID := range tableIDs {
+		tblInfo, err := getTableInfo(t, tableID, oldSchemaIDs[i])
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		// The earlier renames of this job are only visible in the store.
+		err = checkTableNotExistsFromStore(t, newSchemaIDs[i], tableNames[i].L)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if err = renameTable(t, oldSchemaIDs[i], newSchemaIDs[i], tblInfo, tableNames[i]); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		tblInfos = append(tblInfos, tblInfo)
+	}
+
+	ver, err := updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishMultipleTableJob(model.JobStateDone, model.StatePublic, ver, tblInfos)
+	return ver, nil
+}
+
+// renameTable stores tblInfo as tableName in newSchemaID. The auto ID is kept in the
+// database of the table, so it is moved too when the database changes.
+func renameTable(t *meta.Meta, oldSchemaID, newSchemaID int64, tblInfo *model.TableInfo, tableName model.CIStr) error {
+	var baseID int64
+	moveAutoID := oldSchemaID != newSchemaID
+	if moveAutoID {
+		var err error
+		baseID, err = t.GetAutoTableID(tblInfo.GetDBID(oldSchemaID), tblInfo.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The auto ID is in the new database now.
+		tblInfo.OldSchemaID = 0
+	}
+
+	err := renameSelfReferencingFKs(t, oldSchemaID, newSchemaID, tblInfo, tableName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = t.DropTableOrView(oldSchemaID, tblInfo.ID, moveAutoID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo.Name = tableName
+	return errors.Trace(t.CreateTableAndSetAutoID(newSchemaID, tblInfo, baseID))
+}
+
 func getTable(store kv.Storage, schemaID int64, tblInfo *model.TableInfo) (table.Table, error) {
 	alloc := autoid.NewAllocator(store, tblInfo.GetDBID(schemaID), tblInfo.IsAutoIncColUnsigned())
 	tbl, err := table.TableFromMeta(alloc, tblInfo)
@@ -123,6 +123,7 @@ func (builder *RequestBuilder) SetFromSessionVars(sv *variable.SessionVars) *Req
 	builder.Request.IsolationLevel = builder.getIsolationLevel()
 	builder.Request.NotFillCache = sv.StmtCtx.NotFillCache
 	builder.Request.ReplicaRead = sv.GetReplicaRead()
+	builder.Request.Deadline = sv.StmtCtx.Deadline
 	return builder
 }
 
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege/privileges"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics"
@@ -48,6 +49,7 @@ import (
 type Domain struct {
 	store           kv.Storage
 	infoHandle      *infoschema.Handle
+	privHandle      *privileges.Handle
 	statsHandle     unsafe.Pointer
 	statsLease      time.Duration
 	ddl             ddl.DDL
@@ -467,6 +469,7 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 		sysSessionPool:  newSessionPool(capacity, factory),
 		statsLease:      statsLease,
 		infoHandle:      infoschema.NewHandle(store),
+		privHandle:      privileges.NewHandle(),
 	}
 }
 
@@ -614,6 +617,54 @@ func (do *Domain) GetEtcdClient() *clientv3.Client {
 	return do.etcdClient
 }
 
+// privilegeLoadInterval is the interval to reload the privilege tables, so
+// the grants done by other TiDB servers take effect.
+const privilegeLoadInterval = 10 * time.Second
+
+// PrivilegeHandle returns the MySQLPrivilege.
+func (do *Domain) PrivilegeHandle() *privileges.Handle {
+	return do.privHandle
+}
+
+// LoadPrivilegeLoop create a goroutine loads privilege tables in a loop, it
+// should be called only once in BootstrapSession.
+func (do *Domain) LoadPrivilegeLoop(ctx sessionctx.Context) error {
+	ctx.GetSessionVars().InRestrictedSQL = true
+	err := do.privHandle.Update(ctx)
+	if err != nil {
+		return err
+	}
+
+	do.wg.Add(1)
+	go func() {
+		defer recoverInDomain("loadPrivilegeInLoop", false)
+		defer do.wg.Done()
+		ticker := time.NewTicker(privilegeLoadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := do.privHandle.Update(ctx)
+				if err != nil {
+					logutil.BgLogger().Error("load privilege failed", zap.Error(err))
+				}
+			case <-do.exit:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// NotifyUpdatePrivilege reloads the privilege tables after they are changed
+// by the current TiDB server.
+func (do *Domain) NotifyUpdatePrivilege(ctx sessionctx.Context) {
+	err := do.privHandle.Update(ctx)
+	if err != nil {
+		logutil.BgLogger().Error("load privilege failed", zap.Error(err))
+	}
+}
+
 // StatsHandle returns the statistic handle.
 func (do *Domain) StatsHandle() *statistics.Handle {
 	return (*statistics.Handle)(atomic.LoadPointer(&do.statsHandle))
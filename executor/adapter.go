@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
@@ -177,6 +178,7 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 	}()
 
 	sctx := a.Ctx
+	a.setDeadline()
 	e, err := a.buildExecutor()
 	if err != nil {
 		return nil, err
@@ -231,6 +233,18 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e Executor) (sqlex
 	return nil, err
 }
 
+// setDeadline attaches the deadline of max_execution_time to the statement
+// context. Like MySQL, the limit only applies to read-only statements.
+func (a *ExecStmt) setDeadline() {
+	if !a.IsReadOnly() {
+		return
+	}
+	sessVars := a.Ctx.GetSessionVars()
+	if maxExecutionTime := sessVars.GetMaxExecutionTime(); maxExecutionTime > 0 {
+		sessVars.StmtCtx.Deadline = time.Now().Add(time.Duration(maxExecutionTime) * time.Millisecond)
+	}
+}
+
 // buildExecutor build a executor from plan, prepared statement may need additional procedure.
 func (a *ExecStmt) buildExecutor() (Executor, error) {
 	ctx := a.Ctx
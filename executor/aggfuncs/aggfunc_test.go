@@ -78,9 +78,9 @@ func (s *testSuite) testMergePartialResult(c *C, p aggTest) {
 	iter := chunk.NewIterator4Chunk(srcChk)
 
 	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
-	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args)
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args, false)
 	c.Assert(err, IsNil)
-	partialDesc, finalDesc := desc.Split([]int{0, 1})
+	partialDesc, finalDesc := desc.Split([]int{0, 1, 2})
 
 	// build partial func for partial phase.
 	partialFunc := aggfuncs.Build(s.ctx, partialDesc, 0)
@@ -129,6 +129,51 @@ func (s *testSuite) testMergePartialResult(c *C, p aggTest) {
 	c.Assert(result, Equals, 0)
 }
 
+// testDistinctAggFunc tests the distinct aggregate function upon the column
+// generated by dataGen. The values [0, 1, 2, 1] and [2, 3, 4, 4, null] are
+// updated into two partial results, p.results are the results of the two
+// partial results and the merged one.
+func (s *testSuite) testDistinctAggFunc(c *C, p aggTest) {
+	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args, true)
+	c.Assert(err, IsNil)
+	partialDesc, finalDesc := desc.Split([]int{0, 1})
+	partialFunc := aggfuncs.Build(s.ctx, partialDesc, 0)
+	finalFunc := aggfuncs.Build(s.ctx, finalDesc, 0)
+
+	sc := s.ctx.GetSessionVars().StmtCtx
+	finalPr := finalFunc.AllocPartialResult()
+	resultChk := chunk.NewChunkWithCapacity([]*types.FieldType{desc.RetTp}, 1)
+	for i, values := range [][]int{{0, 1, 2, 1}, {2, 3, 4, 4, -1}} {
+		srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{p.dataType}, len(values))
+		for _, v := range values {
+			dt := types.Datum{}
+			if v >= 0 {
+				dt = p.dataGen(v)
+			}
+			srcChk.AppendDatum(0, &dt)
+		}
+		partialPr := partialFunc.AllocPartialResult()
+		iter := chunk.NewIterator4Chunk(srcChk)
+		for row := iter.Begin(); row != iter.End(); row = iter.Next() {
+			c.Assert(partialFunc.UpdatePartialResult(s.ctx, []chunk.Row{row}, partialPr), IsNil)
+		}
+		resultChk.Reset()
+		c.Assert(partialFunc.AppendFinalResult2Chunk(s.ctx, partialPr, resultChk), IsNil)
+		dt := resultChk.GetRow(0).GetDatum(0, desc.RetTp)
+		result, err := dt.CompareDatum(sc, &p.results[i])
+		c.Assert(err, IsNil)
+		c.Assert(result, Equals, 0, Commentf("partial result %d of %s", i, p.funcName))
+		c.Assert(finalFunc.MergePartialResult(s.ctx, partialPr, finalPr), IsNil)
+	}
+	resultChk.Reset()
+	c.Assert(finalFunc.AppendFinalResult2Chunk(s.ctx, finalPr, resultChk), IsNil)
+	dt := resultChk.GetRow(0).GetDatum(0, desc.RetTp)
+	result, err := dt.CompareDatum(sc, &p.results[2])
+	c.Assert(err, IsNil)
+	c.Assert(result, Equals, 0, Commentf("final result of %s", p.funcName))
+}
+
 func buildAggTester(funcName string, tp byte, numRows int, results ...interface{}) aggTest {
 	return buildAggTesterWithFieldType(funcName, types.NewFieldType(tp), numRows, results...)
 }
@@ -169,7 +214,7 @@ func (s *testSuite) testAggFunc(c *C, p aggTest) {
 	srcChk.AppendDatum(0, &types.Datum{})
 
 	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
-	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args)
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args, false)
 	c.Assert(err, IsNil)
 	finalFunc := aggfuncs.Build(s.ctx, desc, 0)
 	finalPr := finalFunc.AllocPartialResult()
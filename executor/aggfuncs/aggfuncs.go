@@ -28,6 +28,7 @@ var (
 	_ AggFunc = (*countOriginal4Int)(nil)
 	_ AggFunc = (*countOriginal4Real)(nil)
 	_ AggFunc = (*countOriginal4String)(nil)
+	_ AggFunc = (*countOriginalWithDistinct)(nil)
 
 	// All the AggFunc implementations for "FIRSTROW" are listed here.
 	_ AggFunc = (*firstRow4Int)(nil)
@@ -45,13 +46,34 @@ var (
 	// All the AggFunc implementations for "AVG" are listed here.
 	_ AggFunc = (*avgOriginal4Int64)(nil)
 	_ AggFunc = (*avgPartial4Int64)(nil)
+	_ AggFunc = (*avgOriginal4DistinctInt64)(nil)
 
 	_ AggFunc = (*avgOriginal4Float64)(nil)
 	_ AggFunc = (*avgPartial4Float64)(nil)
+	_ AggFunc = (*avgOriginal4DistinctFloat64)(nil)
 
 	// All the AggFunc implementations for "SUM" are listed here.
 	_ AggFunc = (*sum4Int64)(nil)
 	_ AggFunc = (*sum4Float64)(nil)
+	_ AggFunc = (*sum4DistinctInt64)(nil)
+	_ AggFunc = (*sum4DistinctFloat64)(nil)
+
+	// All the AggFunc implementations for "BIT_OR"/"BIT_XOR"/"BIT_AND" are listed here.
+	_ AggFunc = (*bitOrUint64)(nil)
+	_ AggFunc = (*bitXorUint64)(nil)
+	_ AggFunc = (*bitAndUint64)(nil)
+
+	// All the AggFunc implementations for "GROUP_CONCAT" are listed here.
+	_ AggFunc = (*groupConcat)(nil)
+	_ AggFunc = (*groupConcatOrder)(nil)
+
+	// All the AggFunc implementations for "VAR_POP"/"VAR_SAMP"/"STDDEV_POP"/"STDDEV_SAMP" are listed here.
+	_ AggFunc = (*varianceOriginal4Float64)(nil)
+	_ AggFunc = (*variancePartial4Float64)(nil)
+
+	// All the AggFunc implementations for "APPROX_COUNT_DISTINCT" are listed here.
+	_ AggFunc = (*approxCountDistinctOriginal)(nil)
+	_ AggFunc = (*approxCountDistinctPartial)(nil)
 )
 
 // PartialResult represents data structure to store the partial result for the
@@ -14,11 +14,16 @@
 package aggfuncs
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 )
 
 // Build is used to build a specific AggFunc implementation according to the
@@ -37,6 +42,24 @@ func Build(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal
 		return buildMaxMin(aggFuncDesc, ordinal, true)
 	case ast.AggFuncMin:
 		return buildMaxMin(aggFuncDesc, ordinal, false)
+	case ast.AggFuncBitOr:
+		return buildBitOr(aggFuncDesc, ordinal)
+	case ast.AggFuncBitXor:
+		return buildBitXor(aggFuncDesc, ordinal)
+	case ast.AggFuncBitAnd:
+		return buildBitAnd(aggFuncDesc, ordinal)
+	case ast.AggFuncGroupConcat:
+		return buildGroupConcat(ctx, aggFuncDesc, ordinal)
+	case ast.AggFuncVarPop:
+		return buildVariance(aggFuncDesc, ordinal, false, false)
+	case ast.AggFuncVarSamp:
+		return buildVariance(aggFuncDesc, ordinal, true, false)
+	case ast.AggFuncStddevPop:
+		return buildVariance(aggFuncDesc, ordinal, false, true)
+	case ast.AggFuncStddevSamp:
+		return buildVariance(aggFuncDesc, ordinal, true, true)
+	case ast.AggFuncApproxCountDistinct:
+		return buildApproxCountDistinct(aggFuncDesc, ordinal)
 	}
 	return nil
 }
@@ -48,6 +71,13 @@ func buildCount(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		ordinal: ordinal,
 	}
 
+	// The distinct aggregate functions are never pushed down, so the partial
+	// and final ones are built with the same implementation, whose partial
+	// results are merged by the final workers.
+	if aggFuncDesc.HasDistinct {
+		return &countOriginalWithDistinct{baseCount{base}}
+	}
+
 	switch aggFuncDesc.Mode {
 	case aggregation.CompleteMode, aggregation.Partial1Mode:
 		switch aggFuncDesc.Args[0].GetType().EvalType() {
@@ -73,6 +103,14 @@ func buildSum(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 			ordinal: ordinal,
 		},
 	}
+	if aggFuncDesc.HasDistinct {
+		switch aggFuncDesc.RetTp.EvalType() {
+		case types.ETInt:
+			return &sum4DistinctInt64{base}
+		default:
+			return &sum4DistinctFloat64{base}
+		}
+	}
 	switch aggFuncDesc.RetTp.EvalType() {
 	case types.ETInt:
 		return &sum4Int64{base}
@@ -87,6 +125,14 @@ func buildAvg(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
+	if aggFuncDesc.HasDistinct {
+		switch aggFuncDesc.RetTp.EvalType() {
+		case types.ETInt:
+			return &avgOriginal4DistinctInt64{baseAvgInt64{base}}
+		default:
+			return &avgOriginal4DistinctFloat64{baseAvgFloat64{base}}
+		}
+	}
 	switch aggFuncDesc.Mode {
 	// Build avg functions which consume the original data and update their
 	// partial results.
@@ -170,3 +216,98 @@ func buildMaxMin(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, isMax bool)
 	}
 	return nil
 }
+
+// buildBitOr builds the AggFunc implementation for function "BIT_OR".
+func buildBitOr(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitOrUint64{baseBitAggFunc{base}}
+}
+
+// buildBitXor builds the AggFunc implementation for function "BIT_XOR".
+func buildBitXor(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitXorUint64{baseBitAggFunc{base}}
+}
+
+// buildBitAnd builds the AggFunc implementation for function "BIT_AND".
+func buildBitAnd(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitAndUint64{baseBitAggFunc{base}}
+}
+
+// buildGroupConcat builds the AggFunc implementation for function "GROUP_CONCAT".
+func buildGroupConcat(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	// The last argument is promised to be a non-null string constant by the
+	// parser, so the error should never happen.
+	sep, _, err := aggFuncDesc.Args[len(aggFuncDesc.Args)-1].EvalString(ctx, chunk.Row{})
+	if err != nil {
+		panic(fmt.Sprintf("Error happened when buildGroupConcat: %s", err.Error()))
+	}
+	var maxLen uint64
+	if s, ok := ctx.GetSessionVars().GetSystemVar(variable.GroupConcatMaxLen); ok {
+		maxLen, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("Error happened when buildGroupConcat: illegal value for system variable group_concat_max_len %s", s))
+		}
+	}
+	baseGroupConcat := baseGroupConcat4String{
+		baseAggFunc: base,
+		sep:         sep,
+		maxLen:      maxLen,
+	}
+	if len(aggFuncDesc.OrderByItems) > 0 {
+		return &groupConcatOrder{baseGroupConcat4String: baseGroupConcat, byItems: aggFuncDesc.OrderByItems}
+	}
+	return &groupConcat{baseGroupConcat}
+}
+
+// buildVariance builds the AggFunc implementation for function "VAR_POP",
+// "VAR_SAMP", "STDDEV_POP" and "STDDEV_SAMP".
+func buildVariance(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, isSamp, isStddev bool) AggFunc {
+	base := baseVariance4Float64{
+		baseAggFunc: baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+		},
+		isSamp:   isSamp,
+		isStddev: isStddev,
+	}
+	switch aggFuncDesc.Mode {
+	case aggregation.CompleteMode, aggregation.Partial1Mode:
+		return &varianceOriginal4Float64{base}
+	case aggregation.Partial2Mode, aggregation.FinalMode:
+		return &variancePartial4Float64{base}
+	}
+	return nil
+}
+
+// buildApproxCountDistinct builds the AggFunc implementation for function
+// "APPROX_COUNT_DISTINCT".
+func buildApproxCountDistinct(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseApproxCountDistinct{
+		baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+		},
+	}
+	switch aggFuncDesc.Mode {
+	case aggregation.CompleteMode, aggregation.Partial1Mode:
+		return &approxCountDistinctOriginal{base}
+	case aggregation.Partial2Mode, aggregation.FinalMode:
+		return &approxCountDistinctPartial{base}
+	}
+	return nil
+}
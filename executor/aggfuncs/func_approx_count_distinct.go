@@ -0,0 +1,111 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hyperloglog"
+)
+
+// All the following approx_count_distinct function implementations estimate
+// the number of distinct values by the HyperLogLog sketch stored in
+// "partialResult4ApproxCountDistinct".
+//
+// "baseApproxCountDistinct" is wrapped by:
+// - "approxCountDistinctOriginal"
+// - "approxCountDistinctPartial"
+type baseApproxCountDistinct struct {
+	baseAggFunc
+}
+
+type partialResult4ApproxCountDistinct struct {
+	sketch *hyperloglog.Sketch
+}
+
+func (e *baseApproxCountDistinct) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4ApproxCountDistinct{sketch: hyperloglog.NewSketch()})
+}
+
+func (e *baseApproxCountDistinct) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4ApproxCountDistinct)(pr)
+	p.sketch.Reset()
+}
+
+func (e *baseApproxCountDistinct) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4ApproxCountDistinct)(src), (*partialResult4ApproxCountDistinct)(dst)
+	p2.sketch.Merge(p1.sketch)
+	return nil
+}
+
+func (e *baseApproxCountDistinct) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4ApproxCountDistinct)(pr)
+	chk.AppendInt64(e.ordinal, p.sketch.Estimate())
+	return nil
+}
+
+type approxCountDistinctOriginal struct {
+	baseApproxCountDistinct
+}
+
+func (e *approxCountDistinctOriginal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4ApproxCountDistinct)(pr)
+	sc := sctx.GetSessionVars().StmtCtx
+	values := make([]types.Datum, len(e.args))
+	for _, row := range rowsInGroup {
+		hasNull := false
+		for i, arg := range e.args {
+			value, err := arg.Eval(row)
+			if err != nil {
+				return err
+			}
+			if value.IsNull() {
+				hasNull = true
+				break
+			}
+			values[i] = value
+		}
+		if hasNull {
+			continue
+		}
+		if err := p.sketch.InsertValues(sc, values...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type approxCountDistinctPartial struct {
+	baseApproxCountDistinct
+}
+
+func (e *approxCountDistinctPartial) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4ApproxCountDistinct)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalString(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		sketch, err := hyperloglog.DecodeSketch([]byte(input))
+		if err != nil {
+			return err
+		}
+		p.sketch.Merge(sketch)
+	}
+	return nil
+}
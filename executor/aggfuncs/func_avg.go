@@ -17,6 +17,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/set"
 )
 
 // All the following avg function implementations return the decimal result,
@@ -25,6 +26,7 @@ import (
 // "baseAvgDecimal" is wrapped by:
 // - "avgOriginal4Int64"
 // - "avgPartial4Int64"
+// - "avgOriginal4DistinctInt64"
 type baseAvgInt64 struct {
 	baseAggFunc
 }
@@ -216,3 +218,119 @@ func (e *avgPartial4Float64) MergePartialResult(sctx sessionctx.Context, src Par
 	p2.count += p1.count
 	return nil
 }
+
+type partialResult4AvgDistinctInt64 struct {
+	partialResult4AvgInt64
+	valSet set.Int64Set
+}
+
+// avgOriginal4DistinctInt64 averages the distinct values, the values already
+// counted are kept in a per-group hash set.
+type avgOriginal4DistinctInt64 struct {
+	baseAvgInt64
+}
+
+func (e *avgOriginal4DistinctInt64) AllocPartialResult() PartialResult {
+	p := &partialResult4AvgDistinctInt64{valSet: set.NewInt64Set()}
+	return PartialResult(p)
+}
+
+func (e *avgOriginal4DistinctInt64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4AvgDistinctInt64)(pr)
+	p.sum = 0
+	p.count = 0
+	p.valSet = set.NewInt64Set()
+}
+
+func (e *avgOriginal4DistinctInt64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4AvgDistinctInt64)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalInt(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || p.valSet.Exist(input) {
+			continue
+		}
+
+		newSum, err := types.AddInt64(p.sum, input)
+		if err != nil {
+			return err
+		}
+		p.valSet.Insert(input)
+		p.sum = newSum
+		p.count++
+	}
+	return nil
+}
+
+func (e *avgOriginal4DistinctInt64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4AvgDistinctInt64)(src), (*partialResult4AvgDistinctInt64)(dst)
+	for val := range p1.valSet {
+		if p2.valSet.Exist(val) {
+			continue
+		}
+		newSum, err := types.AddInt64(p2.sum, val)
+		if err != nil {
+			return err
+		}
+		p2.valSet.Insert(val)
+		p2.sum = newSum
+		p2.count++
+	}
+	return nil
+}
+
+type partialResult4AvgDistinctFloat64 struct {
+	partialResult4AvgFloat64
+	valSet set.Float64Set
+}
+
+// avgOriginal4DistinctFloat64 averages the distinct values, the values
+// already counted are kept in a per-group hash set.
+type avgOriginal4DistinctFloat64 struct {
+	baseAvgFloat64
+}
+
+func (e *avgOriginal4DistinctFloat64) AllocPartialResult() PartialResult {
+	p := &partialResult4AvgDistinctFloat64{valSet: set.NewFloat64Set()}
+	return PartialResult(p)
+}
+
+func (e *avgOriginal4DistinctFloat64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4AvgDistinctFloat64)(pr)
+	p.sum = 0
+	p.count = 0
+	p.valSet = set.NewFloat64Set()
+}
+
+func (e *avgOriginal4DistinctFloat64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4AvgDistinctFloat64)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || p.valSet.Exist(input) {
+			continue
+		}
+
+		p.valSet.Insert(input)
+		p.sum += input
+		p.count++
+	}
+	return nil
+}
+
+func (e *avgOriginal4DistinctFloat64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4AvgDistinctFloat64)(src), (*partialResult4AvgDistinctFloat64)(dst)
+	for val := range p1.valSet {
+		if p2.valSet.Exist(val) {
+			continue
+		}
+		p2.valSet.Insert(val)
+		p2.sum += val
+		p2.count++
+	}
+	return nil
+}
@@ -38,3 +38,13 @@ func (s *testSuite) TestAvg(c *C) {
 		s.testAggFunc(c, test)
 	}
 }
+
+func (s *testSuite) TestAvgDistinct(c *C) {
+	tests := []aggTest{
+		buildAggTester(ast.AggFuncAvg, mysql.TypeLonglong, 5, int64(1), int64(3), int64(2)),
+		buildAggTester(ast.AggFuncAvg, mysql.TypeDouble, 5, 1.0, 3.0, 2.0),
+	}
+	for _, test := range tests {
+		s.testDistinctAggFunc(c, test)
+	}
+}
@@ -1,8 +1,13 @@
 package aggfuncs
 
 import (
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+	"github.com/pingcap/tidb/util/set"
 )
 
 type baseCount struct {
@@ -117,3 +122,105 @@ func (*countPartial) MergePartialResult(sctx sessionctx.Context, src, dst Partia
 	*p2 += *p1
 	return nil
 }
+
+type partialResult4CountWithDistinct struct {
+	count int64
+
+	valSet set.StringSet
+}
+
+// countOriginalWithDistinct counts the distinct tuples of its arguments, the
+// encoded tuples are kept in a per-group hash set, so the partial results of
+// different workers can be merged by a union of the sets.
+type countOriginalWithDistinct struct {
+	baseCount
+}
+
+func (e *countOriginalWithDistinct) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4CountWithDistinct{
+		count:  0,
+		valSet: set.NewStringSet(),
+	})
+}
+
+func (e *countOriginalWithDistinct) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4CountWithDistinct)(pr)
+	p.count = 0
+	p.valSet = set.NewStringSet()
+}
+
+func (e *countOriginalWithDistinct) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4CountWithDistinct)(pr)
+	chk.AppendInt64(e.ordinal, p.count)
+	return nil
+}
+
+func (e *countOriginalWithDistinct) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (err error) {
+	p := (*partialResult4CountWithDistinct)(pr)
+
+	// The buffer is allocated for each call since the function may be shared
+	// by several workers.
+	var encodedBytes []byte
+	for _, row := range rowsInGroup {
+		hasNull, isNull := false, false
+		encodedBytes = encodedBytes[:0]
+
+		for i := 0; i < len(e.args) && !hasNull; i++ {
+			encodedBytes, isNull, err = e.evalAndEncode(sctx, e.args[i], row, encodedBytes)
+			if err != nil {
+				return err
+			}
+			hasNull = isNull
+		}
+		if hasNull || p.valSet.Exist(string(encodedBytes)) {
+			continue
+		}
+		p.valSet.Insert(string(encodedBytes))
+		p.count++
+	}
+
+	return nil
+}
+
+// evalAndEncode evaluates one argument and appends it to the encoded bytes,
+// the encoding is memory comparable so the tuples of several arguments can't
+// be confused with each other.
+func (e *countOriginalWithDistinct) evalAndEncode(sctx sessionctx.Context, arg expression.Expression,
+	row chunk.Row, buf []byte) (_ []byte, isNull bool, err error) {
+	switch arg.GetType().EvalType() {
+	case types.ETInt:
+		var val int64
+		val, isNull, err = arg.EvalInt(sctx, row)
+		if err != nil || isNull {
+			break
+		}
+		buf = codec.EncodeInt(buf, val)
+	case types.ETReal:
+		var val float64
+		val, isNull, err = arg.EvalReal(sctx, row)
+		if err != nil || isNull {
+			break
+		}
+		buf = codec.EncodeFloat(buf, val)
+	default:
+		var val string
+		val, isNull, err = arg.EvalString(sctx, row)
+		if err != nil || isNull {
+			break
+		}
+		buf = codec.EncodeBytes(buf, hack.Slice(val))
+	}
+	return buf, isNull, err
+}
+
+func (e *countOriginalWithDistinct) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4CountWithDistinct)(src), (*partialResult4CountWithDistinct)(dst)
+	for val := range p1.valSet {
+		if p2.valSet.Exist(val) {
+			continue
+		}
+		p2.valSet.Insert(val)
+		p2.count++
+	}
+	return nil
+}
@@ -14,9 +14,16 @@
 package aggfuncs_test
 
 import (
+	"fmt"
+
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/executor/aggfuncs"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 )
 
 func (s *testSuite) TestMergePartialResult4Count(c *C) {
@@ -35,3 +42,43 @@ func (s *testSuite) TestCount(c *C) {
 		s.testAggFunc(c, test)
 	}
 }
+
+func (s *testSuite) TestCountDistinct(c *C) {
+	tests := []aggTest{
+		buildAggTester(ast.AggFuncCount, mysql.TypeLonglong, 5, int64(3), int64(3), int64(5)),
+		buildAggTester(ast.AggFuncCount, mysql.TypeDouble, 5, int64(3), int64(3), int64(5)),
+		buildAggTester(ast.AggFuncCount, mysql.TypeString, 5, int64(3), int64(3), int64(5)),
+	}
+	for _, test := range tests {
+		s.testDistinctAggFunc(c, test)
+	}
+}
+
+func (s *testSuite) TestCountDistinctMultiArgs(c *C) {
+	intTp, strTp := types.NewFieldType(mysql.TypeLonglong), types.NewFieldType(mysql.TypeString)
+	args := []expression.Expression{
+		&expression.Column{RetType: intTp, Index: 0},
+		&expression.Column{RetType: strTp, Index: 1},
+	}
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, ast.AggFuncCount, args, true)
+	c.Assert(err, IsNil)
+	aggFunc := aggfuncs.Build(s.ctx, desc, 0)
+	pr := aggFunc.AllocPartialResult()
+
+	// (i%3, i%4) has 12 distinct values, and the tuples containing null are
+	// ignored.
+	srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{intTp, strTp}, 48)
+	for i := 0; i < 24; i++ {
+		srcChk.AppendInt64(0, int64(i%3))
+		srcChk.AppendString(1, fmt.Sprintf("%d", i%4))
+		srcChk.AppendNull(0)
+		srcChk.AppendString(1, fmt.Sprintf("%d", i%4))
+	}
+	iter := chunk.NewIterator4Chunk(srcChk)
+	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
+		c.Assert(aggFunc.UpdatePartialResult(s.ctx, []chunk.Row{row}, pr), IsNil)
+	}
+	resultChk := chunk.NewChunkWithCapacity([]*types.FieldType{desc.RetTp}, 1)
+	c.Assert(aggFunc.AppendFinalResult2Chunk(s.ctx, pr, resultChk), IsNil)
+	c.Assert(resultChk.GetRow(0).GetInt64(0), Equals, int64(12))
+}
@@ -0,0 +1,232 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"bytes"
+	"sort"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// All the following group_concat function implementations concatenate the
+// values of the arguments except the last one, which is the separator. The
+// result is truncated to group_concat_max_len bytes.
+//
+// "baseGroupConcat4String" is wrapped by:
+// - "groupConcat"
+// - "groupConcatOrder"
+type baseGroupConcat4String struct {
+	baseAggFunc
+
+	sep    string
+	maxLen uint64
+	// truncated is set when the first truncation happens, according to MySQL,
+	// only one warning is reported no matter how many groups are truncated.
+	truncated int32
+}
+
+// evalValue concatenates the values of the arguments in the row, isNull is
+// true if any of them is null.
+func (e *baseGroupConcat4String) evalValue(sctx sessionctx.Context, row chunk.Row, buf *bytes.Buffer) (isNull bool, err error) {
+	buf.Reset()
+	for _, arg := range e.args[:len(e.args)-1] {
+		v, isNull, err := arg.EvalString(sctx, row)
+		if err != nil || isNull {
+			return isNull, err
+		}
+		buf.WriteString(v)
+	}
+	return false, nil
+}
+
+// truncatePartialResultIfNeed truncates the buffer to maxLen bytes, and
+// appends a warning the first time it happens.
+func (e *baseGroupConcat4String) truncatePartialResultIfNeed(sctx sessionctx.Context, buffer *bytes.Buffer) {
+	if e.maxLen == 0 || uint64(buffer.Len()) <= e.maxLen {
+		return
+	}
+	buffer.Truncate(int(e.maxLen))
+	if atomic.CompareAndSwapInt32(&e.truncated, 0, 1) {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(expression.ErrCutValueGroupConcat.GenWithStackByArgs(e.args[0].String()))
+	}
+}
+
+type partialResult4GroupConcat struct {
+	valsBuf *bytes.Buffer
+	// buffer is nil if there is no non-null input.
+	buffer *bytes.Buffer
+}
+
+type groupConcat struct {
+	baseGroupConcat4String
+}
+
+func (e *groupConcat) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4GroupConcat{valsBuf: &bytes.Buffer{}})
+}
+
+func (e *groupConcat) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4GroupConcat)(pr)
+	p.buffer = nil
+}
+
+func (e *groupConcat) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4GroupConcat)(pr)
+	for _, row := range rowsInGroup {
+		isNull, err := e.evalValue(sctx, row, p.valsBuf)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		if p.buffer == nil {
+			p.buffer = &bytes.Buffer{}
+		} else {
+			p.buffer.WriteString(e.sep)
+		}
+		p.buffer.Write(p.valsBuf.Bytes())
+		e.truncatePartialResultIfNeed(sctx, p.buffer)
+	}
+	return nil
+}
+
+func (e *groupConcat) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4GroupConcat)(src), (*partialResult4GroupConcat)(dst)
+	if p1.buffer == nil {
+		return nil
+	}
+	if p2.buffer == nil {
+		p2.buffer = &bytes.Buffer{}
+	} else {
+		p2.buffer.WriteString(e.sep)
+	}
+	p2.buffer.Write(p1.buffer.Bytes())
+	e.truncatePartialResultIfNeed(sctx, p2.buffer)
+	return nil
+}
+
+func (e *groupConcat) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4GroupConcat)(pr)
+	if p.buffer == nil {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendString(e.ordinal, p.buffer.String())
+	return nil
+}
+
+// groupConcatRow is a concatenated value along with its sort keys.
+type groupConcatRow struct {
+	value string
+	keys  []types.Datum
+}
+
+type partialResult4GroupConcatOrder struct {
+	valsBuf *bytes.Buffer
+	rows    []groupConcatRow
+}
+
+// groupConcatOrder implements GROUP_CONCAT with ORDER BY, it collects all the
+// values of a group and sorts them before concatenating.
+type groupConcatOrder struct {
+	baseGroupConcat4String
+
+	byItems []*aggregation.ByItems
+}
+
+func (e *groupConcatOrder) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4GroupConcatOrder{valsBuf: &bytes.Buffer{}})
+}
+
+func (e *groupConcatOrder) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4GroupConcatOrder)(pr)
+	p.rows = p.rows[:0]
+}
+
+func (e *groupConcatOrder) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4GroupConcatOrder)(pr)
+	for _, row := range rowsInGroup {
+		isNull, err := e.evalValue(sctx, row, p.valsBuf)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		keys := make([]types.Datum, 0, len(e.byItems))
+		for _, item := range e.byItems {
+			key, err := item.Expr.Eval(row)
+			if err != nil {
+				return err
+			}
+			// The key may reference the memory of the input chunk, which is
+			// reused later, so it's copied here.
+			keys = append(keys, *key.Copy())
+		}
+		p.rows = append(p.rows, groupConcatRow{value: p.valsBuf.String(), keys: keys})
+	}
+	return nil
+}
+
+func (e *groupConcatOrder) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4GroupConcatOrder)(src), (*partialResult4GroupConcatOrder)(dst)
+	p2.rows = append(p2.rows, p1.rows...)
+	return nil
+}
+
+func (e *groupConcatOrder) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4GroupConcatOrder)(pr)
+	if len(p.rows) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	var err error
+	sort.SliceStable(p.rows, func(i, j int) bool {
+		for k, item := range e.byItems {
+			cmp, cmpErr := p.rows[i].keys[k].CompareDatum(sc, &p.rows[j].keys[k])
+			if cmpErr != nil {
+				err = cmpErr
+				return false
+			}
+			if cmp != 0 {
+				return (cmp < 0) != item.Desc
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	buffer := &bytes.Buffer{}
+	for i, row := range p.rows {
+		if i > 0 {
+			buffer.WriteString(e.sep)
+		}
+		buffer.WriteString(row.value)
+		if e.maxLen > 0 && uint64(buffer.Len()) > e.maxLen {
+			break
+		}
+	}
+	e.truncatePartialResultIfNeed(sctx, buffer)
+	chk.AppendString(e.ordinal, buffer.String())
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/executor/aggfuncs"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// buildGroupConcatDesc builds the desc of "group_concat(col order by col [desc] separator sep)"
+// upon a bigint column.
+func (s *testSuite) buildGroupConcatDesc(c *C, sep string, orderBy bool, desc bool) *aggregation.AggFuncDesc {
+	col := &expression.Column{RetType: types.NewFieldType(mysql.TypeLonglong), Index: 0}
+	args := []expression.Expression{col, &expression.Constant{Value: types.NewStringDatum(sep), RetType: types.NewFieldType(mysql.TypeString)}}
+	aggDesc, err := aggregation.NewAggFuncDesc(s.ctx, ast.AggFuncGroupConcat, args, false)
+	c.Assert(err, IsNil)
+	if orderBy {
+		aggDesc.OrderByItems = []*aggregation.ByItems{{Expr: col, Desc: desc}}
+	}
+	return aggDesc
+}
+
+func (s *testSuite) evalGroupConcat(c *C, aggDesc *aggregation.AggFuncDesc, numRows int) types.Datum {
+	srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}, numRows+1)
+	for i := 0; i < numRows; i++ {
+		srcChk.AppendInt64(0, int64(i))
+	}
+	srcChk.AppendNull(0)
+	aggFunc := aggfuncs.Build(s.ctx, aggDesc, 0)
+	pr := aggFunc.AllocPartialResult()
+	iter := chunk.NewIterator4Chunk(srcChk)
+	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
+		c.Assert(aggFunc.UpdatePartialResult(s.ctx, []chunk.Row{row}, pr), IsNil)
+	}
+	resultChk := chunk.NewChunkWithCapacity([]*types.FieldType{aggDesc.RetTp}, 1)
+	c.Assert(aggFunc.AppendFinalResult2Chunk(s.ctx, pr, resultChk), IsNil)
+	return resultChk.GetRow(0).GetDatum(0, aggDesc.RetTp)
+}
+
+func (s *testSuite) TestGroupConcat(c *C) {
+	tests := []struct {
+		sep     string
+		orderBy bool
+		desc    bool
+		numRows int
+		result  interface{}
+	}{
+		{",", false, false, 0, nil},
+		{",", false, false, 5, "0,1,2,3,4"},
+		{"--", false, false, 3, "0--1--2"},
+		{"", false, false, 3, "012"},
+		{",", true, false, 5, "0,1,2,3,4"},
+		{",", true, true, 5, "4,3,2,1,0"},
+		{",", true, true, 0, nil},
+	}
+	for _, t := range tests {
+		aggDesc := s.buildGroupConcatDesc(c, t.sep, t.orderBy, t.desc)
+		result := s.evalGroupConcat(c, aggDesc, t.numRows)
+		if t.result == nil {
+			c.Assert(result.IsNull(), IsTrue)
+		} else {
+			c.Assert(result.GetString(), Equals, t.result)
+		}
+	}
+
+	// The result is truncated to group_concat_max_len with a warning.
+	sessVars := s.ctx.GetSessionVars()
+	c.Assert(sessVars.SetSystemVar(variable.GroupConcatMaxLen, "6"), IsNil)
+	defer func() {
+		c.Assert(sessVars.SetSystemVar(variable.GroupConcatMaxLen, "1024"), IsNil)
+	}()
+	for _, orderBy := range []bool{false, true} {
+		sessVars.StmtCtx.SetWarnings(nil)
+		aggDesc := s.buildGroupConcatDesc(c, ",", orderBy, true)
+		result := s.evalGroupConcat(c, aggDesc, 10)
+		if orderBy {
+			c.Assert(result.GetString(), Equals, "9,8,7,")
+		} else {
+			c.Assert(result.GetString(), Equals, "0,1,2,")
+		}
+		c.Assert(sessVars.StmtCtx.WarningCount(), Equals, uint16(1))
+	}
+}
+
+func (s *testSuite) TestMergePartialResult4GroupConcat(c *C) {
+	for _, orderBy := range []bool{false, true} {
+		aggDesc := s.buildGroupConcatDesc(c, ",", orderBy, true)
+		partialDesc, finalDesc := aggDesc.Split([]int{0})
+		partialFunc := aggfuncs.Build(s.ctx, partialDesc, 0)
+		finalFunc := aggfuncs.Build(s.ctx, finalDesc, 0)
+		finalPr := finalFunc.AllocPartialResult()
+
+		srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}, 5)
+		for i := 0; i < 5; i++ {
+			srcChk.AppendInt64(0, int64(i))
+		}
+		// The rows are split into two partial groups: [0, 2) and [2, 5).
+		for _, rows := range [][2]int{{0, 2}, {2, 5}} {
+			partialPr := partialFunc.AllocPartialResult()
+			for i := rows[0]; i < rows[1]; i++ {
+				c.Assert(partialFunc.UpdatePartialResult(s.ctx, []chunk.Row{srcChk.GetRow(i)}, partialPr), IsNil)
+			}
+			c.Assert(finalFunc.MergePartialResult(s.ctx, partialPr, finalPr), IsNil)
+		}
+		resultChk := chunk.NewChunkWithCapacity([]*types.FieldType{aggDesc.RetTp}, 1)
+		c.Assert(finalFunc.AppendFinalResult2Chunk(s.ctx, finalPr, resultChk), IsNil)
+		if orderBy {
+			c.Assert(resultChk.GetRow(0).GetString(0), Equals, "4,3,2,1,0")
+		} else {
+			c.Assert(resultChk.GetRow(0).GetString(0), Equals, "0,1,2,3,4")
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/set"
 )
 
 type partialResult4SumFloat64 struct {
@@ -152,3 +153,141 @@ func (e *sum4Int64) MergePartialResult(sctx sessionctx.Context, src, dst Partial
 	p2.isNull = false
 	return nil
 }
+
+type partialResult4SumDistinctFloat64 struct {
+	partialResult4SumFloat64
+	valSet set.Float64Set
+}
+
+// sum4DistinctFloat64 sums the distinct values, the values already summed are
+// kept in a per-group hash set.
+type sum4DistinctFloat64 struct {
+	baseSumAggFunc
+}
+
+func (e *sum4DistinctFloat64) AllocPartialResult() PartialResult {
+	p := new(partialResult4SumDistinctFloat64)
+	p.isNull = true
+	p.valSet = set.NewFloat64Set()
+	return PartialResult(p)
+}
+
+func (e *sum4DistinctFloat64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SumDistinctFloat64)(pr)
+	p.val = 0
+	p.isNull = true
+	p.valSet = set.NewFloat64Set()
+}
+
+func (e *sum4DistinctFloat64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SumDistinctFloat64)(pr)
+	if p.isNull {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, p.val)
+	return nil
+}
+
+func (e *sum4DistinctFloat64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4SumDistinctFloat64)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || p.valSet.Exist(input) {
+			continue
+		}
+		p.valSet.Insert(input)
+		p.val += input
+		p.isNull = false
+	}
+	return nil
+}
+
+func (e *sum4DistinctFloat64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4SumDistinctFloat64)(src), (*partialResult4SumDistinctFloat64)(dst)
+	for val := range p1.valSet {
+		if p2.valSet.Exist(val) {
+			continue
+		}
+		p2.valSet.Insert(val)
+		p2.val += val
+		p2.isNull = false
+	}
+	return nil
+}
+
+type partialResult4SumDistinctInt64 struct {
+	partialResult4Int64
+	valSet set.Int64Set
+}
+
+// sum4DistinctInt64 sums the distinct values, the values already summed are
+// kept in a per-group hash set.
+type sum4DistinctInt64 struct {
+	baseSumAggFunc
+}
+
+func (e *sum4DistinctInt64) AllocPartialResult() PartialResult {
+	p := new(partialResult4SumDistinctInt64)
+	p.isNull = true
+	p.valSet = set.NewInt64Set()
+	return PartialResult(p)
+}
+
+func (e *sum4DistinctInt64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SumDistinctInt64)(pr)
+	p.val = 0
+	p.isNull = true
+	p.valSet = set.NewInt64Set()
+}
+
+func (e *sum4DistinctInt64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SumDistinctInt64)(pr)
+	if p.isNull {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendInt64(e.ordinal, p.val)
+	return nil
+}
+
+func (e *sum4DistinctInt64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4SumDistinctInt64)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalInt(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || p.valSet.Exist(input) {
+			continue
+		}
+		newSum, err := types.AddInt64(p.val, input)
+		if err != nil {
+			return err
+		}
+		p.valSet.Insert(input)
+		p.val = newSum
+		p.isNull = false
+	}
+	return nil
+}
+
+func (e *sum4DistinctInt64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4SumDistinctInt64)(src), (*partialResult4SumDistinctInt64)(dst)
+	for val := range p1.valSet {
+		if p2.valSet.Exist(val) {
+			continue
+		}
+		newSum, err := types.AddInt64(p2.val, val)
+		if err != nil {
+			return err
+		}
+		p2.valSet.Insert(val)
+		p2.val = newSum
+		p2.isNull = false
+	}
+	return nil
+}
@@ -38,3 +38,13 @@ func (s *testSuite) TestSum(c *C) {
 		s.testAggFunc(c, test)
 	}
 }
+
+func (s *testSuite) TestSumDistinct(c *C) {
+	tests := []aggTest{
+		buildAggTester(ast.AggFuncSum, mysql.TypeLonglong, 5, int64(3), int64(9), int64(10)),
+		buildAggTester(ast.AggFuncSum, mysql.TypeDouble, 5, 3.0, 9.0, 10.0),
+	}
+	for _, test := range tests {
+		s.testDistinctAggFunc(c, test)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// All the following variance function implementations return the float64
+// result of var_pop, var_samp, stddev_pop or stddev_samp, which store the
+// partial results in "partialResult4Variance".
+//
+// "baseVariance4Float64" is wrapped by:
+// - "varianceOriginal4Float64"
+// - "variancePartial4Float64"
+type baseVariance4Float64 struct {
+	baseAggFunc
+
+	// isSamp indicates the sample variance, whose divisor is count-1.
+	isSamp bool
+	// isStddev indicates the standard deviation, which is the square root of
+	// the variance.
+	isStddev bool
+}
+
+type partialResult4Variance struct {
+	count int64
+	sum   float64
+	// variance is the sum of squared deviations from the mean.
+	variance float64
+}
+
+func (e *baseVariance4Float64) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4Variance{})
+}
+
+func (e *baseVariance4Float64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4Variance)(pr)
+	p.count = 0
+	p.sum = 0
+	p.variance = 0
+}
+
+func (e *baseVariance4Float64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4Variance)(src), (*partialResult4Variance)(dst)
+	if p1.count == 0 {
+		return nil
+	}
+	p2.variance = aggregation.MergeVariance(p1.count, p2.count, p1.sum, p2.sum, p1.variance, p2.variance)
+	p2.count += p1.count
+	p2.sum += p1.sum
+	return nil
+}
+
+func (e *baseVariance4Float64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4Variance)(pr)
+	count := p.count
+	if e.isSamp {
+		count--
+	}
+	if count <= 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	result := p.variance / float64(count)
+	if e.isStddev {
+		result = math.Sqrt(result)
+	}
+	chk.AppendFloat64(e.ordinal, result)
+	return nil
+}
+
+type varianceOriginal4Float64 struct {
+	baseVariance4Float64
+}
+
+func (e *varianceOriginal4Float64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4Variance)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		p.count++
+		p.sum += input
+		p.variance = aggregation.CalculateVariance(p.count, p.sum, p.variance, input)
+	}
+	return nil
+}
+
+type variancePartial4Float64 struct {
+	baseVariance4Float64
+}
+
+func (e *variancePartial4Float64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4Variance)(pr)
+	for _, row := range rowsInGroup {
+		inputCount, isNull, err := e.args[0].EvalInt(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || inputCount == 0 {
+			continue
+		}
+		inputSum, isNull, err := e.args[1].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		inputVariance, isNull, err := e.args[2].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		p.variance = aggregation.MergeVariance(inputCount, p.count, inputSum, p.sum, inputVariance, p.variance)
+		p.count += inputCount
+		p.sum += inputSum
+	}
+	return nil
+}
@@ -86,8 +86,17 @@ func getKeysNeedCheck(ctx context.Context, sctx sessionctx.Context, t table.Tabl
 	}
 
 	var err error
+	pt, isPartitioned := t.(table.PartitionedTable)
 	for _, row := range rows {
-		toBeCheckRows, err = getKeysNeedCheckOneRow(sctx, t, row, nUnique, handleCol, toBeCheckRows)
+		// The keys of a partitioned table are checked in the partition which the row belongs to.
+		tbl := t
+		if isPartitioned {
+			tbl, err = pt.GetPartitionByRow(sctx, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		toBeCheckRows, err = getKeysNeedCheckOneRow(sctx, tbl, row, nUnique, handleCol, toBeCheckRows)
 		if err != nil {
 			return nil, err
 		}
@@ -92,6 +92,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildSort(v)
 	case *plannercore.PhysicalTopN:
 		return b.buildTopN(v)
+	case *plannercore.PhysicalUnionAll:
+		return b.buildUnionAll(v)
 	case *plannercore.PhysicalUnionScan:
 		return b.buildUnionScanExec(v)
 	case *plannercore.PhysicalHashJoin:
@@ -104,6 +106,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildHashAgg(v)
 	case *plannercore.PhysicalProjection:
 		return b.buildProjection(v)
+	case *plannercore.PhysicalExpand:
+		return b.buildExpand(v)
 	case *plannercore.PhysicalMemTable:
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
@@ -192,6 +196,8 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) Executor {
 		Table:        v.Table,
 		Column:       v.Column,
 		IndexName:    v.IndexName,
+		User:         v.User,
+		Roles:        v.Roles,
 		IfNotExists:  v.IfNotExists,
 		Flag:         v.Flag,
 		Full:         v.Full,
@@ -245,6 +251,7 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 		allAssignmentsAreConstant: v.AllAssignmentsAreConstant,
 		hasRefCols:                v.NeedFillDefaultValue,
 		SelectExec:                selectExec,
+		fkCheckers:                buildFKCheckers(b.ctx, b.is, v.Table),
 	}
 	err := ivs.initInsertColumns()
 	if err != nil {
@@ -264,6 +271,7 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 func (b *executorBuilder) buildReplace(vals *InsertValues) Executor {
 	replaceExec := &ReplaceExec{
 		InsertValues: vals,
+		fkCascader:   newFKCascader(b.ctx, b.is, vals.Table),
 	}
 	return replaceExec
 }
@@ -286,6 +294,20 @@ func (b *executorBuilder) buildExplain(v *plannercore.Explain) Executor {
 	return explainExec
 }
 
+func (b *executorBuilder) buildUnionAll(v *plannercore.PhysicalUnionAll) Executor {
+	childExecs := make([]Executor, len(v.Children()))
+	for i, child := range v.Children() {
+		childExecs[i] = b.build(child)
+		if b.err != nil {
+			return nil
+		}
+	}
+	e := &UnionExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ExplainID(), childExecs...),
+	}
+	return e
+}
+
 func (b *executorBuilder) buildUnionScanExec(v *plannercore.PhysicalUnionScan) Executor {
 	reader := b.build(v.Children()[0])
 	if b.err != nil {
@@ -528,6 +550,10 @@ func (b *executorBuilder) buildHashAgg(v *plannercore.PhysicalHashAgg) Executor
 			ordinal = append(ordinal, partialOrdinal+1)
 			partialOrdinal++
 		}
+		if aggregation.NeedVariance(aggDesc.Name) {
+			ordinal = append(ordinal, partialOrdinal, partialOrdinal+1)
+			partialOrdinal += 2
+		}
 		partialAggDesc, finalDesc := aggDesc.Split(ordinal)
 		partialAggFunc := aggfuncs.Build(b.ctx, partialAggDesc, i)
 		finalAggFunc := aggfuncs.Build(b.ctx, finalDesc, i)
@@ -573,6 +599,21 @@ func (b *executorBuilder) buildProjection(v *plannercore.PhysicalProjection) Exe
 	return e
 }
 
+func (b *executorBuilder) buildExpand(v *plannercore.PhysicalExpand) Executor {
+	childExec := b.build(v.Children()[0])
+	if b.err != nil {
+		return nil
+	}
+	e := &ExpandExec{
+		baseExecutor:        newBaseExecutor(b.ctx, v.Schema(), v.ExplainID(), childExec),
+		levelEvaluatorSuits: make([]*expression.EvaluatorSuite, 0, len(v.LevelExprs)),
+	}
+	for _, exprs := range v.LevelExprs {
+		e.levelEvaluatorSuits = append(e.levelEvaluatorSuits, expression.NewEvaluatorSuite(exprs))
+	}
+	return e
+}
+
 func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Executor {
 	if v.RowCount != 0 && v.RowCount != 1 {
 		b.err = errors.Errorf("buildTableDual failed, invalid row count for dual table: %v", v.RowCount)
@@ -648,8 +689,10 @@ func (b *executorBuilder) buildTopN(v *plannercore.PhysicalTopN) Executor {
 
 func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 	tblID2table := make(map[int64]table.Table)
+	tbls := make([]table.Table, 0, len(v.TblColPosInfos))
 	for _, info := range v.TblColPosInfos {
 		tblID2table[info.TblID], _ = b.is.TableByID(info.TblID)
+		tbls = append(tbls, tblID2table[info.TblID])
 	}
 	b.startTS = b.ctx.GetSessionVars().TxnCtx.GetForUpdateTS()
 	selExec := b.build(v.SelectPlan)
@@ -662,6 +705,7 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 		baseExecutor:   base,
 		tblID2Table:    tblID2table,
 		tblColPosInfos: v.TblColPosInfos,
+		fkCascader:     newFKCascader(b.ctx, b.is, tbls...),
 	}
 	return deleteExec
 }
@@ -769,6 +813,10 @@ func buildNoRangeTableReader(b *executorBuilder, v *plannercore.PhysicalTableRea
 	}
 	ts := v.TablePlans[0].(*plannercore.PhysicalTableScan)
 	tbl, _ := b.is.TableByID(ts.Table.ID)
+	if isPartition, physicalTableID := ts.IsPartition(); isPartition {
+		pt := tbl.(table.PartitionedTable)
+		tbl = pt.GetPartition(physicalTableID)
+	}
 	startTS, err := b.getStartTS()
 	if err != nil {
 		return nil, err
@@ -814,6 +862,13 @@ func buildNoRangeIndexReader(b *executorBuilder, v *plannercore.PhysicalIndexRea
 	}
 	is := v.IndexPlans[0].(*plannercore.PhysicalIndexScan)
 	tbl, _ := b.is.TableByID(is.Table.ID)
+	isPartition, physicalTableID := is.IsPartition()
+	if isPartition {
+		pt := tbl.(table.PartitionedTable)
+		tbl = pt.GetPartition(physicalTableID)
+	} else {
+		physicalTableID = is.Table.ID
+	}
 	startTS, err := b.getStartTS()
 	if err != nil {
 		return nil, err
@@ -822,7 +877,7 @@ func buildNoRangeIndexReader(b *executorBuilder, v *plannercore.PhysicalIndexRea
 		baseExecutor:    newBaseExecutor(b.ctx, v.Schema(), v.ExplainID()),
 		dagPB:           dagReq,
 		startTS:         startTS,
-		physicalTableID: is.Table.ID,
+		physicalTableID: physicalTableID,
 		table:           tbl,
 		index:           is.Index,
 		keepOrder:       is.KeepOrder,
@@ -867,6 +922,10 @@ func buildNoRangeIndexLookUpReader(b *executorBuilder, v *plannercore.PhysicalIn
 	is := v.IndexPlans[0].(*plannercore.PhysicalIndexScan)
 	indexReq.OutputOffsets = []uint32{uint32(len(is.Index.Columns))}
 	tbl, _ := b.is.TableByID(is.Table.ID)
+	if isPartition, physicalTableID := is.IsPartition(); isPartition {
+		pt := tbl.(table.PartitionedTable)
+		tbl = pt.GetPartition(physicalTableID)
+	}
 
 	for i := 0; i < v.Schema().Len(); i++ {
 		tableReq.OutputOffsets = append(tableReq.OutputOffsets, uint32(i))
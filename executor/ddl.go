@@ -85,6 +85,10 @@ func (e *DDLExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeDropDatabase(x)
 	case *ast.DropTableStmt:
 		err = e.executeDropTableOrView(x)
+	case *ast.RenameTableStmt:
+		err = e.executeRenameTable(x)
+	case *ast.TruncateTableStmt:
+		err = e.executeTruncateTable(x)
 	}
 	if err != nil {
 		// If the owner return ErrTableNotExists error when running this DDL, it may be caused by schema changed,
@@ -230,6 +234,41 @@ func (e *DDLExec) executeDropTableOrView(s *ast.DropTableStmt) error {
 	return nil
 }
 
+func (e *DDLExec) executeTruncateTable(s *ast.TruncateTableStmt) error {
+	if isSystemTable(s.Table.Schema.L, s.Table.Name.L) {
+		return errors.Errorf("Truncate tidb system table '%s.%s' is forbidden", s.Table.Schema.L, s.Table.Name.L)
+	}
+	tbl, err := e.is.TableByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return err
+	}
+	dom := domain.GetDomain(e.ctx)
+	ident := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
+	if err = dom.DDL().TruncateTable(e.ctx, ident); err != nil {
+		return err
+	}
+	// The statistics of the old table ID are stale after the data is dropped.
+	if h := dom.StatsHandle(); h != nil {
+		h.InvalidateTableStats(tbl.Meta().ID)
+		if pi := tbl.Meta().GetPartitionInfo(); pi != nil {
+			for _, def := range pi.Definitions {
+				h.InvalidateTableStats(def.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
+	oldIdents := make([]ast.Ident, 0, len(s.TableToTables))
+	newIdents := make([]ast.Ident, 0, len(s.TableToTables))
+	for _, t := range s.TableToTables {
+		oldIdents = append(oldIdents, ast.Ident{Schema: t.OldTable.Schema, Name: t.OldTable.Name})
+		newIdents = append(newIdents, ast.Ident{Schema: t.NewTable.Schema, Name: t.NewTable.Name})
+	}
+	return domain.GetDomain(e.ctx).DDL().RenameTables(e.ctx, oldIdents, newIdents)
+}
+
 func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
 	ti := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
 	err := domain.GetDomain(e.ctx).DDL().DropIndex(e.ctx, ti, model.NewCIStr(s.IndexName), s.IfExists)
@@ -241,6 +280,41 @@ func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
 
 func (e *DDLExec) executeAlterTable(s *ast.AlterTableStmt) error {
 	ti := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
-	err := domain.GetDomain(e.ctx).DDL().AlterTable(e.ctx, ti, s.Specs)
-	return err
+	dom := domain.GetDomain(e.ctx)
+	err := dom.DDL().AlterTable(e.ctx, ti, s.Specs)
+	if err != nil {
+		return err
+	}
+	h := dom.StatsHandle()
+	if h == nil {
+		return nil
+	}
+	for _, spec := range s.Specs {
+		switch spec.Tp {
+		case ast.AlterTableRenameColumn, ast.AlterTableRenameIndex:
+			// The cached statistics refer to the renamed column or index by its old info.
+			tbl, err := dom.InfoSchema().TableByName(ti.Schema, ti.Name)
+			if err != nil {
+				return err
+			}
+			h.UpdateTableInfo(tbl.Meta())
+		case ast.AlterTableDropPartition, ast.AlterTableTruncatePartition:
+			// The statistics of the dropped partitions are stale.
+			oldTbl, err := e.is.TableByName(ti.Schema, ti.Name)
+			if err != nil {
+				return err
+			}
+			newTbl, err := dom.InfoSchema().TableByName(ti.Schema, ti.Name)
+			if err != nil {
+				return err
+			}
+			newPi := newTbl.Meta().GetPartitionInfo()
+			for _, def := range oldTbl.Meta().GetPartitionInfo().Definitions {
+				if newPi == nil || newPi.GetNameByID(def.ID) == "" {
+					h.InvalidateTableStats(def.ID)
+				}
+			}
+		}
+	}
+	return nil
 }
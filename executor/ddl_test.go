@@ -18,12 +18,15 @@ import (
 	. "github.com/pingcap/check"
 	ddlutil "github.com/pingcap/tidb/ddl/util"
 	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/util/testkit"
+	"github.com/pingcap/tidb/util/testutil"
 	"math"
 	"strings"
 )
@@ -263,3 +266,511 @@ func (s *testSuite6) TestSetDDLErrorCountLimit(c *C) {
 	res := tk.MustQuery("select @@global.tidb_ddl_error_count_limit")
 	res.Check(testkit.Rows("100"))
 }
+
+func (s *testSuite6) TestRenameTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop database if exists rename1")
+	tk.MustExec("drop database if exists rename2")
+	tk.MustExec("create database rename1")
+	tk.MustExec("create database rename2")
+	tk.MustExec("use rename1")
+	tk.MustExec("create table t (a int primary key auto_increment, b int)")
+	tk.MustExec("insert into t (b) values (1), (2)")
+	getTableID := func(name string) int64 {
+		tbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("rename1"), model.NewCIStr(name))
+		c.Assert(err, IsNil)
+		return tbl.Meta().ID
+	}
+	tblID := getTableID("t")
+
+	tk.MustExec("rename table t to t1")
+	tk.MustQuery("select a, b from t1").Check(testkit.Rows("1 1", "2 2"))
+	c.Assert(getTableID("t1"), Equals, tblID)
+	_, err := tk.Exec("select * from t")
+	c.Assert(terror.ErrorEqual(err, infoschema.ErrTableNotExists), IsTrue)
+
+	tk.MustExec("alter table t1 rename to t2")
+	tk.MustExec("alter table t2 rename as t3")
+	tk.MustExec("alter table t3 rename t4")
+	tk.MustExec("alter table t4 rename t4")
+	tk.MustQuery("select a, b from t4").Check(testkit.Rows("1 1", "2 2"))
+
+	// Move the table to another database, the auto ID goes with it.
+	tk.MustExec("alter table t4 rename rename2.t")
+	tk.MustExec("insert into rename2.t (b) values (3)")
+	tk.MustQuery("select b from rename2.t where a > 2").Check(testkit.Rows("3"))
+	tk.MustQuery("show tables").Check(testkit.Rows())
+	tk.MustExec("rename table rename2.t to rename1.t")
+	tk.MustExec("insert into t (b) values (4)")
+	tk.MustQuery("select b from t where a > 2").Check(testkit.Rows("3", "4"))
+	c.Assert(getTableID("t"), Equals, tblID)
+
+	tk.MustExec("create table t1 (a int)")
+	tk.MustGetErrCode("rename table t to t1", mysql.ErrTableExists)
+	tk.MustGetErrCode("alter table t rename to t1", mysql.ErrTableExists)
+	tk.MustGetErrCode("rename table t to t", mysql.ErrTableExists)
+	tk.MustGetErrCode("rename table t_none to t2", mysql.ErrFileNotFound)
+	tk.MustGetErrCode("alter table t_none rename to t2", mysql.ErrNoSuchTable)
+	tk.MustGetErrCode("rename table t to rename_none.t", mysql.ErrErrorOnRename)
+
+	// Swap the tables in one statement.
+	tk.MustExec("insert into t1 values (10)")
+	tk.MustExec("rename table t to tmp, t1 to t, tmp to t1")
+	tk.MustQuery("select a from t").Check(testkit.Rows("10"))
+	tk.MustQuery("select b from t1").Check(testkit.Rows("1", "2", "3", "4"))
+	c.Assert(getTableID("t1"), Equals, tblID)
+	tk.MustQuery("show tables").Check(testkit.Rows("t", "t1"))
+
+	// A later rename can use a table moved by an earlier one.
+	tk.MustExec("rename table t1 to rename2.t1, rename2.t1 to rename2.t2")
+	tk.MustQuery("select b from rename2.t2").Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+
+	// All the renames fail if one of them fails.
+	tk.MustGetErrCode("rename table t to t3, t_none to t4", mysql.ErrFileNotFound)
+	tk.MustGetErrCode("rename table t to t3, rename2.t2 to t3", mysql.ErrTableExists)
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+
+	tk.MustExec("drop database rename1")
+	tk.MustExec("drop database rename2")
+}
+
+func (s *testSuite6) TestRenameColumnAndIndex(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_rename")
+	tk.MustExec("create table t_rename (a int primary key, b int, c int, key idx_b(b), unique key uk_c(c))")
+	tk.MustExec("insert into t_rename values (1, 10, 100), (2, 20, 200)")
+	tk.MustExec("analyze table t_rename")
+
+	tk.MustExec("alter table t_rename rename column b to bb")
+	tk.MustExec("alter table t_rename rename index idx_b to idx_bb")
+	tk.MustExec("alter table t_rename rename key uk_c to UK_C")
+	tk.MustQuery("select bb from t_rename use index(idx_bb) where bb > 10").Check(testkit.Rows("20"))
+	tk.MustQuery("show create table t_rename").Check(testutil.RowsWithSep("|",
+		""+
+			"t_rename CREATE TABLE `t_rename` (\n"+
+			"  `a` int(11) NOT NULL,\n"+
+			"  `bb` int(11) DEFAULT NULL,\n"+
+			"  `c` int(11) DEFAULT NULL,\n"+
+			"  PRIMARY KEY (`a`),\n"+
+			"  KEY `idx_bb` (`bb`),\n"+
+			"  UNIQUE KEY `UK_C` (`c`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+	))
+
+	// The cached statistics follow the new names.
+	tbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t_rename"))
+	c.Assert(err, IsNil)
+	statsTbl := s.domain.StatsHandle().GetTableStats(tbl.Meta())
+	c.Assert(statsTbl.Pseudo, IsFalse)
+	c.Assert(statsTbl.ColumnByName("bb"), NotNil)
+	c.Assert(statsTbl.IndexStartWithColumn("bb").Info.Name.O, Equals, "idx_bb")
+
+	// Renaming to the same name does nothing.
+	tk.MustExec("alter table t_rename rename column bb to bb")
+	tk.MustExec("alter table t_rename rename index idx_bb to idx_bb")
+
+	tk.MustGetErrCode("alter table t_rename rename column b to d", mysql.ErrBadField)
+	tk.MustGetErrCode("alter table t_rename rename column bb to c", mysql.ErrDupFieldName)
+	tk.MustGetErrCode("alter table t_rename rename index idx_b to idx_d", mysql.ErrKeyDoesNotExist)
+	tk.MustGetErrCode("alter table t_rename rename index idx_bb to uk_c", mysql.ErrDupKeyName)
+	tk.MustGetErrCode("alter table t_rename rename index idx_bb to `primary`", mysql.ErrWrongNameForIndex)
+	tk.MustExec("drop table if exists t_rename_pk")
+	tk.MustExec("create table t_rename_pk (a varchar(10) primary key)")
+	tk.MustGetErrCode("alter table t_rename_pk rename index `primary` to idx_a", mysql.ErrWrongNameForIndex)
+	tk.MustExec("drop table t_rename_pk")
+	tk.MustGetErrCode("alter table t_none rename index idx_bb to idx_b", mysql.ErrNoSuchTable)
+	tk.MustExec("drop table t_rename")
+}
+
+func (s *testSuite6) TestTruncateTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists truncate_test")
+	tk.MustExec("create table truncate_test (a int primary key auto_increment, b int, key idx(b))")
+	tk.MustExec("insert into truncate_test (b) values (1), (2), (3)")
+	tk.MustExec("analyze table truncate_test")
+	oldTbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("truncate_test"))
+	c.Assert(err, IsNil)
+	oldID := oldTbl.Meta().ID
+	c.Assert(s.domain.StatsHandle().GetTableStats(oldTbl.Meta()).Pseudo, IsFalse)
+
+	tk.MustExec("truncate table truncate_test")
+	tk.MustQuery("select * from truncate_test").Check(testkit.Rows())
+	newTbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("truncate_test"))
+	c.Assert(err, IsNil)
+	c.Assert(newTbl.Meta().ID, Not(Equals), oldID)
+	_, ok := s.domain.InfoSchema().TableByID(oldID)
+	c.Assert(ok, IsFalse)
+	c.Assert(s.domain.StatsHandle().GetTableStats(newTbl.Meta()).Pseudo, IsTrue)
+
+	// The auto ID starts again and the index is empty.
+	tk.MustExec("insert into truncate_test (b) values (4)")
+	tk.MustQuery("select a, b from truncate_test").Check(testkit.Rows("1 4"))
+	tk.MustQuery("select b from truncate_test use index(idx)").Check(testkit.Rows("4"))
+
+	// The data range of the old table ID is left for GC.
+	rows := tk.MustQuery("select element_id from mysql.gc_delete_range").Rows()
+	found := false
+	for _, row := range rows {
+		if row[0] == fmt.Sprint(oldID) {
+			found = true
+		}
+	}
+	c.Assert(found, IsTrue)
+
+	tk.MustExec("truncate truncate_test")
+	tk.MustQuery("select * from truncate_test").Check(testkit.Rows())
+	_, err = tk.Exec("truncate table truncate_none")
+	c.Assert(terror.ErrorEqual(err, infoschema.ErrTableNotExists), IsTrue)
+	_, err = tk.Exec("truncate table mysql.gc_delete_range")
+	c.Assert(err, NotNil)
+	tk.MustExec("drop table truncate_test")
+}
+
+func (s *testSuite6) TestCreatePartitionTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_range, t_list, t_hash, t_columns")
+
+	tk.MustExec(`create table t_range (a int, b int, unique key uk_a(a))
+		partition by range (a) (
+		partition p0 values less than (10) comment 'small',
+		partition p1 values less than (20),
+		partition p2 values less than maxvalue)`)
+	tk.MustQuery("show create table t_range").Check(testutil.RowsWithSep("|",
+		""+
+			"t_range CREATE TABLE `t_range` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL,\n"+
+			"  UNIQUE KEY `uk_a` (`a`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY RANGE ( `a` ) (\n"+
+			"  PARTITION `p0` VALUES LESS THAN (10) COMMENT 'small',\n"+
+			"  PARTITION `p1` VALUES LESS THAN (20),\n"+
+			"  PARTITION `p2` VALUES LESS THAN (MAXVALUE)\n"+
+			")",
+	))
+	tk.MustExec("create table t_list (a int, b int) partition by list (a + 1) (partition p0 values in (1, 3, null), partition p1 values in (2, 4))")
+	tk.MustQuery("show create table t_list").Check(testutil.RowsWithSep("|",
+		""+
+			"t_list CREATE TABLE `t_list` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY LIST ( `a` + 1 ) (\n"+
+			"  PARTITION `p0` VALUES IN (1,3,NULL),\n"+
+			"  PARTITION `p1` VALUES IN (2,4)\n"+
+			")",
+	))
+	tk.MustExec("create table t_hash (a int primary key, b int) partition by hash (a) partitions 4")
+	tk.MustQuery("show create table t_hash").Check(testutil.RowsWithSep("|",
+		""+
+			"t_hash CREATE TABLE `t_hash` (\n"+
+			"  `a` int(11) NOT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL,\n"+
+			"  PRIMARY KEY (`a`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY HASH ( `a` )\n"+
+			"PARTITIONS 4",
+	))
+	tk.MustExec("create table t_columns (a varchar(10), b int) partition by range columns (a) (partition p0 values less than ('m'), partition p1 values less than (maxvalue))")
+	tk.MustQuery("show create table t_columns").Check(testutil.RowsWithSep("|",
+		""+
+			"t_columns CREATE TABLE `t_columns` (\n"+
+			"  `a` varchar(10) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY RANGE COLUMNS(`a`) (\n"+
+			"  PARTITION `p0` VALUES LESS THAN (\"m\"),\n"+
+			"  PARTITION `p1` VALUES LESS THAN (MAXVALUE)\n"+
+			")",
+	))
+	tk.MustExec("create table t_columns2 (a varchar(10)) partition by list columns (a) (partition p0 values in ('x', 'y'), partition p1 values in ('z'))")
+	tk.MustExec("insert into t_columns2 values ('x'), ('z')")
+	tk.MustGetErrCode("insert into t_columns2 values ('w')", mysql.ErrNoPartitionForGivenValue)
+	tk.MustQuery("select * from t_columns2 order by a").Check(testkit.Rows("x", "z"))
+	tk.MustExec("drop table t_columns2")
+
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (5))", mysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a) (partition p0 values less than maxvalue, partition p1 values less than (5))", mysql.ErrPartitionMaxvalue)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a) (partition p0 values less than (10), partition p0 values less than (20))", mysql.ErrSameNamePartition)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a) (partition p0 values in (10))", mysql.ErrPartitionWrongValues)
+	tk.MustGetErrCode("create table t_err (a int) partition by list (a) (partition p0 values in (1, 2), partition p1 values in (2))", mysql.ErrMultipleDefConstInListPart)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a)", mysql.ErrPartitionsMustBeDefined)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (a) partitions 2 (partition p0 values less than (10))", mysql.ErrPartitionWrongNoPart)
+	tk.MustGetErrCode("create table t_err (a int) partition by range (c) (partition p0 values less than (10))", mysql.ErrBadField)
+	tk.MustGetErrCode("create table t_err (a varchar(10)) partition by range (a) (partition p0 values less than (10))", mysql.ErrPartitionFuncNotAllowed)
+	tk.MustGetErrCode("create table t_err (a int, b int, primary key (a)) partition by hash (b) partitions 2", mysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustGetErrCode("create table t_err (a int, b int, unique key (a)) partition by hash (a + b) partitions 2", mysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustGetErrCode("create table t_err (a int) partition by range columns (b) (partition p0 values less than (10))", mysql.ErrFieldNotFoundPart)
+
+	// The columns used by the partition function can't be dropped or renamed.
+	tk.MustGetErrCode("alter table t_range drop column a", mysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_range rename column a to c", mysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_list add unique index uk_b(b)", mysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec("drop table t_range, t_list, t_hash, t_columns")
+}
+
+func (s *testSuite6) TestPartitionTableDML(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_range, t_list, t_hash, t_pk")
+	tk.MustExec(`create table t_range (a int, b int, key idx_b(b))
+		partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30))`)
+	tk.MustExec("insert into t_range values (1, 1), (11, 11), (21, 21), (null, 0)")
+	tk.MustQuery("select * from t_range order by b").Check(testkit.Rows("<nil> 0", "1 1", "11 11", "21 21"))
+	tk.MustQuery("select b from t_range use index(idx_b) where b > 5 order by b").Check(testkit.Rows("11", "21"))
+	tk.MustGetErrCode("insert into t_range values (30, 30)", mysql.ErrNoPartitionForGivenValue)
+
+	// The physical layout follows the partitions.
+	tbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t_range"))
+	c.Assert(err, IsNil)
+	pt, ok := tbl.(table.PartitionedTable)
+	c.Assert(ok, IsTrue)
+	pi := tbl.Meta().GetPartitionInfo()
+	p1 := pt.GetPartition(pi.Definitions[1].ID)
+	c.Assert(p1.GetPhysicalID(), Equals, pi.Definitions[1].ID)
+
+	// The uncommitted data of every partition is visible in the transaction.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_range values (12, 12), (2, 2)")
+	tk.MustExec("delete from t_range where a = 1")
+	tk.MustQuery("select * from t_range order by b").Check(testkit.Rows("<nil> 0", "2 2", "11 11", "12 12", "21 21"))
+	tk.MustQuery("select b from t_range use index(idx_b) where b >= 11 order by b").Check(testkit.Rows("11", "12", "21"))
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t_range order by b").Check(testkit.Rows("<nil> 0", "2 2", "11 11", "12 12", "21 21"))
+	tk.MustQuery("explain select * from t_range").Check(testkit.Rows(
+		"Union_8 30000.00 root ",
+		"├─TableReader_10 10000.00 root data:TableScan_9",
+		"│ └─TableScan_9 10000.00 cop table:t_range, partition:p0, range:[-inf,+inf], keep order:false, stats:pseudo",
+		"├─TableReader_12 10000.00 root data:TableScan_11",
+		"│ └─TableScan_11 10000.00 cop table:t_range, partition:p1, range:[-inf,+inf], keep order:false, stats:pseudo",
+		"└─TableReader_14 10000.00 root data:TableScan_13",
+		"  └─TableScan_13 10000.00 cop table:t_range, partition:p2, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+
+	// Every partition has its own statistics.
+	tk.MustExec("analyze table t_range")
+	h := s.domain.StatsHandle()
+	c.Assert(h.GetPartitionStats(tbl.Meta(), pi.Definitions[0].ID).Count, Equals, int64(2))
+	c.Assert(h.GetPartitionStats(tbl.Meta(), pi.Definitions[1].ID).Count, Equals, int64(2))
+	c.Assert(h.GetPartitionStats(tbl.Meta(), pi.Definitions[2].ID).Count, Equals, int64(1))
+
+	tk.MustExec("create table t_list (a int, b int) partition by list (a) (partition p0 values in (1, 3, null), partition p1 values in (2, 4))")
+	tk.MustExec("insert into t_list values (1, 1), (2, 2), (null, 3), (4, 4)")
+	tk.MustGetErrCode("insert into t_list values (5, 5)", mysql.ErrNoPartitionForGivenValue)
+	tk.MustQuery("select * from t_list order by b").Check(testkit.Rows("1 1", "2 2", "<nil> 3", "4 4"))
+
+	tk.MustExec("create table t_hash (a int, b int, key idx_a(a)) partition by hash (a) partitions 3")
+	tk.MustExec("insert into t_hash values (1, 1), (-2, 2), (3, 3), (null, 4)")
+	tk.MustQuery("select * from t_hash order by b").Check(testkit.Rows("1 1", "-2 2", "3 3", "<nil> 4"))
+	tk.MustQuery("select a from t_hash use index(idx_a) where a > 0 order by a").Check(testkit.Rows("1", "3"))
+	tk.MustExec("delete from t_hash where a < 0")
+	tk.MustQuery("select * from t_hash order by b").Check(testkit.Rows("1 1", "3 3", "<nil> 4"))
+
+	// The duplicated keys are checked in the partition of the row.
+	tk.MustExec("create table t_pk (a int primary key, b int) partition by hash (a) partitions 2")
+	tk.MustExec("insert into t_pk values (1, 1), (2, 2)")
+	tk.MustGetErrCode("insert into t_pk values (1, 3)", mysql.ErrDupEntry)
+	tk.MustExec("replace into t_pk values (1, 3)")
+	tk.MustQuery("select * from t_pk order by a").Check(testkit.Rows("1 3", "2 2"))
+	tk.MustExec("drop table t_range, t_list, t_hash, t_pk")
+}
+
+func (s *testSuite6) TestAlterTablePartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_part, t_normal, t_hash")
+	tk.MustExec("create table t_part (a int, b int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("insert into t_part values (1, 1), (11, 11)")
+
+	tk.MustExec("alter table t_part add partition (partition p2 values less than (30), partition p3 values less than maxvalue)")
+	tk.MustExec("insert into t_part values (21, 21), (31, 31)")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("1 1", "11 11", "21 21", "31 31"))
+	tk.MustGetErrCode("alter table t_part add partition (partition p4 values less than (40))", mysql.ErrPartitionMaxvalue)
+	tk.MustGetErrCode("alter table t_part add partition (partition p0 values less than (50))", mysql.ErrSameNamePartition)
+
+	tbl, err := s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t_part"))
+	c.Assert(err, IsNil)
+	pi := tbl.Meta().GetPartitionInfo()
+	p1ID := pi.Definitions[1].ID
+
+	tk.MustExec("alter table t_part truncate partition p1")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("1 1", "21 21", "31 31"))
+	tk.MustExec("insert into t_part values (12, 12)")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("1 1", "12 12", "21 21", "31 31"))
+	tbl, err = s.domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t_part"))
+	c.Assert(err, IsNil)
+	c.Assert(tbl.Meta().GetPartitionInfo().Definitions[1].ID, Not(Equals), p1ID)
+	tk.MustQuery(fmt.Sprintf("select element_id from mysql.gc_delete_range where element_id = %d", p1ID)).Check(testkit.Rows(fmt.Sprint(p1ID)))
+
+	p0ID := pi.Definitions[0].ID
+	tk.MustExec("alter table t_part drop partition p0")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("12 12", "21 21", "31 31"))
+	tk.MustQuery(fmt.Sprintf("select element_id from mysql.gc_delete_range where element_id = %d", p0ID)).Check(testkit.Rows(fmt.Sprint(p0ID)))
+	// The rows which belonged to the dropped partition go to the next one now.
+	tk.MustExec("insert into t_part values (1, 1)")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("1 1", "12 12", "21 21", "31 31"))
+
+	tk.MustGetErrCode("alter table t_part drop partition p9", mysql.ErrDropPartitionNonExistent)
+	tk.MustExec("alter table t_part drop partition if exists p9")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1507 Error in list of partitions to DROP"))
+	tk.MustGetErrCode("alter table t_part drop partition p1, p2, p3", mysql.ErrDropLastPartition)
+	tk.MustGetErrCode("alter table t_part truncate partition p9", mysql.ErrUnknownPartition)
+
+	tk.MustExec("create table t_normal (a int)")
+	tk.MustGetErrCode("alter table t_normal add partition (partition p0 values less than (10))", mysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t_normal drop partition p0", mysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t_normal truncate partition p0", mysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustExec("create table t_hash (a int) partition by hash (a) partitions 2")
+	tk.MustGetErrCode("alter table t_hash drop partition p0", mysql.ErrOnlyOnRangeListPartition)
+	tk.MustExec("insert into t_hash values (1), (2)")
+	tk.MustExec("alter table t_hash truncate partition p1")
+	tk.MustQuery("select * from t_hash").Check(testkit.Rows("2"))
+
+	// Truncating a partitioned table gives every partition a new ID.
+	tk.MustExec("truncate table t_part")
+	tk.MustQuery("select * from t_part").Check(testkit.Rows())
+	tk.MustExec("alter table t_part add index idx_b(b)")
+	tk.MustExec("insert into t_part values (5, 5), (15, 15), (35, 35)")
+	tk.MustQuery("select * from t_part order by a").Check(testkit.Rows("5 5", "15 15", "35 35"))
+	tk.MustQuery("select b from t_part use index(idx_b) order by b").Check(testkit.Rows("5", "15", "35"))
+	tk.MustExec("drop table t_part, t_normal, t_hash")
+}
+
+func (s *testSuite6) TestForeignKey(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_grand, fk_child, fk_null, fk_restrict, fk_parent")
+	tk.MustExec("create table fk_parent (id int primary key, name varchar(10))")
+	tk.MustExec("create table fk_child (id int primary key, pid int, constraint fk_c foreign key (pid) references fk_parent (id) on delete cascade)")
+	tk.MustExec("create table fk_grand (id int primary key, cid int, foreign key (cid) references fk_child (id) on delete cascade)")
+	tk.MustExec("create table fk_null (id int primary key, pid int, foreign key (pid) references fk_parent (id) on delete set null)")
+	tk.MustExec("create table fk_restrict (id int primary key, pid int, foreign key (pid) references fk_parent (id))")
+	tk.MustQuery("show create table fk_child").Check(testkit.Rows("fk_child CREATE TABLE `fk_child` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `pid` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `fk_c` (`pid`),\n" +
+		"  CONSTRAINT `fk_c` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`) ON DELETE CASCADE\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The referenced row must exist unless a column of the foreign key is NULL.
+	tk.MustExec("insert into fk_parent values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into fk_child values (1, 1), (2, 1), (3, 2), (4, NULL)")
+	tk.MustGetErrCode("insert into fk_child values (5, 4)", mysql.ErrNoReferencedRow2)
+	tk.MustExec("insert into fk_grand values (1, 1), (2, 3)")
+	tk.MustExec("insert into fk_null values (1, 1), (2, 2)")
+	tk.MustExec("insert into fk_restrict values (1, 3)")
+
+	// The deletes are cascaded recursively.
+	tk.MustExec("delete from fk_parent where id = 1")
+	tk.MustQuery("select * from fk_child").Check(testkit.Rows("3 2", "4 <nil>"))
+	tk.MustQuery("select * from fk_grand").Check(testkit.Rows("2 3"))
+	tk.MustQuery("select * from fk_null").Check(testkit.Rows("1 <nil>", "2 2"))
+	tk.MustGetErrCode("delete from fk_parent where id = 3", mysql.ErrRowIsReferenced2)
+	tk.MustQuery("select id from fk_parent").Check(testkit.Rows("2", "3"))
+	tk.MustGetErrCode("delete from fk_parent", mysql.ErrRowIsReferenced2)
+	tk.MustQuery("select * from fk_child").Check(testkit.Rows("3 2", "4 <nil>"))
+	tk.MustQuery("select * from fk_null").Check(testkit.Rows("1 <nil>", "2 2"))
+
+	// The rows updated by ON DELETE SET NULL enforce the ON UPDATE actions of the foreign keys referencing them.
+	tk.MustExec("create table fk_mid (id int primary key, pid int, key (pid), foreign key (pid) references fk_parent (id) on delete set null)")
+	tk.MustExec("create table fk_leaf (id int primary key, mpid int, foreign key (mpid) references fk_mid (pid))")
+	tk.MustExec("create table fk_leaf_null (id int primary key, mpid int, foreign key (mpid) references fk_mid (pid) on update set null)")
+	tk.MustExec("insert into fk_parent values (4, 'd'), (5, 'e')")
+	tk.MustExec("insert into fk_mid values (1, 4), (2, 5)")
+	tk.MustExec("insert into fk_leaf values (1, 4)")
+	tk.MustExec("insert into fk_leaf_null values (1, 5)")
+	tk.MustGetErrCode("delete from fk_parent where id = 4", mysql.ErrRowIsReferenced2)
+	tk.MustQuery("select * from fk_mid").Check(testkit.Rows("1 4", "2 5"))
+	tk.MustExec("delete from fk_parent where id = 5")
+	tk.MustQuery("select * from fk_mid").Check(testkit.Rows("1 4", "2 <nil>"))
+	tk.MustQuery("select * from fk_leaf_null").Check(testkit.Rows("1 <nil>"))
+	tk.MustExec("drop table fk_leaf, fk_leaf_null, fk_mid")
+	tk.MustExec("delete from fk_parent where id = 4")
+
+	// The foreign keys are not checked if foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("insert into fk_child values (5, 9)")
+	tk.MustExec("delete from fk_parent where id = 3")
+	tk.MustQuery("select * from fk_restrict").Check(testkit.Rows("1 3"))
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("delete from fk_restrict")
+	tk.MustExec("delete from fk_child where id = 5")
+
+	// The referenced tables and the columns and indexes used by foreign keys can't be dropped.
+	tk.MustGetErrCode("drop table fk_parent", mysql.ErrRowIsReferenced)
+	tk.MustGetErrCode("truncate table fk_parent", mysql.ErrTruncateIllegalFk)
+	tk.MustGetErrCode("alter table fk_child drop column pid", mysql.ErrFkColumnCannotDrop)
+	tk.MustGetErrCode("alter table fk_child drop index fk_c", mysql.ErrDropIndexFk)
+
+	// The referenced tables and columns can't be renamed, the references of the children aren't changed.
+	tk.MustGetErrCode("rename table fk_parent to fk_parent2", mysql.ErrAlterOperationNotSupportedReason)
+	tk.MustGetErrCode("alter table fk_parent rename to fk_parent2", mysql.ErrAlterOperationNotSupportedReason)
+	tk.MustGetErrCode("alter table fk_parent rename column id to pid", mysql.ErrAlterOperationNotSupportedReason)
+	tk.MustGetErrCode("alter table fk_parent change id pid int", mysql.ErrAlterOperationNotSupportedReason)
+	tk.MustExec("alter table fk_parent rename column name to title")
+	tk.MustExec("alter table fk_parent rename column title to name")
+	tk.MustExec("create table fk_self (id int primary key, pid int, foreign key (pid) references fk_self (id))")
+	tk.MustExec("rename table fk_self to fk_self2")
+	tk.MustExec("alter table fk_self2 rename column id to sid")
+	tk.MustQuery("show create table fk_self2").Check(testkit.Rows("fk_self2 CREATE TABLE `fk_self2` (\n" +
+		"  `sid` int(11) NOT NULL,\n" +
+		"  `pid` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`sid`),\n" +
+		"  KEY `pid` (`pid`),\n" +
+		"  CONSTRAINT `fk_self_ibfk_1` FOREIGN KEY (`pid`) REFERENCES `fk_self2` (`sid`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into fk_self2 values (1, NULL), (2, 1)")
+	tk.MustGetErrCode("insert into fk_self2 values (3, 9)", mysql.ErrNoReferencedRow2)
+	tk.MustExec("drop table fk_self2")
+
+	tk.MustGetErrCode("create table fk_t (a int, foreign key (a) references fk_none (id))", mysql.ErrFkCannotOpenParent)
+	tk.MustGetErrCode("create table fk_t (a int, foreign key (a) references fk_parent (name))", mysql.ErrFkNoIndexParent)
+	tk.MustGetErrCode("create table fk_t (a int, foreign key (a) references fk_parent (id, name))", mysql.ErrWrongFkDef)
+	tk.MustGetErrCode("create table fk_t (a int not null, foreign key (a) references fk_parent (id) on delete set null)", mysql.ErrFkColumnNotNull)
+	tk.MustGetErrCode("create table fk_t (a varchar(10), foreign key (a) references fk_parent (id))", mysql.ErrFKIncompatibleColumns)
+	tk.MustGetErrCode("create table fk_t (a int, constraint fk_x foreign key (a) references fk_parent (id), constraint fk_x foreign key (a) references fk_parent (id))", mysql.ErrFkDupName)
+	tk.MustGetErrCode("create table fk_t (a int, foreign key (a) references fk_parent (id)) partition by hash (a) partitions 2", mysql.ErrForeignKeyOnPartitioned)
+
+	// The foreign keys can be added and dropped by ALTER TABLE.
+	tk.MustExec("alter table fk_restrict drop foreign key fk_restrict_ibfk_1")
+	tk.MustExec("insert into fk_restrict values (2, 9)")
+	tk.MustGetErrCode("alter table fk_restrict drop foreign key fk_restrict_ibfk_1", mysql.ErrCantDropFieldOrKey)
+	tk.MustGetErrCode("alter table fk_restrict add constraint fk_r foreign key (id) references fk_none (id)", mysql.ErrFkCannotOpenParent)
+	tk.MustGetErrCode("alter table fk_restrict add constraint fk_r foreign key (pid) references fk_parent (id) on delete set null", mysql.ErrNoReferencedRow2)
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("alter table fk_restrict add constraint fk_x foreign key (pid) references fk_parent (id)")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("alter table fk_restrict drop foreign key fk_x")
+	tk.MustExec("delete from fk_restrict where id = 2")
+	tk.MustExec("alter table fk_restrict add constraint fk_r foreign key (pid) references fk_parent (id) on delete set null")
+	tk.MustGetErrCode("insert into fk_restrict values (3, 9)", mysql.ErrNoReferencedRow2)
+	tk.MustQuery("show create table fk_restrict").Check(testkit.Rows("fk_restrict CREATE TABLE `fk_restrict` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `pid` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `pid` (`pid`),\n" +
+		"  CONSTRAINT `fk_r` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`) ON DELETE SET NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The referenced row is locked by the check, a child row can't be committed if the row is deleted meanwhile.
+	tk.MustExec("insert into fk_parent values (6, 'f')")
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test")
+	tk1.MustExec("begin")
+	tk1.MustExec("insert into fk_restrict values (6, 6)")
+	tk.MustExec("delete from fk_parent where id = 6")
+	_, err := tk1.Exec("commit")
+	c.Assert(err, NotNil)
+	tk.MustQuery("select * from fk_restrict where id = 6").Check(testkit.Rows())
+
+	tk.MustExec("drop table fk_grand, fk_child, fk_null, fk_restrict")
+	tk.MustExec("drop table fk_parent")
+}
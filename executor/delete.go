@@ -16,6 +16,7 @@ package executor
 import (
 	"context"
 
+	"github.com/pingcap/tidb/kv"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
@@ -33,6 +34,12 @@ type DeleteExec struct {
 	// tblColPosInfos stores relationship between column ordinal to its table handle.
 	// the columns ordinals is present in ordinal range format, @see plannercore.TblColPosInfos
 	tblColPosInfos plannercore.TblColPosInfoSlice
+
+	// fkCascader enforces the foreign keys referencing the deleted rows.
+	fkCascader *fkCascader
+	// cascadedTblIDs records the tables whose rows may be removed by the cascades before they are
+	// reached by the executor.
+	cascadedTblIDs map[int64]bool
 }
 
 // Next implements the Executor Next interface.
@@ -41,20 +48,51 @@ func (e *DeleteExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return e.deleteSingleTableByChunk(ctx)
 }
 
-func (e *DeleteExec) deleteOneRow(tbl table.Table, handleIndex int, isExtraHandle bool, row []types.Datum) error {
+func (e *DeleteExec) deleteOneRow(ctx context.Context, tbl table.Table, handleIndex int, isExtraHandle bool, row []types.Datum) error {
 	end := len(row)
 	if isExtraHandle {
 		end--
 	}
 	handle := row[handleIndex].GetInt64()
+	if e.isCascadedTbl(tbl) {
+		// The row may have been deleted by the cascades of the rows deleted before.
+		txn, err := e.ctx.Txn(true)
+		if err != nil {
+			return err
+		}
+		if _, err = txn.Get(ctx, tbl.RecordKey(handle)); kv.IsErrNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
 	err := e.removeRow(e.ctx, tbl, handle, row[:end])
 	if err != nil {
 		return err
 	}
+	if e.fkCascader != nil {
+		return e.fkCascader.onDeleteRow(ctx, e.ctx, tbl, row[:end])
+	}
 
 	return nil
 }
 
+func (e *DeleteExec) isCascadedTbl(tbl table.Table) bool {
+	if e.fkCascader == nil {
+		return false
+	}
+	tblID := tbl.Meta().ID
+	cascaded, ok := e.cascadedTblIDs[tblID]
+	if !ok {
+		if e.cascadedTblIDs == nil {
+			e.cascadedTblIDs = make(map[int64]bool)
+		}
+		cascaded = e.fkCascader.canCascadeTo(tbl)
+		e.cascadedTblIDs[tblID] = cascaded
+	}
+	return cascaded
+}
+
 func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 	var (
 		tbl           table.Table
@@ -83,7 +121,7 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 
 		for chunkRow := iter.Begin(); chunkRow != iter.End(); chunkRow = iter.Next() {
 			datumRow := chunkRow.GetDatumRow(fields)
-			err = e.deleteOneRow(tbl, handleIndex, isExtrahandle, datumRow)
+			err = e.deleteOneRow(ctx, tbl, handleIndex, isExtrahandle, datumRow)
 			if err != nil {
 				return err
 			}
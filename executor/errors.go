@@ -41,6 +41,16 @@ var (
 	ErrWrongObject                 = terror.ClassExecutor.New(mysql.ErrWrongObject, mysql.MySQLErrName[mysql.ErrWrongObject])
 	ErrRoleNotGranted              = terror.ClassPrivilege.New(mysql.ErrRoleNotGranted, mysql.MySQLErrName[mysql.ErrRoleNotGranted])
 	ErrQueryInterrupted            = terror.ClassExecutor.New(mysql.ErrQueryInterrupted, mysql.MySQLErrName[mysql.ErrQueryInterrupted])
+	ErrNoSuchThread                = terror.ClassExecutor.New(mysql.ErrNoSuchThread, mysql.MySQLErrName[mysql.ErrNoSuchThread])
+	ErrMaxExecTimeExceeded         = terror.ClassExecutor.New(mysql.ErrMaxExecTimeExceeded, mysql.MySQLErrName[mysql.ErrMaxExecTimeExceeded])
+	ErrUserAlreadyExists           = terror.ClassExecutor.New(mysql.ErrUserAlreadyExists, mysql.MySQLErrName[mysql.ErrUserAlreadyExists])
+	ErrBadUser                     = terror.ClassExecutor.New(mysql.ErrBadUser, mysql.MySQLErrName[mysql.ErrBadUser])
+	ErrPluginIsNotLoaded           = terror.ClassExecutor.New(mysql.ErrPluginIsNotLoaded, mysql.MySQLErrName[mysql.ErrPluginIsNotLoaded])
+	ErrNonexistingGrant            = terror.ClassExecutor.New(mysql.ErrNonexistingGrant, mysql.MySQLErrName[mysql.ErrNonexistingGrant])
+	ErrIllegalGrantForTable        = terror.ClassExecutor.New(mysql.ErrIllegalGrantForTable, mysql.MySQLErrName[mysql.ErrIllegalGrantForTable])
+	ErrKillDenied                  = terror.ClassExecutor.New(mysql.ErrKillDenied, mysql.MySQLErrName[mysql.ErrKillDenied])
+	ErrRowIsReferenced2            = terror.ClassExecutor.New(mysql.ErrRowIsReferenced2, mysql.MySQLErrName[mysql.ErrRowIsReferenced2])
+	ErrNoReferencedRow2            = terror.ClassExecutor.New(mysql.ErrNoReferencedRow2, mysql.MySQLErrName[mysql.ErrNoReferencedRow2])
 )
 
 func init() {
@@ -67,7 +77,17 @@ func init() {
 		mysql.ErrWrongObject:                 mysql.ErrWrongObject,
 		mysql.ErrRoleNotGranted:              mysql.ErrRoleNotGranted,
 		mysql.ErrQueryInterrupted:            mysql.ErrQueryInterrupted,
+		mysql.ErrNoSuchThread:                mysql.ErrNoSuchThread,
+		mysql.ErrMaxExecTimeExceeded:         mysql.ErrMaxExecTimeExceeded,
 		mysql.ErrWrongValueCountOnRow:        mysql.ErrWrongValueCountOnRow,
+		mysql.ErrUserAlreadyExists:           mysql.ErrUserAlreadyExists,
+		mysql.ErrBadUser:                     mysql.ErrBadUser,
+		mysql.ErrPluginIsNotLoaded:           mysql.ErrPluginIsNotLoaded,
+		mysql.ErrNonexistingGrant:            mysql.ErrNonexistingGrant,
+		mysql.ErrIllegalGrantForTable:        mysql.ErrIllegalGrantForTable,
+		mysql.ErrKillDenied:                  mysql.ErrKillDenied,
+		mysql.ErrRowIsReferenced2:            mysql.ErrRowIsReferenced2,
+		mysql.ErrNoReferencedRow2:            mysql.ErrNoReferencedRow2,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassExecutor] = tableMySQLErrCodes
 }
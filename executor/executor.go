@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
@@ -158,6 +159,9 @@ func Next(ctx context.Context, e Executor, req *chunk.Chunk) error {
 	if atomic.CompareAndSwapUint32(&sessVars.Killed, 1, 0) {
 		return ErrQueryInterrupted
 	}
+	if deadline := sessVars.StmtCtx.Deadline; !deadline.IsZero() && time.Now().After(deadline) {
+		return ErrMaxExecTimeExceeded
+	}
 	return e.Next(ctx, req)
 }
 
@@ -342,6 +346,38 @@ func (e *TableDualExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// UnionExec pulls all its children's result and returns to its parent directly.
+// It is used to read the partitions of a partitioned table, the children are
+// read one by one.
+type UnionExec struct {
+	baseExecutor
+
+	childIdx int
+}
+
+// Open implements the Executor Open interface.
+func (e *UnionExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.childIdx = 0
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *UnionExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for ; e.childIdx < len(e.children); e.childIdx++ {
+		if err := Next(ctx, e.children[e.childIdx], req); err != nil {
+			return err
+		}
+		if req.NumRows() > 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
 // SelectionExec represents a filter executor.
 type SelectionExec struct {
 	baseExecutor
@@ -551,8 +587,8 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 	if len(hints) == 0 {
 		return
 	}
-	var memoryQuotaHint, useToJAHint *ast.TableOptimizerHint
-	var memoryQuotaHintCnt, useToJAHintCnt, readReplicaHintCnt int
+	var memoryQuotaHint, useToJAHint, maxExecutionTimeHint *ast.TableOptimizerHint
+	var memoryQuotaHintCnt, useToJAHintCnt, readReplicaHintCnt, maxExecutionTimeHintCnt int
 	for _, hint := range hints {
 		switch hint.HintName.L {
 		case "memory_quota":
@@ -563,6 +599,9 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 			useToJAHintCnt++
 		case "read_consistent_replica":
 			readReplicaHintCnt++
+		case "max_execution_time":
+			maxExecutionTimeHint = hint
+			maxExecutionTimeHintCnt++
 		}
 	}
 	// Handle MEMORY_QUOTA
@@ -602,6 +641,15 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 		stmtHints.HasReplicaReadHint = true
 		stmtHints.ReplicaRead = byte(kv.ReplicaReadFollower)
 	}
+	// Handle MAX_EXECUTION_TIME
+	if maxExecutionTimeHintCnt != 0 {
+		if maxExecutionTimeHintCnt > 1 {
+			warn := errors.New("There are multiple MAX_EXECUTION_TIME hints, only the last one will take effect")
+			warns = append(warns, warn)
+		}
+		stmtHints.HasMaxExecutionTime = true
+		stmtHints.MaxExecutionTime = maxExecutionTimeHint.MaxExecutionTime
+	}
 	return
 }
 
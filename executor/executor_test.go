@@ -37,6 +37,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -1247,3 +1248,36 @@ func (s *testSuite8) TearDownTest(c *C) {
 		tk.MustExec(fmt.Sprintf("drop table %v", tableName))
 	}
 }
+
+func (s *testSuite) TestMaxExecutionTime(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+
+	tk.MustQuery("select /*+ MAX_EXECUTION_TIME(1000) */ a from t limit 1").Check(testkit.Rows("0"))
+	sc := tk.Se.GetSessionVars().StmtCtx
+	c.Assert(sc.HasMaxExecutionTime, IsTrue)
+	c.Assert(sc.MaxExecutionTime, Equals, uint64(1000))
+	c.Assert(sc.Deadline.IsZero(), IsFalse)
+
+	tk.MustExec("insert into t values (100)")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.Deadline.IsZero(), IsTrue)
+
+	// The cartesian product has 1M rows, which can't be returned in 1ms.
+	err := tk.QueryToErr("select /*+ MAX_EXECUTION_TIME(1) */ a.a from t a, t b, t c")
+	c.Assert(terror.ErrorEqual(err, executor.ErrMaxExecTimeExceeded), IsTrue, Commentf("err %v", err))
+
+	tk.MustExec("set @@max_execution_time = 1")
+	err = tk.QueryToErr("select a.a from t a, t b, t c")
+	c.Assert(terror.ErrorEqual(err, executor.ErrMaxExecTimeExceeded), IsTrue, Commentf("err %v", err))
+	// The hint takes precedence over the session variable.
+	c.Assert(tk.MustQuery("select /*+ MAX_EXECUTION_TIME(0) */ a from t a").Rows(), HasLen, 101)
+	tk.MustExec("set @@max_execution_time = 0")
+	c.Assert(tk.MustQuery("select a from t").Rows(), HasLen, 101)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// ExpandExec outputs every chunk of its child once for each level of
+// expressions. It's placed below the HashAggExec of GROUP BY ... WITH ROLLUP,
+// where every level is a grouping set which rolls up some group-by columns
+// to NULL.
+type ExpandExec struct {
+	baseExecutor
+
+	levelEvaluatorSuits []*expression.EvaluatorSuite
+	childResult         *chunk.Chunk
+	// curLevel is the next level to be evaluated on childResult.
+	curLevel int
+}
+
+// Open implements the Executor Open interface.
+func (e *ExpandExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.childResult = newFirstChunk(e.children[0])
+	e.curLevel = len(e.levelEvaluatorSuits)
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *ExpandExec) Close() error {
+	e.childResult = nil
+	return e.baseExecutor.Close()
+}
+
+// Next implements the Executor Next interface.
+func (e *ExpandExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	if e.curLevel == len(e.levelEvaluatorSuits) {
+		err := Next(ctx, e.children[0], e.childResult)
+		if err != nil {
+			return err
+		}
+		if e.childResult.NumRows() == 0 {
+			return nil
+		}
+		e.curLevel = 0
+	}
+	input := e.childResult
+	// The column expressions are evaluated by swapping the columns out of the
+	// input, so all the levels except the last one work on a copy of it.
+	if e.curLevel < len(e.levelEvaluatorSuits)-1 {
+		input = input.CopyConstruct()
+	}
+	err := e.levelEvaluatorSuits[e.curLevel].Run(e.ctx, input, req)
+	e.curLevel++
+	return err
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+func (s *pkgTestSuite) TestExpandExec(c *C) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().InitChunkSize = 2
+	ctx.GetSessionVars().MaxChunkSize = 2
+	intTp := types.NewFieldType(mysql.TypeLonglong)
+	cols := []*expression.Column{
+		{Index: 0, RetType: intTp},
+		{Index: 1, RetType: intTp},
+	}
+	src := buildMockDataSource(mockDataSourceParameters{
+		schema: expression.NewSchema(cols...),
+		genDataFunc: func(row int, typ *types.FieldType) interface{} {
+			return int64(row)
+		},
+		rows: 3,
+		ctx:  ctx,
+	})
+	src.prepareChunks()
+
+	// select a, b, grouping(a), grouping(b) from t group by a, b with rollup
+	null := &expression.Constant{Value: types.NewDatum(nil), RetType: intTp}
+	levelExprs := [][]expression.Expression{
+		{cols[0], cols[1], &expression.Constant{Value: types.NewIntDatum(0), RetType: intTp}},
+		{cols[0], null, &expression.Constant{Value: types.NewIntDatum(1), RetType: intTp}},
+		{null, null, &expression.Constant{Value: types.NewIntDatum(3), RetType: intTp}},
+	}
+	schema := expression.NewSchema(
+		&expression.Column{Index: 0, RetType: intTp},
+		&expression.Column{Index: 1, RetType: intTp},
+		&expression.Column{Index: 2, RetType: intTp},
+	)
+	exec := &ExpandExec{
+		baseExecutor: newBaseExecutor(ctx, schema, nil, src),
+	}
+	for _, exprs := range levelExprs {
+		exec.levelEvaluatorSuits = append(exec.levelEvaluatorSuits, expression.NewEvaluatorSuite(exprs))
+	}
+	c.Assert(exec.Open(context.Background()), IsNil)
+	var result []string
+	chk := newFirstChunk(exec)
+	for {
+		c.Assert(exec.Next(context.Background(), chk), IsNil)
+		if chk.NumRows() == 0 {
+			break
+		}
+		for i := 0; i < chk.NumRows(); i++ {
+			row := chk.GetRow(i)
+			vals := make([]string, 0, row.Len())
+			for j := 0; j < row.Len(); j++ {
+				if row.IsNull(j) {
+					vals = append(vals, "<nil>")
+				} else {
+					vals = append(vals, strconv.FormatInt(row.GetInt64(j), 10))
+				}
+			}
+			result = append(result, strings.Join(vals, " "))
+		}
+	}
+	c.Assert(exec.Close(), IsNil)
+	c.Assert(result, DeepEquals, []string{
+		// The first chunk of the child.
+		"0 0 0", "1 1 0",
+		"0 <nil> 1", "1 <nil> 1",
+		"<nil> <nil> 3", "<nil> <nil> 3",
+		// The second chunk of the child.
+		"2 2 0",
+		"2 <nil> 1",
+		"<nil> <nil> 3",
+	})
+}
@@ -0,0 +1,327 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+)
+
+// fkChecker checks the referenced row of a foreign key exists when a row is inserted into the child table.
+type fkChecker struct {
+	desc       string
+	colOffsets []int
+	// refTbl is nil if the referenced table doesn't exist, then no row can be inserted except NULLs.
+	refTbl  table.Table
+	refCols []*table.Column
+	refIdx  *model.IndexInfo
+}
+
+// buildFKCheckers builds the checkers for the foreign keys of the table, it returns nil if
+// foreign_key_checks is off.
+func buildFKCheckers(sctx sessionctx.Context, is infoschema.InfoSchema, tbl table.Table) []*fkChecker {
+	tblInfo := tbl.Meta()
+	if len(tblInfo.ForeignKeys) == 0 || !sctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	dbInfo, ok := is.SchemaByTable(tblInfo)
+	if !ok {
+		return nil
+	}
+	checkers := make([]*fkChecker, 0, len(tblInfo.ForeignKeys))
+	for _, fk := range tblInfo.ForeignKeys {
+		checker := &fkChecker{
+			desc:       infoschema.FormatForeignKey(dbInfo.Name, tblInfo.Name, fk),
+			colOffsets: make([]int, 0, len(fk.Cols)),
+		}
+		for _, colName := range fk.Cols {
+			checker.colOffsets = append(checker.colOffsets, table.FindCol(tbl.Cols(), colName.L).Offset)
+		}
+		refSchema := fk.RefSchema
+		if refSchema.L == "" {
+			refSchema = dbInfo.Name
+		}
+		refTbl, err := is.TableByName(refSchema, fk.RefTable)
+		if err == nil && refTbl.Meta().GetPartitionInfo() == nil {
+			refCols, refIdx, ok := tables.FKLookupColumns(refTbl, fk.RefCols)
+			if ok {
+				checker.refTbl, checker.refCols, checker.refIdx = refTbl, refCols, refIdx
+			}
+		}
+		checkers = append(checkers, checker)
+	}
+	return checkers
+}
+
+// check checks the row to be inserted. A foreign key with any NULL column is always satisfied.
+func (c *fkChecker) check(ctx context.Context, sctx sessionctx.Context, row []types.Datum) error {
+	vals := make([]types.Datum, 0, len(c.colOffsets))
+	for _, offset := range c.colOffsets {
+		if row[offset].IsNull() {
+			return nil
+		}
+		vals = append(vals, row[offset])
+	}
+	if c.refTbl == nil {
+		return ErrNoReferencedRow2.GenWithStackByArgs(c.desc)
+	}
+	handles, err := tables.LookupFKRows(ctx, sctx, c.refTbl, c.refCols, c.refIdx, vals, 1)
+	if err != nil {
+		return err
+	}
+	if len(handles) == 0 {
+		return ErrNoReferencedRow2.GenWithStackByArgs(c.desc)
+	}
+	// The lookup only reads the snapshot, lock the record key of the referenced row so the transaction
+	// fails to commit if the row is deleted by another transaction meanwhile, a deletion always writes it.
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	return txn.LockKeys(ctx, new(kv.LockCtx), c.refTbl.RecordKey(handles[0]))
+}
+
+// fkCascade is a foreign key which references the table whose rows are being deleted.
+type fkCascade struct {
+	desc          string
+	onDelete      ast.ReferOptionType
+	onUpdate      ast.ReferOptionType
+	refColOffsets []int
+	childTbl      table.Table
+	childCols     []*table.Column
+	childIdx      *model.IndexInfo
+}
+
+// fkCascader enforces the ON DELETE actions of the foreign keys which reference the tables
+// whose rows are deleted, the cascaded deletes are enforced recursively. There is no UPDATE
+// statement, the ON UPDATE actions are only enforced for the rows updated by ON DELETE SET NULL.
+type fkCascader struct {
+	is       infoschema.InfoSchema
+	cascades map[int64][]*fkCascade
+}
+
+// newFKCascader returns nil if foreign_key_checks is off or none of the tables is referenced.
+func newFKCascader(sctx sessionctx.Context, is infoschema.InfoSchema, tbls ...table.Table) *fkCascader {
+	if !sctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	c := &fkCascader{is: is, cascades: make(map[int64][]*fkCascade)}
+	for _, tbl := range tbls {
+		if len(c.getCascades(tbl)) > 0 {
+			return c
+		}
+	}
+	return nil
+}
+
+func (c *fkCascader) getCascades(tbl table.Table) []*fkCascade {
+	tblInfo := tbl.Meta()
+	if cascades, ok := c.cascades[tblInfo.ID]; ok {
+		return cascades
+	}
+	var cascades []*fkCascade
+	if dbInfo, ok := c.is.SchemaByTable(tblInfo); ok {
+		for _, referredFK := range c.is.ReferredFKs(dbInfo.Name, tblInfo.Name) {
+			fk := referredFK.FK
+			childCols, childIdx, ok := tables.FKLookupColumns(referredFK.ChildTable, fk.Cols)
+			if !ok {
+				continue
+			}
+			cascade := &fkCascade{
+				desc:          referredFK.String(),
+				onDelete:      ast.ReferOptionType(fk.OnDelete),
+				onUpdate:      ast.ReferOptionType(fk.OnUpdate),
+				refColOffsets: make([]int, 0, len(fk.RefCols)),
+				childTbl:      referredFK.ChildTable,
+				childCols:     childCols,
+				childIdx:      childIdx,
+			}
+			for _, colName := range fk.RefCols {
+				col := table.FindCol(tbl.Cols(), colName.L)
+				if col == nil {
+					break
+				}
+				cascade.refColOffsets = append(cascade.refColOffsets, col.Offset)
+			}
+			if len(cascade.refColOffsets) == len(childCols) {
+				cascades = append(cascades, cascade)
+			}
+		}
+	}
+	c.cascades[tblInfo.ID] = cascades
+	return cascades
+}
+
+// canCascadeTo returns whether the cascaded deletes of the rows of tbl may remove the rows of tbl itself.
+func (c *fkCascader) canCascadeTo(tbl table.Table) bool {
+	tblID := tbl.Meta().ID
+	visited := make(map[int64]struct{})
+	queue := []table.Table{tbl}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, cascade := range c.getCascades(cur) {
+			if cascade.onDelete != ast.ReferOptionCascade {
+				continue
+			}
+			childID := cascade.childTbl.Meta().ID
+			if childID == tblID {
+				return true
+			}
+			if _, ok := visited[childID]; !ok {
+				visited[childID] = struct{}{}
+				queue = append(queue, cascade.childTbl)
+			}
+		}
+	}
+	return false
+}
+
+// onDeleteRow enforces the foreign keys referencing the deleted row of tbl. It must be called after
+// the row is removed, so a row referencing itself or a cycle of cascades is not visited again.
+func (c *fkCascader) onDeleteRow(ctx context.Context, sctx sessionctx.Context, tbl table.Table, row []types.Datum) error {
+	for _, cascade := range c.getCascades(tbl) {
+		vals, ok := cascade.refValues(row)
+		if !ok {
+			continue
+		}
+		handles, err := cascade.lookupChildRows(ctx, sctx, cascade.onDelete, vals)
+		if err != nil {
+			return err
+		}
+		for _, h := range handles {
+			if err = c.applyOnDelete(ctx, sctx, cascade, h); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// onUpdateRow enforces the foreign keys referencing the columns of tbl which are changed from oldRow
+// to newRow. It must be called after the row is updated.
+func (c *fkCascader) onUpdateRow(ctx context.Context, sctx sessionctx.Context, tbl table.Table, oldRow, newRow []types.Datum) error {
+	sc := sctx.GetSessionVars().StmtCtx
+	for _, cascade := range c.getCascades(tbl) {
+		vals, ok := cascade.refValues(oldRow)
+		if !ok {
+			continue
+		}
+		newVals := make([]types.Datum, 0, len(cascade.refColOffsets))
+		changed := false
+		for _, offset := range cascade.refColOffsets {
+			cmp, err := oldRow[offset].CompareDatum(sc, &newRow[offset])
+			if err != nil {
+				return err
+			}
+			changed = changed || cmp != 0
+			newVals = append(newVals, newRow[offset])
+		}
+		if !changed {
+			continue
+		}
+		handles, err := cascade.lookupChildRows(ctx, sctx, cascade.onUpdate, vals)
+		if err != nil {
+			return err
+		}
+		for _, h := range handles {
+			switch cascade.onUpdate {
+			case ast.ReferOptionCascade:
+				err = c.updateChildRow(ctx, sctx, cascade, h, newVals)
+			case ast.ReferOptionSetNull:
+				err = c.updateChildRow(ctx, sctx, cascade, h, nil)
+			default:
+				err = ErrRowIsReferenced2.GenWithStackByArgs(cascade.desc)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refValues returns the values of the referenced columns in the row, it returns false if any of them is NULL.
+func (cascade *fkCascade) refValues(row []types.Datum) ([]types.Datum, bool) {
+	vals := make([]types.Datum, 0, len(cascade.refColOffsets))
+	for _, offset := range cascade.refColOffsets {
+		if row[offset].IsNull() {
+			return nil, false
+		}
+		vals = append(vals, row[offset])
+	}
+	return vals, true
+}
+
+// lookupChildRows returns the handles of the child rows referencing vals, only one is needed to
+// report the error if the action is neither CASCADE nor SET NULL.
+func (cascade *fkCascade) lookupChildRows(ctx context.Context, sctx sessionctx.Context, action ast.ReferOptionType,
+	vals []types.Datum) ([]int64, error) {
+	limit := 0
+	if action != ast.ReferOptionCascade && action != ast.ReferOptionSetNull {
+		limit = 1
+	}
+	return tables.LookupFKRows(ctx, sctx, cascade.childTbl, cascade.childCols, cascade.childIdx, vals, limit)
+}
+
+// updateChildRow sets the referencing columns of the child row to vals, or NULLs if vals is nil, and
+// enforces the foreign keys referencing the child row.
+func (c *fkCascader) updateChildRow(ctx context.Context, sctx sessionctx.Context, cascade *fkCascade, h int64, vals []types.Datum) error {
+	oldRow, err := cascade.childTbl.Row(sctx, h)
+	if err != nil {
+		return err
+	}
+	newRow := make([]types.Datum, len(oldRow))
+	copy(newRow, oldRow)
+	touched := make([]bool, len(oldRow))
+	for i, col := range cascade.childCols {
+		if vals == nil {
+			newRow[col.Offset].SetNull()
+		} else {
+			newRow[col.Offset] = vals[i]
+		}
+		touched[col.Offset] = true
+	}
+	if err = cascade.childTbl.UpdateRecord(sctx, h, oldRow, newRow, touched); err != nil {
+		return err
+	}
+	return c.onUpdateRow(ctx, sctx, cascade.childTbl, oldRow, newRow)
+}
+
+func (c *fkCascader) applyOnDelete(ctx context.Context, sctx sessionctx.Context, cascade *fkCascade, h int64) error {
+	switch cascade.onDelete {
+	case ast.ReferOptionCascade:
+		childRow, err := cascade.childTbl.Row(sctx, h)
+		if err != nil {
+			return err
+		}
+		if err = cascade.childTbl.RemoveRecord(sctx, h, childRow); err != nil {
+			return err
+		}
+		return c.onDeleteRow(ctx, sctx, cascade.childTbl, childRow)
+	case ast.ReferOptionSetNull:
+		return c.updateChildRow(ctx, sctx, cascade, h, nil)
+	default:
+		// RESTRICT and NO ACTION are the same as the default, the checks are not deferred.
+		return ErrRowIsReferenced2.GenWithStackByArgs(cascade.desc)
+	}
+}
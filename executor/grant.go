@@ -0,0 +1,588 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// The privilege tables are changed by replacing the whole rows, because there
+// is no UPDATE statement: privRow holds the columns of a row in order, and
+// the values of the columns which are not NULL.
+type privRow struct {
+	cols []string
+	vals map[string]string
+}
+
+// quoteString quotes s as a string literal in a SQL statement.
+func quoteString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `'`, `\'`, -1)
+	return "'" + s + "'"
+}
+
+// userCond is the condition matching the rows of the user in the privilege tables.
+func userCond(user, host string) string {
+	return fmt.Sprintf("User = %s AND Host = %s", quoteString(user), quoteString(host))
+}
+
+// executeInTxn changes the privilege tables in a transaction of a system
+// session, so that a statement either changes all of the rows or none of
+// them. The privileges are reloaded after the transaction is committed.
+func (e *SimpleExec) executeInTxn(ctx context.Context, fn func() error) error {
+	tmp, err := domain.GetDomain(e.ctx).SysSessionPool().Get()
+	if err != nil {
+		return err
+	}
+	e.sysSession = tmp.(sessionctx.Context)
+	defer e.releaseSysSession(tmp)
+
+	if _, _, err = e.querySysSession(ctx, "begin"); err != nil {
+		return err
+	}
+	if err = fn(); err != nil {
+		return err
+	}
+	if _, _, err = e.querySysSession(ctx, "commit"); err != nil {
+		return err
+	}
+	e.notifyUpdatePrivilege()
+	return nil
+}
+
+// releaseSysSession rolls back the transaction which is not committed, the
+// session is closed instead of being put back to the pool if it fails.
+func (e *SimpleExec) releaseSysSession(res pools.Resource) {
+	e.sysSession = nil
+	if _, err := res.(sqlexec.SQLExecutor).Execute(context.Background(), "rollback"); err != nil {
+		res.Close()
+		return
+	}
+	domain.GetDomain(e.ctx).SysSessionPool().Put(res)
+}
+
+// querySysSession executes the sql in the system session and returns the
+// rows and the fields of the result.
+func (e *SimpleExec) querySysSession(ctx context.Context, sql string) ([]chunk.Row, []*ast.ResultField, error) {
+	rss, err := e.sysSession.(sqlexec.SQLExecutor).Execute(ctx, sql)
+	if err != nil || len(rss) == 0 {
+		return nil, nil, err
+	}
+	rs := rss[0]
+	defer terror.Call(rs.Close)
+	var rows []chunk.Row
+	for {
+		req := rs.NewChunk()
+		if err = rs.Next(ctx, req); err != nil {
+			return nil, nil, err
+		}
+		if req.NumRows() == 0 {
+			return rows, rs.Fields(), nil
+		}
+		iter := chunk.NewIterator4Chunk(req)
+		for r := iter.Begin(); r != iter.End(); r = iter.Next() {
+			rows = append(rows, r)
+		}
+	}
+}
+
+// queryPrivTables executes the sql on the privilege tables, in the
+// transaction of executeInTxn if there is one.
+func (e *SimpleExec) queryPrivTables(sql string) ([]chunk.Row, []*ast.ResultField, error) {
+	if e.sysSession != nil {
+		return e.querySysSession(context.TODO(), sql)
+	}
+	return e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+}
+
+func (e *SimpleExec) execRestrictedSQL(sql string) error {
+	_, _, err := e.queryPrivTables(sql)
+	return err
+}
+
+// selectPrivRow returns the row of the privilege table matching the condition,
+// or nil if there is no such row.
+func (e *SimpleExec) selectPrivRow(table, cond string) (*privRow, error) {
+	sql := fmt.Sprintf("SELECT * FROM %s.%s WHERE %s", mysql.SystemDB, table, cond)
+	rows, fields, err := e.queryPrivTables(sql)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	row := &privRow{cols: make([]string, 0, len(fields)), vals: make(map[string]string, len(fields))}
+	for i, f := range fields {
+		row.cols = append(row.cols, f.ColumnAsName.O)
+		if !rows[0].IsNull(i) {
+			row.vals[f.ColumnAsName.O] = rows[0].GetString(i)
+		}
+	}
+	return row, nil
+}
+
+// newPrivRow returns a row which only holds the given columns, the other
+// columns take the default values when it is written.
+func newPrivRow(cols []string, vals []string) *privRow {
+	row := &privRow{cols: cols, vals: make(map[string]string, len(cols))}
+	for i, col := range cols {
+		row.vals[col] = vals[i]
+	}
+	return row
+}
+
+func (row *privRow) set(col, val string) {
+	if _, ok := row.vals[col]; !ok {
+		found := false
+		for _, c := range row.cols {
+			if c == col {
+				found = true
+				break
+			}
+		}
+		if !found {
+			row.cols = append(row.cols, col)
+		}
+	}
+	row.vals[col] = val
+}
+
+func (e *SimpleExec) replacePrivRow(table string, row *privRow) error {
+	values := make([]string, 0, len(row.cols))
+	for _, col := range row.cols {
+		val, ok := row.vals[col]
+		if !ok {
+			values = append(values, "NULL")
+			continue
+		}
+		values = append(values, quoteString(val))
+	}
+	sql := fmt.Sprintf("REPLACE INTO %s.%s (%s) VALUES (%s)", mysql.SystemDB, table,
+		strings.Join(row.cols, ", "), strings.Join(values, ", "))
+	return e.execRestrictedSQL(sql)
+}
+
+func (e *SimpleExec) userExists(user, host string) (bool, error) {
+	row, err := e.selectPrivRow(mysql.UserTable, userCond(user, host))
+	return row != nil, err
+}
+
+// currentUser resolves CURRENT_USER() to the account of the session.
+func (e *SimpleExec) currentUser(user *auth.UserIdentity) *auth.UserIdentity {
+	if !user.CurrentUser {
+		return user
+	}
+	sessUser := e.ctx.GetSessionVars().User
+	if sessUser == nil {
+		return user
+	}
+	return &auth.UserIdentity{Username: sessUser.AuthUsername, Hostname: sessUser.AuthHostname}
+}
+
+// grantScope is the resolved privilege level of GRANT and REVOKE.
+type grantScope struct {
+	level ast.GrantLevelType
+	db    string
+	table string
+}
+
+func (e *SimpleExec) resolveGrantLevel(level *ast.GrantLevel) (*grantScope, error) {
+	scope := &grantScope{level: level.Level, db: level.DBName, table: level.TableName}
+	if scope.level == ast.GrantLevelGlobal {
+		return scope, nil
+	}
+	if scope.db == "" {
+		scope.db = e.ctx.GetSessionVars().CurrentDB
+		if scope.db == "" {
+			return nil, core.ErrNoDB
+		}
+	}
+	if scope.level == ast.GrantLevelTable {
+		tbl, err := e.is.TableByName(model.NewCIStr(scope.db), model.NewCIStr(scope.table))
+		if err != nil {
+			return nil, err
+		}
+		scope.table = tbl.Meta().Name.O
+	} else if _, ok := e.is.SchemaByName(model.NewCIStr(scope.db)); !ok {
+		return nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(scope.db)
+	}
+	return scope, nil
+}
+
+// privsOfLevel expands the privileges of the statement into the privileges
+// valid at the level.
+func privsOfLevel(level ast.GrantLevelType, elems []*ast.PrivElem, withGrant bool) ([]mysql.PrivilegeType, error) {
+	var all []mysql.PrivilegeType
+	switch level {
+	case ast.GrantLevelGlobal:
+		all = mysql.AllGlobalPrivs
+	case ast.GrantLevelDB:
+		all = mysql.AllDBPrivs
+	default:
+		all = mysql.AllTablePrivs
+	}
+	var privs []mysql.PrivilegeType
+	for _, elem := range elems {
+		if elem.Priv == mysql.AllPriv {
+			privs = append(privs, all...)
+			continue
+		}
+		valid := elem.Priv == mysql.GrantPriv
+		for _, priv := range all {
+			if priv == elem.Priv {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, ErrIllegalGrantForTable
+		}
+		privs = append(privs, elem.Priv)
+	}
+	if withGrant {
+		privs = append(privs, mysql.GrantPriv)
+	}
+	return privs, nil
+}
+
+func (e *SimpleExec) executeGrant(s *ast.GrantStmt) error {
+	scope, err := e.resolveGrantLevel(s.Level)
+	if err != nil {
+		return err
+	}
+	privs, err := privsOfLevel(scope.level, s.Privs, s.WithGrant)
+	if err != nil {
+		return err
+	}
+	for _, spec := range s.Users {
+		user := e.currentUser(spec.User)
+		exists, err := e.userExists(user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !exists && spec.AuthOpt == nil:
+			return ErrCantCreateUserWithGrant
+		case !exists:
+			err = e.createUser(user, spec.AuthOpt, false)
+		case spec.AuthOpt != nil:
+			err = e.alterUser(user, spec.AuthOpt)
+		}
+		if err != nil {
+			return err
+		}
+		err = e.changePrivileges(user, scope, privs, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeRevoke(s *ast.RevokeStmt) error {
+	scope, err := e.resolveGrantLevel(s.Level)
+	if err != nil {
+		return err
+	}
+	privs, err := privsOfLevel(scope.level, s.Privs, false)
+	if err != nil {
+		return err
+	}
+	for _, spec := range s.Users {
+		user := e.currentUser(spec.User)
+		exists, err := e.userExists(user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNonexistingGrant.GenWithStackByArgs(user.Username, user.Hostname)
+		}
+		err = e.changePrivileges(user, scope, privs, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changePrivileges grants or revokes the privileges of the user at the scope.
+func (e *SimpleExec) changePrivileges(user *auth.UserIdentity, scope *grantScope, privs []mysql.PrivilegeType, grant bool) error {
+	switch scope.level {
+	case ast.GrantLevelGlobal:
+		return e.changeRowPrivileges(mysql.UserTable, userCond(user.Username, user.Hostname), nil, nil, privs, grant, user)
+	case ast.GrantLevelDB:
+		cond := fmt.Sprintf("%s AND DB = %s", userCond(user.Username, user.Hostname), quoteString(scope.db))
+		cols := []string{"Host", "DB", "User"}
+		vals := []string{user.Hostname, scope.db, user.Username}
+		return e.changeRowPrivileges(mysql.DBTable, cond, cols, vals, privs, grant, user)
+	default:
+		return e.changeTablePrivileges(user, scope, privs, grant)
+	}
+}
+
+// changeRowPrivileges sets the privilege columns of the row in mysql.user or
+// mysql.db, the row is created with the key columns if it doesn't exist.
+func (e *SimpleExec) changeRowPrivileges(table, cond string, keyCols, keyVals []string,
+	privs []mysql.PrivilegeType, grant bool, user *auth.UserIdentity) error {
+	row, err := e.selectPrivRow(table, cond)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		if !grant || keyCols == nil {
+			return ErrNonexistingGrant.GenWithStackByArgs(user.Username, user.Hostname)
+		}
+		row = newPrivRow(keyCols, keyVals)
+	}
+	val := "N"
+	if grant {
+		val = "Y"
+	}
+	for _, priv := range privs {
+		row.set(mysql.Priv2UserCol[priv], val)
+	}
+	return e.replacePrivRow(table, row)
+}
+
+func (e *SimpleExec) changeTablePrivileges(user *auth.UserIdentity, scope *grantScope, privs []mysql.PrivilegeType, grant bool) error {
+	cond := fmt.Sprintf("%s AND DB = %s AND Table_name = %s", userCond(user.Username, user.Hostname),
+		quoteString(scope.db), quoteString(scope.table))
+	row, err := e.selectPrivRow(mysql.TablePrivTable, cond)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		if !grant {
+			return ErrNonexistingGrant.GenWithStackByArgs(user.Username, user.Hostname)
+		}
+		row = newPrivRow([]string{"Host", "DB", "User", "Table_name"},
+			[]string{user.Hostname, scope.db, user.Username, scope.table})
+	}
+	tablePriv := privileges.DecodeSetToPrivilege(row.vals["Table_priv"])
+	for _, priv := range privs {
+		if grant {
+			tablePriv |= priv
+		} else {
+			tablePriv &^= priv
+		}
+	}
+	row.set("Table_priv", privileges.EncodePrivilegeToSet(tablePriv))
+	row.set("Grantor", e.grantor())
+	return e.replacePrivRow(mysql.TablePrivTable, row)
+}
+
+func (e *SimpleExec) grantor() string {
+	if user := e.ctx.GetSessionVars().User; user != nil {
+		return fmt.Sprintf("%s@%s", user.AuthUsername, user.AuthHostname)
+	}
+	return ""
+}
+
+// roleEdgeCond is the condition matching the grant of the role to the user in mysql.role_edges.
+func roleEdgeCond(role *auth.RoleIdentity, user *auth.UserIdentity) string {
+	return fmt.Sprintf("FROM_USER = %s AND FROM_HOST = %s AND TO_USER = %s AND TO_HOST = %s",
+		quoteString(role.Username), quoteString(role.Hostname), quoteString(user.Username), quoteString(user.Hostname))
+}
+
+// checkRoleGrantees checks that all the roles and the users of GRANT or REVOKE role exist.
+func (e *SimpleExec) checkRoleGrantees(op string, roles []*auth.RoleIdentity, users []*auth.UserIdentity) error {
+	for _, role := range roles {
+		exists, err := e.userExists(role.Username, role.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCannotUser.GenWithStackByArgs(op, role.String())
+		}
+	}
+	for _, user := range users {
+		exists, err := e.userExists(user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCannotUser.GenWithStackByArgs(op, userString(user))
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) resolveUsers(users []*auth.UserIdentity) []*auth.UserIdentity {
+	resolved := make([]*auth.UserIdentity, 0, len(users))
+	for _, user := range users {
+		resolved = append(resolved, e.currentUser(user))
+	}
+	return resolved
+}
+
+func (e *SimpleExec) executeGrantRole(s *ast.GrantRoleStmt) error {
+	users := e.resolveUsers(s.Users)
+	if err := e.checkRoleGrantees("GRANT ROLE", s.Roles, users); err != nil {
+		return err
+	}
+	for _, user := range users {
+		for _, role := range s.Roles {
+			sql := fmt.Sprintf("REPLACE INTO %s.%s (FROM_HOST, FROM_USER, TO_HOST, TO_USER) VALUES (%s, %s, %s, %s)",
+				mysql.SystemDB, mysql.RoleEdgeTable, quoteString(role.Hostname), quoteString(role.Username),
+				quoteString(user.Hostname), quoteString(user.Username))
+			if err := e.execRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// executeRevokeRole removes the grants of the roles, a revoked role is no
+// longer a default role of the user either.
+func (e *SimpleExec) executeRevokeRole(s *ast.RevokeRoleStmt) error {
+	users := e.resolveUsers(s.Users)
+	if err := e.checkRoleGrantees("REVOKE ROLE", s.Roles, users); err != nil {
+		return err
+	}
+	for _, user := range users {
+		for _, role := range s.Roles {
+			sql := fmt.Sprintf("DELETE FROM %s.%s WHERE %s", mysql.SystemDB, mysql.RoleEdgeTable, roleEdgeCond(role, user))
+			if err := e.execRestrictedSQL(sql); err != nil {
+				return err
+			}
+			sql = fmt.Sprintf("DELETE FROM %s.%s WHERE %s AND DEFAULT_ROLE_USER = %s AND DEFAULT_ROLE_HOST = %s",
+				mysql.SystemDB, mysql.DefaultRoleTable, userCond(user.Username, user.Hostname),
+				quoteString(role.Username), quoteString(role.Hostname))
+			if err := e.execRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropRoleEdges removes the account from the role grants and the default
+// roles, both as a grantee and as a role.
+func (e *SimpleExec) dropRoleEdges(user *auth.UserIdentity) error {
+	name, host := quoteString(user.Username), quoteString(user.Hostname)
+	conds := []struct {
+		table string
+		cond  string
+	}{
+		{mysql.RoleEdgeTable, fmt.Sprintf("FROM_USER = %s AND FROM_HOST = %s", name, host)},
+		{mysql.RoleEdgeTable, fmt.Sprintf("TO_USER = %s AND TO_HOST = %s", name, host)},
+		{mysql.DefaultRoleTable, userCond(user.Username, user.Hostname)},
+		{mysql.DefaultRoleTable, fmt.Sprintf("DEFAULT_ROLE_USER = %s AND DEFAULT_ROLE_HOST = %s", name, host)},
+	}
+	for _, c := range conds {
+		sql := fmt.Sprintf("DELETE FROM %s.%s WHERE %s", mysql.SystemDB, c.table, c.cond)
+		if err := e.execRestrictedSQL(sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeSetRole changes the active roles of the session.
+func (e *SimpleExec) executeSetRole(s *ast.SetRoleStmt) error {
+	sessVars := e.ctx.GetSessionVars()
+	pm := privilege.GetPrivilegeManager(e.ctx)
+	if pm == nil || sessVars.User == nil {
+		return nil
+	}
+	user := &auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}
+	var roles []*auth.RoleIdentity
+	switch s.SetRoleOpt {
+	case ast.SetRoleDefault:
+		roles = pm.GetDefaultRoles(user.Username, user.Hostname)
+	case ast.SetRoleAll:
+		roles = pm.GetAllRoles(user.Username, user.Hostname)
+	case ast.SetRoleAllExcept:
+		for _, role := range pm.GetAllRoles(user.Username, user.Hostname) {
+			if !containsRole(s.RoleList, role) {
+				roles = append(roles, role)
+			}
+		}
+	case ast.SetRoleRegular:
+		for _, role := range s.RoleList {
+			if !pm.FindEdge(e.ctx, role, user) {
+				return ErrRoleNotGranted.GenWithStackByArgs(role.String(), userString(user))
+			}
+		}
+		roles = s.RoleList
+	}
+	sessVars.ActiveRoles = roles
+	return nil
+}
+
+func containsRole(roles []*auth.RoleIdentity, role *auth.RoleIdentity) bool {
+	for _, r := range roles {
+		if r.Username == role.Username && r.Hostname == role.Hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// executeSetDefaultRole replaces the default roles of the users, which are
+// activated when they log in.
+func (e *SimpleExec) executeSetDefaultRole(s *ast.SetDefaultRoleStmt) error {
+	pm := privilege.GetPrivilegeManager(e.ctx)
+	users := e.resolveUsers(s.UserList)
+	if err := e.checkRoleGrantees("SET DEFAULT ROLE", nil, users); err != nil {
+		return err
+	}
+	for _, user := range users {
+		var roles []*auth.RoleIdentity
+		switch s.SetRoleOpt {
+		case ast.SetRoleAll:
+			if pm != nil {
+				roles = pm.GetAllRoles(user.Username, user.Hostname)
+			}
+		case ast.SetRoleRegular:
+			for _, role := range s.RoleList {
+				if pm != nil && !pm.FindEdge(e.ctx, role, user) {
+					return ErrRoleNotGranted.GenWithStackByArgs(role.String(), userString(user))
+				}
+			}
+			roles = s.RoleList
+		}
+		sql := fmt.Sprintf("DELETE FROM %s.%s WHERE %s", mysql.SystemDB, mysql.DefaultRoleTable, userCond(user.Username, user.Hostname))
+		if err := e.execRestrictedSQL(sql); err != nil {
+			return err
+		}
+		for _, role := range roles {
+			sql := fmt.Sprintf("INSERT INTO %s.%s (HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER) VALUES (%s, %s, %s, %s)",
+				mysql.SystemDB, mysql.DefaultRoleTable, quoteString(user.Hostname), quoteString(user.Username),
+				quoteString(role.Hostname), quoteString(role.Username))
+			if err := e.execRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -61,6 +61,9 @@ type InsertValues struct {
 	// Other statements like `insert select from` don't guarantee consecutive autoID.
 	// https://dev.mysql.com/doc/refman/8.0/en/innodb-auto-increment-handling.html
 	lazyFillAutoID bool
+
+	// fkCheckers checks the referenced rows of the foreign keys exist.
+	fkCheckers []*fkChecker
 }
 
 type defaultVal struct {
@@ -662,6 +665,11 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) (int64,
 	if err != nil {
 		return 0, err
 	}
+	for _, checker := range e.fkCheckers {
+		if err = checker.check(ctx, e.ctx, row); err != nil {
+			return 0, err
+		}
+	}
 	if !e.ctx.GetSessionVars().ConstraintCheckInPlace {
 		txn.SetOption(kv.PresumeKeyNotExists, nil)
 	}
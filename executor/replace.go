@@ -29,6 +29,9 @@ import (
 type ReplaceExec struct {
 	*InsertValues
 	Priority int
+
+	// fkCascader enforces the foreign keys referencing the removed rows.
+	fkCascader *fkCascader
 }
 
 // Close implements the Executor Close interface.
@@ -76,6 +79,11 @@ func (e *ReplaceExec) removeRow(ctx context.Context, txn kv.Transaction, handle
 	if err != nil {
 		return false, err
 	}
+	if e.fkCascader != nil {
+		if err = e.fkCascader.onDeleteRow(ctx, e.ctx, r.t, oldRow); err != nil {
+			return false, err
+		}
+	}
 	e.ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
 	return false, nil
 }
@@ -26,10 +26,12 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -45,10 +47,12 @@ type ShowExec struct {
 
 	Tp        ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName    model.CIStr
-	Table     *ast.TableName  // Used for showing columns.
-	Column    *ast.ColumnName // Used for `desc table column`.
-	IndexName model.CIStr     // Used for show table regions.
-	Flag      int             // Some flag parsed from sql, such as FULL.
+	Table     *ast.TableName       // Used for showing columns.
+	Column    *ast.ColumnName      // Used for `desc table column`.
+	IndexName model.CIStr          // Used for show table regions.
+	Flag      int                  // Some flag parsed from sql, such as FULL.
+	User      *auth.UserIdentity   // Used for show grants.
+	Roles     []*auth.RoleIdentity // Used for show grants.
 
 	is infoschema.InfoSchema
 
@@ -107,6 +111,30 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowWarnings(false)
 	case ast.ShowErrors:
 		return e.fetchShowWarnings(true)
+	case ast.ShowColumns:
+		return e.fetchShowColumns()
+	case ast.ShowIndex:
+		return e.fetchShowIndex()
+	case ast.ShowTableStatus:
+		return e.fetchShowTableStatus()
+	case ast.ShowStatus:
+		return e.fetchShowStatus()
+	case ast.ShowCharset:
+		return e.fetchShowCharset()
+	case ast.ShowCollation:
+		return e.fetchShowCollation()
+	case ast.ShowEngines:
+		return e.fetchShowEngines()
+	case ast.ShowStatsMeta:
+		return e.fetchShowStatsMeta()
+	case ast.ShowStatsHistograms:
+		return e.fetchShowStatsHistograms()
+	case ast.ShowStatsBuckets:
+		return e.fetchShowStatsBuckets()
+	case ast.ShowStatsHealthy:
+		return e.fetchShowStatsHealthy()
+	case ast.ShowGrants:
+		return e.fetchShowGrants()
 	}
 	return nil
 }
@@ -129,7 +157,11 @@ func (e *ShowExec) fetchShowDatabases() error {
 	sort.Strings(dbs)
 	// let information_schema be the first database
 	moveInfoSchemaToFront(dbs)
+	pm := privilege.GetPrivilegeManager(e.ctx)
 	for _, d := range dbs {
+		if pm != nil && !pm.DBIsVisible(e.ctx.GetSessionVars().ActiveRoles, d) {
+			continue
+		}
 		e.appendRow([]interface{}{
 			d,
 		})
@@ -159,6 +191,25 @@ func (e *ShowExec) fetchShowTables() error {
 	return nil
 }
 
+func (e *ShowExec) fetchShowGrants() error {
+	// The user is nil for the sessions which are not authenticated.
+	if e.User == nil {
+		return ErrNonexistingGrant.GenWithStackByArgs("", "")
+	}
+	pm := privilege.GetPrivilegeManager(e.ctx)
+	if pm == nil {
+		return nil
+	}
+	grants, err := pm.ShowGrants(e.ctx, e.User, e.Roles)
+	if err != nil {
+		return err
+	}
+	for _, g := range grants {
+		e.appendRow([]interface{}{g})
+	}
+	return nil
+}
+
 func (e *ShowExec) fetchShowVariables() (err error) {
 	var (
 		value         string
@@ -204,6 +255,178 @@ func (e *ShowExec) fetchShowVariables() (err error) {
 	return nil
 }
 
+// infoschemaColumnOffsets looks up the offsets of the named columns in the
+// information_schema table, so the rows built for it can be picked by name.
+func (e *ShowExec) infoschemaColumnOffsets(tblName string, colNames ...string) ([]int, error) {
+	tbl, err := e.is.TableByName(model.NewCIStr(infoschema.Name), model.NewCIStr(tblName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offsets := make([]int, 0, len(colNames))
+	for _, name := range colNames {
+		col := table.FindCol(tbl.Cols(), name)
+		if col == nil {
+			return nil, errors.Errorf("column %s not found in information_schema.%s", name, tblName)
+		}
+		offsets = append(offsets, col.Offset)
+	}
+	return offsets, nil
+}
+
+// datumsToRow picks the datums at the given offsets of a row built for
+// information_schema, returns the whole row if no offset is given.
+func datumsToRow(datums []types.Datum, offsets ...int) []interface{} {
+	if len(offsets) == 0 {
+		row := make([]interface{}, 0, len(datums))
+		for i := range datums {
+			row = append(row, datums[i].GetValue())
+		}
+		return row
+	}
+	row := make([]interface{}, 0, len(offsets))
+	for _, offset := range offsets {
+		row = append(row, datums[offset].GetValue())
+	}
+	return row
+}
+
+func (e *ShowExec) getTableAndSchema() (table.Table, *model.DBInfo, error) {
+	tb, err := e.getTable()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbInfo, ok := e.is.SchemaByName(e.Table.Schema)
+	if !ok {
+		return nil, nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(e.Table.Schema.O)
+	}
+	return tb, dbInfo, nil
+}
+
+// fetchShowColumns composes show [full] columns result from the rows of
+// information_schema.columns.
+func (e *ShowExec) fetchShowColumns() error {
+	tb, dbInfo, err := e.getTableAndSchema()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	colNames := []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_KEY", "COLUMN_DEFAULT", "EXTRA"}
+	if e.Full {
+		colNames = []string{"COLUMN_NAME", "COLUMN_TYPE", "COLLATION_NAME", "IS_NULLABLE", "COLUMN_KEY",
+			"COLUMN_DEFAULT", "EXTRA", "PRIVILEGES", "COLUMN_COMMENT"}
+	}
+	offsets, err := e.infoschemaColumnOffsets("COLUMNS", colNames...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range infoschema.DataForColumnsInTable(dbInfo, tb.Meta()) {
+		e.appendRow(datumsToRow(row, offsets...))
+	}
+	return nil
+}
+
+// fetchShowIndex composes show index result from the rows of
+// information_schema.statistics.
+func (e *ShowExec) fetchShowIndex() error {
+	tb, dbInfo, err := e.getTableAndSchema()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offsets, err := e.infoschemaColumnOffsets("STATISTICS", "TABLE_NAME", "NON_UNIQUE", "INDEX_NAME",
+		"SEQ_IN_INDEX", "COLUMN_NAME", "COLLATION", "CARDINALITY", "SUB_PART", "PACKED", "NULLABLE",
+		"INDEX_TYPE", "COMMENT", "INDEX_COMMENT")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows, err := infoschema.DataForStatisticsInTable(e.ctx, dbInfo, tb.Meta())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range rows {
+		// NON_UNIQUE is a string in information_schema.statistics but an integer in show index.
+		nonUnique, err := strconv.ParseInt(row[offsets[1]].GetString(), 10, 64)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		record := datumsToRow(row, offsets...)
+		record[1] = nonUnique
+		e.appendRow(record)
+	}
+	return nil
+}
+
+// fetchShowTableStatus composes show table status result from the rows of
+// information_schema.tables.
+func (e *ShowExec) fetchShowTableStatus() error {
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	offsets, err := e.infoschemaColumnOffsets("TABLES", "TABLE_NAME", "ENGINE", "VERSION", "ROW_FORMAT",
+		"TABLE_ROWS", "AVG_ROW_LENGTH", "DATA_LENGTH", "MAX_DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE",
+		"AUTO_INCREMENT", "CREATE_TIME", "UPDATE_TIME", "CHECK_TIME", "TABLE_COLLATION", "CHECKSUM",
+		"CREATE_OPTIONS", "TABLE_COMMENT")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows, err := infoschema.DataForTables(e.ctx, []*model.DBInfo{dbInfo})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	nameOffset := offsets[0]
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][nameOffset].GetString() < rows[j][nameOffset].GetString()
+	})
+	for _, row := range rows {
+		e.appendRow(datumsToRow(row, offsets...))
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowStatus() error {
+	statusVars, err := variable.GetStatusVars(e.ctx.GetSessionVars())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	names := make([]string, 0, len(statusVars))
+	for name, v := range statusVars {
+		if e.GlobalScope && v.Scope == variable.ScopeSession {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.appendRow([]interface{}{name, fmt.Sprintf("%v", statusVars[name].Value)})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCharset() error {
+	offsets, err := e.infoschemaColumnOffsets("CHARACTER_SETS", "CHARACTER_SET_NAME", "DESCRIPTION",
+		"DEFAULT_COLLATE_NAME", "MAXLEN")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range infoschema.DataForCharacterSets() {
+		e.appendRow(datumsToRow(row, offsets...))
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCollation() error {
+	for _, row := range infoschema.DataForCollations() {
+		e.appendRow(datumsToRow(row))
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowEngines() error {
+	for _, row := range infoschema.DataForEngines() {
+		e.appendRow(datumsToRow(row))
+	}
+	return nil
+}
+
 func getDefaultCollate(charsetName string) string {
 	for _, c := range charset.GetSupportedCharsets() {
 		if strings.EqualFold(c.Name, charsetName) {
@@ -333,6 +556,7 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			buf.WriteString(",\n")
 		}
 	}
+	appendForeignKeys(ctx, tableInfo, sqlMode, buf)
 
 	buf.WriteString("\n")
 
@@ -374,9 +598,97 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 	if len(tableInfo.Comment) > 0 {
 		fmt.Fprintf(buf, " COMMENT='%s'", format.OutputFormat(tableInfo.Comment))
 	}
+	appendPartitionInfo(tableInfo.GetPartitionInfo(), sqlMode, buf)
 	return nil
 }
 
+// appendForeignKeys appends the public foreign keys of the table. The referenced table is qualified
+// by its schema only if it's in another schema.
+func appendForeignKeys(ctx sessionctx.Context, tableInfo *model.TableInfo, sqlMode mysql.SQLMode, buf *bytes.Buffer) {
+	var schema model.CIStr
+	if is, ok := ctx.GetSessionVars().TxnCtx.InfoSchema.(infoschema.InfoSchema); ok {
+		if dbInfo, ok := is.SchemaByTable(tableInfo); ok {
+			schema = dbInfo.Name
+		}
+	}
+	joinNames := func(names []model.CIStr) string {
+		escaped := make([]string, 0, len(names))
+		for _, name := range names {
+			escaped = append(escaped, escape(name, sqlMode))
+		}
+		return strings.Join(escaped, ",")
+	}
+	for _, fk := range tableInfo.ForeignKeys {
+		if fk.State != model.StatePublic {
+			continue
+		}
+		fmt.Fprintf(buf, ",\n  CONSTRAINT %s FOREIGN KEY (%s) REFERENCES ", escape(fk.Name, sqlMode), joinNames(fk.Cols))
+		if fk.RefSchema.L != "" && fk.RefSchema.L != schema.L {
+			fmt.Fprintf(buf, "%s.", escape(fk.RefSchema, sqlMode))
+		}
+		fmt.Fprintf(buf, "%s (%s)", escape(fk.RefTable, sqlMode), joinNames(fk.RefCols))
+		if onDelete := ast.ReferOptionType(fk.OnDelete); onDelete != ast.ReferOptionNoOption {
+			fmt.Fprintf(buf, " ON DELETE %s", onDelete)
+		}
+		if onUpdate := ast.ReferOptionType(fk.OnUpdate); onUpdate != ast.ReferOptionNoOption {
+			fmt.Fprintf(buf, " ON UPDATE %s", onUpdate)
+		}
+	}
+}
+
+// appendPartitionInfo appends the PARTITION BY clause of a partitioned table.
+func appendPartitionInfo(pi *model.PartitionInfo, sqlMode mysql.SQLMode, buf *bytes.Buffer) {
+	if pi == nil {
+		return
+	}
+	if len(pi.Columns) > 0 {
+		fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", pi.Type.String())
+		for i, col := range pi.Columns {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(escape(col, sqlMode))
+		}
+		buf.WriteString(")")
+	} else {
+		fmt.Fprintf(buf, "\nPARTITION BY %s ( %s )", pi.Type.String(), pi.Expr)
+	}
+	if pi.Type == model.PartitionTypeHash && hasDefaultPartitionNames(pi) {
+		fmt.Fprintf(buf, "\nPARTITIONS %d", len(pi.Definitions))
+		return
+	}
+	buf.WriteString(" (\n")
+	for i, def := range pi.Definitions {
+		fmt.Fprintf(buf, "  PARTITION %s", escape(def.Name, sqlMode))
+		switch pi.Type {
+		case model.PartitionTypeRange:
+			fmt.Fprintf(buf, " VALUES LESS THAN (%s)", strings.Join(def.LessThan, ","))
+		case model.PartitionTypeList:
+			fmt.Fprintf(buf, " VALUES IN (%s)", strings.Join(def.InValues, ","))
+		}
+		if len(def.Comment) > 0 {
+			fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+		}
+		if i < len(pi.Definitions)-1 {
+			buf.WriteString(",\n")
+		} else {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString(")")
+}
+
+// hasDefaultPartitionNames checks whether the partitions are named p0, p1 ... as if
+// they are created by PARTITIONS n.
+func hasDefaultPartitionNames(pi *model.PartitionInfo) bool {
+	for i, def := range pi.Definitions {
+		if def.Name.L != fmt.Sprintf("p%d", i) || len(def.Comment) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *ShowExec) fetchShowCreateTable() error {
 	tb, err := e.getTable()
 	if err != nil {
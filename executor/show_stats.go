@@ -0,0 +1,173 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/store/tikv/oracle"
+)
+
+// statsTable is a table with its non-pseudo statistics in the stats cache.
+type statsTable struct {
+	dbName string
+	tbl    *model.TableInfo
+	stats  *statistics.Table
+}
+
+// tablesWithStats returns all the tables which have been analyzed, sorted by
+// database and table name.
+func (e *ShowExec) tablesWithStats() []statsTable {
+	h := domain.GetDomain(e.ctx).StatsHandle()
+	dbs := e.is.AllSchemas()
+	sort.Slice(dbs, func(i, j int) bool { return dbs[i].Name.L < dbs[j].Name.L })
+	var tables []statsTable
+	for _, db := range dbs {
+		tbls := make([]*model.TableInfo, len(db.Tables))
+		copy(tbls, db.Tables)
+		sort.Slice(tbls, func(i, j int) bool { return tbls[i].Name.L < tbls[j].Name.L })
+		for _, tbl := range tbls {
+			statsTbl := h.GetTableStats(tbl)
+			if statsTbl.Pseudo {
+				continue
+			}
+			tables = append(tables, statsTable{dbName: db.Name.O, tbl: tbl, stats: statsTbl})
+		}
+	}
+	return tables
+}
+
+func formatStatsVersion(version uint64) string {
+	return oracle.GetTimeFromTS(version).Format("2006-01-02 15:04:05")
+}
+
+func (e *ShowExec) fetchShowStatsMeta() error {
+	for _, t := range e.tablesWithStats() {
+		e.appendRow([]interface{}{
+			t.dbName,
+			t.tbl.Name.O,
+			"",
+			formatStatsVersion(t.stats.Version),
+			t.stats.ModifyCount,
+			t.stats.Count,
+		})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowStatsHistograms() error {
+	for _, t := range e.tablesWithStats() {
+		for _, col := range t.tbl.Columns {
+			c, ok := t.stats.Columns[col.ID]
+			if !ok {
+				continue
+			}
+			e.histogramToRow(t, col.Name.O, 0, c.Histogram, c.AvgColSize(t.stats.Count, false))
+		}
+		for _, idx := range t.tbl.Indices {
+			i, ok := t.stats.Indices[idx.ID]
+			if !ok {
+				continue
+			}
+			e.histogramToRow(t, idx.Name.O, 1, i.Histogram, 0)
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) histogramToRow(t statsTable, colName string, isIndex int64, hist statistics.Histogram, avgColSize float64) {
+	e.appendRow([]interface{}{
+		t.dbName,
+		t.tbl.Name.O,
+		"",
+		colName,
+		isIndex,
+		formatStatsVersion(hist.LastUpdateVersion),
+		hist.NDV,
+		hist.NullCount,
+		avgColSize,
+	})
+}
+
+func (e *ShowExec) fetchShowStatsBuckets() error {
+	for _, t := range e.tablesWithStats() {
+		for _, col := range t.tbl.Columns {
+			c, ok := t.stats.Columns[col.ID]
+			if !ok {
+				continue
+			}
+			if err := e.bucketsToRows(t, col.Name.O, 0, c.Histogram); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, idx := range t.tbl.Indices {
+			i, ok := t.stats.Indices[idx.ID]
+			if !ok {
+				continue
+			}
+			if err := e.bucketsToRows(t, idx.Name.O, len(idx.Columns), i.Histogram); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// bucketsToRows appends the buckets of the histogram to the result, numOfCols
+// is the number of index columns and is 0 for a column histogram.
+func (e *ShowExec) bucketsToRows(t statsTable, colName string, numOfCols int, hist statistics.Histogram) error {
+	isIndex := int64(0)
+	if numOfCols > 0 {
+		isIndex = 1
+	}
+	for i := 0; i < hist.Len(); i++ {
+		lowerBound, err := statistics.ValueToString(hist.GetLower(i), numOfCols)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		upperBound, err := statistics.ValueToString(hist.GetUpper(i), numOfCols)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		e.appendRow([]interface{}{
+			t.dbName,
+			t.tbl.Name.O,
+			"",
+			colName,
+			isIndex,
+			i,
+			hist.Buckets[i].Count,
+			hist.Buckets[i].Repeat,
+			lowerBound,
+			upperBound,
+		})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowStatsHealthy() error {
+	for _, t := range e.tablesWithStats() {
+		e.appendRow([]interface{}{
+			t.dbName,
+			t.tbl.Name.O,
+			"",
+			t.stats.HealthyPercentage(),
+		})
+	}
+	return nil
+}
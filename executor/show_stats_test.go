@@ -0,0 +1,73 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+// rowsOfDB keeps the rows of the given database, the first column of the
+// rows must be the database name.
+func rowsOfDB(rows [][]interface{}, db string) [][]interface{} {
+	var res [][]interface{}
+	for _, row := range rows {
+		if row[0] == db {
+			res = append(res, row)
+		}
+	}
+	return res
+}
+
+func (s *testSuite1) TestShowStats(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop database if exists show_stats")
+	tk.MustExec("create database show_stats")
+	tk.MustExec("use show_stats")
+	tk.MustExec("create table t (a int, b varchar(10), key idx(a, b))")
+	tk.MustExec("insert into t values (1, 'x'), (2, 'y'), (2, 'y'), (3, NULL)")
+	c.Assert(rowsOfDB(tk.MustQuery("show stats_meta").Rows(), "show_stats"), HasLen, 0)
+
+	tk.MustExec("analyze table t")
+	rows := rowsOfDB(tk.MustQuery("show stats_meta").Rows(), "show_stats")
+	c.Assert(rows, HasLen, 1)
+	c.Assert(fmt.Sprint(rows[0][1:3], rows[0][4:]), Equals, "[t ] [0 4]")
+
+	rows = rowsOfDB(tk.MustQuery("show stats_histograms").Rows(), "show_stats")
+	for _, row := range rows {
+		row[5] = "time"
+	}
+	c.Assert(fmt.Sprint(rows), Equals, "[[show_stats t  a 0 time 3 0 1] [show_stats t  b 0 time 2 1 1.5] [show_stats t  idx 1 time 3 0 0]]")
+
+	rows = rowsOfDB(tk.MustQuery("show stats_buckets").Rows(), "show_stats")
+	c.Assert(fmt.Sprint(rows), Equals, "["+
+		"[show_stats t  a 0 0 1 1 1 1] [show_stats t  a 0 1 3 2 2 2] [show_stats t  a 0 2 4 1 3 3] "+
+		"[show_stats t  b 0 0 1 1 x x] [show_stats t  b 0 1 3 2 y y] "+
+		"[show_stats t  idx 1 0 1 1 (1, x) (1, x)] [show_stats t  idx 1 1 3 2 (2, y) (2, y)] [show_stats t  idx 1 2 4 1 (3, NULL) (3, NULL)]]")
+
+	c.Assert(fmt.Sprint(rowsOfDB(tk.MustQuery("show stats_healthy").Rows(), "show_stats")), Equals, "[[show_stats t  100]]")
+	tbl, err := s.dom.InfoSchema().TableByName(model.NewCIStr("show_stats"), model.NewCIStr("t"))
+	c.Assert(err, IsNil)
+	version := s.dom.StatsHandle().GetTableStats(tbl.Meta()).Version
+	tk.MustExec(fmt.Sprintf("replace into mysql.stats_meta (version, table_id, modify_count, count) values (%d, %d, 1, 4)", version+1, tbl.Meta().ID))
+	c.Assert(s.dom.StatsHandle().Update(s.dom.InfoSchema()), IsNil)
+	c.Assert(fmt.Sprint(rowsOfDB(tk.MustQuery("show stats_healthy").Rows(), "show_stats")), Equals, "[[show_stats t  75]]")
+	tk.MustExec(fmt.Sprintf("replace into mysql.stats_meta (version, table_id, modify_count, count) values (%d, %d, 5, 4)", version+2, tbl.Meta().ID))
+	c.Assert(s.dom.StatsHandle().Update(s.dom.InfoSchema()), IsNil)
+	c.Assert(fmt.Sprint(rowsOfDB(tk.MustQuery("show stats_healthy").Rows(), "show_stats")), Equals, "[[show_stats t  0]]")
+	tk.MustExec("drop database show_stats")
+}
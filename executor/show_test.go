@@ -79,3 +79,70 @@ func (s *testSuite5) TestShowEscape(c *C) {
 	tk.MustExec("drop table \"t`abl\"\"e\"")
 	tk.MustExec("set sql_mode=@old_sql_mode")
 }
+
+func (s *testSuite5) TestShowColumnsAndIndex(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists show_cols")
+	tk.MustExec("create table show_cols (a int primary key, b varchar(10) not null default 'x' comment 'bb', c int, key k_bc(b, c))")
+	tk.MustQuery("show columns from show_cols").Check(testutil.RowsWithSep("|",
+		"a|int(11)|NO|PRI|<nil>|",
+		"b|varchar(10)|NO|MUL|x|",
+		"c|int(11)|YES||<nil>|",
+	))
+	tk.MustQuery("show full fields in show_cols from test").Check(testutil.RowsWithSep("|",
+		"a|int(11)|<nil>|NO|PRI|<nil>||select,insert,update,references|",
+		"b|varchar(10)|utf8mb4_bin|NO|MUL|x||select,insert,update,references|bb",
+		"c|int(11)|<nil>|YES||<nil>||select,insert,update,references|",
+	))
+	tk.MustQuery("show index from test.show_cols").Check(testutil.RowsWithSep("|",
+		"show_cols|0|PRIMARY|1|a|A|<nil>|<nil>|<nil>||BTREE||",
+		"show_cols|1|k_bc|1|b|A|<nil>|<nil>|<nil>||BTREE||",
+		"show_cols|1|k_bc|2|c|A|<nil>|<nil>|<nil>|YES|BTREE||",
+	))
+	tk.MustQuery("show keys in show_cols").Check(tk.MustQuery("show indexes from show_cols from test").Rows())
+
+	_, err := tk.Exec("show columns from show_cols_not_exist")
+	c.Assert(err, NotNil)
+}
+
+func (s *testSuite5) TestShowTableStatus(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database if not exists show_status_db")
+	tk.MustExec("use show_status_db")
+	tk.MustExec("create table t2 (a int auto_increment primary key)")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("insert into t2 values ()")
+	rows := tk.MustQuery("show table status").Rows()
+	c.Assert(rows, HasLen, 2)
+	c.Assert(rows[0][0], Equals, "t1")
+	c.Assert(rows[0][1], Equals, "InnoDB")
+	c.Assert(rows[0][10], Equals, "<nil>")
+	c.Assert(rows[1][0], Equals, "t2")
+	c.Assert(rows[1][10], Not(Equals), "<nil>")
+	c.Assert(rows[1][14], Equals, "utf8mb4_bin")
+	tk.MustExec("use test")
+	c.Assert(tk.MustQuery("show table status from show_status_db").Rows(), HasLen, 2)
+	c.Assert(tk.QueryToErr("show table status from show_status_db_not_exist"), NotNil)
+	tk.MustExec("drop database show_status_db")
+}
+
+func (s *testSuite5) TestShowStatusAndCharset(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	rows := tk.MustQuery("show status").Rows()
+	c.Assert(len(rows), GreaterEqual, 4)
+	tk.MustQuery("show status").Sort().Check(tk.MustQuery("show global status").Sort().Rows())
+	c.Assert(rows[:4], DeepEquals, testutil.RowsWithSep("|",
+		"Ssl_cipher|",
+		"Ssl_cipher_list|",
+		"Ssl_verify_mode|0",
+		"Ssl_version|",
+	))
+	rows = tk.MustQuery("show charset").Rows()
+	c.Assert(len(rows), Greater, 0)
+	c.Assert(rows, HasLen, len(tk.MustQuery("show character set").Rows()))
+	c.Assert(tk.MustQuery("show collation").Rows(), HasLen, len(tk.MustQuery("select * from information_schema.collations").Rows()))
+	tk.MustQuery("show engines").Check(testutil.RowsWithSep("|",
+		"InnoDB|DEFAULT|Supports transactions, row-level locking, and foreign keys|YES|YES|YES",
+	))
+}
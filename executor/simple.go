@@ -15,12 +15,18 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
@@ -29,13 +35,17 @@ import (
 
 // SimpleExec represents simple statement executor.
 // For statements do simple execution.
-// includes `UseStmt`,`BeginStmt`, `CommitStmt` and `RollbackStmt`.
+// includes `UseStmt`,`BeginStmt`, `CommitStmt`, `RollbackStmt`, `KillStmt`
+// and the account management statements.
 type SimpleExec struct {
 	baseExecutor
 
 	Statement ast.StmtNode
 	done      bool
 	is        infoschema.InfoSchema
+
+	// sysSession is the session whose transaction changes the privilege tables.
+	sysSession sessionctx.Context
 }
 
 // Next implements the Executor Next interface.
@@ -53,6 +63,26 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		e.executeCommit(x)
 	case *ast.RollbackStmt:
 		err = e.executeRollback(x)
+	case *ast.KillStmt:
+		err = e.executeKillStmt(x)
+	case *ast.CreateUserStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeCreateUser(x) })
+	case *ast.AlterUserStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeAlterUser(x) })
+	case *ast.DropUserStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeDropUser(x) })
+	case *ast.GrantStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeGrant(x) })
+	case *ast.RevokeStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeRevoke(x) })
+	case *ast.GrantRoleStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeGrantRole(x) })
+	case *ast.RevokeRoleStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeRevokeRole(x) })
+	case *ast.SetRoleStmt:
+		err = e.executeSetRole(x)
+	case *ast.SetDefaultRoleStmt:
+		err = e.executeInTxn(ctx, func() error { return e.executeSetDefaultRole(x) })
 	}
 	e.done = true
 	return err
@@ -65,6 +95,10 @@ func (e *SimpleExec) executeUse(s *ast.UseStmt) error {
 	if !exists {
 		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(dbname)
 	}
+	if pm := privilege.GetPrivilegeManager(e.ctx); pm != nil && !pm.DBIsVisible(e.ctx.GetSessionVars().ActiveRoles, dbname.O) {
+		user := e.ctx.GetSessionVars().User
+		return ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, dbname.O)
+	}
 	e.ctx.GetSessionVars().CurrentDB = dbname.O
 	// character_set_database is the character set used by the default database.
 	// The server sets this variable whenever the default database changes.
@@ -112,3 +146,206 @@ func (e *SimpleExec) executeRollback(s *ast.RollbackStmt) error {
 	}
 	return nil
 }
+
+func (e *SimpleExec) executeKillStmt(s *ast.KillStmt) error {
+	sm := e.ctx.GetSessionManager()
+	if sm == nil {
+		return nil
+	}
+	pi, ok := sm.GetProcessInfo(s.ConnectionID)
+	if !ok {
+		return ErrNoSuchThread.GenWithStackByArgs(s.ConnectionID)
+	}
+	// Killing the connections of other users requires the SUPER privilege.
+	if user := e.ctx.GetSessionVars().User; user != nil && pi.User != user.Username {
+		pm := privilege.GetPrivilegeManager(e.ctx)
+		if pm != nil && !pm.RequestVerification(e.ctx.GetSessionVars().ActiveRoles, "", "", "", mysql.SuperPriv) {
+			return ErrKillDenied.GenWithStackByArgs(s.ConnectionID)
+		}
+	}
+	sm.Kill(s.ConnectionID, s.Query)
+	return nil
+}
+
+// authString returns the plugin and the stored authentication string of the
+// authentication option, the plugin defaults to defaultPlugin.
+func authString(opt *ast.AuthOption, defaultPlugin string) (string, string, error) {
+	plugin := defaultPlugin
+	if opt == nil {
+		return plugin, "", nil
+	}
+	if opt.AuthPlugin != "" {
+		plugin = opt.AuthPlugin
+	}
+	switch plugin {
+	case mysql.AuthNativePassword:
+		if opt.ByAuthString {
+			return plugin, auth.EncodePassword(opt.AuthString), nil
+		}
+		if opt.HashString != "" && (len(opt.HashString) != mysql.PWDHashLen+1 || opt.HashString[0] != '*') {
+			return "", "", ErrPasswordFormat
+		}
+	case mysql.AuthCachingSha2Password:
+		if opt.ByAuthString {
+			return plugin, auth.NewSha2Password(opt.AuthString), nil
+		}
+		if opt.HashString != "" && !strings.HasPrefix(opt.HashString, "$A$") {
+			return "", "", ErrPasswordFormat
+		}
+	default:
+		return "", "", ErrPluginIsNotLoaded.GenWithStackByArgs(plugin)
+	}
+	return plugin, opt.HashString, nil
+}
+
+func userString(user *auth.UserIdentity) string {
+	return fmt.Sprintf("'%s'@'%s'", user.Username, user.Hostname)
+}
+
+func (e *SimpleExec) executeCreateUser(s *ast.CreateUserStmt) error {
+	op := "CREATE USER"
+	if s.IsCreateRole {
+		op = "CREATE ROLE"
+	}
+	var users []*ast.UserSpec
+	var failed []string
+	for _, spec := range s.Specs {
+		exists, err := e.userExists(spec.User.Username, spec.User.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			users = append(users, spec)
+			continue
+		}
+		if !s.IfNotExists {
+			failed = append(failed, userString(spec.User))
+			continue
+		}
+		e.ctx.GetSessionVars().StmtCtx.AppendNote(ErrUserAlreadyExists.GenWithStackByArgs(userString(spec.User)))
+	}
+	if len(failed) > 0 {
+		return ErrCannotUser.GenWithStackByArgs(op, strings.Join(failed, ","))
+	}
+	for _, spec := range users {
+		if err := e.createUser(spec.User, spec.AuthOpt, s.IsCreateRole); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createUser inserts the account into mysql.user, a role is an account which
+// is locked so that nobody can log in as it.
+func (e *SimpleExec) createUser(user *auth.UserIdentity, opt *ast.AuthOption, isRole bool) error {
+	plugin, pwd, err := authString(opt, mysql.AuthNativePassword)
+	if err != nil {
+		return err
+	}
+	locked := "N"
+	if isRole {
+		locked = "Y"
+	}
+	sql := fmt.Sprintf("INSERT INTO %s.%s (Host, User, authentication_string, plugin, account_locked) VALUES (%s, %s, %s, %s, %s)",
+		mysql.SystemDB, mysql.UserTable, quoteString(user.Hostname), quoteString(user.Username),
+		quoteString(pwd), quoteString(plugin), quoteString(locked))
+	return e.execRestrictedSQL(sql)
+}
+
+func (e *SimpleExec) executeAlterUser(s *ast.AlterUserStmt) error {
+	specs := s.Specs
+	if s.CurrentAuth != nil {
+		user := e.ctx.GetSessionVars().User
+		if user == nil {
+			return ErrCannotUser.GenWithStackByArgs("ALTER USER", "USER()")
+		}
+		specs = []*ast.UserSpec{{
+			User:    &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname},
+			AuthOpt: s.CurrentAuth,
+		}}
+	}
+	var failed []string
+	for _, spec := range specs {
+		user := e.currentUser(spec.User)
+		exists, err := e.userExists(user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if s.IfExists {
+				e.ctx.GetSessionVars().StmtCtx.AppendNote(ErrBadUser.GenWithStackByArgs(userString(user)))
+			} else {
+				failed = append(failed, userString(user))
+			}
+			continue
+		}
+		if spec.AuthOpt == nil {
+			continue
+		}
+		if err := e.alterUser(user, spec.AuthOpt); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return ErrCannotUser.GenWithStackByArgs("ALTER USER", strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// alterUser changes the authentication of an existing user, the plugin of
+// the account is kept unless the option names another one.
+func (e *SimpleExec) alterUser(user *auth.UserIdentity, opt *ast.AuthOption) error {
+	row, err := e.selectPrivRow(mysql.UserTable, userCond(user.Username, user.Hostname))
+	if err != nil || row == nil {
+		return err
+	}
+	plugin, pwd, err := authString(opt, row.vals["plugin"])
+	if err != nil {
+		return err
+	}
+	row.set("authentication_string", pwd)
+	row.set("plugin", plugin)
+	return e.replacePrivRow(mysql.UserTable, row)
+}
+
+func (e *SimpleExec) executeDropUser(s *ast.DropUserStmt) error {
+	op := "DROP USER"
+	if s.IsDropRole {
+		op = "DROP ROLE"
+	}
+	var failed []string
+	for _, user := range s.UserList {
+		user = e.currentUser(user)
+		exists, err := e.userExists(user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if s.IfExists {
+				e.ctx.GetSessionVars().StmtCtx.AppendNote(ErrBadUser.GenWithStackByArgs(userString(user)))
+			} else {
+				failed = append(failed, userString(user))
+			}
+			continue
+		}
+		for _, table := range []string{mysql.UserTable, mysql.DBTable, mysql.TablePrivTable} {
+			sql := fmt.Sprintf("DELETE FROM %s.%s WHERE %s", mysql.SystemDB, table, userCond(user.Username, user.Hostname))
+			if err := e.execRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+		if err := e.dropRoleEdges(user); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return ErrCannotUser.GenWithStackByArgs(op, strings.Join(failed, ","))
+	}
+	return nil
+}
+
+func (e *SimpleExec) notifyUpdatePrivilege() {
+	if dom := domain.GetDomain(e.ctx); dom != nil {
+		dom.NotifyUpdatePrivilege(e.ctx)
+	}
+}
@@ -14,7 +14,10 @@
 package executor_test
 
 import (
+	"sync/atomic"
+
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/planner/core"
@@ -82,3 +85,17 @@ func (s *testSuite3) TestUseDB(c *C) {
 	_, err = tk.Exec("USE ``")
 	c.Assert(terror.ErrorEqual(core.ErrNoDB, err), IsTrue, Commentf("err %v", err))
 }
+
+func (s *testSuite3) TestKillStmt(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	// Without a session manager, KILL is a no-op.
+	tk.MustExec("kill 1")
+	tk.MustExec("kill connection 1")
+	tk.MustExec("kill query 1")
+
+	// A killed session interrupts the running statement once.
+	atomic.StoreUint32(&tk.Se.GetSessionVars().Killed, 1)
+	err := tk.QueryToErr("select 1")
+	c.Assert(terror.ErrorEqual(err, executor.ErrQueryInterrupted), IsTrue, Commentf("err %v", err))
+	tk.MustQuery("select 1").Check(testkit.Rows("1"))
+}
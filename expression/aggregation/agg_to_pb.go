@@ -40,6 +40,31 @@ func AggFuncToPBExpr(sc *stmtctx.StatementContext, client kv.Client, aggFunc *Ag
 		tp = tipb.ExprType_Sum
 	case ast.AggFuncAvg:
 		tp = tipb.ExprType_Avg
+	case ast.AggFuncBitAnd:
+		tp = tipb.ExprType_Agg_BitAnd
+	case ast.AggFuncBitOr:
+		tp = tipb.ExprType_Agg_BitOr
+	case ast.AggFuncBitXor:
+		tp = tipb.ExprType_Agg_BitXor
+	case ast.AggFuncGroupConcat:
+		// The partial results of GROUP_CONCAT with ORDER BY can't be
+		// concatenated in order, so it's not pushed down.
+		if len(aggFunc.OrderByItems) > 0 {
+			return nil
+		}
+		tp = tipb.ExprType_GroupConcat
+	case ast.AggFuncVarPop:
+		tp = tipb.ExprType_VarPop
+	case ast.AggFuncVarSamp:
+		tp = tipb.ExprType_VarSamp
+	case ast.AggFuncStddevPop:
+		tp = tipb.ExprType_StddevPop
+	case ast.AggFuncStddevSamp:
+		tp = tipb.ExprType_StddevSamp
+	default:
+		// The coprocessor doesn't implement the other functions, such as
+		// APPROX_COUNT_DISTINCT, they are calculated by TiDB.
+		return nil
 	}
 	if !client.IsRequestTypeSupported(kv.ReqTypeSelect, int64(tp)) {
 		return nil
@@ -72,6 +97,22 @@ func PBExprToAggFuncDesc(sc *stmtctx.StatementContext, aggFunc *tipb.Expr, field
 		name = ast.AggFuncSum
 	case tipb.ExprType_Avg:
 		name = ast.AggFuncAvg
+	case tipb.ExprType_Agg_BitAnd:
+		name = ast.AggFuncBitAnd
+	case tipb.ExprType_Agg_BitOr:
+		name = ast.AggFuncBitOr
+	case tipb.ExprType_Agg_BitXor:
+		name = ast.AggFuncBitXor
+	case tipb.ExprType_GroupConcat:
+		name = ast.AggFuncGroupConcat
+	case tipb.ExprType_VarPop:
+		name = ast.AggFuncVarPop
+	case tipb.ExprType_VarSamp:
+		name = ast.AggFuncVarSamp
+	case tipb.ExprType_StddevPop:
+		name = ast.AggFuncStddevPop
+	case tipb.ExprType_StddevSamp:
+		name = ast.AggFuncStddevSamp
 	default:
 		return nil, errors.Errorf("unknown aggregation function type: %v", aggFunc.Tp)
 	}
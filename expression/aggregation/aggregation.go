@@ -19,9 +19,11 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hyperloglog"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
@@ -56,27 +58,46 @@ func NewDistAggFunc(expr *tipb.Expr, fieldTps []*types.FieldType, sc *stmtctx.St
 	}
 	switch expr.Tp {
 	case tipb.ExprType_Sum:
-		return &sumFunction{aggFunction: newAggFunc(ast.AggFuncSum, args)}, nil
+		return &sumFunction{aggFunction: newAggFunc(ast.AggFuncSum, args, false)}, nil
 	case tipb.ExprType_Count:
-		return &countFunction{aggFunction: newAggFunc(ast.AggFuncCount, args)}, nil
+		return &countFunction{aggFunction: newAggFunc(ast.AggFuncCount, args, false)}, nil
 	case tipb.ExprType_Avg:
-		return &avgFunction{aggFunction: newAggFunc(ast.AggFuncAvg, args)}, nil
+		return &avgFunction{aggFunction: newAggFunc(ast.AggFuncAvg, args, false)}, nil
 	case tipb.ExprType_Max:
-		return &maxMinFunction{aggFunction: newAggFunc(ast.AggFuncMax, args), isMax: true}, nil
+		return &maxMinFunction{aggFunction: newAggFunc(ast.AggFuncMax, args, false), isMax: true}, nil
 	case tipb.ExprType_Min:
-		return &maxMinFunction{aggFunction: newAggFunc(ast.AggFuncMin, args)}, nil
+		return &maxMinFunction{aggFunction: newAggFunc(ast.AggFuncMin, args, false)}, nil
 	case tipb.ExprType_First:
-		return &firstRowFunction{aggFunction: newAggFunc(ast.AggFuncFirstRow, args)}, nil
+		return &firstRowFunction{aggFunction: newAggFunc(ast.AggFuncFirstRow, args, false)}, nil
+	case tipb.ExprType_Agg_BitAnd:
+		return newBitAndFunction(newAggFunc(ast.AggFuncBitAnd, args, false)), nil
+	case tipb.ExprType_Agg_BitOr:
+		return newBitOrFunction(newAggFunc(ast.AggFuncBitOr, args, false)), nil
+	case tipb.ExprType_Agg_BitXor:
+		return newBitXorFunction(newAggFunc(ast.AggFuncBitXor, args, false)), nil
+	case tipb.ExprType_GroupConcat:
+		return &concatFunction{aggFunction: newAggFunc(ast.AggFuncGroupConcat, args, false)}, nil
+	case tipb.ExprType_VarPop:
+		return &varianceFunction{aggFunction: newAggFunc(ast.AggFuncVarPop, args, false)}, nil
+	case tipb.ExprType_VarSamp:
+		return &varianceFunction{aggFunction: newAggFunc(ast.AggFuncVarSamp, args, false), isSamp: true}, nil
+	case tipb.ExprType_StddevPop:
+		return &varianceFunction{aggFunction: newAggFunc(ast.AggFuncStddevPop, args, false), isStddev: true}, nil
+	case tipb.ExprType_StddevSamp:
+		return &varianceFunction{aggFunction: newAggFunc(ast.AggFuncStddevSamp, args, false), isSamp: true, isStddev: true}, nil
 	}
 	return nil, errors.Errorf("Unknown aggregate function type %v", expr.Tp)
 }
 
 // AggEvaluateContext is used to store intermediate result when calculating aggregate functions.
 type AggEvaluateContext struct {
-	Count       int64
-	Value       types.Datum
-	Buffer      *bytes.Buffer // Buffer is used for group_concat.
-	GotFirstRow bool          // It will check if the agg has met the first row key.
+	DistinctChecker *distinctChecker
+	Count           int64
+	Value           types.Datum
+	Buffer          *bytes.Buffer       // Buffer is used for group_concat.
+	GotFirstRow     bool                // It will check if the agg has met the first row key.
+	Variance        float64             // Variance is the sum of squared deviations, used for var_pop, var_samp, stddev_pop and stddev_samp.
+	Sketch          *hyperloglog.Sketch // Sketch is used for approx_count_distinct.
 }
 
 // AggFunctionMode stands for the aggregation function's mode.
@@ -101,8 +122,8 @@ type aggFunction struct {
 	*AggFuncDesc
 }
 
-func newAggFunc(funcName string, args []expression.Expression) aggFunction {
-	agg := &AggFuncDesc{}
+func newAggFunc(funcName string, args []expression.Expression, hasDistinct bool) aggFunction {
+	agg := &AggFuncDesc{HasDistinct: hasDistinct}
 	agg.Name = funcName
 	agg.Args = args
 	return aggFunction{AggFuncDesc: agg}
@@ -111,10 +132,16 @@ func newAggFunc(funcName string, args []expression.Expression) aggFunction {
 // CreateContext implements Aggregation interface.
 func (af *aggFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
 	evalCtx := &AggEvaluateContext{}
+	if af.HasDistinct {
+		evalCtx.DistinctChecker = createDistinctChecker(sc)
+	}
 	return evalCtx
 }
 
 func (af *aggFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	if af.HasDistinct {
+		evalCtx.DistinctChecker = createDistinctChecker(sc)
+	}
 	evalCtx.Value.SetNull()
 }
 
@@ -127,6 +154,15 @@ func (af *aggFunction) updateSum(sc *stmtctx.StatementContext, evalCtx *AggEvalu
 	if value.IsNull() {
 		return nil
 	}
+	if af.HasDistinct {
+		d, err1 := evalCtx.DistinctChecker.Check([]types.Datum{value})
+		if err1 != nil {
+			return err1
+		}
+		if !d {
+			return nil
+		}
+	}
 	evalCtx.Value, err = calculateSum(sc, evalCtx.Value, value)
 	if err != nil {
 		return err
@@ -137,19 +173,64 @@ func (af *aggFunction) updateSum(sc *stmtctx.StatementContext, evalCtx *AggEvalu
 
 // NeedCount indicates whether the aggregate function should record count.
 func NeedCount(name string) bool {
-	return name == ast.AggFuncCount || name == ast.AggFuncAvg
+	switch name {
+	case ast.AggFuncCount, ast.AggFuncAvg,
+		ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		return true
+	default:
+		return false
+	}
 }
 
 // NeedValue indicates whether the aggregate function should record value.
 func NeedValue(name string) bool {
 	switch name {
-	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin:
+	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin,
+		ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor, ast.AggFuncGroupConcat,
+		ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp,
+		ast.AggFuncApproxCountDistinct:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedVariance indicates whether the aggregate function should record variance.
+func NeedVariance(name string) bool {
+	switch name {
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
 		return true
 	default:
 		return false
 	}
 }
 
+// PartialValueType returns the type of the value recorded in the partial
+// result, retTp is the return type of the aggregate function.
+// The value of var_pop, var_samp, stddev_pop and stddev_samp is the sum of the
+// inputs, and the value of approx_count_distinct is the encoded sketch.
+func PartialValueType(name string, retTp *types.FieldType) *types.FieldType {
+	switch name {
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		return NewDoubleFieldType()
+	case ast.AggFuncApproxCountDistinct:
+		ft := types.NewFieldType(mysql.TypeBlob)
+		ft.Flen = mysql.MaxBlobWidth
+		types.SetBinChsClnFlag(ft)
+		return ft
+	default:
+		return retTp
+	}
+}
+
+// NewDoubleFieldType returns the binary double type used by the variance functions.
+func NewDoubleFieldType() *types.FieldType {
+	ft := types.NewFieldType(mysql.TypeDouble)
+	ft.Flen, ft.Decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+	types.SetBinChsClnFlag(ft)
+	return ft
+}
+
 // IsAllFirstRow checks whether functions in `aggFuncs` are all FirstRow.
 func IsAllFirstRow(aggFuncs []*AggFuncDesc) bool {
 	for _, fun := range aggFuncs {
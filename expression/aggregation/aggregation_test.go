@@ -14,6 +14,7 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/pingcap/check"
@@ -63,7 +64,7 @@ func (s *testAggFuncSuit) TestAvg(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	avgFunc := desc.GetAggFunc(ctx)
 	evalCtx := avgFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -81,6 +82,20 @@ func (s *testAggFuncSuit) TestAvg(c *C) {
 	c.Assert(err, IsNil)
 	result = avgFunc.GetResult(evalCtx)
 	c.Assert(result.GetInt64(), Equals, int64(67))
+
+	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{col}, true)
+	c.Assert(err, IsNil)
+	distinctAvgFunc := desc.GetAggFunc(ctx)
+	evalCtx = distinctAvgFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+	for _, row := range s.rows {
+		err := distinctAvgFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+		c.Assert(err, IsNil)
+	}
+	result = distinctAvgFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(50))
+	partialResult := distinctAvgFunc.GetPartialResult(evalCtx)
+	c.Assert(partialResult[0].GetInt64(), Equals, int64(100))
+	c.Assert(partialResult[1].GetInt64(), Equals, int64(5050))
 }
 
 func (s *testAggFuncSuit) TestAvgFinalMode(c *C) {
@@ -97,7 +112,7 @@ func (s *testAggFuncSuit) TestAvgFinalMode(c *C) {
 		Index:   1,
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
-	aggFunc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{cntCol, sumCol})
+	aggFunc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{cntCol, sumCol}, false)
 	c.Assert(err, IsNil)
 	aggFunc.Mode = FinalMode
 	avgFunc := aggFunc.GetAggFunc(ctx)
@@ -117,7 +132,7 @@ func (s *testAggFuncSuit) TestSum(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncSum, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncSum, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	sumFunc := desc.GetAggFunc(ctx)
 	evalCtx := sumFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -137,6 +152,24 @@ func (s *testAggFuncSuit) TestSum(c *C) {
 	c.Assert(result.GetInt64(), Equals, int64(338350))
 	partialResult := sumFunc.GetPartialResult(evalCtx)
 	c.Assert(partialResult[0].GetInt64(), Equals, int64(338350))
+
+	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncSum, []expression.Expression{col}, true)
+	c.Assert(err, IsNil)
+	distinctSumFunc := desc.GetAggFunc(ctx)
+	evalCtx = distinctSumFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+	for _, row := range s.rows {
+		err := distinctSumFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+		c.Assert(err, IsNil)
+	}
+	result = distinctSumFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(5050))
+
+	// The distinct values are cleared after resetting the context.
+	distinctSumFunc.ResetContext(s.ctx.GetSessionVars().StmtCtx, evalCtx)
+	err = distinctSumFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.rows[0])
+	c.Assert(err, IsNil)
+	result = distinctSumFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(1))
 }
 
 func (s *testAggFuncSuit) TestCount(c *C) {
@@ -145,7 +178,7 @@ func (s *testAggFuncSuit) TestCount(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncCount, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncCount, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	countFunc := desc.GetAggFunc(ctx)
 	evalCtx := countFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -165,6 +198,38 @@ func (s *testAggFuncSuit) TestCount(c *C) {
 	c.Assert(result.GetInt64(), Equals, int64(5050))
 	partialResult := countFunc.GetPartialResult(evalCtx)
 	c.Assert(partialResult[0].GetInt64(), Equals, int64(5050))
+
+	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncCount, []expression.Expression{col}, true)
+	c.Assert(err, IsNil)
+	distinctCountFunc := desc.GetAggFunc(ctx)
+	evalCtx = distinctCountFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+	for _, row := range s.rows {
+		err := distinctCountFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+		c.Assert(err, IsNil)
+	}
+	result = distinctCountFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(100))
+}
+
+func (s *testAggFuncSuit) TestCountDistinctMultiArgs(c *C) {
+	args := []expression.Expression{
+		&expression.Column{Index: 0, RetType: types.NewFieldType(mysql.TypeLonglong)},
+		&expression.Column{Index: 1, RetType: types.NewFieldType(mysql.TypeLonglong)},
+	}
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncCount, args, true)
+	c.Assert(err, IsNil)
+	countFunc := desc.GetAggFunc(s.ctx)
+	sc := s.ctx.GetSessionVars().StmtCtx
+	evalCtx := countFunc.CreateContext(sc)
+	// (i%3, i%5) has 15 distinct values, and the tuples containing null are ignored.
+	for i := 0; i < 60; i++ {
+		err = countFunc.Update(evalCtx, sc, chunk.MutRowFromDatums(types.MakeDatums(i%3, i%5)).ToRow())
+		c.Assert(err, IsNil)
+		err = countFunc.Update(evalCtx, sc, chunk.MutRowFromDatums(types.MakeDatums(i%3, nil)).ToRow())
+		c.Assert(err, IsNil)
+	}
+	result := countFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(15))
 }
 
 func (s *testAggFuncSuit) TestFirstRow(c *C) {
@@ -174,7 +239,7 @@ func (s *testAggFuncSuit) TestFirstRow(c *C) {
 	}
 
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	firstRowFunc := desc.GetAggFunc(ctx)
 	evalCtx := firstRowFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -201,10 +266,10 @@ func (s *testAggFuncSuit) TestMaxMin(c *C) {
 	}
 
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncMax, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncMax, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	maxFunc := desc.GetAggFunc(ctx)
-	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncMin, []expression.Expression{col})
+	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncMin, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	minFunc := desc.GetAggFunc(ctx)
 	maxEvalCtx := maxFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -257,3 +322,205 @@ func (s *testAggFuncSuit) TestMaxMin(c *C) {
 	partialResult := minFunc.GetPartialResult(minEvalCtx)
 	c.Assert(partialResult[0].GetInt64(), Equals, int64(1))
 }
+
+func (s *testAggFuncSuit) TestBitFuncs(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	ctx := mock.NewContext()
+	rows := [][]types.Datum{
+		types.MakeDatums(7),
+		types.MakeDatums(nil),
+		types.MakeDatums(13),
+		types.MakeDatums(-1),
+	}
+	tests := []struct {
+		name     string
+		empty    uint64
+		expected uint64
+	}{
+		{ast.AggFuncBitAnd, math.MaxUint64, 5},
+		{ast.AggFuncBitOr, 0, math.MaxUint64},
+		{ast.AggFuncBitXor, 0, 7 ^ 13 ^ math.MaxUint64},
+	}
+	for _, t := range tests {
+		desc, err := NewAggFuncDesc(s.ctx, t.name, []expression.Expression{col}, false)
+		c.Assert(err, IsNil)
+		c.Assert(mysql.HasUnsignedFlag(desc.RetTp.Flag), IsTrue)
+		defaultValue := desc.GetDefaultValue()
+		c.Assert(defaultValue.GetUint64(), Equals, t.empty)
+		bitFunc := desc.GetAggFunc(ctx)
+		evalCtx := bitFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+
+		result := bitFunc.GetResult(evalCtx)
+		c.Assert(result.GetUint64(), Equals, t.empty, Commentf("%s", t.name))
+		for _, row := range rows {
+			err := bitFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, chunk.MutRowFromDatums(row).ToRow())
+			c.Assert(err, IsNil)
+		}
+		result = bitFunc.GetResult(evalCtx)
+		c.Assert(result.GetUint64(), Equals, t.expected, Commentf("%s", t.name))
+		partialResult := bitFunc.GetPartialResult(evalCtx)
+		c.Assert(partialResult[0].GetUint64(), Equals, t.expected, Commentf("%s", t.name))
+
+		bitFunc.ResetContext(s.ctx.GetSessionVars().StmtCtx, evalCtx)
+		result = bitFunc.GetResult(evalCtx)
+		c.Assert(result.GetUint64(), Equals, t.empty, Commentf("%s", t.name))
+	}
+}
+
+func (s *testAggFuncSuit) TestGroupConcat(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	sep := &expression.Constant{Value: types.NewStringDatum(";"), RetType: types.NewFieldType(mysql.TypeVarString)}
+	ctx := mock.NewContext()
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncGroupConcat, []expression.Expression{col, sep}, false)
+	c.Assert(err, IsNil)
+	c.Assert(desc.RetTp.Tp, Equals, mysql.TypeVarString)
+	defaultValue := desc.GetDefaultValue()
+	c.Assert(defaultValue.IsNull(), IsTrue)
+	concatFunc := desc.GetAggFunc(ctx)
+	evalCtx := concatFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+
+	result := concatFunc.GetResult(evalCtx)
+	c.Assert(result.IsNull(), IsTrue)
+	for _, row := range s.rows[:4] {
+		err := concatFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+		c.Assert(err, IsNil)
+	}
+	err = concatFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.nullRow)
+	c.Assert(err, IsNil)
+	result = concatFunc.GetResult(evalCtx)
+	c.Assert(result.GetString(), Equals, "1;2;2;3")
+	partialResult := concatFunc.GetPartialResult(evalCtx)
+	c.Assert(partialResult[0].GetString(), Equals, "1;2;2;3")
+
+	// The final phase concatenates the partial results with the separator.
+	partialDesc, finalDesc := desc.Split([]int{0})
+	c.Assert(partialDesc.Mode, Equals, Partial1Mode)
+	c.Assert(finalDesc.Args, HasLen, 2)
+	finalFunc := finalDesc.GetAggFunc(ctx)
+	finalCtx := finalFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+	for _, partial := range []string{"1;2", "3"} {
+		err := finalFunc.Update(finalCtx, s.ctx.GetSessionVars().StmtCtx, chunk.MutRowFromDatums(types.MakeDatums(partial)).ToRow())
+		c.Assert(err, IsNil)
+	}
+	result = finalFunc.GetResult(finalCtx)
+	c.Assert(result.GetString(), Equals, "1;2;3")
+
+	concatFunc.ResetContext(s.ctx.GetSessionVars().StmtCtx, evalCtx)
+	result = concatFunc.GetResult(evalCtx)
+	c.Assert(result.IsNull(), IsTrue)
+}
+
+func (s *testAggFuncSuit) TestVariance(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	ctx := mock.NewContext()
+	sc := s.ctx.GetSessionVars().StmtCtx
+	// The values are i repeated i times for i in [1, 100], whose mean is 67
+	// and population variance is 561.
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{ast.AggFuncVarPop, 561},
+		{ast.AggFuncVarSamp, 561 * 5050.0 / 5049},
+		{ast.AggFuncStddevPop, math.Sqrt(561)},
+		{ast.AggFuncStddevSamp, math.Sqrt(561 * 5050.0 / 5049)},
+	}
+	for _, t := range tests {
+		desc, err := NewAggFuncDesc(s.ctx, t.name, []expression.Expression{col}, false)
+		c.Assert(err, IsNil)
+		c.Assert(desc.RetTp.Tp, Equals, mysql.TypeDouble)
+		varFunc := desc.GetAggFunc(ctx)
+		evalCtx := varFunc.CreateContext(sc)
+		result := varFunc.GetResult(evalCtx)
+		c.Assert(result.IsNull(), IsTrue)
+
+		for _, row := range s.rows {
+			err := varFunc.Update(evalCtx, sc, row)
+			c.Assert(err, IsNil)
+		}
+		err = varFunc.Update(evalCtx, sc, s.nullRow)
+		c.Assert(err, IsNil)
+		result = varFunc.GetResult(evalCtx)
+		c.Assert(math.Abs(result.GetFloat64()-t.expected) < 1e-9, IsTrue, Commentf("%s: %v", t.name, result.GetFloat64()))
+
+		// Merge the partial results of two halves in the final phase.
+		_, finalDesc := desc.Split([]int{0, 1, 2})
+		finalFunc := finalDesc.GetAggFunc(ctx)
+		finalCtx := finalFunc.CreateContext(sc)
+		for _, rows := range [][]chunk.Row{s.rows[:1000], s.rows[1000:]} {
+			varFunc.ResetContext(sc, evalCtx)
+			for _, row := range rows {
+				err := varFunc.Update(evalCtx, sc, row)
+				c.Assert(err, IsNil)
+			}
+			partialResult := varFunc.GetPartialResult(evalCtx)
+			c.Assert(partialResult, HasLen, 3)
+			err := finalFunc.Update(finalCtx, sc, chunk.MutRowFromDatums(partialResult).ToRow())
+			c.Assert(err, IsNil)
+		}
+		result = finalFunc.GetResult(finalCtx)
+		c.Assert(math.Abs(result.GetFloat64()-t.expected) < 1e-9, IsTrue, Commentf("%s: %v", t.name, result.GetFloat64()))
+	}
+
+	// The sample variance of a single value is null.
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncVarSamp, []expression.Expression{col}, false)
+	c.Assert(err, IsNil)
+	varFunc := desc.GetAggFunc(ctx)
+	evalCtx := varFunc.CreateContext(sc)
+	c.Assert(varFunc.Update(evalCtx, sc, s.rows[0]), IsNil)
+	result := varFunc.GetResult(evalCtx)
+	c.Assert(result.IsNull(), IsTrue)
+}
+
+func (s *testAggFuncSuit) TestApproxCountDistinct(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	ctx := mock.NewContext()
+	sc := s.ctx.GetSessionVars().StmtCtx
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncApproxCountDistinct, []expression.Expression{col}, false)
+	c.Assert(err, IsNil)
+	defaultValue := desc.GetDefaultValue()
+	c.Assert(defaultValue.GetInt64(), Equals, int64(0))
+	approxFunc := desc.GetAggFunc(ctx)
+	evalCtx := approxFunc.CreateContext(sc)
+	result := approxFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(0))
+
+	for _, row := range s.rows {
+		err := approxFunc.Update(evalCtx, sc, row)
+		c.Assert(err, IsNil)
+	}
+	err = approxFunc.Update(evalCtx, sc, s.nullRow)
+	c.Assert(err, IsNil)
+	// The estimation of 100 distinct values is 101.
+	result = approxFunc.GetResult(evalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(101))
+
+	// Merge the sketches of two halves in the final phase.
+	_, finalDesc := desc.Split([]int{0})
+	finalFunc := finalDesc.GetAggFunc(ctx)
+	finalCtx := finalFunc.CreateContext(sc)
+	for _, rows := range [][]chunk.Row{s.rows[:1000], s.rows[1000:]} {
+		approxFunc.ResetContext(sc, evalCtx)
+		for _, row := range rows {
+			err := approxFunc.Update(evalCtx, sc, row)
+			c.Assert(err, IsNil)
+		}
+		partialResult := approxFunc.GetPartialResult(evalCtx)
+		err := finalFunc.Update(finalCtx, sc, chunk.MutRowFromDatums(partialResult).ToRow())
+		c.Assert(err, IsNil)
+	}
+	result = finalFunc.GetResult(finalCtx)
+	c.Assert(result.GetInt64(), Equals, int64(101))
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hyperloglog"
+)
+
+// approxCountDistinctFunction implements APPROX_COUNT_DISTINCT with a
+// HyperLogLog sketch, the partial result is the encoded sketch.
+type approxCountDistinctFunction struct {
+	aggFunction
+}
+
+// CreateContext implements Aggregation interface.
+func (af *approxCountDistinctFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
+	return &AggEvaluateContext{Sketch: hyperloglog.NewSketch()}
+}
+
+// ResetContext implements Aggregation interface.
+func (af *approxCountDistinctFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Sketch.Reset()
+}
+
+// Update implements Aggregation interface.
+func (af *approxCountDistinctFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	values := make([]types.Datum, 0, len(af.Args))
+	for _, arg := range af.Args {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return err
+		}
+		if value.IsNull() {
+			return nil
+		}
+		values = append(values, value)
+	}
+	if af.Mode == CompleteMode || af.Mode == Partial1Mode {
+		return evalCtx.Sketch.InsertValues(sc, values...)
+	}
+	sketch, err := hyperloglog.DecodeSketch(values[0].GetBytes())
+	if err != nil {
+		return err
+	}
+	evalCtx.Sketch.Merge(sketch)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (af *approxCountDistinctFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	d.SetInt64(evalCtx.Sketch.Estimate())
+	return d
+}
+
+// GetPartialResult implements Aggregation interface.
+func (af *approxCountDistinctFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{types.NewBytesDatum(evalCtx.Sketch.Encode())}
+}
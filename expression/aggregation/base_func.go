@@ -17,11 +17,13 @@ import (
 	"bytes"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"math"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
@@ -102,6 +104,14 @@ func (a *baseFuncDesc) typeInfer(ctx sessionctx.Context) error {
 		a.typeInfer4Avg(ctx)
 	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
 		a.typeInfer4MaxMin(ctx)
+	case ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor:
+		return a.typeInfer4BitFuncs(ctx)
+	case ast.AggFuncGroupConcat:
+		return a.typeInfer4GroupConcat(ctx)
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		return a.typeInfer4PopOrSamp(ctx)
+	case ast.AggFuncApproxCountDistinct:
+		a.typeInfer4ApproxCountDistinct(ctx)
 	default:
 		return errors.Errorf("unsupported agg function: %s", a.Name)
 	}
@@ -157,6 +167,46 @@ func (a *baseFuncDesc) typeInfer4MaxMin(ctx sessionctx.Context) {
 	}
 }
 
+// typeInfer4BitFuncs casts the argument to integer, the bit functions always
+// return an unsigned 64-bit integer.
+func (a *baseFuncDesc) typeInfer4BitFuncs(ctx sessionctx.Context) error {
+	a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+	a.RetTp.Flen = 21
+	types.SetBinChsClnFlag(a.RetTp)
+	a.RetTp.Flag |= mysql.UnsignedFlag | mysql.NotNullFlag
+	var err error
+	a.Args[0], err = expression.WrapWithCastAsInt(ctx, a.Args[0])
+	return err
+}
+
+// typeInfer4GroupConcat casts the arguments to string, the last argument is
+// the separator which is already a string.
+func (a *baseFuncDesc) typeInfer4GroupConcat(ctx sessionctx.Context) error {
+	a.RetTp = types.NewFieldType(mysql.TypeVarString)
+	a.RetTp.Charset, a.RetTp.Collate = charset.GetDefaultCharsetAndCollate()
+	a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxBlobWidth, 0
+	for i := 0; i < len(a.Args)-1; i++ {
+		var err error
+		if a.Args[i], err = expression.WrapWithCastAsString(ctx, a.Args[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeInfer4PopOrSamp casts the argument to real, the variance and standard
+// deviation functions always return a double.
+func (a *baseFuncDesc) typeInfer4PopOrSamp(ctx sessionctx.Context) error {
+	a.RetTp = NewDoubleFieldType()
+	var err error
+	a.Args[0], err = expression.WrapWithCastAsReal(ctx, a.Args[0])
+	return err
+}
+
+func (a *baseFuncDesc) typeInfer4ApproxCountDistinct(ctx sessionctx.Context) {
+	a.typeInfer4Count(ctx)
+}
+
 // GetDefaultValue gets the default value when the function's input is null.
 // According to MySQL, default values of the function are listed as follows:
 // e.g.
@@ -175,11 +225,16 @@ func (a *baseFuncDesc) typeInfer4MaxMin(ctx sessionctx.Context) {
 // +------+--------+--------+----------+------------+-----------+----------------------+--------+--------+-----------------+
 func (a *baseFuncDesc) GetDefaultValue() (v types.Datum) {
 	switch a.Name {
-	case ast.AggFuncCount:
+	case ast.AggFuncCount, ast.AggFuncApproxCountDistinct:
 		v = types.NewIntDatum(0)
 	case ast.AggFuncFirstRow, ast.AggFuncAvg, ast.AggFuncSum, ast.AggFuncMax,
-		ast.AggFuncMin:
+		ast.AggFuncMin, ast.AggFuncGroupConcat,
+		ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
 		v = types.Datum{}
+	case ast.AggFuncBitOr, ast.AggFuncBitXor:
+		v = types.NewUintDatum(0)
+	case ast.AggFuncBitAnd:
+		v = types.NewUintDatum(math.MaxUint64)
 	}
 	return
 }
@@ -187,8 +242,9 @@ func (a *baseFuncDesc) GetDefaultValue() (v types.Datum) {
 // We do not need to wrap cast upon these functions,
 // since the EvalXXX method called by the arg is determined by the corresponding arg type.
 var noNeedCastAggFuncs = map[string]struct{}{
-	ast.AggFuncCount:    {},
-	ast.AggFuncMax:      {},
-	ast.AggFuncMin:      {},
-	ast.AggFuncFirstRow: {},
+	ast.AggFuncCount:               {},
+	ast.AggFuncMax:                 {},
+	ast.AggFuncMin:                 {},
+	ast.AggFuncFirstRow:            {},
+	ast.AggFuncApproxCountDistinct: {},
 }
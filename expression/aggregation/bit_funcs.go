@@ -0,0 +1,85 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// bitFunction implements BIT_AND, BIT_OR and BIT_XOR. The partial results of the
+// bit functions are merged with the same operator, so all the modes share the
+// same update logic.
+type bitFunction struct {
+	aggFunction
+	// initValue is the result of the function when there is no non-null input.
+	initValue uint64
+	op        func(x, y uint64) uint64
+}
+
+func newBitAndFunction(aggFunc aggFunction) *bitFunction {
+	return &bitFunction{aggFunction: aggFunc, initValue: math.MaxUint64, op: func(x, y uint64) uint64 { return x & y }}
+}
+
+func newBitOrFunction(aggFunc aggFunction) *bitFunction {
+	return &bitFunction{aggFunction: aggFunc, op: func(x, y uint64) uint64 { return x | y }}
+}
+
+func newBitXorFunction(aggFunc aggFunction) *bitFunction {
+	return &bitFunction{aggFunction: aggFunc, op: func(x, y uint64) uint64 { return x ^ y }}
+}
+
+// CreateContext implements Aggregation interface.
+func (bf *bitFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
+	evalCtx := bf.aggFunction.CreateContext(sc)
+	evalCtx.Value.SetUint64(bf.initValue)
+	return evalCtx
+}
+
+// ResetContext implements Aggregation interface.
+func (bf *bitFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Value.SetUint64(bf.initValue)
+}
+
+// Update implements Aggregation interface.
+func (bf *bitFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	value, err := bf.Args[0].Eval(row)
+	if err != nil {
+		return err
+	}
+	if value.IsNull() {
+		return nil
+	}
+	var val uint64
+	if value.Kind() == types.KindUint64 {
+		val = value.GetUint64()
+	} else {
+		val = uint64(value.GetInt64())
+	}
+	evalCtx.Value.SetUint64(bf.op(evalCtx.Value.GetUint64(), val))
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (bf *bitFunction) GetResult(evalCtx *AggEvaluateContext) types.Datum {
+	return evalCtx.Value
+}
+
+// GetPartialResult implements Aggregation interface.
+func (bf *bitFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{bf.GetResult(evalCtx)}
+}
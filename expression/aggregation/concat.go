@@ -0,0 +1,80 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"bytes"
+
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// concatFunction implements GROUP_CONCAT, the last argument is the separator.
+// The partial result is the concatenated string of a partial group, which is
+// concatenated again in the final phase, so all the modes share the same
+// update logic. The result is truncated by group_concat_max_len in TiDB.
+type concatFunction struct {
+	aggFunction
+}
+
+// ResetContext implements Aggregation interface.
+func (cf *concatFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Buffer = nil
+}
+
+// Update implements Aggregation interface.
+func (cf *concatFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	values := make([]string, 0, len(cf.Args)-1)
+	for _, arg := range cf.Args[:len(cf.Args)-1] {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return err
+		}
+		if value.IsNull() {
+			return nil
+		}
+		str, err := value.ToString()
+		if err != nil {
+			return err
+		}
+		values = append(values, str)
+	}
+	if evalCtx.Buffer == nil {
+		evalCtx.Buffer = &bytes.Buffer{}
+	} else {
+		sep, err := cf.Args[len(cf.Args)-1].Eval(row)
+		if err != nil {
+			return err
+		}
+		evalCtx.Buffer.WriteString(sep.GetString())
+	}
+	for _, str := range values {
+		evalCtx.Buffer.WriteString(str)
+	}
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (cf *concatFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	if evalCtx.Buffer != nil {
+		d.SetString(evalCtx.Buffer.String())
+	}
+	return d
+}
+
+// GetPartialResult implements Aggregation interface.
+func (cf *concatFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{cf.GetResult(evalCtx)}
+}
@@ -14,6 +14,9 @@
 package aggregation
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -26,19 +29,53 @@ type AggFuncDesc struct {
 	baseFuncDesc
 	// Mode represents the execution mode of the aggregation function.
 	Mode AggFunctionMode
+	// HasDistinct represents whether the aggregation function contains distinct attribute.
+	HasDistinct bool
+	// OrderByItems represents the order by clause used in GROUP_CONCAT.
+	OrderByItems []*ByItems
+}
+
+// ByItems wraps a "by" item of the order by clause in aggregation functions.
+type ByItems struct {
+	Expr expression.Expression
+	Desc bool
+}
+
+// String implements fmt.Stringer interface.
+func (by *ByItems) String() string {
+	if by.Desc {
+		return fmt.Sprintf("%s true", by.Expr)
+	}
+	return by.Expr.String()
+}
+
+// Clone makes a copy of ByItems.
+func (by *ByItems) Clone() *ByItems {
+	return &ByItems{Expr: by.Expr.Clone(), Desc: by.Desc}
 }
 
 // NewAggFuncDesc creates an aggregation function signature descriptor.
-func NewAggFuncDesc(ctx sessionctx.Context, name string, args []expression.Expression) (*AggFuncDesc, error) {
+func NewAggFuncDesc(ctx sessionctx.Context, name string, args []expression.Expression, hasDistinct bool) (*AggFuncDesc, error) {
 	b, err := newBaseFuncDesc(ctx, name, args)
 	if err != nil {
 		return nil, err
 	}
-	return &AggFuncDesc{baseFuncDesc: b}, nil
+	return &AggFuncDesc{baseFuncDesc: b, HasDistinct: hasDistinct}, nil
 }
 
 // Equal checks whether two aggregation function signatures are equal.
 func (a *AggFuncDesc) Equal(ctx sessionctx.Context, other *AggFuncDesc) bool {
+	if a.HasDistinct != other.HasDistinct {
+		return false
+	}
+	if len(a.OrderByItems) != len(other.OrderByItems) {
+		return false
+	}
+	for i := range a.OrderByItems {
+		if a.OrderByItems[i].Desc != other.OrderByItems[i].Desc || !a.OrderByItems[i].Expr.Equal(ctx, other.OrderByItems[i].Expr) {
+			return false
+		}
+	}
 	return a.baseFuncDesc.equal(ctx, &other.baseFuncDesc)
 }
 
@@ -46,6 +83,12 @@ func (a *AggFuncDesc) Equal(ctx sessionctx.Context, other *AggFuncDesc) bool {
 func (a *AggFuncDesc) Clone() *AggFuncDesc {
 	clone := *a
 	clone.baseFuncDesc = *a.baseFuncDesc.clone()
+	if a.OrderByItems != nil {
+		clone.OrderByItems = make([]*ByItems, len(a.OrderByItems))
+		for i, item := range a.OrderByItems {
+			clone.OrderByItems[i] = item.Clone()
+		}
+	}
 	return &clone
 }
 
@@ -67,6 +110,7 @@ func (a *AggFuncDesc) Split(ordinal []int) (partialAggDesc, finalAggDesc *AggFun
 	}
 	finalAggDesc.Name = a.Name
 	finalAggDesc.RetTp = a.RetTp
+	finalAggDesc.HasDistinct = a.HasDistinct
 	switch a.Name {
 	case ast.AggFuncAvg:
 		args := make([]expression.Expression, 0, 2)
@@ -79,11 +123,36 @@ func (a *AggFuncDesc) Split(ordinal []int) (partialAggDesc, finalAggDesc *AggFun
 			RetType: a.RetTp,
 		})
 		finalAggDesc.Args = args
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		args := make([]expression.Expression, 0, 3)
+		args = append(args, &expression.Column{
+			Index:   ordinal[0],
+			RetType: types.NewFieldType(mysql.TypeLonglong),
+		})
+		args = append(args, &expression.Column{
+			Index:   ordinal[1],
+			RetType: NewDoubleFieldType(),
+		})
+		args = append(args, &expression.Column{
+			Index:   ordinal[2],
+			RetType: NewDoubleFieldType(),
+		})
+		finalAggDesc.Args = args
+	case ast.AggFuncGroupConcat:
+		// The separator is kept to concatenate the partial results.
+		args := make([]expression.Expression, 0, 2)
+		args = append(args, &expression.Column{
+			Index:   ordinal[0],
+			RetType: a.RetTp,
+		})
+		args = append(args, a.Args[len(a.Args)-1])
+		finalAggDesc.Args = args
+		finalAggDesc.OrderByItems = a.OrderByItems
 	default:
 		args := make([]expression.Expression, 0, 1)
 		args = append(args, &expression.Column{
 			Index:   ordinal[0],
-			RetType: a.RetTp,
+			RetType: PartialValueType(a.Name, a.RetTp),
 		})
 		finalAggDesc.Args = args
 	}
@@ -130,8 +199,15 @@ func (a *AggFuncDesc) EvalNullValueInOuterJoin(ctx sessionctx.Context, schema *e
 	case ast.AggFuncSum, ast.AggFuncMax, ast.AggFuncMin,
 		ast.AggFuncFirstRow:
 		return a.evalNullValueInOuterJoin4Sum(ctx, schema)
-	case ast.AggFuncAvg:
+	case ast.AggFuncAvg, ast.AggFuncGroupConcat,
+		ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
 		return types.Datum{}, false
+	case ast.AggFuncApproxCountDistinct:
+		return a.evalNullValueInOuterJoin4Count(ctx, schema)
+	case ast.AggFuncBitAnd:
+		return a.evalNullValueInOuterJoin4BitFuncs(ctx, schema, types.NewUintDatum(math.MaxUint64))
+	case ast.AggFuncBitOr, ast.AggFuncBitXor:
+		return a.evalNullValueInOuterJoin4BitFuncs(ctx, schema, types.NewUintDatum(0))
 	default:
 		panic("unsupported agg function")
 	}
@@ -153,6 +229,24 @@ func (a *AggFuncDesc) GetAggFunc(ctx sessionctx.Context) Aggregation {
 		return &maxMinFunction{aggFunction: aggFunc, isMax: false}
 	case ast.AggFuncFirstRow:
 		return &firstRowFunction{aggFunction: aggFunc}
+	case ast.AggFuncBitAnd:
+		return newBitAndFunction(aggFunc)
+	case ast.AggFuncBitOr:
+		return newBitOrFunction(aggFunc)
+	case ast.AggFuncBitXor:
+		return newBitXorFunction(aggFunc)
+	case ast.AggFuncGroupConcat:
+		return &concatFunction{aggFunction: aggFunc}
+	case ast.AggFuncVarPop:
+		return &varianceFunction{aggFunction: aggFunc}
+	case ast.AggFuncVarSamp:
+		return &varianceFunction{aggFunction: aggFunc, isSamp: true}
+	case ast.AggFuncStddevPop:
+		return &varianceFunction{aggFunction: aggFunc, isStddev: true}
+	case ast.AggFuncStddevSamp:
+		return &varianceFunction{aggFunction: aggFunc, isSamp: true, isStddev: true}
+	case ast.AggFuncApproxCountDistinct:
+		return &approxCountDistinctFunction{aggFunction: aggFunc}
 	default:
 		panic("unsupported agg function")
 	}
@@ -177,3 +271,20 @@ func (a *AggFuncDesc) evalNullValueInOuterJoin4Sum(ctx sessionctx.Context, schem
 	}
 	return con.Value, true
 }
+
+// evalNullValueInOuterJoin4BitFuncs returns the value of the bit function upon a
+// single row, nullValue is the result when the argument evaluates to null.
+func (a *AggFuncDesc) evalNullValueInOuterJoin4BitFuncs(ctx sessionctx.Context, schema *expression.Schema, nullValue types.Datum) (types.Datum, bool) {
+	result := expression.EvaluateExprWithNull(ctx, schema, a.Args[0])
+	con, ok := result.(*expression.Constant)
+	if !ok {
+		return types.Datum{}, false
+	}
+	if con.Value.IsNull() {
+		return nullValue, true
+	}
+	if con.Value.Kind() == types.KindInt64 {
+		return types.NewUintDatum(uint64(con.Value.GetInt64())), true
+	}
+	return con.Value, true
+}
@@ -22,12 +22,27 @@ import (
 func ExplainAggFunc(agg *AggFuncDesc) string {
 	var buffer bytes.Buffer
 	fmt.Fprintf(&buffer, "%s(", agg.Name)
+	if agg.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
 	for i, arg := range agg.Args {
 		buffer.WriteString(arg.ExplainInfo())
 		if i+1 < len(agg.Args) {
 			buffer.WriteString(", ")
 		}
 	}
+	if len(agg.OrderByItems) > 0 {
+		buffer.WriteString(" order by ")
+		for i, item := range agg.OrderByItems {
+			buffer.WriteString(item.Expr.ExplainInfo())
+			if item.Desc {
+				buffer.WriteString(" desc")
+			}
+			if i+1 < len(agg.OrderByItems) {
+				buffer.WriteString(", ")
+			}
+		}
+	}
 	buffer.WriteString(")")
 	return buffer.String()
 }
@@ -89,3 +89,27 @@ func calculateSum(sc *stmtctx.StatementContext, sum, v types.Datum) (data types.
 		return data, errors.Errorf("invalid value %v for aggregate", sum.Kind())
 	}
 }
+
+// CalculateVariance returns the sum of squared deviations after adding the input,
+// count and sum are the number and the sum of the values including the input.
+func CalculateVariance(count int64, sum, variance, input float64) float64 {
+	if count <= 1 {
+		return variance
+	}
+	t := float64(count)*input - sum
+	return variance + t*t/(float64(count)*float64(count-1))
+}
+
+// MergeVariance merges the sum of squared deviations of two groups, each of
+// which is described by its count, sum and the sum of squared deviations.
+func MergeVariance(srcCount, dstCount int64, srcSum, dstSum, srcVariance, dstVariance float64) float64 {
+	if srcCount == 0 {
+		return dstVariance
+	}
+	if dstCount == 0 {
+		return srcVariance
+	}
+	src, dst := float64(srcCount), float64(dstCount)
+	t := (src/dst)*dstSum - srcSum
+	return srcVariance + dstVariance + (dst/src)/(src+dst)*t*t
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// varianceFunction implements VAR_POP, VAR_SAMP, STDDEV_POP and STDDEV_SAMP.
+// The partial result consists of the count, the sum and the sum of squared
+// deviations of the inputs.
+type varianceFunction struct {
+	aggFunction
+	isSamp   bool
+	isStddev bool
+}
+
+// ResetContext implements Aggregation interface.
+func (vf *varianceFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Count = 0
+	evalCtx.Value.SetNull()
+	evalCtx.Variance = 0
+}
+
+// Update implements Aggregation interface.
+func (vf *varianceFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	switch vf.Mode {
+	case Partial1Mode, CompleteMode:
+		return vf.updateVariance(evalCtx, sc, row)
+	default:
+		return vf.mergeVariance(evalCtx, sc, row)
+	}
+}
+
+func (vf *varianceFunction) updateVariance(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	value, err := vf.Args[0].Eval(row)
+	if err != nil {
+		return err
+	}
+	if value.IsNull() {
+		return nil
+	}
+	input, err := value.ToFloat64(sc)
+	if err != nil {
+		return err
+	}
+	sum := input
+	if !evalCtx.Value.IsNull() {
+		sum += evalCtx.Value.GetFloat64()
+	}
+	evalCtx.Count++
+	evalCtx.Value.SetFloat64(sum)
+	evalCtx.Variance = CalculateVariance(evalCtx.Count, sum, evalCtx.Variance, input)
+	return nil
+}
+
+func (vf *varianceFunction) mergeVariance(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	values := make([]types.Datum, 0, 3)
+	for _, arg := range vf.Args {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return err
+		}
+		if value.IsNull() {
+			return nil
+		}
+		values = append(values, value)
+	}
+	count := values[0].GetInt64()
+	if count == 0 {
+		return nil
+	}
+	sum, err := values[1].ToFloat64(sc)
+	if err != nil {
+		return err
+	}
+	variance, err := values[2].ToFloat64(sc)
+	if err != nil {
+		return err
+	}
+	var dstSum float64
+	if !evalCtx.Value.IsNull() {
+		dstSum = evalCtx.Value.GetFloat64()
+	}
+	evalCtx.Variance = MergeVariance(count, evalCtx.Count, sum, dstSum, variance, evalCtx.Variance)
+	evalCtx.Count += count
+	evalCtx.Value.SetFloat64(dstSum + sum)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (vf *varianceFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	count := evalCtx.Count
+	if vf.isSamp {
+		count--
+	}
+	if count <= 0 {
+		return d
+	}
+	result := evalCtx.Variance / float64(count)
+	if vf.isStddev {
+		result = math.Sqrt(result)
+	}
+	d.SetFloat64(result)
+	return d
+}
+
+// GetPartialResult implements Aggregation interface.
+func (vf *varianceFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{types.NewIntDatum(evalCtx.Count), evalCtx.Value, types.NewFloat64Datum(evalCtx.Variance)}
+}
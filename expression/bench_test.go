@@ -379,7 +379,22 @@ func genVecBuiltinFuncBenchCase(ctx sessionctx.Context, funcName string, testCas
 		input.SetNumVirtualRows(testCase.chunkSize)
 	}
 
-	baseFunc, err := funcs[funcName].getFunction(ctx, cols)
+	var err error
+	if funcName == ast.Cast {
+		var fc functionClass
+		tp := eType2FieldType(testCase.retEvalType)
+		switch testCase.retEvalType {
+		case types.ETInt:
+			fc = &castAsIntFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETReal:
+			fc = &castAsRealFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETString:
+			fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
+		baseFunc, err = fc.getFunction(ctx, cols)
+	} else {
+		baseFunc, err = funcs[funcName].getFunction(ctx, cols)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -331,17 +331,28 @@ type functionClass interface {
 // any set there.
 var funcs = map[string]functionClass{
 	// common functions
-	ast.IsNull: &isNullFunctionClass{baseFunctionClass{ast.IsNull, 1, 1}},
+	ast.IsNull:    &isNullFunctionClass{baseFunctionClass{ast.IsNull, 1, 1}},
+	ast.IsTruth:   &isTrueOrFalseFunctionClass{baseFunctionClass{ast.IsTruth, 1, 1}, opcode.IsTruth},
+	ast.IsFalsity: &isTrueOrFalseFunctionClass{baseFunctionClass{ast.IsFalsity, 1, 1}, opcode.IsFalsity},
 
 	// string functions
 	ast.Length:      &lengthFunctionClass{baseFunctionClass{ast.Length, 1, 1}},
 	ast.OctetLength: &lengthFunctionClass{baseFunctionClass{ast.OctetLength, 1, 1}},
 	ast.Strcmp:      &strcmpFunctionClass{baseFunctionClass{ast.Strcmp, 2, 2}},
+	ast.Like:        &likeFunctionClass{baseFunctionClass{ast.Like, 3, 3}},
+	ast.Regexp:      &regexpFunctionClass{baseFunctionClass{ast.Regexp, 2, 2}},
 
 	// control functions
+	ast.Case:   &caseWhenFunctionClass{baseFunctionClass{ast.Case, 1, -1}},
 	ast.If:     &ifFunctionClass{baseFunctionClass{ast.If, 3, 3}},
 	ast.Ifnull: &ifNullFunctionClass{baseFunctionClass{ast.Ifnull, 2, 2}},
 
+	// compare functions
+	ast.Coalesce: &coalesceFunctionClass{baseFunctionClass{ast.Coalesce, 1, -1}},
+	ast.Greatest: &greatestFunctionClass{baseFunctionClass{ast.Greatest, 2, -1}},
+	ast.Least:    &leastFunctionClass{baseFunctionClass{ast.Least, 2, -1}},
+	ast.Interval: &intervalFunctionClass{baseFunctionClass{ast.Interval, 2, -1}},
+
 	ast.LogicAnd:   &logicAndFunctionClass{baseFunctionClass{ast.LogicAnd, 2, 2}},
 	ast.LogicOr:    &logicOrFunctionClass{baseFunctionClass{ast.LogicOr, 2, 2}},
 	ast.GE:         &compareFunctionClass{baseFunctionClass{ast.GE, 2, 2}, opcode.GE},
@@ -354,12 +365,48 @@ var funcs = map[string]functionClass{
 	ast.Minus:      &arithmeticMinusFunctionClass{baseFunctionClass{ast.Minus, 2, 2}},
 	ast.Div:        &arithmeticDivideFunctionClass{baseFunctionClass{ast.Div, 2, 2}},
 	ast.Mul:        &arithmeticMultiplyFunctionClass{baseFunctionClass{ast.Mul, 2, 2}},
+	ast.And:        &bitOpFunctionClass{baseFunctionClass{ast.And, 2, 2}, opcode.And},
+	ast.Or:         &bitOpFunctionClass{baseFunctionClass{ast.Or, 2, 2}, opcode.Or},
+	ast.Xor:        &bitOpFunctionClass{baseFunctionClass{ast.Xor, 2, 2}, opcode.Xor},
+	ast.LeftShift:  &bitOpFunctionClass{baseFunctionClass{ast.LeftShift, 2, 2}, opcode.LeftShift},
+	ast.RightShift: &bitOpFunctionClass{baseFunctionClass{ast.RightShift, 2, 2}, opcode.RightShift},
+	ast.BitNeg:     &bitNegFunctionClass{baseFunctionClass{ast.BitNeg, 1, 1}},
+	ast.BitCount:   &bitCountFunctionClass{baseFunctionClass{ast.BitCount, 1, 1}},
 	ast.UnaryNot:   &unaryNotFunctionClass{baseFunctionClass{ast.UnaryNot, 1, 1}},
 	ast.UnaryMinus: &unaryMinusFunctionClass{baseFunctionClass{ast.UnaryMinus, 1, 1}},
 	ast.In:         &inFunctionClass{baseFunctionClass{ast.In, 2, -1}},
 	ast.RowFunc:    &rowFunctionClass{baseFunctionClass{ast.RowFunc, 2, -1}},
 	ast.SetVar:     &setVarFunctionClass{baseFunctionClass{ast.SetVar, 2, 2}},
 	ast.GetVar:     &getVarFunctionClass{baseFunctionClass{ast.GetVar, 1, 1}},
+	ast.Grouping:   &groupingFunctionClass{baseFunctionClass{ast.Grouping, 2, -1}},
+
+	// information functions
+	ast.ConnectionID: &connectionIDFunctionClass{baseFunctionClass{ast.ConnectionID, 0, 0}},
+	ast.CurrentUser:  &currentUserFunctionClass{baseFunctionClass{ast.CurrentUser, 0, 0}},
+	ast.Database:     &databaseFunctionClass{baseFunctionClass{ast.Database, 0, 0}},
+	// This function is a synonym for DATABASE().
+	// See http://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_schema
+	ast.Schema:       &databaseFunctionClass{baseFunctionClass{ast.Schema, 0, 0}},
+	ast.FoundRows:    &foundRowsFunctionClass{baseFunctionClass{ast.FoundRows, 0, 0}},
+	ast.LastInsertId: &lastInsertIDFunctionClass{baseFunctionClass{ast.LastInsertId, 0, 1}},
+	ast.User:         &userFunctionClass{baseFunctionClass{ast.User, 0, 0}},
+	ast.SessionUser:  &userFunctionClass{baseFunctionClass{ast.SessionUser, 0, 0}},
+	ast.SystemUser:   &userFunctionClass{baseFunctionClass{ast.SystemUser, 0, 0}},
+	ast.Version:      &versionFunctionClass{baseFunctionClass{ast.Version, 0, 0}},
+	ast.RowCount:     &rowCountFunctionClass{baseFunctionClass{ast.RowCount, 0, 0}},
+
+	// encryption and compression functions
+	ast.AesDecrypt:  &aesDecryptFunctionClass{baseFunctionClass{ast.AesDecrypt, 2, 3}},
+	ast.AesEncrypt:  &aesEncryptFunctionClass{baseFunctionClass{ast.AesEncrypt, 2, 3}},
+	ast.CRC32:       &crc32FunctionClass{baseFunctionClass{ast.CRC32, 1, 1}},
+	ast.FromBase64:  &fromBase64FunctionClass{baseFunctionClass{ast.FromBase64, 1, 1}},
+	ast.MD5:         &md5FunctionClass{baseFunctionClass{ast.MD5, 1, 1}},
+	ast.RandomBytes: &randomBytesFunctionClass{baseFunctionClass{ast.RandomBytes, 1, 1}},
+	ast.SHA1:        &sha1FunctionClass{baseFunctionClass{ast.SHA1, 1, 1}},
+	ast.SHA:         &sha1FunctionClass{baseFunctionClass{ast.SHA, 1, 1}},
+	ast.SHA2:        &sha2FunctionClass{baseFunctionClass{ast.SHA2, 2, 2}},
+	ast.ToBase64:    &toBase64FunctionClass{baseFunctionClass{ast.ToBase64, 1, 1}},
+	ast.UUID:        &uuidFunctionClass{baseFunctionClass{ast.UUID, 0, 0}},
 }
 
 // IsFunctionSupported check if given function name is a builtin sql function.
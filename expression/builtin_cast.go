@@ -0,0 +1,455 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// We implement 3 CastAsXXFunctionClass for `cast` built-in functions.
+// XX means the return type of the `cast` built-in functions.
+// XX contains the following 3 types:
+// Int, Real, String.
+
+// We implement 9 CastYYAsXXSig built-in function signatures.
+// YY also contains the 3 types as XX above.
+
+// Casting to or from the other types, e.g. DECIMAL and DATETIME, is not
+// supported since they have no eval type here: the planner rejects such a
+// `cast` with ErrNotSupportedYet, and BuildCastFunction returns an error.
+
+package expression
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &castAsIntFunctionClass{}
+	_ functionClass = &castAsRealFunctionClass{}
+	_ functionClass = &castAsStringFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinCastIntAsIntSig{}
+	_ builtinFunc = &builtinCastIntAsRealSig{}
+	_ builtinFunc = &builtinCastIntAsStringSig{}
+
+	_ builtinFunc = &builtinCastRealAsIntSig{}
+	_ builtinFunc = &builtinCastRealAsRealSig{}
+	_ builtinFunc = &builtinCastRealAsStringSig{}
+
+	_ builtinFunc = &builtinCastStringAsIntSig{}
+	_ builtinFunc = &builtinCastStringAsRealSig{}
+	_ builtinFunc = &builtinCastStringAsStringSig{}
+)
+
+type castAsIntFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsIntFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsInt)
+	case types.ETReal:
+		sig = &builtinCastRealAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsInt)
+	case types.ETString:
+		sig = &builtinCastStringAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsInt)
+	default:
+		return nil, errors.Errorf("unexpected types.EvalType %v", argTp)
+	}
+	return sig, nil
+}
+
+type castAsRealFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsRealFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsReal)
+	case types.ETReal:
+		sig = &builtinCastRealAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsReal)
+	case types.ETString:
+		sig = &builtinCastStringAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsReal)
+	default:
+		return nil, errors.Errorf("unexpected types.EvalType %v", argTp)
+	}
+	return sig, nil
+}
+
+type castAsStringFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsStringFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsString)
+	case types.ETReal:
+		sig = &builtinCastRealAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsString)
+	case types.ETString:
+		sig = &builtinCastStringAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsString)
+	default:
+		return nil, errors.Errorf("unexpected types.EvalType %v", argTp)
+	}
+	return sig, nil
+}
+
+type builtinCastIntAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	return b.args[0].EvalInt(b.ctx, row)
+}
+
+type builtinCastIntAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if !mysql.HasUnsignedFlag(b.args[0].GetType().Flag) {
+		res = float64(val)
+	} else {
+		res = float64(uint64(val))
+	}
+	return res, false, nil
+}
+
+type builtinCastIntAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if !mysql.HasUnsignedFlag(b.args[0].GetType().Flag) {
+		res = strconv.FormatInt(val, 10)
+	} else {
+		res = strconv.FormatUint(uint64(val), 10)
+	}
+	res, err = types.ProduceStrWithSpecifiedTp(res, b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+type builtinCastRealAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = castRealToInt(b.ctx.GetSessionVars().StmtCtx, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastRealAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	return b.args[0].EvalReal(b.ctx, row)
+}
+
+type builtinCastRealAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrWithSpecifiedTp(formatRealForCast(val, b.args[0].GetType()), b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+type builtinCastStringAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	if b.args[0].GetType().Hybrid() {
+		return b.args[0].EvalInt(b.ctx, row)
+	}
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = castStringToInt(b.ctx.GetSessionVars().StmtCtx, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastStringAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.StrToFloat(b.ctx.GetSessionVars().StmtCtx, val)
+	return res, false, err
+}
+
+type builtinCastStringAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	res, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrWithSpecifiedTp(res, b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+// castRealToInt rounds the float value to the nearest integer, the overflow error
+// is turned into a warning if the statement context allows.
+func castRealToInt(sc *stmtctx.StatementContext, val float64, unsigned bool) (res int64, err error) {
+	if !unsigned {
+		res, err = types.ConvertFloatToInt(val, types.IntergerSignedLowerBound(mysql.TypeLonglong), types.IntergerSignedUpperBound(mysql.TypeLonglong), mysql.TypeLonglong)
+	} else {
+		var uintVal uint64
+		uintVal, err = types.ConvertFloatToUint(sc, val, types.IntergerUnsignedUpperBound(mysql.TypeLonglong), mysql.TypeLonglong)
+		res = int64(uintVal)
+	}
+	if types.ErrOverflow.Equal(err) {
+		err = sc.HandleOverflow(err, err)
+	}
+	return res, err
+}
+
+// formatRealForCast formats the float value in the shortest representation,
+// float32 values are formatted by 32 bits to avoid the precision noise of float64.
+func formatRealForCast(val float64, argTp *types.FieldType) string {
+	bits := 64
+	if argTp.Tp == mysql.TypeFloat {
+		bits = 32
+	}
+	return strconv.FormatFloat(val, 'f', -1, bits)
+}
+
+// castStringToInt converts the string to an integer at the best effort. The truncation is
+// recorded as a warning on the statement context, so is casting a negative number to unsigned
+// or a number larger than MaxInt64 to signed.
+func castStringToInt(sc *stmtctx.StatementContext, val string, unsigned bool) (res int64, err error) {
+	val = strings.TrimSpace(val)
+	isNegative := len(val) > 1 && val[0] == '-'
+	if !isNegative {
+		var ures uint64
+		ures, err = types.StrToUint(sc, val)
+		res = int64(ures)
+		if err == nil && !unsigned && ures > uint64(math.MaxInt64) {
+			sc.AppendWarning(types.ErrCastAsSignedOverflow)
+		}
+	} else {
+		res, err = types.StrToInt(sc, val)
+		if err == nil && unsigned {
+			sc.AppendWarning(types.ErrCastNegIntAsUnsigned)
+		}
+	}
+	if types.ErrOverflow.Equal(err) {
+		if isNegative {
+			res = math.MinInt64
+		} else {
+			uval := uint64(math.MaxUint64)
+			res = int64(uval)
+		}
+		warnErr := types.ErrTruncatedWrongVal.GenWithStackByArgs("INTEGER", val)
+		err = sc.HandleOverflow(err, warnErr)
+	}
+	return res, err
+}
+
+// BuildCastFunction builds a CAST ScalarFunction from the Expression.
+func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (Expression, error) {
+	var fc functionClass
+	switch tp.EvalType() {
+	case types.ETInt:
+		fc = &castAsIntFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETReal:
+		fc = &castAsRealFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETString:
+		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	default:
+		return nil, errors.Errorf("unexpected types.EvalType %v", tp.EvalType())
+	}
+	f, err := fc.getFunction(ctx, []Expression{expr})
+	if err != nil {
+		return nil, err
+	}
+	res := &ScalarFunction{
+		FuncName: model.NewCIStr(ast.Cast),
+		RetType:  tp,
+		Function: f,
+	}
+	return FoldConstant(res), nil
+}
+
+// IsCastableEvalType returns true if the values can be cast to the eval type of tp.
+func IsCastableEvalType(tp *types.FieldType) bool {
+	switch tp.EvalType() {
+	case types.ETInt, types.ETReal, types.ETString:
+		return true
+	}
+	return false
+}
+
+// WrapWithCastAsInt wraps `expr` with `cast` if the return type of expr is not
+// type int, otherwise, returns `expr` directly.
+func WrapWithCastAsInt(ctx sessionctx.Context, expr Expression) (Expression, error) {
+	if expr.GetType().EvalType() == types.ETInt {
+		return expr, nil
+	}
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	tp.Flen, tp.Decimal = expr.GetType().Flen, 0
+	types.SetBinChsClnFlag(tp)
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsReal wraps `expr` with `cast` if the return type of expr is not
+// type real, otherwise, returns `expr` directly.
+func WrapWithCastAsReal(ctx sessionctx.Context, expr Expression) (Expression, error) {
+	if expr.GetType().EvalType() == types.ETReal {
+		return expr, nil
+	}
+	tp := types.NewFieldType(mysql.TypeDouble)
+	tp.Flen, tp.Decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+	types.SetBinChsClnFlag(tp)
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsString wraps `expr` with `cast` if the return type of expr is not
+// type string, otherwise, returns `expr` directly.
+func WrapWithCastAsString(ctx sessionctx.Context, expr Expression) (Expression, error) {
+	if expr.GetType().EvalType() == types.ETString {
+		return expr, nil
+	}
+	argLen := types.UnspecifiedLength
+	if expr.GetType().EvalType() == types.ETInt {
+		argLen = mysql.MaxIntWidth
+	}
+	tp := types.NewFieldType(mysql.TypeVarString)
+	tp.Charset, tp.Collate = charset.GetDefaultCharsetAndCollate()
+	tp.Flen, tp.Decimal = argLen, types.UnspecifiedLength
+	return BuildCastFunction(ctx, expr, tp)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestCastFunctions(c *C) {
+	sc := s.ctx.GetSessionVars().StmtCtx
+	oldTruncate, oldOverflow := sc.TruncateAsWarning, sc.OverflowAsWarning
+	sc.TruncateAsWarning, sc.OverflowAsWarning = true, true
+	defer func() {
+		sc.TruncateAsWarning, sc.OverflowAsWarning = oldTruncate, oldOverflow
+	}()
+
+	signedTp := types.NewFieldType(mysql.TypeLonglong)
+	unsignedTp := types.NewFieldType(mysql.TypeLonglong)
+	unsignedTp.Flag |= mysql.UnsignedFlag
+	doubleTp := types.NewFieldType(mysql.TypeDouble)
+	charTp := types.NewFieldType(mysql.TypeVarString)
+	char2Tp := types.NewFieldType(mysql.TypeVarString)
+	char2Tp.Flen = 2
+
+	tests := []struct {
+		arg      interface{}
+		tp       *types.FieldType
+		expected interface{}
+		warnings int
+	}{
+		{int64(-1), signedTp, int64(-1), 0},
+		{int64(-1), unsignedTp, uint64(math.MaxUint64), 0},
+		{float64(1.5), signedTp, int64(2), 0},
+		{float64(-1.5), signedTp, int64(-2), 0},
+		{float64(1e20), signedTp, int64(math.MaxInt64), 1},
+		{"12abc", signedTp, int64(12), 1},
+		{"-5", unsignedTp, uint64(math.MaxUint64 - 4), 1},
+		{"18446744073709551615", signedTp, int64(-1), 1},
+		{int64(3), doubleTp, float64(3), 0},
+		{"1.5e1x", doubleTp, float64(15), 1},
+		{int64(-12), charTp, "-12", 0},
+		{float64(1.25), charTp, "1.25", 0},
+		{"abc", char2Tp, "ab", 1},
+		{nil, signedTp, nil, 0},
+		{nil, charTp, nil, 0},
+	}
+	for _, t := range tests {
+		sc.SetWarnings(nil)
+		arg := s.datumsToConstants(types.MakeDatums(t.arg))[0]
+		f, err := BuildCastFunction(s.ctx, arg, t.tp)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(t.expected), Commentf("cast %v as %v", t.arg, t.tp))
+		c.Assert(sc.WarningCount(), Equals, uint16(t.warnings), Commentf("cast %v as %v", t.arg, t.tp))
+	}
+
+	// Casting to the same type without length limit is a no-op on the value.
+	col := &Column{RetType: types.NewFieldType(mysql.TypeLonglong), Index: 0}
+	f, err := BuildCastFunction(s.ctx, col, signedTp)
+	c.Assert(err, IsNil)
+	c.Assert(f.(*ScalarFunction).Function, FitsTypeOf, &builtinCastIntAsIntSig{})
+
+	// The types without an eval type here can't be cast to.
+	_, err = BuildCastFunction(s.ctx, col, types.NewFieldType(mysql.TypeNewDecimal))
+	c.Assert(err, NotNil)
+	_, err = BuildCastFunction(s.ctx, col, types.NewFieldType(mysql.TypeDatetime))
+	c.Assert(err, NotNil)
+	c.Assert(IsCastableEvalType(types.NewFieldType(mysql.TypeNewDecimal)), IsFalse)
+	c.Assert(IsCastableEvalType(doubleTp), IsTrue)
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+var vecBuiltinCastCases = map[string][]vecExprBenchCase{
+	ast.Cast: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETInt}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETReal}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&numStrGener{rangeInt64Gener{math.MinInt32, math.MaxInt32}}}},
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETInt}},
+		{
+			retEvalType:        types.ETReal,
+			childrenTypes:      []types.EvalType{types.ETInt},
+			childrenFieldTypes: []*types.FieldType{{Tp: mysql.TypeLonglong, Flag: mysql.UnsignedFlag}},
+			geners:             []dataGenerator{&rangeInt64Gener{math.MinInt64 / 2, math.MaxInt64 / 2}},
+		},
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETReal}},
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&numStrGener{rangeInt64Gener{math.MinInt32, math.MaxInt32}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETInt}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETReal}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}},
+	},
+}
+
+func (s *testEvaluatorSuite) TestVectorizedBuiltinCastFunc(c *C) {
+	testVectorizedBuiltinFunc(c, vecBuiltinCastCases)
+}
+
+func BenchmarkVectorizedBuiltinCastFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinCastCases)
+}
@@ -25,10 +25,29 @@ import (
 )
 
 var (
+	_ functionClass = &coalesceFunctionClass{}
+	_ functionClass = &greatestFunctionClass{}
+	_ functionClass = &leastFunctionClass{}
+	_ functionClass = &intervalFunctionClass{}
 	_ functionClass = &compareFunctionClass{}
 )
 
 var (
+	_ builtinFunc = &builtinCoalesceIntSig{}
+	_ builtinFunc = &builtinCoalesceRealSig{}
+	_ builtinFunc = &builtinCoalesceStringSig{}
+
+	_ builtinFunc = &builtinGreatestIntSig{}
+	_ builtinFunc = &builtinGreatestRealSig{}
+	_ builtinFunc = &builtinGreatestStringSig{}
+
+	_ builtinFunc = &builtinLeastIntSig{}
+	_ builtinFunc = &builtinLeastRealSig{}
+	_ builtinFunc = &builtinLeastStringSig{}
+
+	_ builtinFunc = &builtinIntervalIntSig{}
+	_ builtinFunc = &builtinIntervalRealSig{}
+
 	_ builtinFunc = &builtinLTIntSig{}
 	_ builtinFunc = &builtinLTRealSig{}
 	_ builtinFunc = &builtinLTStringSig{}
@@ -50,6 +69,551 @@ var (
 	_ builtinFunc = &builtinNEStringSig{}
 )
 
+// coalesceFunctionClass returns the first non-NULL value in the list,
+// or NULL if there are no non-NULL values.
+type coalesceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *coalesceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+
+	fieldTps := make([]*types.FieldType, 0, len(args))
+	for _, arg := range args {
+		fieldTps = append(fieldTps, arg.GetType())
+	}
+
+	// Use the aggregated field type as retType.
+	resultFieldType := types.AggFieldType(fieldTps)
+	resultEvalType := types.AggregateEvalType(fieldTps, &resultFieldType.Flag)
+	retEvalTp := resultFieldType.EvalType()
+
+	fieldEvalTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		fieldEvalTps = append(fieldEvalTps, retEvalTp)
+	}
+
+	bf := newBaseBuiltinFuncWithTp(ctx, args, retEvalTp, fieldEvalTps...)
+
+	bf.tp.Flag |= resultFieldType.Flag
+	resultFieldType.Flen, resultFieldType.Decimal = 0, types.UnspecifiedLength
+
+	// Set retType to BINARY(0) if all arguments are of type NULL.
+	if resultFieldType.Tp == mysql.TypeNull {
+		types.SetBinChsClnFlag(bf.tp)
+	} else {
+		maxIntLen := 0
+		maxFlen := 0
+
+		// Find the max length of field in `maxFlen`,
+		// and max integer-part length in `maxIntLen`.
+		for _, argTp := range fieldTps {
+			if argTp.Decimal > resultFieldType.Decimal {
+				resultFieldType.Decimal = argTp.Decimal
+			}
+			argIntLen := argTp.Flen
+			if argTp.Decimal > 0 {
+				argIntLen -= argTp.Decimal + 1
+			}
+
+			// Reduce the sign bit if it is a signed integer.
+			if !mysql.HasUnsignedFlag(argTp.Flag) {
+				argIntLen--
+			}
+			if argIntLen > maxIntLen {
+				maxIntLen = argIntLen
+			}
+			if argTp.Flen > maxFlen || argTp.Flen == types.UnspecifiedLength {
+				maxFlen = argTp.Flen
+			}
+		}
+		// For integer, field length = maxIntLen + (1/0 for sign bit).
+		if resultEvalType == types.ETInt {
+			resultFieldType.Flen = maxIntLen
+			if !mysql.HasUnsignedFlag(resultFieldType.Flag) {
+				resultFieldType.Flen++
+			}
+			resultFieldType.Decimal = 0
+			bf.tp = resultFieldType
+		} else {
+			// Set the field length to maxFlen for other types.
+			bf.tp.Flen = maxFlen
+		}
+	}
+
+	switch retEvalTp {
+	case types.ETInt:
+		sig = &builtinCoalesceIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceInt)
+	case types.ETReal:
+		sig = &builtinCoalesceRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceReal)
+	case types.ETString:
+		sig = &builtinCoalesceStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceString)
+	}
+
+	return sig, nil
+}
+
+// builtinCoalesceIntSig is builtin function coalesce signature which return type int
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceIntSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalInt(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceRealSig is builtin function coalesce signature which return type real
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceRealSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalReal(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceStringSig is builtin function coalesce signature which return type string
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceStringSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalString(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// getCmpTp4MinMax gets compare type for GREATEST and LEAST.
+func getCmpTp4MinMax(args []Expression) (argTp types.EvalType) {
+	ft := args[0].GetType()
+	argTp = ft.EvalType()
+	for i := 1; i < len(args); i++ {
+		argTp = getBaseCmpType(argTp, args[i].GetType().EvalType(), ft, args[i].GetType())
+		ft = args[i].GetType()
+	}
+	return argTp
+}
+
+type greatestFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *greatestFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	tp := getCmpTp4MinMax(args)
+	argTps := make([]types.EvalType, len(args))
+	for i := range args {
+		argTps[i] = tp
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, tp, argTps...)
+	switch tp {
+	case types.ETInt:
+		sig = &builtinGreatestIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestInt)
+	case types.ETReal:
+		sig = &builtinGreatestRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestReal)
+	case types.ETString:
+		sig = &builtinGreatestStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestString)
+	}
+	return sig, nil
+}
+
+type builtinGreatestIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestIntSig) Clone() builtinFunc {
+	newSig := &builtinGreatestIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinGreatestIntSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestIntSig) evalInt(row chunk.Row) (max int64, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v int64
+		v, isNull, err = b.args[i].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestRealSig) Clone() builtinFunc {
+	newSig := &builtinGreatestRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinGreatestRealSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestRealSig) evalReal(row chunk.Row) (max float64, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v float64
+		v, isNull, err = b.args[i].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestStringSig) Clone() builtinFunc {
+	newSig := &builtinGreatestStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGreatestStringSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestStringSig) evalString(row chunk.Row) (max string, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v string
+		v, isNull, err = b.args[i].EvalString(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if types.CompareString(v, max) > 0 {
+			max = v
+		}
+	}
+	return
+}
+
+type leastFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *leastFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	tp := getCmpTp4MinMax(args)
+	argTps := make([]types.EvalType, len(args))
+	for i := range args {
+		argTps[i] = tp
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, tp, argTps...)
+	switch tp {
+	case types.ETInt:
+		sig = &builtinLeastIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastInt)
+	case types.ETReal:
+		sig = &builtinLeastRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastReal)
+	case types.ETString:
+		sig = &builtinLeastStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastString)
+	}
+	return sig, nil
+}
+
+type builtinLeastIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastIntSig) Clone() builtinFunc {
+	newSig := &builtinLeastIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinLeastIntSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastIntSig) evalInt(row chunk.Row) (min int64, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v int64
+		v, isNull, err = b.args[i].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v < min {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastRealSig) Clone() builtinFunc {
+	newSig := &builtinLeastRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinLeastRealSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastRealSig) evalReal(row chunk.Row) (min float64, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v float64
+		v, isNull, err = b.args[i].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v < min {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastStringSig) Clone() builtinFunc {
+	newSig := &builtinLeastStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinLeastStringSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastStringSig) evalString(row chunk.Row) (min string, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v string
+		v, isNull, err = b.args[i].EvalString(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if types.CompareString(v, min) < 0 {
+			min = v
+		}
+	}
+	return
+}
+
+type intervalFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *intervalFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+
+	allInt := true
+	for i := range args {
+		if args[i].GetType().EvalType() != types.ETInt {
+			allInt = false
+		}
+	}
+
+	argTps, argTp := make([]types.EvalType, 0, len(args)), types.ETReal
+	if allInt {
+		argTp = types.ETInt
+	}
+	for range args {
+		argTps = append(argTps, argTp)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, argTps...)
+	var sig builtinFunc
+	if allInt {
+		sig = &builtinIntervalIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IntervalInt)
+	} else {
+		sig = &builtinIntervalRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IntervalReal)
+	}
+	return sig, nil
+}
+
+type builtinIntervalIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIntervalIntSig) Clone() builtinFunc {
+	newSig := &builtinIntervalIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinIntervalIntSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_interval
+func (b *builtinIntervalIntSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull {
+		return -1, false, nil
+	}
+	isUint1 := mysql.HasUnsignedFlag(b.args[0].GetType().Flag)
+	idx, err := b.binSearch(arg0, isUint1, b.args[1:], row)
+	return int64(idx), err != nil, err
+}
+
+// binSearch is a binary search method.
+// All arguments are treated as integers.
+// It is required that arg[0] < args[1] < args[2] < ... < args[n] for this function to work correctly.
+// This is because a binary search is used (very fast).
+func (b *builtinIntervalIntSig) binSearch(target int64, isUint1 bool, args []Expression, row chunk.Row) (_ int, err error) {
+	i, j, cmp := 0, len(args), false
+	for i < j {
+		mid := i + (j-i)/2
+		v, isNull, err1 := args[mid].EvalInt(b.ctx, row)
+		if err1 != nil {
+			err = err1
+			break
+		}
+		if isNull {
+			v = target
+		}
+		isUint2 := mysql.HasUnsignedFlag(args[mid].GetType().Flag)
+		switch {
+		case !isUint1 && !isUint2:
+			cmp = target < v
+		case isUint1 && isUint2:
+			cmp = uint64(target) < uint64(v)
+		case !isUint1 && isUint2:
+			cmp = target < 0 || uint64(target) < uint64(v)
+		case isUint1 && !isUint2:
+			cmp = v > 0 && uint64(target) < uint64(v)
+		}
+		if !cmp {
+			i = mid + 1
+		} else {
+			j = mid
+		}
+	}
+	return i, err
+}
+
+type builtinIntervalRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIntervalRealSig) Clone() builtinFunc {
+	newSig := &builtinIntervalRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinIntervalRealSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_interval
+func (b *builtinIntervalRealSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull {
+		return -1, false, nil
+	}
+	idx, err := b.binSearch(arg0, b.args[1:], row)
+	return int64(idx), err != nil, err
+}
+
+// binSearch is a binary search method.
+// All arguments are treated as reals.
+// It is required that arg[0] < args[1] < args[2] < ... < args[n] for this function to work correctly.
+func (b *builtinIntervalRealSig) binSearch(target float64, args []Expression, row chunk.Row) (_ int, err error) {
+	i, j := 0, len(args)
+	for i < j {
+		mid := i + (j-i)/2
+		v, isNull, err1 := args[mid].EvalReal(b.ctx, row)
+		if err1 != nil {
+			err = err1
+			break
+		}
+		if isNull {
+			i = mid + 1
+		} else if cmp := target < v; !cmp {
+			i = mid + 1
+		} else {
+			j = mid
+		}
+	}
+	return i, err
+}
+
 type compareFunctionClass struct {
 	baseFunctionClass
 
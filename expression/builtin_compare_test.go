@@ -17,6 +17,7 @@ import (
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 )
 
@@ -48,3 +49,103 @@ func (s *testEvaluatorSuite) TestCompare(c *C) {
 		c.Assert(res, Equals, t.expected)
 	}
 }
+
+func (s *testEvaluatorSuite) TestCoalesce(c *C) {
+	cases := []struct {
+		args     []interface{}
+		expected interface{}
+		isNil    bool
+	}{
+		{[]interface{}{nil}, nil, true},
+		{[]interface{}{nil, nil}, nil, true},
+		{[]interface{}{nil, nil, nil}, nil, true},
+		{[]interface{}{nil, 1}, int64(1), false},
+		{[]interface{}{nil, 1.1}, float64(1.1), false},
+		{[]interface{}{nil, 1.1, 2.2}, float64(1.1), false},
+		{[]interface{}{nil, "abc"}, "abc", false},
+		{[]interface{}{"abc", nil, "def"}, "abc", false},
+	}
+
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Coalesce, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetValue(), DeepEquals, t.expected)
+		}
+	}
+
+	_, err := funcs[ast.Coalesce].getFunction(s.ctx, []Expression{Zero, One})
+	c.Assert(err, IsNil)
+}
+
+func (s *testEvaluatorSuite) TestGreatestLeastFuncs(c *C) {
+	for _, t := range []struct {
+		args             []interface{}
+		expectedGreatest interface{}
+		expectedLeast    interface{}
+		isNil            bool
+	}{
+		{[]interface{}{1, 2, 3, 4}, int64(4), int64(1), false},
+		{[]interface{}{1.1, 3.3, 2.2}, float64(3.3), float64(1.1), false},
+		{[]interface{}{"a", "c", "b"}, "c", "a", false},
+		{[]interface{}{nil, 2}, nil, nil, true},
+		{[]interface{}{1, nil, 2}, nil, nil, true},
+	} {
+		f0, err := newFunctionForTest(s.ctx, ast.Greatest, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err := f0.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetValue(), DeepEquals, t.expectedGreatest)
+		}
+
+		f1, err := newFunctionForTest(s.ctx, ast.Least, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err = f1.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetValue(), DeepEquals, t.expectedLeast)
+		}
+	}
+	_, err := funcs[ast.Greatest].getFunction(s.ctx, []Expression{Zero, One})
+	c.Assert(err, IsNil)
+	_, err = funcs[ast.Least].getFunction(s.ctx, []Expression{Zero, One})
+	c.Assert(err, IsNil)
+}
+
+func (s *testEvaluatorSuite) TestIntervalFunc(c *C) {
+	for _, t := range []struct {
+		args []types.Datum
+		ret  int64
+	}{
+		{types.MakeDatums(nil, 1, 2), -1},
+		{types.MakeDatums(1, 2, 3), 0},
+		{types.MakeDatums(2, 1, 3), 1},
+		{types.MakeDatums(3, 1, 2), 2},
+		{types.MakeDatums(23, 1, 23, 23, 23, 30, 44, 200), 4},
+		{types.MakeDatums(23.0, 1.7, 15.3, 23.1, 30.0, 44.0, 200.0), 2},
+		{types.MakeDatums(nil, 1.7, 15.3), -1},
+		{types.MakeDatums(9007199254740992, 9007199254740993), 0},
+		{types.MakeDatums(uint64(9223372036854775808), uint64(9223372036854775809)), 0},
+		{types.MakeDatums(9223372036854775807, uint64(9223372036854775808)), 0},
+		{types.MakeDatums(-9223372036854775807, uint64(9223372036854775808)), 0},
+		{types.MakeDatums(uint64(9223372036854775806), 9223372036854775807), 0},
+		{types.MakeDatums(uint64(9223372036854775806), -9223372036854775807), 1},
+	} {
+		fc := funcs[ast.Interval]
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(t.args))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v.GetInt64(), Equals, t.ret)
+	}
+}
@@ -290,3 +290,104 @@ func vecCompareInt(isUnsigned0, isUnsigned1 bool, largs, rargs, result *chunk.Co
 		types.VecCompareII(largs.Int64s(), rargs.Int64s(), result.Int64s())
 	}
 }
+
+func (b *builtinIntervalIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinIntervalIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for j := 0; j < argLen; j++ {
+		buf, err := b.bufAllocator.get(types.ETInt, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[j].VecEvalInt(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[j] = buf
+	}
+
+	isUint1 := mysql.HasUnsignedFlag(b.args[0].GetType().Flag)
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			i64s[i] = -1
+			continue
+		}
+		target := bufs[0].GetInt64(i)
+		lo, hi := 1, argLen
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			v := target
+			if !bufs[mid].IsNull(i) {
+				v = bufs[mid].GetInt64(i)
+			}
+			isUint2 := mysql.HasUnsignedFlag(b.args[mid].GetType().Flag)
+			var cmp bool
+			switch {
+			case !isUint1 && !isUint2:
+				cmp = target < v
+			case isUint1 && isUint2:
+				cmp = uint64(target) < uint64(v)
+			case !isUint1 && isUint2:
+				cmp = target < 0 || uint64(target) < uint64(v)
+			case isUint1 && !isUint2:
+				cmp = v > 0 && uint64(target) < uint64(v)
+			}
+			if !cmp {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		i64s[i] = int64(lo - 1)
+	}
+	return nil
+}
+
+func (b *builtinIntervalRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinIntervalRealSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for j := 0; j < argLen; j++ {
+		buf, err := b.bufAllocator.get(types.ETReal, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[j].VecEvalReal(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[j] = buf
+	}
+
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			i64s[i] = -1
+			continue
+		}
+		target := bufs[0].GetFloat64(i)
+		lo, hi := 1, argLen
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			if bufs[mid].IsNull(i) || !(target < bufs[mid].GetFloat64(i)) {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		i64s[i] = int64(lo - 1)
+	}
+	return nil
+}
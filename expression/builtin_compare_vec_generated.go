@@ -511,3 +511,311 @@ func (b *builtinNEStringSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column
 func (b *builtinNEStringSig) vectorized() bool {
 	return true
 }
+
+func (b *builtinCoalesceIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	result.ResizeInt64(n, true)
+	rs := result.Int64s()
+	buf1, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+	for j := 0; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalInt(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args := buf1.Int64s()
+		for i := 0; i < n; i++ {
+			if !buf1.IsNull(i) && result.IsNull(i) {
+				rs[i] = args[i]
+				result.SetNull(i, false)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinCoalesceIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCoalesceRealSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	result.ResizeFloat64(n, true)
+	rs := result.Float64s()
+	buf1, err := b.bufAllocator.get(types.ETReal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+	for j := 0; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalReal(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args := buf1.Float64s()
+		for i := 0; i < n; i++ {
+			if !buf1.IsNull(i) && result.IsNull(i) {
+				rs[i] = args[i]
+				result.SetNull(i, false)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinCoalesceRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCoalesceStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[i].VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		isNull := true
+		for j := 0; j < argLen; j++ {
+			if !bufs[j].IsNull(i) {
+				result.AppendString(bufs[j].GetString(i))
+				isNull = false
+				break
+			}
+		}
+		if isNull {
+			result.AppendNull()
+		}
+	}
+	return nil
+}
+
+func (b *builtinCoalesceStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinGreatestIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	rs := result.Int64s()
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalInt(b.ctx, input, buf); err != nil {
+			return err
+		}
+		result.MergeNulls(buf)
+		args := buf.Int64s()
+		for i := 0; i < n; i++ {
+			if result.IsNull(i) {
+				continue
+			}
+			if args[i] > rs[i] {
+				rs[i] = args[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinGreatestIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinGreatestRealSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETReal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalReal(b.ctx, input, result); err != nil {
+		return err
+	}
+	rs := result.Float64s()
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalReal(b.ctx, input, buf); err != nil {
+			return err
+		}
+		result.MergeNulls(buf)
+		args := buf.Float64s()
+		for i := 0; i < n; i++ {
+			if result.IsNull(i) {
+				continue
+			}
+			if args[i] > rs[i] {
+				rs[i] = args[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinGreatestRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinGreatestStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[i].VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		isNull := false
+		var val string
+		for j := 0; j < argLen; j++ {
+			if bufs[j].IsNull(i) {
+				isNull = true
+				break
+			}
+			v := bufs[j].GetString(i)
+			if j == 0 || types.CompareString(v, val) > 0 {
+				val = v
+			}
+		}
+		if isNull {
+			result.AppendNull()
+		} else {
+			result.AppendString(val)
+		}
+	}
+	return nil
+}
+
+func (b *builtinGreatestStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeastIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	rs := result.Int64s()
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalInt(b.ctx, input, buf); err != nil {
+			return err
+		}
+		result.MergeNulls(buf)
+		args := buf.Int64s()
+		for i := 0; i < n; i++ {
+			if result.IsNull(i) {
+				continue
+			}
+			if args[i] < rs[i] {
+				rs[i] = args[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinLeastIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeastRealSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETReal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalReal(b.ctx, input, result); err != nil {
+		return err
+	}
+	rs := result.Float64s()
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalReal(b.ctx, input, buf); err != nil {
+			return err
+		}
+		result.MergeNulls(buf)
+		args := buf.Float64s()
+		for i := 0; i < n; i++ {
+			if result.IsNull(i) {
+				continue
+			}
+			if args[i] < rs[i] {
+				rs[i] = args[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builtinLeastRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeastStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[i].VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		isNull := false
+		var val string
+		for j := 0; j < argLen; j++ {
+			if bufs[j].IsNull(i) {
+				isNull = true
+				break
+			}
+			v := bufs[j].GetString(i)
+			if j == 0 || types.CompareString(v, val) < 0 {
+				val = v
+			}
+		}
+		if isNull {
+			result.AppendNull()
+		} else {
+			result.AppendString(val)
+		}
+	}
+	return nil
+}
+
+func (b *builtinLeastStringSig) vectorized() bool {
+	return true
+}
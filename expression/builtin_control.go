@@ -24,11 +24,15 @@ import (
 )
 
 var (
+	_ functionClass = &caseWhenFunctionClass{}
 	_ functionClass = &ifFunctionClass{}
 	_ functionClass = &ifNullFunctionClass{}
 )
 
 var (
+	_ builtinFunc = &builtinCaseWhenIntSig{}
+	_ builtinFunc = &builtinCaseWhenRealSig{}
+	_ builtinFunc = &builtinCaseWhenStringSig{}
 	_ builtinFunc = &builtinIfNullIntSig{}
 	_ builtinFunc = &builtinIfNullRealSig{}
 	_ builtinFunc = &builtinIfNullStringSig{}
@@ -111,6 +115,186 @@ func InferType4ControlFuncs(lhs, rhs *types.FieldType) *types.FieldType {
 	return resultFieldType
 }
 
+type caseWhenFunctionClass struct {
+	baseFunctionClass
+}
+
+// getFunction see https://dev.mysql.com/doc/refman/5.7/en/control-flow-functions.html#operator_case
+func (c *caseWhenFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	l := len(args)
+	// Fill in each 'THEN' clause parameter type.
+	fieldTps := make([]*types.FieldType, 0, (l+1)/2)
+	decimal, flen, isBinaryStr, isBinaryFlag := args[1].GetType().Decimal, 0, false, false
+	for i := 1; i < l; i += 2 {
+		fieldTps = append(fieldTps, args[i].GetType())
+		decimal = mathutil.Max(decimal, args[i].GetType().Decimal)
+		flen = mathutil.Max(flen, args[i].GetType().Flen)
+		isBinaryStr = isBinaryStr || types.IsBinaryStr(args[i].GetType())
+		isBinaryFlag = isBinaryFlag || !types.IsNonBinaryStr(args[i].GetType())
+	}
+	if l%2 == 1 {
+		fieldTps = append(fieldTps, args[l-1].GetType())
+		decimal = mathutil.Max(decimal, args[l-1].GetType().Decimal)
+		flen = mathutil.Max(flen, args[l-1].GetType().Flen)
+		isBinaryStr = isBinaryStr || types.IsBinaryStr(args[l-1].GetType())
+		isBinaryFlag = isBinaryFlag || !types.IsNonBinaryStr(args[l-1].GetType())
+	}
+
+	fieldTp := types.AggFieldType(fieldTps)
+	tp := fieldTp.EvalType()
+
+	if tp == types.ETInt {
+		decimal = 0
+	}
+	fieldTp.Decimal, fieldTp.Flen = decimal, flen
+	if fieldTp.EvalType().IsStringKind() && !isBinaryStr {
+		fieldTp.Charset, fieldTp.Collate = charset.CharsetUTF8MB4, charset.CollationUTF8MB4
+	}
+	if isBinaryFlag {
+		fieldTp.Flag |= mysql.BinaryFlag
+	}
+	// Set retType to BINARY(0) if all arguments are of type NULL.
+	if fieldTp.Tp == mysql.TypeNull {
+		fieldTp.Flen, fieldTp.Decimal = 0, types.UnspecifiedLength
+		types.SetBinChsClnFlag(fieldTp)
+	}
+	argTps := make([]types.EvalType, 0, l)
+	for i := 0; i < l-1; i += 2 {
+		argTps = append(argTps, types.ETInt, tp)
+	}
+	if l%2 == 1 {
+		argTps = append(argTps, tp)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, tp, argTps...)
+	bf.tp = fieldTp
+
+	switch tp {
+	case types.ETInt:
+		bf.tp.Decimal = 0
+		sig = &builtinCaseWhenIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CaseWhenInt)
+	case types.ETReal:
+		sig = &builtinCaseWhenRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CaseWhenReal)
+	case types.ETString:
+		bf.tp.Decimal = types.UnspecifiedLength
+		sig = &builtinCaseWhenStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CaseWhenString)
+	}
+	return sig, nil
+}
+
+type builtinCaseWhenIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCaseWhenIntSig) Clone() builtinFunc {
+	newSig := &builtinCaseWhenIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinCaseWhenIntSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/control-flow-functions.html#operator_case
+func (b *builtinCaseWhenIntSig) evalInt(row chunk.Row) (ret int64, isNull bool, err error) {
+	var condition int64
+	args, l := b.getArgs(), len(b.getArgs())
+	for i := 0; i < l-1; i += 2 {
+		condition, isNull, err = args[i].EvalInt(b.ctx, row)
+		if err != nil {
+			return 0, isNull, err
+		}
+		if isNull || condition == 0 {
+			continue
+		}
+		ret, isNull, err = args[i+1].EvalInt(b.ctx, row)
+		return ret, isNull, err
+	}
+	// when clause(condition, result) -> args[i], args[i+1]; (i >= 0 && i+1 < l-1)
+	// else clause -> args[l-1]
+	// If case clause has else clause, l%2 == 1.
+	if l%2 == 1 {
+		ret, isNull, err = args[l-1].EvalInt(b.ctx, row)
+		return ret, isNull, err
+	}
+	return ret, true, nil
+}
+
+type builtinCaseWhenRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCaseWhenRealSig) Clone() builtinFunc {
+	newSig := &builtinCaseWhenRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinCaseWhenRealSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/control-flow-functions.html#operator_case
+func (b *builtinCaseWhenRealSig) evalReal(row chunk.Row) (ret float64, isNull bool, err error) {
+	var condition int64
+	args, l := b.getArgs(), len(b.getArgs())
+	for i := 0; i < l-1; i += 2 {
+		condition, isNull, err = args[i].EvalInt(b.ctx, row)
+		if err != nil {
+			return 0, isNull, err
+		}
+		if isNull || condition == 0 {
+			continue
+		}
+		ret, isNull, err = args[i+1].EvalReal(b.ctx, row)
+		return ret, isNull, err
+	}
+	// when clause(condition, result) -> args[i], args[i+1]; (i >= 0 && i+1 < l-1)
+	// else clause -> args[l-1]
+	// If case clause has else clause, l%2 == 1.
+	if l%2 == 1 {
+		ret, isNull, err = args[l-1].EvalReal(b.ctx, row)
+		return ret, isNull, err
+	}
+	return ret, true, nil
+}
+
+type builtinCaseWhenStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCaseWhenStringSig) Clone() builtinFunc {
+	newSig := &builtinCaseWhenStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinCaseWhenStringSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/control-flow-functions.html#operator_case
+func (b *builtinCaseWhenStringSig) evalString(row chunk.Row) (ret string, isNull bool, err error) {
+	var condition int64
+	args, l := b.getArgs(), len(b.getArgs())
+	for i := 0; i < l-1; i += 2 {
+		condition, isNull, err = args[i].EvalInt(b.ctx, row)
+		if err != nil {
+			return "", isNull, err
+		}
+		if isNull || condition == 0 {
+			continue
+		}
+		ret, isNull, err = args[i+1].EvalString(b.ctx, row)
+		return ret, isNull, err
+	}
+	// when clause(condition, result) -> args[i], args[i+1]; (i >= 0 && i+1 < l-1)
+	// else clause -> args[l-1]
+	// If case clause has else clause, l%2 == 1.
+	if l%2 == 1 {
+		ret, isNull, err = args[l-1].EvalString(b.ctx, row)
+		return ret, isNull, err
+	}
+	return ret, true, nil
+}
+
 type ifFunctionClass struct {
 	baseFunctionClass
 }
@@ -0,0 +1,61 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestCaseWhen(c *C) {
+	tbl := []struct {
+		Arg []interface{}
+		Ret interface{}
+	}{
+		{[]interface{}{true, 1, true, 2, 3}, 1},
+		{[]interface{}{false, 1, true, 2, 3}, 2},
+		{[]interface{}{nil, 1, true, 2, 3}, 2},
+		{[]interface{}{false, 1, false, 2, 3}, 3},
+		{[]interface{}{nil, 1, nil, 2, 3}, 3},
+		{[]interface{}{false, 1, nil, 2}, nil},
+		{[]interface{}{nil, 1, false, 2}, nil},
+		{[]interface{}{1, 1.1, 0, 2.2}, 1.1},
+		{[]interface{}{0, "a", 0, "b", "c"}, "c"},
+	}
+	fc := funcs[ast.Case]
+	for _, t := range tbl {
+		f, err := fc.getFunction(s.ctx, s.primitiveValsToConstants(t.Arg))
+		c.Assert(err, IsNil)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+	// Result type inference.
+	f, err := fc.getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{1, 1, 2}))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().EvalType(), Equals, types.ETInt)
+	f, err = fc.getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{1, 1, 2.5}))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().EvalType(), Equals, types.ETReal)
+	f, err = fc.getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{1, "a", 2}))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().EvalType(), Equals, types.ETString)
+	f, err = fc.getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{1, nil}))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().Tp, Equals, mysql.TypeNull)
+}
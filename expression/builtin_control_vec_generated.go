@@ -259,3 +259,182 @@ func (b *builtinIfStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Col
 func (b *builtinIfStringSig) vectorized() bool {
 	return true
 }
+
+func (b *builtinCaseWhenIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	args, l := b.getArgs(), len(b.getArgs())
+	bufs := make([]*chunk.Column, l)
+	var err error
+	for j := 0; j < l-1; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalInt(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	for j := 1; j < l; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalInt(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	if l%2 == 1 { // else part
+		if bufs[l-1], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[l-1])
+		if err = args[l-1].VecEvalInt(b.ctx, input, bufs[l-1]); err != nil {
+			return err
+		}
+	}
+	result.ResizeInt64(n, false)
+	resultSlice := result.Int64s()
+ROW:
+	for i := 0; i < n; i++ {
+		for j := 0; j < l-1; j += 2 {
+			if bufs[j].IsNull(i) || bufs[j].GetInt64(i) == 0 {
+				continue
+			}
+			resultSlice[i] = bufs[j+1].GetInt64(i)
+			result.SetNull(i, bufs[j+1].IsNull(i))
+			continue ROW
+		}
+		if l%2 == 1 { // else part
+			resultSlice[i] = bufs[l-1].GetInt64(i)
+			result.SetNull(i, bufs[l-1].IsNull(i))
+		} else {
+			result.SetNull(i, true)
+		}
+	}
+	return nil
+}
+
+func (b *builtinCaseWhenIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCaseWhenRealSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	args, l := b.getArgs(), len(b.getArgs())
+	bufs := make([]*chunk.Column, l)
+	var err error
+	for j := 0; j < l-1; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalInt(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	for j := 1; j < l; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETReal, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalReal(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	if l%2 == 1 { // else part
+		if bufs[l-1], err = b.bufAllocator.get(types.ETReal, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[l-1])
+		if err = args[l-1].VecEvalReal(b.ctx, input, bufs[l-1]); err != nil {
+			return err
+		}
+	}
+	result.ResizeFloat64(n, false)
+	resultSlice := result.Float64s()
+ROW:
+	for i := 0; i < n; i++ {
+		for j := 0; j < l-1; j += 2 {
+			if bufs[j].IsNull(i) || bufs[j].GetInt64(i) == 0 {
+				continue
+			}
+			resultSlice[i] = bufs[j+1].GetFloat64(i)
+			result.SetNull(i, bufs[j+1].IsNull(i))
+			continue ROW
+		}
+		if l%2 == 1 { // else part
+			resultSlice[i] = bufs[l-1].GetFloat64(i)
+			result.SetNull(i, bufs[l-1].IsNull(i))
+		} else {
+			result.SetNull(i, true)
+		}
+	}
+	return nil
+}
+
+func (b *builtinCaseWhenRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCaseWhenStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	args, l := b.getArgs(), len(b.getArgs())
+	bufs := make([]*chunk.Column, l)
+	var err error
+	for j := 0; j < l-1; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalInt(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	for j := 1; j < l; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETString, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalString(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	if l%2 == 1 { // else part
+		if bufs[l-1], err = b.bufAllocator.get(types.ETString, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[l-1])
+		if err = args[l-1].VecEvalString(b.ctx, input, bufs[l-1]); err != nil {
+			return err
+		}
+	}
+	result.ReserveString(n)
+ROW:
+	for i := 0; i < n; i++ {
+		for j := 0; j < l-1; j += 2 {
+			if bufs[j].IsNull(i) || bufs[j].GetInt64(i) == 0 {
+				continue
+			}
+			if bufs[j+1].IsNull(i) {
+				result.AppendNull()
+			} else {
+				result.AppendString(bufs[j+1].GetString(i))
+			}
+			continue ROW
+		}
+		if l%2 == 1 { // else part
+			if bufs[l-1].IsNull(i) {
+				result.AppendNull()
+			} else {
+				result.AppendString(bufs[l-1].GetString(i))
+			}
+		} else {
+			result.AppendNull()
+		}
+	}
+	return nil
+}
+
+func (b *builtinCaseWhenStringSig) vectorized() bool {
+	return true
+}
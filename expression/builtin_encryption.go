@@ -0,0 +1,798 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"crypto/aes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/encrypt"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &aesDecryptFunctionClass{}
+	_ functionClass = &aesEncryptFunctionClass{}
+	_ functionClass = &md5FunctionClass{}
+	_ functionClass = &sha1FunctionClass{}
+	_ functionClass = &sha2FunctionClass{}
+	_ functionClass = &crc32FunctionClass{}
+	_ functionClass = &toBase64FunctionClass{}
+	_ functionClass = &fromBase64FunctionClass{}
+	_ functionClass = &randomBytesFunctionClass{}
+	_ functionClass = &uuidFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinAesDecryptSig{}
+	_ builtinFunc = &builtinAesDecryptIVSig{}
+	_ builtinFunc = &builtinAesEncryptSig{}
+	_ builtinFunc = &builtinAesEncryptIVSig{}
+	_ builtinFunc = &builtinMD5Sig{}
+	_ builtinFunc = &builtinSHA1Sig{}
+	_ builtinFunc = &builtinSHA2Sig{}
+	_ builtinFunc = &builtinCRC32Sig{}
+	_ builtinFunc = &builtinToBase64Sig{}
+	_ builtinFunc = &builtinFromBase64Sig{}
+	_ builtinFunc = &builtinRandomBytesSig{}
+	_ builtinFunc = &builtinUUIDSig{}
+)
+
+// ivSize indicates the size of the initialization vector supplied to aes_encrypt and aes_decrypt.
+const ivSize = aes.BlockSize
+
+// aesModeAttr indicates that the key length and iv attribute for specific block_encryption_mode.
+// keySize is the key length in bits and mode is the encryption mode.
+// ivRequired indicates that initialization vector is required or not.
+type aesModeAttr struct {
+	modeName   string
+	keySize    int
+	ivRequired bool
+}
+
+var aesModes = map[string]*aesModeAttr{
+	"aes-128-ecb": {"ecb", 16, false},
+	"aes-192-ecb": {"ecb", 24, false},
+	"aes-256-ecb": {"ecb", 32, false},
+	"aes-128-cbc": {"cbc", 16, true},
+	"aes-192-cbc": {"cbc", 24, true},
+	"aes-256-cbc": {"cbc", 32, true},
+	"aes-128-ofb": {"ofb", 16, true},
+	"aes-192-ofb": {"ofb", 24, true},
+	"aes-256-ofb": {"ofb", 32, true},
+	"aes-128-cfb": {"cfb", 16, true},
+	"aes-192-cfb": {"cfb", 24, true},
+	"aes-256-cfb": {"cfb", 32, true},
+}
+
+// getAesMode returns the aesModeAttr of the block_encryption_mode of the session.
+func getAesMode(ctx sessionctx.Context) (*aesModeAttr, error) {
+	blockMode, _ := ctx.GetSessionVars().GetSystemVar(variable.BlockEncryptionMode)
+	mode, exists := aesModes[strings.ToLower(blockMode)]
+	if !exists {
+		return nil, errors.Errorf("unsupported block encryption mode - %v", blockMode)
+	}
+	return mode, nil
+}
+
+type aesDecryptFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *aesDecryptFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		argTps = append(argTps, types.ETString)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, argTps...)
+	bf.tp.Flen = args[0].GetType().Flen // At most.
+	types.SetBinChsClnFlag(bf.tp)
+
+	mode, err := getAesMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mode.ivRequired {
+		if len(args) != 3 {
+			return nil, ErrIncorrectParameterCount.GenWithStackByArgs("aes_decrypt")
+		}
+		sig := &builtinAesDecryptIVSig{bf, mode}
+		sig.setPbCode(tipb.ScalarFuncSig_AesDecryptIV)
+		return sig, nil
+	}
+	sig := &builtinAesDecryptSig{bf, mode}
+	sig.setPbCode(tipb.ScalarFuncSig_AesDecrypt)
+	return sig, nil
+}
+
+type builtinAesDecryptSig struct {
+	baseBuiltinFunc
+	*aesModeAttr
+}
+
+func (b *builtinAesDecryptSig) Clone() builtinFunc {
+	newSig := &builtinAesDecryptSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.aesModeAttr = b.aesModeAttr
+	return newSig
+}
+
+// evalString evals AES_DECRYPT(crypt_str, key_key).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_aes-decrypt
+func (b *builtinAesDecryptSig) evalString(row chunk.Row) (string, bool, error) {
+	// According to doc: If either function argument is NULL, the function returns NULL.
+	cryptStr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	keyStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if len(b.args) == 3 {
+		// For modes that do not require init_vector, it is ignored and a warning is generated if it is specified.
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnOptionIgnored.GenWithStackByArgs("IV"))
+	}
+
+	key := encrypt.DeriveKeyMySQL([]byte(keyStr), b.keySize)
+	var plainText []byte
+	switch b.modeName {
+	case "ecb":
+		plainText, err = encrypt.AESDecryptWithECB([]byte(cryptStr), key)
+	default:
+		return "", true, errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	if err != nil {
+		return "", true, nil
+	}
+	return string(plainText), false, nil
+}
+
+type builtinAesDecryptIVSig struct {
+	baseBuiltinFunc
+	*aesModeAttr
+}
+
+func (b *builtinAesDecryptIVSig) Clone() builtinFunc {
+	newSig := &builtinAesDecryptIVSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.aesModeAttr = b.aesModeAttr
+	return newSig
+}
+
+// evalString evals AES_DECRYPT(crypt_str, key_key, iv).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_aes-decrypt
+func (b *builtinAesDecryptIVSig) evalString(row chunk.Row) (string, bool, error) {
+	// According to doc: If either function argument is NULL, the function returns NULL.
+	cryptStr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	keyStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	iv, isNull, err := b.args[2].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if len(iv) < ivSize {
+		return "", true, errIncorrectArgs.GenWithStackByArgs("The initialization vector supplied to aes_decrypt is too short. Must be at least 16 bytes long")
+	}
+	// init_vector must be 16 bytes or longer (bytes in excess of 16 are ignored)
+	iv = iv[0:ivSize]
+
+	key := encrypt.DeriveKeyMySQL([]byte(keyStr), b.keySize)
+	var plainText []byte
+	switch b.modeName {
+	case "cbc":
+		plainText, err = encrypt.AESDecryptWithCBC([]byte(cryptStr), key, []byte(iv))
+	case "ofb":
+		plainText, err = encrypt.AESDecryptWithOFB([]byte(cryptStr), key, []byte(iv))
+	case "cfb":
+		plainText, err = encrypt.AESDecryptWithCFB([]byte(cryptStr), key, []byte(iv))
+	default:
+		return "", true, errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	if err != nil {
+		return "", true, nil
+	}
+	return string(plainText), false, nil
+}
+
+type aesEncryptFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *aesEncryptFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		argTps = append(argTps, types.ETString)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, argTps...)
+	bf.tp.Flen = aes.BlockSize * (args[0].GetType().Flen/aes.BlockSize + 1) // At most.
+	types.SetBinChsClnFlag(bf.tp)
+
+	mode, err := getAesMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mode.ivRequired {
+		if len(args) != 3 {
+			return nil, ErrIncorrectParameterCount.GenWithStackByArgs("aes_encrypt")
+		}
+		sig := &builtinAesEncryptIVSig{bf, mode}
+		sig.setPbCode(tipb.ScalarFuncSig_AesEncryptIV)
+		return sig, nil
+	}
+	sig := &builtinAesEncryptSig{bf, mode}
+	sig.setPbCode(tipb.ScalarFuncSig_AesEncrypt)
+	return sig, nil
+}
+
+type builtinAesEncryptSig struct {
+	baseBuiltinFunc
+	*aesModeAttr
+}
+
+func (b *builtinAesEncryptSig) Clone() builtinFunc {
+	newSig := &builtinAesEncryptSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.aesModeAttr = b.aesModeAttr
+	return newSig
+}
+
+// evalString evals AES_ENCRYPT(str, key_str).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_aes-decrypt
+func (b *builtinAesEncryptSig) evalString(row chunk.Row) (string, bool, error) {
+	// According to doc: If either function argument is NULL, the function returns NULL.
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	keyStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if len(b.args) == 3 {
+		// For modes that do not require init_vector, it is ignored and a warning is generated if it is specified.
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnOptionIgnored.GenWithStackByArgs("IV"))
+	}
+
+	key := encrypt.DeriveKeyMySQL([]byte(keyStr), b.keySize)
+	var cipherText []byte
+	switch b.modeName {
+	case "ecb":
+		cipherText, err = encrypt.AESEncryptWithECB([]byte(str), key)
+	default:
+		return "", true, errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	if err != nil {
+		return "", true, nil
+	}
+	return string(cipherText), false, nil
+}
+
+type builtinAesEncryptIVSig struct {
+	baseBuiltinFunc
+	*aesModeAttr
+}
+
+func (b *builtinAesEncryptIVSig) Clone() builtinFunc {
+	newSig := &builtinAesEncryptIVSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.aesModeAttr = b.aesModeAttr
+	return newSig
+}
+
+// evalString evals AES_ENCRYPT(str, key_str, iv).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_aes-decrypt
+func (b *builtinAesEncryptIVSig) evalString(row chunk.Row) (string, bool, error) {
+	// According to doc: If either function argument is NULL, the function returns NULL.
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	keyStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	iv, isNull, err := b.args[2].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if len(iv) < ivSize {
+		return "", true, errIncorrectArgs.GenWithStackByArgs("The initialization vector supplied to aes_encrypt is too short. Must be at least 16 bytes long")
+	}
+	// init_vector must be 16 bytes or longer (bytes in excess of 16 are ignored)
+	iv = iv[0:ivSize]
+
+	key := encrypt.DeriveKeyMySQL([]byte(keyStr), b.keySize)
+	var cipherText []byte
+	switch b.modeName {
+	case "cbc":
+		cipherText, err = encrypt.AESEncryptWithCBC([]byte(str), key, []byte(iv))
+	case "ofb":
+		cipherText, err = encrypt.AESEncryptWithOFB([]byte(str), key, []byte(iv))
+	case "cfb":
+		cipherText, err = encrypt.AESEncryptWithCFB([]byte(str), key, []byte(iv))
+	default:
+		return "", true, errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	if err != nil {
+		return "", true, nil
+	}
+	return string(cipherText), false, nil
+}
+
+type md5FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *md5FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETString)
+	bf.tp.Flen = 32
+	sig := &builtinMD5Sig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_MD5)
+	return sig, nil
+}
+
+type builtinMD5Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinMD5Sig) Clone() builtinFunc {
+	newSig := &builtinMD5Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinMD5Sig.
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_md5
+func (b *builtinMD5Sig) evalString(row chunk.Row) (string, bool, error) {
+	arg, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	sum := md5.Sum([]byte(arg))
+	hexStr := fmt.Sprintf("%x", sum)
+	return hexStr, false, nil
+}
+
+type sha1FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *sha1FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETString)
+	bf.tp.Flen = 40
+	sig := &builtinSHA1Sig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_SHA1)
+	return sig, nil
+}
+
+type builtinSHA1Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSHA1Sig) Clone() builtinFunc {
+	newSig := &builtinSHA1Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals SHA1(str).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_sha1
+// The value is returned as a string of 40 hexadecimal digits, or NULL if the argument was NULL.
+func (b *builtinSHA1Sig) evalString(row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	hasher := sha1.New()
+	_, err = hasher.Write([]byte(str))
+	if err != nil {
+		return "", true, err
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil)), false, nil
+}
+
+type sha2FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *sha2FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	var err error
+	if args[1], err = WrapWithCastAsInt(ctx, args[1]); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETString, types.ETInt)
+	bf.tp.Flen = 128 // sha512
+	sig := &builtinSHA2Sig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_SHA2)
+	return sig, nil
+}
+
+type builtinSHA2Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSHA2Sig) Clone() builtinFunc {
+	newSig := &builtinSHA2Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// Supported hash length of SHA-2 family
+const (
+	SHA0   = 0
+	SHA224 = 224
+	SHA256 = 256
+	SHA384 = 384
+	SHA512 = 512
+)
+
+// newSHA2Hasher returns the hasher of the SHA-2 family for hashLength,
+// or nil if hashLength is not supported.
+func newSHA2Hasher(hashLength int64) hash.Hash {
+	switch hashLength {
+	case SHA0, SHA256:
+		return sha256.New()
+	case SHA224:
+		return sha256.New224()
+	case SHA384:
+		return sha512.New384()
+	case SHA512:
+		return sha512.New()
+	}
+	return nil
+}
+
+// evalString evals SHA2(str, hash_length).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_sha2
+func (b *builtinSHA2Sig) evalString(row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	hashLength, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	hasher := newSHA2Hasher(hashLength)
+	if hasher == nil {
+		return "", true, nil
+	}
+
+	_, err = hasher.Write([]byte(str))
+	if err != nil {
+		return "", true, err
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil)), false, nil
+}
+
+type crc32FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *crc32FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETString)
+	bf.tp.Flen = 10
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinCRC32Sig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_CRC32)
+	return sig, nil
+}
+
+type builtinCRC32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCRC32Sig) Clone() builtinFunc {
+	newSig := &builtinCRC32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a CRC32(expr).
+// See https://dev.mysql.com/doc/refman/5.7/en/mathematical-functions.html#function_crc32
+func (b *builtinCRC32Sig) evalInt(row chunk.Row) (int64, bool, error) {
+	x, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	r := crc32.ChecksumIEEE([]byte(x))
+	return int64(r), false, nil
+}
+
+// base64NeededEncodedLength return the base64 encoded string length.
+func base64NeededEncodedLength(n int) int {
+	// Returns -1 indicate the result will overflow.
+	if strconv.IntSize == 64 && n > 3221225472 || strconv.IntSize == 32 && n > 1610612733 {
+		return -1
+	}
+	length := (n + 2) / 3 * 4
+	return length + (length-1)/76
+}
+
+// splitToSubN splits a string every n runes into a string[].
+func splitToSubN(s string, n int) []string {
+	subs := make([]string, 0, len(s)/n+1)
+	for len(s) > n {
+		subs = append(subs, s[:n])
+		s = s[n:]
+	}
+	subs = append(subs, s)
+	return subs
+}
+
+// base64Encode encodes str like MySQL, which adds a newline after each 76
+// characters of encoded output to divide long output into multiple lines.
+func base64Encode(str string) string {
+	newStr := base64.StdEncoding.EncodeToString([]byte(str))
+	if len(newStr) > 76 {
+		newStr = strings.Join(splitToSubN(newStr, 76), "\n")
+	}
+	return newStr
+}
+
+// base64Decode decodes str like MySQL, the space and tab characters are ignored.
+func base64Decode(str string) ([]byte, error) {
+	str = strings.Replace(str, "\t", "", -1)
+	str = strings.Replace(str, " ", "", -1)
+	return base64.StdEncoding.DecodeString(str)
+}
+
+// getMaxAllowedPacket returns the max_allowed_packet of the session.
+func getMaxAllowedPacket(ctx sessionctx.Context) (uint64, error) {
+	valStr, _ := ctx.GetSessionVars().GetSystemVar(variable.MaxAllowedPacket)
+	maxAllowedPacket, err := strconv.ParseUint(valStr, 10, 64)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return maxAllowedPacket, nil
+}
+
+type toBase64FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *toBase64FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETString)
+	if args[0].GetType().Flen == types.UnspecifiedLength {
+		bf.tp.Flen = types.UnspecifiedLength
+	} else {
+		bf.tp.Flen = base64NeededEncodedLength(args[0].GetType().Flen)
+	}
+
+	maxAllowedPacket, err := getMaxAllowedPacket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinToBase64Sig{bf, maxAllowedPacket}
+	sig.setPbCode(tipb.ScalarFuncSig_ToBase64)
+	return sig, nil
+}
+
+type builtinToBase64Sig struct {
+	baseBuiltinFunc
+	maxAllowedPacket uint64
+}
+
+func (b *builtinToBase64Sig) Clone() builtinFunc {
+	newSig := &builtinToBase64Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.maxAllowedPacket = b.maxAllowedPacket
+	return newSig
+}
+
+// evalString evals a builtinToBase64Sig.
+// See https://dev.mysql.com/doc/refman/5.7/en/string-functions.html#function_to-base64
+func (b *builtinToBase64Sig) evalString(row chunk.Row) (d string, isNull bool, err error) {
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	needEncodeLen := base64NeededEncodedLength(len(str))
+	if needEncodeLen == -1 {
+		return "", true, nil
+	}
+	if needEncodeLen > int(b.maxAllowedPacket) {
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnAllowedPacketOverflowed.GenWithStackByArgs("to_base64", b.maxAllowedPacket))
+		return "", true, nil
+	}
+	return base64Encode(str), false, nil
+}
+
+type fromBase64FunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fromBase64FunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETString)
+	if args[0].GetType().Flen == types.UnspecifiedLength {
+		bf.tp.Flen = types.UnspecifiedLength
+	} else {
+		bf.tp.Flen = args[0].GetType().Flen * 3 / 4
+	}
+	types.SetBinChsClnFlag(bf.tp)
+
+	maxAllowedPacket, err := getMaxAllowedPacket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinFromBase64Sig{bf, maxAllowedPacket}
+	sig.setPbCode(tipb.ScalarFuncSig_FromBase64)
+	return sig, nil
+}
+
+type builtinFromBase64Sig struct {
+	baseBuiltinFunc
+	maxAllowedPacket uint64
+}
+
+func (b *builtinFromBase64Sig) Clone() builtinFunc {
+	newSig := &builtinFromBase64Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.maxAllowedPacket = b.maxAllowedPacket
+	return newSig
+}
+
+// evalString evals FROM_BASE64(str).
+// See https://dev.mysql.com/doc/refman/5.7/en/string-functions.html#function_from-base64
+func (b *builtinFromBase64Sig) evalString(row chunk.Row) (string, bool, error) {
+	str, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	needDecodeLen := base64.StdEncoding.DecodedLen(len(str))
+	if needDecodeLen > int(b.maxAllowedPacket) {
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnAllowedPacketOverflowed.GenWithStackByArgs("from_base64", b.maxAllowedPacket))
+		return "", true, nil
+	}
+
+	result, err := base64Decode(str)
+	if err != nil {
+		// When error occurs, return NULL.
+		return "", true, nil
+	}
+	return string(result), false, nil
+}
+
+type randomBytesFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *randomBytesFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	var err error
+	if args[0], err = WrapWithCastAsInt(ctx, args[0]); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETInt)
+	bf.tp.Flen = 1024 // Max allowed random bytes
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinRandomBytesSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_RandomBytes)
+	return sig, nil
+}
+
+type builtinRandomBytesSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRandomBytesSig) Clone() builtinFunc {
+	newSig := &builtinRandomBytesSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals RANDOM_BYTES(len).
+// See https://dev.mysql.com/doc/refman/5.7/en/encryption-functions.html#function_random-bytes
+func (b *builtinRandomBytesSig) evalString(row chunk.Row) (string, bool, error) {
+	length, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if length < 1 || length > 1024 {
+		return "", false, types.ErrOverflow.GenWithStackByArgs("length", "random_bytes")
+	}
+	buf := make([]byte, length)
+	if n, err := rand.Read(buf); err != nil {
+		return "", true, err
+	} else if int64(n) != length {
+		return "", false, errors.New("fail to generate random bytes")
+	}
+	return string(buf), false, nil
+}
+
+type uuidFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *uuidFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString)
+	bf.tp.Flen = 36
+	sig := &builtinUUIDSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_UUID)
+	return sig, nil
+}
+
+type builtinUUIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUUIDSig) Clone() builtinFunc {
+	newSig := &builtinUUIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinUUIDSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_uuid
+func (b *builtinUUIDSig) evalString(_ chunk.Row) (d string, isNull bool, err error) {
+	var id uuid.UUID
+	id, err = uuid.NewUUID()
+	if err != nil {
+		return
+	}
+	d = id.String()
+	return
+}
@@ -0,0 +1,430 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"encoding/hex"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+var aesTests = []struct {
+	mode   string
+	origin interface{}
+	params []interface{}
+	crypt  interface{}
+}{
+	// test for ecb
+	{"aes-128-ecb", "pingcap", []interface{}{"1234567890123456"}, "697BFE9B3F8C2F289DD82C88C7BC95C4"},
+	{"aes-128-ecb", "pingcap123", []interface{}{"1234567890123456"}, "CEC348F4EF5F84D3AA6C4FA184C65766"},
+	{"aes-128-ecb", "pingcap", []interface{}{"123456789012345678901234"}, "6F1589686860C8E8C7A40A78B25FF2C0"},
+	{"aes-128-ecb", "pingcap", []interface{}{"123"}, "996E0CA8688D7AD20819B90B273E01C6"},
+	{"aes-128-ecb", "pingcap", []interface{}{123}, "996E0CA8688D7AD20819B90B273E01C6"},
+	{"aes-128-ecb", nil, []interface{}{123}, nil},
+	{"aes-192-ecb", "pingcap", []interface{}{"1234567890123456"}, "9B139FD002E6496EA2D5C73A2265E661"},
+	{"aes-256-ecb", "pingcap", []interface{}{"1234567890123456"}, "F80DCDEDDBE5663BDB68F74AEDDB8EE3"},
+	// test for cbc
+	{"aes-128-cbc", "pingcap", []interface{}{"1234567890123456", "1234567890123456"}, "2ECA0077C5EA5768A0485AA522774792"},
+	{"aes-128-cbc", "pingcap", []interface{}{"123456789012345678901234", "1234567890123456"}, "483788634DA8817423BA0934FD2C096E"},
+	{"aes-128-cbc", "pingcap", []interface{}{"1234567890123456", nil}, nil},
+	// test for ofb
+	{"aes-128-ofb", "pingcap", []interface{}{"1234567890123456", "1234567890123456"}, "0515A36BBF3DE0"},
+	{"aes-256-ofb", "pingcap", []interface{}{"1234567890123456", "1234567890123456"}, "2E70FCAC0C0834"},
+	// test for cfb
+	{"aes-128-cfb", "pingcap", []interface{}{"1234567890123456", "1234567890123456"}, "0515A36BBF3DE0"},
+	{"aes-256-cfb", "pingcap", []interface{}{"1234567890123456", "1234567890123456"}, "2E70FCAC0C0834"},
+}
+
+func (s *testEvaluatorSuite) TestAESEncrypt(c *C) {
+	fc := funcs[ast.AesEncrypt]
+	for _, tt := range aesTests {
+		err := variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum(tt.mode))
+		c.Assert(err, IsNil)
+		args := []types.Datum{types.NewDatum(tt.origin)}
+		for _, param := range tt.params {
+			args = append(args, types.NewDatum(param))
+		}
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(args))
+		c.Assert(err, IsNil)
+		crypt, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(toHex(crypt), DeepEquals, types.NewDatum(tt.crypt), Commentf("%v", tt))
+	}
+	err := variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum("aes-128-ecb"))
+	c.Assert(err, IsNil)
+	s.testNullInput(c, ast.AesEncrypt)
+	s.testAmbiguousInput(c, ast.AesEncrypt)
+
+	// Test for the IV length.
+	err = variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum("aes-128-cbc"))
+	c.Assert(err, IsNil)
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums("pingcap", "1234567890123456", "12345")))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(terror.ErrorEqual(err, errIncorrectArgs), IsTrue)
+	// The iv is required in cbc mode.
+	_, err = fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums("pingcap", "1234567890123456")))
+	c.Assert(terror.ErrorEqual(err, ErrIncorrectParameterCount), IsTrue)
+	// Unknown block encryption mode.
+	c.Assert(s.ctx.GetSessionVars().SetSystemVar(variable.BlockEncryptionMode, "aes-128-unknown"), IsNil)
+	_, err = fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums("pingcap", "1234567890123456")))
+	c.Assert(err, NotNil)
+	c.Assert(s.ctx.GetSessionVars().SetSystemVar(variable.BlockEncryptionMode, "aes-128-ecb"), IsNil)
+}
+
+func (s *testEvaluatorSuite) TestAESDecrypt(c *C) {
+	fc := funcs[ast.AesDecrypt]
+	for _, tt := range aesTests {
+		err := variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum(tt.mode))
+		c.Assert(err, IsNil)
+		args := []types.Datum{fromHex(tt.crypt)}
+		for _, param := range tt.params {
+			args = append(args, types.NewDatum(param))
+		}
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(args))
+		c.Assert(err, IsNil)
+		str, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		if tt.crypt == nil {
+			c.Assert(str.IsNull(), IsTrue)
+			continue
+		}
+		c.Assert(str.GetString(), Equals, tt.origin, Commentf("%v", tt))
+	}
+	err := variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum("aes-128-ecb"))
+	c.Assert(err, IsNil)
+	s.testNullInput(c, ast.AesDecrypt)
+	s.testAmbiguousInput(c, ast.AesDecrypt)
+
+	// Wrong data is decrypted to NULL.
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums("pingcap", "1234567890123456")))
+	c.Assert(err, IsNil)
+	str, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(str.IsNull(), IsTrue)
+}
+
+func (s *testEvaluatorSuite) testNullInput(c *C, fnName string) {
+	fc := funcs[fnName]
+	arg := types.NewStringDatum("str")
+	var argNull types.Datum
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{arg, argNull}))
+	c.Assert(err, IsNil)
+	crypt, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(crypt.IsNull(), IsTrue)
+
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{argNull, arg}))
+	c.Assert(err, IsNil)
+	crypt, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(crypt.IsNull(), IsTrue)
+}
+
+func (s *testEvaluatorSuite) testAmbiguousInput(c *C, fnName string) {
+	fc := funcs[fnName]
+	arg := types.NewStringDatum("str")
+	// test for modes that require init_vector
+	err := variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum("aes-128-cbc"))
+	c.Assert(err, IsNil)
+	_, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{arg, arg}))
+	c.Assert(err, NotNil)
+
+	// test for modes that do not require init_vector
+	err = variable.SetSessionSystemVar(s.ctx.GetSessionVars(), variable.BlockEncryptionMode, types.NewDatum("aes-128-ecb"))
+	c.Assert(err, IsNil)
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{arg, arg, types.NewStringDatum("iv < 16 bytes")}))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	warnings := s.ctx.GetSessionVars().StmtCtx.GetWarnings()
+	c.Assert(len(warnings), GreaterEqual, 1)
+	c.Assert(terror.ErrorEqual(warnings[len(warnings)-1].Err, errWarnOptionIgnored), IsTrue)
+}
+
+func toHex(d types.Datum) (h types.Datum) {
+	if d.IsNull() {
+		return
+	}
+	x, _ := d.ToString()
+	h.SetString(strings.ToUpper(hex.EncodeToString(hack.Slice(x))))
+	return
+}
+
+func fromHex(str interface{}) (d types.Datum) {
+	if str == nil {
+		return
+	}
+	if s, ok := str.(string); ok {
+		h, _ := hex.DecodeString(s)
+		d.SetBytes(h)
+	}
+	return
+}
+
+func (s *testEvaluatorSuite) TestMD5Hash(c *C) {
+	cases := []struct {
+		args     interface{}
+		expected string
+		isNil    bool
+		getErr   bool
+	}{
+		{"", "d41d8cd98f00b204e9800998ecf8427e", false, false},
+		{"a", "0cc175b9c0f1b6a831c399e269772661", false, false},
+		{"ab", "187ef4436122d1cc2f40dc2b92f0eba0", false, false},
+		{"abc", "900150983cd24fb0d6963f7d28e17f72", false, false},
+		{123, "202cb962ac59075b964b07152d234b70", false, false},
+		{"123", "202cb962ac59075b964b07152d234b70", false, false},
+		{123.123, "46ddc40585caa8abc07c460b3485781e", false, false},
+		{nil, "", true, false},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.MD5, s.datumsToConstants(types.MakeDatums(t.args))...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(d.GetString(), Equals, t.expected)
+			}
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestSha1Hash(c *C) {
+	fc := funcs[ast.SHA]
+	for _, tt := range shaCases {
+		in := types.NewDatum(tt.origin)
+		f, _ := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{in}))
+		crypt, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		res, err := crypt.ToString()
+		c.Assert(err, IsNil)
+		c.Assert(res, Equals, tt.crypt)
+	}
+	// test NULL input for sha
+	var argNull types.Datum
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{argNull}))
+	c.Assert(err, IsNil)
+	crypt, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(crypt.IsNull(), IsTrue)
+}
+
+var shaCases = []struct {
+	origin interface{}
+	crypt  string
+}{
+	{"test", "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"},
+	{"c4pt0r", "034923dcabf099fc4c8917c0ab91ffcd4c2578a6"},
+	{"pingcap", "73bf9ef43a44f42e2ea2894d62f0917af149a006"},
+	{"foobar", "8843d7f92416211de9ebb963ff4ce28125932878"},
+	{1024, "128351137a9c47206c4507dcf2e6fbeeca3a9079"},
+	{123.45, "22f8b438ad7e89300b51d88684f3f0b9fa1d7a32"},
+}
+
+func (s *testEvaluatorSuite) TestSha2Hash(c *C) {
+	cases := []struct {
+		origin     interface{}
+		hashLength interface{}
+		crypt      interface{}
+		validCase  bool
+	}{
+		{"pingcap", 0, "2871823be240f8ecd1d72f24c99eaa2e58af18b4b8ba99a4fc2823ba5c43930a", true},
+		{"pingcap", 224, "cd036dc9bec69e758401379c522454ea24a6327b48724b449b40c6b7", true},
+		{"pingcap", 256, "2871823be240f8ecd1d72f24c99eaa2e58af18b4b8ba99a4fc2823ba5c43930a", true},
+		{"pingcap", 384, "c50955b6b0c7b9919740d956849eedcb0f0f90bf8a34e8c1f4e071e3773f53bd6f8f16c04425ff728bed04de1b63db51", true},
+		{"pingcap", 512, "ea903c574370774c4844a83b7122105a106e04211673810e1baae7c2ae7aba2cf07465e02f6c413126111ef74a417232683ce7ba210052e63c15fc82204aad80", true},
+		{13572468, 0, "1c91ab1c162fd0cae60a5bb9880f3e7d5a133a65b6057a644b26973d9c55dcfe", true},
+		{13572468, 224, "8ad67735bbf49576219f364f4640d595357a440358d15bf6815a16e4", true},
+		{13572468, 256, "1c91ab1c162fd0cae60a5bb9880f3e7d5a133a65b6057a644b26973d9c55dcfe", true},
+		{13572468.123, 384, "3b4ee302435dc1e15251efd9f3982b1ca6fe4ac778d3260b7bbf3bea613849677eda830239420e448e4c6dc7c2649d89", true},
+		{13572468.123, 512, "4820aa3f2760836557dc1f2d44a0ba7596333fdb60c8a1909481862f4ab0921c00abb23d57b7e67a970363cc3fcb78b25b6a0d45cdcac0e87aa0c96bc51f7f96", true},
+		{nil, 224, nil, false},
+		{"pingcap", nil, nil, false},
+		{"pingcap", 123, nil, false},
+	}
+
+	fc := funcs[ast.SHA2]
+	for _, tt := range cases {
+		str := types.NewDatum(tt.origin)
+		hashLength := types.NewDatum(tt.hashLength)
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{str, hashLength}))
+		c.Assert(err, IsNil)
+		crypt, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		if tt.validCase {
+			res, err := crypt.ToString()
+			c.Assert(err, IsNil)
+			c.Assert(res, Equals, tt.crypt)
+		} else {
+			c.Assert(crypt.IsNull(), IsTrue)
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestCRC32(c *C) {
+	tbl := []struct {
+		input interface{}
+		ret   interface{}
+	}{
+		{nil, nil},
+		{"", uint64(0)},
+		{-1, uint64(808273962)},
+		{"-1", uint64(808273962)},
+		{"mysql", uint64(2501908538)},
+		{"MySQL", uint64(3259397556)},
+		{"hello", uint64(907060870)},
+	}
+
+	for _, t := range tbl {
+		f, err := newFunctionForTest(s.ctx, ast.CRC32, s.datumsToConstants(types.MakeDatums(t.input))...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(t.ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestBase64(c *C) {
+	tests := []struct {
+		origin interface{}
+		base64 interface{}
+	}{
+		{"", ""},
+		{"abc", "YWJj"},
+		{"ab", "YWI="},
+		{"a", "YQ=="},
+		{123, "MTIz"},
+		{nil, nil},
+		{strings.Repeat("abc", 20), strings.Repeat("YWJj", 19) + "\n" + "YWJj"},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, ast.ToBase64, s.datumsToConstants(types.MakeDatums(tt.origin))...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d.GetValue(), DeepEquals, tt.base64)
+
+		if tt.origin == 123 {
+			continue
+		}
+		f, err = newFunctionForTest(s.ctx, ast.FromBase64, s.datumsToConstants(types.MakeDatums(tt.base64))...)
+		c.Assert(err, IsNil)
+		d, err = f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if tt.origin == nil {
+			c.Assert(d.IsNull(), IsTrue)
+		} else {
+			c.Assert(d.GetString(), Equals, tt.origin)
+		}
+	}
+
+	// Spaces and tabs are ignored, invalid input is decoded to NULL.
+	for str, expected := range map[string]interface{}{
+		"YW Jj":    "abc",
+		"YW\tJj\n": "abc",
+		"YWJ":      nil,
+		"Y@Jj":     nil,
+	} {
+		f, err := newFunctionForTest(s.ctx, ast.FromBase64, s.datumsToConstants(types.MakeDatums(str))...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if expected == nil {
+			c.Assert(d.IsNull(), IsTrue, Commentf("%q", str))
+		} else {
+			c.Assert(d.GetString(), Equals, expected, Commentf("%q", str))
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestRandomBytes(c *C) {
+	fc := funcs[ast.RandomBytes]
+	f, err := fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{types.NewDatum(32)}))
+	c.Assert(err, IsNil)
+	out, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(len(out.GetBytes()), Equals, 32)
+
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{types.NewDatum(1025)}))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, NotNil)
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{types.NewDatum(-32)}))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, NotNil)
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{types.NewDatum(0)}))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, NotNil)
+
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants([]types.Datum{types.NewDatum(nil)}))
+	c.Assert(err, IsNil)
+	out, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(out.IsNull(), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestUUID(c *C) {
+	f, err := newFunctionForTest(s.ctx, ast.UUID)
+	c.Assert(err, IsNil)
+	d, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	parts := strings.Split(d.GetString(), "-")
+	c.Assert(len(parts), Equals, 5)
+	for i, p := range parts {
+		switch i {
+		case 0:
+			c.Assert(len(p), Equals, 8)
+		case 1:
+			c.Assert(len(p), Equals, 4)
+		case 2:
+			c.Assert(len(p), Equals, 4)
+		case 3:
+			c.Assert(len(p), Equals, 4)
+		case 4:
+			c.Assert(len(p), Equals, 12)
+		}
+	}
+	d2, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d2.GetString(), Not(Equals), d.GetString())
+
+	// Non-deterministic functions are not folded.
+	_, isConst := FoldConstant(f).(*Constant)
+	c.Assert(isConst, IsFalse)
+	f, err = newFunctionForTest(s.ctx, ast.RandomBytes, s.datumsToConstants(types.MakeDatums(16))...)
+	c.Assert(err, IsNil)
+	_, isConst = FoldConstant(f).(*Constant)
+	c.Assert(isConst, IsFalse)
+	// Deterministic ones are.
+	f, err = newFunctionForTest(s.ctx, ast.MD5, s.datumsToConstants(types.MakeDatums("a"))...)
+	c.Assert(err, IsNil)
+	_, isConst = FoldConstant(f).(*Constant)
+	c.Assert(isConst, IsTrue)
+}
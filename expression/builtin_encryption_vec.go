@@ -0,0 +1,406 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"hash/crc32"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/encrypt"
+)
+
+// vecEvalAes evaluates the arguments of AES_ENCRYPT and AES_DECRYPT and
+// applies fn on each row. If iv is required, it is the third argument.
+func vecEvalAes(b *baseBuiltinFunc, funcName string, ivRequired bool, input *chunk.Chunk, result *chunk.Column,
+	fn func(str, key, iv []byte) ([]byte, error)) error {
+	n := input.NumRows()
+	bufs := make([]*chunk.Column, len(b.args))
+	for i, arg := range b.args {
+		buf, err := b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := arg.VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+
+	isWarning := !ivRequired && len(b.args) == 3
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		// According to doc: If either function argument is NULL, the function returns NULL.
+		if bufs[0].IsNull(i) || bufs[1].IsNull(i) || (ivRequired && bufs[2].IsNull(i)) {
+			result.AppendNull()
+			continue
+		}
+		if isWarning {
+			// For modes that do not require init_vector, it is ignored and a warning is generated if it is specified.
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnOptionIgnored.GenWithStackByArgs("IV"))
+		}
+		var iv []byte
+		if ivRequired {
+			iv = bufs[2].GetBytes(i)
+			if len(iv) < ivSize {
+				return errIncorrectArgs.GenWithStackByArgs("The initialization vector supplied to " + funcName + " is too short. Must be at least 16 bytes long")
+			}
+			// init_vector must be 16 bytes or longer (bytes in excess of 16 are ignored)
+			iv = iv[0:ivSize]
+		}
+		res, err := fn(bufs[0].GetBytes(i), bufs[1].GetBytes(i), iv)
+		if err != nil {
+			result.AppendNull()
+			continue
+		}
+		result.AppendBytes(res)
+	}
+	return nil
+}
+
+func (b *builtinAesDecryptSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAesDecryptSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	if b.modeName != "ecb" {
+		return errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	return vecEvalAes(&b.baseBuiltinFunc, "aes_decrypt", false, input, result, func(str, keyStr, _ []byte) ([]byte, error) {
+		key := encrypt.DeriveKeyMySQL(keyStr, b.keySize)
+		return encrypt.AESDecryptWithECB(str, key)
+	})
+}
+
+func (b *builtinAesDecryptIVSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAesDecryptIVSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	var decrypt func(cryptStr, key, iv []byte) ([]byte, error)
+	switch b.modeName {
+	case "cbc":
+		decrypt = encrypt.AESDecryptWithCBC
+	case "ofb":
+		decrypt = encrypt.AESDecryptWithOFB
+	case "cfb":
+		decrypt = encrypt.AESDecryptWithCFB
+	default:
+		return errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	return vecEvalAes(&b.baseBuiltinFunc, "aes_decrypt", true, input, result, func(str, keyStr, iv []byte) ([]byte, error) {
+		key := encrypt.DeriveKeyMySQL(keyStr, b.keySize)
+		return decrypt(str, key, iv)
+	})
+}
+
+func (b *builtinAesEncryptSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAesEncryptSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	if b.modeName != "ecb" {
+		return errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	return vecEvalAes(&b.baseBuiltinFunc, "aes_encrypt", false, input, result, func(str, keyStr, _ []byte) ([]byte, error) {
+		key := encrypt.DeriveKeyMySQL(keyStr, b.keySize)
+		return encrypt.AESEncryptWithECB(str, key)
+	})
+}
+
+func (b *builtinAesEncryptIVSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAesEncryptIVSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	var encryptFn func(str, key, iv []byte) ([]byte, error)
+	switch b.modeName {
+	case "cbc":
+		encryptFn = encrypt.AESEncryptWithCBC
+	case "ofb":
+		encryptFn = encrypt.AESEncryptWithOFB
+	case "cfb":
+		encryptFn = encrypt.AESEncryptWithCFB
+	default:
+		return errors.Errorf("unsupported block encryption mode - %v", b.modeName)
+	}
+	return vecEvalAes(&b.baseBuiltinFunc, "aes_encrypt", true, input, result, func(str, keyStr, iv []byte) ([]byte, error) {
+		key := encrypt.DeriveKeyMySQL(keyStr, b.keySize)
+		return encryptFn(str, key, iv)
+	})
+}
+
+func (b *builtinMD5Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinMD5Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	digest := md5.New()
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		digest.Reset()
+		if _, err = digest.Write(buf.GetBytes(i)); err != nil {
+			return err
+		}
+		result.AppendString(hex.EncodeToString(digest.Sum(nil)))
+	}
+	return nil
+}
+
+func (b *builtinSHA1Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSHA1Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	hasher := sha1.New()
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		hasher.Reset()
+		if _, err = hasher.Write(buf.GetBytes(i)); err != nil {
+			return err
+		}
+		result.AppendString(hex.EncodeToString(hasher.Sum(nil)))
+	}
+	return nil
+}
+
+func (b *builtinSHA2Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSHA2Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	buf1, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+	if err := b.args[1].VecEvalInt(b.ctx, input, buf1); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	i64s := buf1.Int64s()
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) || buf1.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		hasher := newSHA2Hasher(i64s[i])
+		if hasher == nil {
+			result.AppendNull()
+			continue
+		}
+		if _, err = hasher.Write(buf.GetBytes(i)); err != nil {
+			return err
+		}
+		result.AppendString(hex.EncodeToString(hasher.Sum(nil)))
+	}
+	return nil
+}
+
+func (b *builtinCRC32Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCRC32Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	result.MergeNulls(buf)
+	for i := range i64s {
+		if !buf.IsNull(i) {
+			i64s[i] = int64(crc32.ChecksumIEEE(buf.GetBytes(i)))
+		}
+	}
+	return nil
+}
+
+func (b *builtinToBase64Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinToBase64Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		str := buf.GetString(i)
+		needEncodeLen := base64NeededEncodedLength(len(str))
+		if needEncodeLen == -1 {
+			result.AppendNull()
+			continue
+		}
+		if needEncodeLen > int(b.maxAllowedPacket) {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnAllowedPacketOverflowed.GenWithStackByArgs("to_base64", b.maxAllowedPacket))
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(base64Encode(str))
+	}
+	return nil
+}
+
+func (b *builtinFromBase64Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinFromBase64Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		str := buf.GetString(i)
+		needDecodeLen := base64.StdEncoding.DecodedLen(len(str))
+		if needDecodeLen > int(b.maxAllowedPacket) {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errWarnAllowedPacketOverflowed.GenWithStackByArgs("from_base64", b.maxAllowedPacket))
+			result.AppendNull()
+			continue
+		}
+		res, err := base64Decode(str)
+		if err != nil {
+			// When error occurs, return NULL.
+			result.AppendNull()
+			continue
+		}
+		result.AppendBytes(res)
+	}
+	return nil
+}
+
+func (b *builtinRandomBytesSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRandomBytesSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalInt(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	i64s := buf.Int64s()
+	var randBuf []byte
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		length := i64s[i]
+		if length < 1 || length > 1024 {
+			return types.ErrOverflow.GenWithStackByArgs("length", "random_bytes")
+		}
+		if int64(cap(randBuf)) < length {
+			randBuf = make([]byte, length)
+		}
+		randBuf = randBuf[:length]
+		if _, err := rand.Read(randBuf); err != nil {
+			return err
+		}
+		result.AppendBytes(randBuf)
+	}
+	return nil
+}
+
+func (b *builtinUUIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUUIDSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		id, err := uuid.NewUUID()
+		if err != nil {
+			return err
+		}
+		result.AppendString(id.String())
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+var vecBuiltinEncryptionCases = map[string][]vecExprBenchCase{
+	ast.AesEncrypt: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString}, aesModes: "aes-128-ecb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, aesModes: "aes-128-ecb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-128-cbc"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-256-ofb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-192-cfb"},
+	},
+	ast.AesDecrypt: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString}, aesModes: "aes-128-ecb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, aesModes: "aes-128-ecb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-128-cbc"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-256-ofb"},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{nil, nil, &randLenStrGener{16, 20}}, aesModes: "aes-192-cfb"},
+	},
+	ast.MD5: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}},
+	},
+	ast.SHA1: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}},
+	},
+	ast.SHA2: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA0, SHA0 + 1}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA224, SHA224 + 1}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA256, SHA256 + 1}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA384, SHA384 + 1}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA512, SHA512 + 1}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{nil, &rangeInt64Gener{SHA512 + 1, SHA512 + 2}}},
+	},
+	ast.CRC32: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}},
+	},
+	ast.ToBase64: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&randLenStrGener{0, 128}}},
+	},
+	ast.FromBase64: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&randLenStrGener{10, 100}}},
+	},
+	// The results of RANDOM_BYTES and UUID are random, they are tested in TestVectorizedRandomFunc.
+	ast.RandomBytes: {},
+	ast.UUID:        {},
+}
+
+func (s *testEvaluatorSuite) TestVectorizedBuiltinEncryptionFunc(c *C) {
+	testVectorizedBuiltinFunc(c, vecBuiltinEncryptionCases)
+}
+
+func BenchmarkVectorizedBuiltinEncryptionFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinEncryptionCases)
+}
+
+func (s *testEvaluatorSuite) TestVectorizedRandomFunc(c *C) {
+	ctx := mock.NewContext()
+	input := chunk.New([]*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}, 1024, 1024)
+	for i := 0; i < 1024; i++ {
+		if i%10 == 0 {
+			input.AppendNull(0)
+			continue
+		}
+		input.AppendInt64(0, int64(i%1024+1))
+	}
+	col := &Column{Index: 0, RetType: types.NewFieldType(mysql.TypeLonglong)}
+	f, err := funcs[ast.RandomBytes].getFunction(ctx, []Expression{col})
+	c.Assert(err, IsNil)
+	result := chunk.NewColumn(types.NewFieldType(mysql.TypeString), 1024)
+	c.Assert(f.vecEvalString(input, result), IsNil)
+	for i := 0; i < 1024; i++ {
+		if i%10 == 0 {
+			c.Assert(result.IsNull(i), IsTrue)
+			continue
+		}
+		c.Assert(len(result.GetBytes(i)), Equals, i%1024+1)
+	}
+
+	f, err = funcs[ast.UUID].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	input = chunk.New(nil, 1024, 1024)
+	input.SetNumVirtualRows(1024)
+	result = chunk.NewColumn(types.NewFieldType(mysql.TypeString), 1024)
+	c.Assert(f.vecEvalString(input, result), IsNil)
+	uuids := make(map[string]struct{}, 1024)
+	for i := 0; i < 1024; i++ {
+		id := result.GetString(i)
+		c.Assert(len(id), Equals, 36)
+		uuids[id] = struct{}{}
+	}
+	c.Assert(len(uuids), Equals, 1024)
+}
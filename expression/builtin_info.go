@@ -0,0 +1,329 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+var (
+	_ functionClass = &databaseFunctionClass{}
+	_ functionClass = &userFunctionClass{}
+	_ functionClass = &currentUserFunctionClass{}
+	_ functionClass = &connectionIDFunctionClass{}
+	_ functionClass = &lastInsertIDFunctionClass{}
+	_ functionClass = &rowCountFunctionClass{}
+	_ functionClass = &foundRowsFunctionClass{}
+	_ functionClass = &versionFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinDatabaseSig{}
+	_ builtinFunc = &builtinUserSig{}
+	_ builtinFunc = &builtinCurrentUserSig{}
+	_ builtinFunc = &builtinConnectionIDSig{}
+	_ builtinFunc = &builtinLastInsertIDSig{}
+	_ builtinFunc = &builtinLastInsertIDWithIDSig{}
+	_ builtinFunc = &builtinRowCountSig{}
+	_ builtinFunc = &builtinFoundRowsSig{}
+	_ builtinFunc = &builtinVersionSig{}
+)
+
+type databaseFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *databaseFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString)
+	bf.tp.Flen = 64
+	sig := &builtinDatabaseSig{bf}
+	return sig, nil
+}
+
+type builtinDatabaseSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDatabaseSig) Clone() builtinFunc {
+	newSig := &builtinDatabaseSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinDatabaseSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html
+func (b *builtinDatabaseSig) evalString(row chunk.Row) (string, bool, error) {
+	currentDB := b.ctx.GetSessionVars().CurrentDB
+	return currentDB, currentDB == "", nil
+}
+
+type userFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *userFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString)
+	bf.tp.Flen = 64
+	sig := &builtinUserSig{bf}
+	return sig, nil
+}
+
+type builtinUserSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUserSig) Clone() builtinFunc {
+	newSig := &builtinUserSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinUserSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_user
+func (b *builtinUserSig) evalString(row chunk.Row) (string, bool, error) {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return "", true, nil
+	}
+	return user.String(), false, nil
+}
+
+type currentUserFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *currentUserFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString)
+	bf.tp.Flen = 64
+	sig := &builtinCurrentUserSig{bf}
+	return sig, nil
+}
+
+type builtinCurrentUserSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCurrentUserSig) Clone() builtinFunc {
+	newSig := &builtinCurrentUserSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinCurrentUserSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_current-user
+func (b *builtinCurrentUserSig) evalString(row chunk.Row) (string, bool, error) {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return "", true, nil
+	}
+	return user.AuthIdentityString(), false, nil
+}
+
+type connectionIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *connectionIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt)
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinConnectionIDSig{bf}
+	return sig, nil
+}
+
+type builtinConnectionIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinConnectionIDSig) Clone() builtinFunc {
+	newSig := &builtinConnectionIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinConnectionIDSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_connection-id
+func (b *builtinConnectionIDSig) evalInt(_ chunk.Row) (int64, bool, error) {
+	return int64(b.ctx.GetSessionVars().ConnectionID), false, nil
+}
+
+type lastInsertIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *lastInsertIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+
+	var argsTp []types.EvalType
+	if len(args) == 1 {
+		if args[0], err = WrapWithCastAsInt(ctx, args[0]); err != nil {
+			return nil, err
+		}
+		argsTp = append(argsTp, types.ETInt)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, argsTp...)
+	bf.tp.Flag |= mysql.UnsignedFlag
+
+	if len(args) == 1 {
+		sig = &builtinLastInsertIDWithIDSig{bf}
+	} else {
+		sig = &builtinLastInsertIDSig{bf}
+	}
+	return sig, err
+}
+
+type builtinLastInsertIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLastInsertIDSig) Clone() builtinFunc {
+	newSig := &builtinLastInsertIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals LAST_INSERT_ID().
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_last-insert-id.
+func (b *builtinLastInsertIDSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	res = int64(b.ctx.GetSessionVars().StmtCtx.PrevLastInsertID)
+	return res, false, nil
+}
+
+type builtinLastInsertIDWithIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLastInsertIDWithIDSig) Clone() builtinFunc {
+	newSig := &builtinLastInsertIDWithIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals LAST_INSERT_ID(expr).
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_last-insert-id.
+func (b *builtinLastInsertIDWithIDSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	res, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+
+	b.ctx.GetSessionVars().SetLastInsertID(uint64(res))
+	return res, false, nil
+}
+
+type rowCountFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *rowCountFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt)
+	sig = &builtinRowCountSig{bf}
+	return sig, nil
+}
+
+type builtinRowCountSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRowCountSig) Clone() builtinFunc {
+	newSig := &builtinRowCountSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ROW_COUNT().
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_row-count.
+func (b *builtinRowCountSig) evalInt(_ chunk.Row) (res int64, isNull bool, err error) {
+	res = b.ctx.GetSessionVars().StmtCtx.PrevAffectedRows
+	return res, false, nil
+}
+
+type foundRowsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *foundRowsFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt)
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinFoundRowsSig{bf}
+	return sig, nil
+}
+
+type builtinFoundRowsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinFoundRowsSig) Clone() builtinFunc {
+	newSig := &builtinFoundRowsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinFoundRowsSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_found-rows
+func (b *builtinFoundRowsSig) evalInt(row chunk.Row) (int64, bool, error) {
+	return int64(b.ctx.GetSessionVars().LastFoundRows), false, nil
+}
+
+type versionFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *versionFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString)
+	bf.tp.Flen = 64
+	sig := &builtinVersionSig{bf}
+	return sig, nil
+}
+
+type builtinVersionSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVersionSig) Clone() builtinFunc {
+	newSig := &builtinVersionSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinVersionSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/information-functions.html#function_version
+func (b *builtinVersionSig) evalString(row chunk.Row) (string, bool, error) {
+	return mysql.ServerVersion, false, nil
+}
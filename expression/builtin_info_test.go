@@ -0,0 +1,151 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+func (s *testEvaluatorSuite) TestDatabase(c *C) {
+	fc := funcs[ast.Database]
+	ctx := mock.NewContext()
+	f, err := fc.getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.Kind(), Equals, types.KindNull)
+	ctx.GetSessionVars().CurrentDB = "test"
+	d, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, "test")
+	c.Assert(f.getRetTp().Flen, Equals, 64)
+
+	// Test case for schema().
+	fc = funcs[ast.Schema]
+	c.Assert(fc, NotNil)
+	f, err = fc.getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err = evalBuiltinFunc(f, chunk.MutRowFromDatums(types.MakeDatums()).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, "test")
+}
+
+func (s *testEvaluatorSuite) TestUser(c *C) {
+	ctx := mock.NewContext()
+	sessionVars := ctx.GetSessionVars()
+	sessionVars.User = &auth.UserIdentity{Username: "root", Hostname: "localhost", AuthUsername: "root", AuthHostname: "%"}
+
+	for _, name := range []string{ast.User, ast.SessionUser, ast.SystemUser} {
+		f, err := funcs[name].getFunction(ctx, nil)
+		c.Assert(err, IsNil)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d.GetString(), Equals, "root@localhost")
+	}
+
+	f, err := funcs[ast.CurrentUser].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, "root@%")
+
+	sessionVars.User = nil
+	d, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.Kind(), Equals, types.KindNull)
+}
+
+func (s *testEvaluatorSuite) TestConnectionID(c *C) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().ConnectionID = uint64(1)
+
+	f, err := funcs[ast.ConnectionID].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetUint64(), Equals, uint64(1))
+	c.Assert(mysql.HasUnsignedFlag(f.getRetTp().Flag), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestLastInsertID(c *C) {
+	ctx := mock.NewContext()
+	sc := ctx.GetSessionVars().StmtCtx
+	sc.PrevLastInsertID = 7
+
+	f, err := funcs[ast.LastInsertId].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetUint64(), Equals, uint64(7))
+
+	f, err = funcs[ast.LastInsertId].getFunction(ctx, s.datumsToConstants(types.MakeDatums(nil)))
+	c.Assert(err, IsNil)
+	d, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.Kind(), Equals, types.KindNull)
+	c.Assert(sc.LastInsertID, Equals, uint64(0))
+
+	f, err = funcs[ast.LastInsertId].getFunction(ctx, s.datumsToConstants(types.MakeDatums("12")))
+	c.Assert(err, IsNil)
+	d, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetUint64(), Equals, uint64(12))
+	c.Assert(sc.LastInsertID, Equals, uint64(12))
+	c.Assert(sc.PrevLastInsertID, Equals, uint64(7))
+
+	_, err = funcs[ast.LastInsertId].getFunction(ctx, s.datumsToConstants(types.MakeDatums(1, 2)))
+	c.Assert(err, NotNil)
+}
+
+func (s *testEvaluatorSuite) TestRowCountAndFoundRows(c *C) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().StmtCtx.PrevAffectedRows = -1
+	ctx.GetSessionVars().LastFoundRows = 3
+
+	f, err := funcs[ast.RowCount].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetInt64(), Equals, int64(-1))
+
+	f, err = funcs[ast.FoundRows].getFunction(ctx, nil)
+	c.Assert(err, IsNil)
+	d, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetUint64(), Equals, uint64(3))
+}
+
+func (s *testEvaluatorSuite) TestVersion(c *C) {
+	f, err := funcs[ast.Version].getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	d, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, mysql.ServerVersion)
+}
+
+func (s *testEvaluatorSuite) TestInfoFunctionsUnfoldable(c *C) {
+	for _, name := range []string{ast.Database, ast.User, ast.CurrentUser, ast.ConnectionID,
+		ast.LastInsertId, ast.RowCount, ast.FoundRows, ast.Version} {
+		f, err := newFunctionForTest(s.ctx, name)
+		c.Assert(err, IsNil)
+		_, isConst := FoldConstant(f).(*Constant)
+		c.Assert(isConst, IsFalse, Commentf("%s", name))
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+func (b *builtinDatabaseSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinDatabaseSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	currentDB := b.ctx.GetSessionVars().CurrentDB
+	result.ReserveString(n)
+	if currentDB == "" {
+		for i := 0; i < n; i++ {
+			result.AppendNull()
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			result.AppendString(currentDB)
+		}
+	}
+	return nil
+}
+
+func (b *builtinUserSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUserSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	user := b.ctx.GetSessionVars().User
+	result.ReserveString(n)
+	if user == nil {
+		for i := 0; i < n; i++ {
+			result.AppendNull()
+		}
+		return nil
+	}
+	res := user.String()
+	for i := 0; i < n; i++ {
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinCurrentUserSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCurrentUserSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	user := b.ctx.GetSessionVars().User
+	result.ReserveString(n)
+	if user == nil {
+		for i := 0; i < n; i++ {
+			result.AppendNull()
+		}
+		return nil
+	}
+	res := user.AuthIdentityString()
+	for i := 0; i < n; i++ {
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinConnectionIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinConnectionIDSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	connectionID := int64(b.ctx.GetSessionVars().ConnectionID)
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		i64s[i] = connectionID
+	}
+	return nil
+}
+
+func (b *builtinLastInsertIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLastInsertIDSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	res := int64(b.ctx.GetSessionVars().StmtCtx.PrevLastInsertID)
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		i64s[i] = res
+	}
+	return nil
+}
+
+func (b *builtinLastInsertIDWithIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLastInsertIDWithIDSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	i64s := result.Int64s()
+	for i := len(i64s) - 1; i >= 0; i-- {
+		if !result.IsNull(i) {
+			b.ctx.GetSessionVars().SetLastInsertID(uint64(i64s[i]))
+			break
+		}
+	}
+	return nil
+}
+
+func (b *builtinRowCountSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRowCountSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	res := b.ctx.GetSessionVars().StmtCtx.PrevAffectedRows
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		i64s[i] = res
+	}
+	return nil
+}
+
+func (b *builtinFoundRowsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinFoundRowsSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	foundRows := int64(b.ctx.GetSessionVars().LastFoundRows)
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		i64s[i] = foundRows
+	}
+	return nil
+}
+
+func (b *builtinVersionSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVersionSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		result.AppendString(mysql.ServerVersion)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/stringutil"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &likeFunctionClass{}
+	_ functionClass = &regexpFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinLikeSig{}
+	_ builtinFunc = &builtinRegexpSig{}
+	_ builtinFunc = &builtinRegexpUTF8Sig{}
+)
+
+// IsPatternMatchCaseInsensitive returns true if the pattern matching between the
+// first two args should ignore case, which is decided by their collations.
+// Binary strings are always compared byte by byte.
+func IsPatternMatchCaseInsensitive(args []Expression) bool {
+	expr, pattern := args[0].GetType(), args[1].GetType()
+	if types.IsBinaryStr(expr) || types.IsBinaryStr(pattern) {
+		return false
+	}
+	return types.IsCaseInsensitiveStr(expr) || types.IsCaseInsensitiveStr(pattern)
+}
+
+// compileLikePattern compiles the like pattern using escape as the escape character.
+func compileLikePattern(pattern string, escape byte, ignoreCase bool) (patChars, patTypes []byte) {
+	if ignoreCase {
+		pattern = strings.ToLower(pattern)
+		if escape >= 'A' && escape <= 'Z' {
+			escape += 'a' - 'A'
+		}
+	}
+	return stringutil.CompilePattern(pattern, escape)
+}
+
+// likeMatch matches str with the like pattern compiled by compileLikePattern.
+func likeMatch(str string, patChars, patTypes []byte, ignoreCase bool) bool {
+	if ignoreCase {
+		str = strings.ToLower(str)
+	}
+	return stringutil.DoMatch(str, patChars, patTypes)
+}
+
+type likeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *likeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETString, types.ETString, types.ETInt)
+	bf.tp.Flen = 1
+	sig := &builtinLikeSig{baseBuiltinFunc: bf}
+	sig.setPbCode(tipb.ScalarFuncSig_LikeSig)
+	return sig, nil
+}
+
+type builtinLikeSig struct {
+	baseBuiltinFunc
+	// patChars and patTypes are the compiled pattern, they are memorized if the pattern and the
+	// escape are constants, so the pattern is compiled only once.
+	patChars           []byte
+	patTypes           []byte
+	isMemorizedPattern bool
+}
+
+func (b *builtinLikeSig) Clone() builtinFunc {
+	newSig := &builtinLikeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// compilePattern returns the compiled pattern, it's memorized if the pattern and the escape are constants.
+func (b *builtinLikeSig) compilePattern(pattern string, escape byte, ignoreCase bool) (patChars, patTypes []byte) {
+	if b.isMemorizedPattern {
+		return b.patChars, b.patTypes
+	}
+	patChars, patTypes = compileLikePattern(pattern, escape, ignoreCase)
+	if b.args[1].ConstItem() && b.args[2].ConstItem() {
+		b.patChars, b.patTypes, b.isMemorizedPattern = patChars, patTypes, true
+	}
+	return patChars, patTypes
+}
+
+// evalInt evals a builtinLikeSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/string-comparison-functions.html#operator_like
+func (b *builtinLikeSig) evalInt(row chunk.Row) (int64, bool, error) {
+	valStr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	patternStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	escape, isNull, err := b.args[2].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	ignoreCase := IsPatternMatchCaseInsensitive(b.args)
+	patChars, patTypes := b.compilePattern(patternStr, byte(escape), ignoreCase)
+	if likeMatch(valStr, patChars, patTypes, ignoreCase) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type regexpFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETString, types.ETString)
+	bf.tp.Flen = 1
+	var sig builtinFunc
+	if types.IsBinaryStr(args[0].GetType()) || types.IsBinaryStr(args[1].GetType()) {
+		sig = &builtinRegexpSig{builtinRegexpSharedSig{baseBuiltinFunc: bf}}
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSig)
+	} else {
+		sig = &builtinRegexpUTF8Sig{builtinRegexpSharedSig{baseBuiltinFunc: bf}}
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpUTF8Sig)
+	}
+	return sig, nil
+}
+
+// compileRegexp compiles the pattern of REGEXP, the error is converted to ErrRegexp.
+func compileRegexp(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, ErrRegexp.GenWithStackByArgs(err.Error())
+	}
+	return re, nil
+}
+
+// builtinRegexpSharedSig is the shared part of the REGEXP functions.
+type builtinRegexpSharedSig struct {
+	baseBuiltinFunc
+	// memorizedRegexp and memorizedErr are the result of compiling a constant pattern,
+	// so the pattern is compiled only once.
+	memorizedRegexp *regexp.Regexp
+	memorizedErr    error
+}
+
+func (b *builtinRegexpSharedSig) isMemorizedRegexpInitialized() bool {
+	return !(b.memorizedRegexp == nil && b.memorizedErr == nil)
+}
+
+// compile compiles the pattern, the result is memorized if the pattern is a constant.
+func (b *builtinRegexpSharedSig) compile(pat string, ignoreCase bool) (*regexp.Regexp, error) {
+	if b.isMemorizedRegexpInitialized() {
+		return b.memorizedRegexp, b.memorizedErr
+	}
+	re, err := compileRegexp(pat, ignoreCase)
+	if b.args[1].ConstItem() {
+		b.memorizedRegexp, b.memorizedErr = re, err
+	}
+	return re, err
+}
+
+// evalRegexp evaluates the first two args of a REGEXP function by the given row.
+func (b *builtinRegexpSharedSig) evalRegexp(row chunk.Row, ignoreCase bool) (int64, bool, error) {
+	expr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	pat, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	re, err := b.compile(pat, ignoreCase)
+	if err != nil {
+		return 0, true, err
+	}
+	if re.MatchString(expr) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+// builtinRegexpSig is the REGEXP function for binary strings, which is always case sensitive.
+type builtinRegexpSig struct {
+	builtinRegexpSharedSig
+}
+
+func (b *builtinRegexpSig) Clone() builtinFunc {
+	newSig := &builtinRegexpSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals `expr REGEXP pat` or `expr RLIKE pat`.
+// See https://dev.mysql.com/doc/refman/5.7/en/regexp.html#operator_regexp
+func (b *builtinRegexpSig) evalInt(row chunk.Row) (int64, bool, error) {
+	return b.evalRegexp(row, false)
+}
+
+// builtinRegexpUTF8Sig is the REGEXP function for non-binary strings,
+// it ignores case if the collation is case-insensitive.
+type builtinRegexpUTF8Sig struct {
+	builtinRegexpSharedSig
+}
+
+func (b *builtinRegexpUTF8Sig) Clone() builtinFunc {
+	newSig := &builtinRegexpUTF8Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals `expr REGEXP pat` or `expr RLIKE pat`.
+// See https://dev.mysql.com/doc/refman/5.7/en/regexp.html#operator_regexp
+func (b *builtinRegexpUTF8Sig) evalInt(row chunk.Row) (int64, bool, error) {
+	return b.evalRegexp(row, IsPatternMatchCaseInsensitive(b.args))
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+func newStringConstantWithCollation(s string, collate string) *Constant {
+	tp := types.NewFieldType(mysql.TypeVarString)
+	tp.Charset, tp.Collate = charset.CharsetUTF8MB4, collate
+	tp.Flen = len(s)
+	if collate == charset.CollationBin {
+		tp.Charset = charset.CharsetBin
+	}
+	return &Constant{Value: types.NewStringDatum(s), RetType: tp}
+}
+
+func (s *testEvaluatorSuite) TestLike(c *C) {
+	tests := []struct {
+		input   string
+		pattern string
+		escape  byte
+		collate string
+		match   int64
+	}{
+		{"a", "", '\\', charset.CollationUTF8MB4, 0},
+		{"a", "a", '\\', charset.CollationUTF8MB4, 1},
+		{"a", "b", '\\', charset.CollationUTF8MB4, 0},
+		{"aA", "Aa", '\\', charset.CollationUTF8MB4, 0},
+		{"aA", "Aa", '\\', "utf8mb4_general_ci", 1},
+		{"aAb", "Aa%", '\\', "utf8mb4_general_ci", 1},
+		{"aAb", "aA_", '\\', charset.CollationBin, 1},
+		{"aAb", "Aa_", '\\', charset.CollationBin, 0},
+		{"abc", "a%", '\\', charset.CollationUTF8MB4, 1},
+		{"abc", "a_c", '\\', charset.CollationUTF8MB4, 1},
+		{"a%c", "a\\%c", '\\', charset.CollationUTF8MB4, 1},
+		{"abc", "a\\%c", '\\', charset.CollationUTF8MB4, 0},
+		{"a%c", "a|%c", '|', charset.CollationUTF8MB4, 1},
+		{"abc", "a|%c", '|', charset.CollationUTF8MB4, 0},
+		{"a%c", "aX%c", 'X', "utf8mb4_general_ci", 1},
+		{"abc", "aX%c", 'X', "utf8mb4_general_ci", 0},
+	}
+	for _, tt := range tests {
+		args := []Expression{
+			newStringConstantWithCollation(tt.input, tt.collate),
+			newStringConstantWithCollation(tt.pattern, tt.collate),
+			&Constant{Value: types.NewIntDatum(int64(tt.escape)), RetType: types.NewFieldType(mysql.TypeLonglong)},
+		}
+		fc := funcs[ast.Like]
+		f, err := fc.getFunction(s.ctx, args)
+		c.Assert(err, IsNil)
+		r, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(tt.match), Commentf("%v", tt))
+	}
+
+	f, err := funcs[ast.Like].getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{nil, "a%", int64('\\')}))
+	c.Assert(err, IsNil)
+	r, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(r.IsNull(), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestRegexp(c *C) {
+	tests := []struct {
+		input   string
+		pattern string
+		collate string
+		match   int64
+		err     bool
+	}{
+		{"a", "^$", charset.CollationUTF8MB4, 0, false},
+		{"a", "a", charset.CollationUTF8MB4, 1, false},
+		{"b", "a", charset.CollationUTF8MB4, 0, false},
+		{"aA", "aA", charset.CollationUTF8MB4, 1, false},
+		{"a", ".", charset.CollationUTF8MB4, 1, false},
+		{"ab", ".", charset.CollationUTF8MB4, 1, false},
+		{"b", "..", charset.CollationUTF8MB4, 0, false},
+		{"aab", ".ab", charset.CollationUTF8MB4, 1, false},
+		{"abcd", ".ab", charset.CollationUTF8MB4, 0, false},
+		{"AbC", "abc", charset.CollationUTF8MB4, 0, false},
+		{"AbC", "abc", "utf8mb4_general_ci", 1, false},
+		{"AbC", "abc", charset.CollationBin, 0, false},
+		{"你好", "你好", charset.CollationUTF8MB4, 1, false},
+		{"abc", "(", charset.CollationUTF8MB4, 0, true},
+	}
+	for _, tt := range tests {
+		args := []Expression{
+			newStringConstantWithCollation(tt.input, tt.collate),
+			newStringConstantWithCollation(tt.pattern, tt.collate),
+		}
+		f, err := funcs[ast.Regexp].getFunction(s.ctx, args)
+		c.Assert(err, IsNil)
+		if tt.collate == charset.CollationBin {
+			c.Assert(f.PbCode(), Equals, tipb.ScalarFuncSig_RegexpSig)
+		} else {
+			c.Assert(f.PbCode(), Equals, tipb.ScalarFuncSig_RegexpUTF8Sig)
+		}
+		r, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.err {
+			c.Assert(ErrRegexp.Equal(err), IsTrue)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(tt.match), Commentf("%v", tt))
+	}
+}
+
+func (s *testEvaluatorSuite) TestMemorizedPattern(c *C) {
+	strTp := types.NewFieldType(mysql.TypeVarString)
+	strTp.Charset, strTp.Collate = charset.CharsetUTF8MB4, charset.CollationUTF8MB4
+	strCol := func(idx int) *Column { return &Column{Index: idx, RetType: strTp} }
+	escape := &Constant{Value: types.NewIntDatum('\\'), RetType: types.NewFieldType(mysql.TypeLonglong)}
+	rows := []chunk.Row{
+		chunk.MutRowFromDatums(types.MakeDatums("abc", "abc")).ToRow(),
+		chunk.MutRowFromDatums(types.MakeDatums("abc", "abd")).ToRow(),
+	}
+
+	// The constant pattern is compiled only once.
+	f, err := funcs[ast.Like].getFunction(s.ctx, []Expression{strCol(0), newStringConstantWithCollation("a%", charset.CollationUTF8MB4), escape})
+	c.Assert(err, IsNil)
+	for _, row := range rows {
+		r, err := evalBuiltinFunc(f, row)
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(1))
+	}
+	c.Assert(f.(*builtinLikeSig).isMemorizedPattern, IsTrue)
+	c.Assert(f.Clone().(*builtinLikeSig).isMemorizedPattern, IsFalse)
+
+	f, err = funcs[ast.Like].getFunction(s.ctx, []Expression{strCol(0), strCol(1), escape})
+	c.Assert(err, IsNil)
+	for i, row := range rows {
+		r, err := evalBuiltinFunc(f, row)
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(1-i))
+	}
+	c.Assert(f.(*builtinLikeSig).isMemorizedPattern, IsFalse)
+
+	f, err = funcs[ast.Regexp].getFunction(s.ctx, []Expression{strCol(0), newStringConstantWithCollation("^a", charset.CollationUTF8MB4)})
+	c.Assert(err, IsNil)
+	for _, row := range rows {
+		r, err := evalBuiltinFunc(f, row)
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(1))
+	}
+	c.Assert(f.(*builtinRegexpUTF8Sig).isMemorizedRegexpInitialized(), IsTrue)
+
+	// The error of compiling a constant pattern is memorized as well.
+	f, err = funcs[ast.Regexp].getFunction(s.ctx, []Expression{strCol(0), newStringConstantWithCollation("(", charset.CollationUTF8MB4)})
+	c.Assert(err, IsNil)
+	for _, row := range rows {
+		_, err = evalBuiltinFunc(f, row)
+		c.Assert(ErrRegexp.Equal(err), IsTrue)
+	}
+	c.Assert(f.(*builtinRegexpUTF8Sig).isMemorizedRegexpInitialized(), IsTrue)
+
+	f, err = funcs[ast.Regexp].getFunction(s.ctx, []Expression{strCol(0), strCol(1)})
+	c.Assert(err, IsNil)
+	for i, row := range rows {
+		r, err := evalBuiltinFunc(f, row)
+		c.Assert(err, IsNil)
+		c.Assert(r, testutil.DatumEquals, types.NewDatum(1-i))
+	}
+	c.Assert(f.(*builtinRegexpUTF8Sig).isMemorizedRegexpInitialized(), IsFalse)
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+func (b *builtinLikeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLikeSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufVal, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufVal)
+	if err = b.args[0].VecEvalString(b.ctx, input, bufVal); err != nil {
+		return err
+	}
+	bufPattern, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufPattern)
+	if err = b.args[1].VecEvalString(b.ctx, input, bufPattern); err != nil {
+		return err
+	}
+	bufEscape, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufEscape)
+	if err = b.args[2].VecEvalInt(b.ctx, input, bufEscape); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufVal, bufPattern, bufEscape)
+	ignoreCase := IsPatternMatchCaseInsensitive(b.args)
+	escapes := bufEscape.Int64s()
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		patChars, patTypes := b.compilePattern(bufPattern.GetString(i), byte(escapes[i]), ignoreCase)
+		if likeMatch(bufVal.GetString(i), patChars, patTypes, ignoreCase) {
+			i64s[i] = 1
+		} else {
+			i64s[i] = 0
+		}
+	}
+	return nil
+}
+
+// vecEvalRegexp evaluates the first two args of a REGEXP function in a vectorized manner.
+// The compiled regexp is reused among consecutive rows sharing the same pattern.
+func (b *builtinRegexpSharedSig) vecEvalRegexp(input *chunk.Chunk, result *chunk.Column, ignoreCase bool) error {
+	n := input.NumRows()
+	bufExpr, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufExpr)
+	if err = b.args[0].VecEvalString(b.ctx, input, bufExpr); err != nil {
+		return err
+	}
+	bufPat, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufPat)
+	if err = b.args[1].VecEvalString(b.ctx, input, bufPat); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufExpr, bufPat)
+	var (
+		re      *regexp.Regexp
+		lastPat string
+	)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		pat := bufPat.GetString(i)
+		if re == nil || pat != lastPat {
+			if re, err = b.compile(pat, ignoreCase); err != nil {
+				return err
+			}
+			lastPat = pat
+		}
+		if re.MatchString(bufExpr.GetString(i)) {
+			i64s[i] = 1
+		} else {
+			i64s[i] = 0
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalRegexp(input, result, false)
+}
+
+func (b *builtinRegexpUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpUTF8Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalRegexp(input, result, IsPatternMatchCaseInsensitive(b.args))
+}
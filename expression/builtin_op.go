@@ -16,9 +16,11 @@ package expression
 import (
 	"fmt"
 	"math"
+	"math/bits"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
@@ -30,7 +32,11 @@ var (
 	_ functionClass = &logicOrFunctionClass{}
 	_ functionClass = &unaryMinusFunctionClass{}
 	_ functionClass = &isNullFunctionClass{}
+	_ functionClass = &isTrueOrFalseFunctionClass{}
 	_ functionClass = &unaryNotFunctionClass{}
+	_ functionClass = &bitOpFunctionClass{}
+	_ functionClass = &bitNegFunctionClass{}
+	_ functionClass = &bitCountFunctionClass{}
 )
 
 var (
@@ -40,8 +46,19 @@ var (
 	_ builtinFunc = &builtinIntIsNullSig{}
 	_ builtinFunc = &builtinRealIsNullSig{}
 	_ builtinFunc = &builtinStringIsNullSig{}
+	_ builtinFunc = &builtinIntIsTrueSig{}
+	_ builtinFunc = &builtinRealIsTrueSig{}
+	_ builtinFunc = &builtinIntIsFalseSig{}
+	_ builtinFunc = &builtinRealIsFalseSig{}
 	_ builtinFunc = &builtinUnaryNotRealSig{}
 	_ builtinFunc = &builtinUnaryNotIntSig{}
+	_ builtinFunc = &builtinBitAndSig{}
+	_ builtinFunc = &builtinBitOrSig{}
+	_ builtinFunc = &builtinBitXorSig{}
+	_ builtinFunc = &builtinLeftShiftSig{}
+	_ builtinFunc = &builtinRightShiftSig{}
+	_ builtinFunc = &builtinBitNegSig{}
+	_ builtinFunc = &builtinBitCountSig{}
 )
 
 type logicAndFunctionClass struct {
@@ -381,3 +398,356 @@ func (b *builtinStringIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
 	_, isNull, err := b.args[0].EvalString(b.ctx, row)
 	return evalIsNull(isNull, err)
 }
+
+type isTrueOrFalseFunctionClass struct {
+	baseFunctionClass
+	op opcode.Op
+}
+
+func (c *isTrueOrFalseFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+
+	argTp := args[0].GetType().EvalType()
+	if argTp == types.ETString {
+		argTp = types.ETReal
+	}
+
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, argTp)
+	bf.tp.Flen = 1
+
+	var sig builtinFunc
+	switch c.op {
+	case opcode.IsTruth:
+		switch argTp {
+		case types.ETReal:
+			sig = &builtinRealIsTrueSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_RealIsTrue)
+		case types.ETInt:
+			sig = &builtinIntIsTrueSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_IntIsTrue)
+		default:
+			return nil, errors.Errorf("unexpected types.EvalType %v", argTp)
+		}
+	case opcode.IsFalsity:
+		switch argTp {
+		case types.ETReal:
+			sig = &builtinRealIsFalseSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_RealIsFalse)
+		case types.ETInt:
+			sig = &builtinIntIsFalseSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_IntIsFalse)
+		default:
+			return nil, errors.Errorf("unexpected types.EvalType %v", argTp)
+		}
+	}
+	return sig, nil
+}
+
+// evalIsTrueOrFalse returns 1 if the value is not null and its truth equals to isTrue.
+// Unlike most builtin functions, IS TRUE and IS FALSE never return null.
+func evalIsTrueOrFalse(nonZero, isNull bool, err error, isTrue bool) (int64, bool, error) {
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull || nonZero != isTrue {
+		return 0, false, nil
+	}
+	return 1, false, nil
+}
+
+type builtinRealIsTrueSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRealIsTrueSig) Clone() builtinFunc {
+	newSig := &builtinRealIsTrueSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinRealIsTrueSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	return evalIsTrueOrFalse(val != 0, isNull, err, true)
+}
+
+type builtinIntIsTrueSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIntIsTrueSig) Clone() builtinFunc {
+	newSig := &builtinIntIsTrueSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinIntIsTrueSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	return evalIsTrueOrFalse(val != 0, isNull, err, true)
+}
+
+type builtinRealIsFalseSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRealIsFalseSig) Clone() builtinFunc {
+	newSig := &builtinRealIsFalseSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinRealIsFalseSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	return evalIsTrueOrFalse(val != 0, isNull, err, false)
+}
+
+type builtinIntIsFalseSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIntIsFalseSig) Clone() builtinFunc {
+	newSig := &builtinIntIsFalseSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinIntIsFalseSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	return evalIsTrueOrFalse(val != 0, isNull, err, false)
+}
+
+// wrapBitOpArgs casts the arguments of bit operators to integers, since the
+// operators always work on the 64-bit integer representation of their operands.
+func wrapBitOpArgs(ctx sessionctx.Context, args []Expression) ([]Expression, error) {
+	for i := range args {
+		var err error
+		if args[i], err = WrapWithCastAsInt(ctx, args[i]); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+type bitOpFunctionClass struct {
+	baseFunctionClass
+
+	op opcode.Op
+}
+
+func (c *bitOpFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	args, err := wrapBitOpArgs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	bf.tp.Flag |= mysql.UnsignedFlag
+	var sig builtinFunc
+	switch c.op {
+	case opcode.And:
+		sig = &builtinBitAndSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_BitAndSig)
+	case opcode.Or:
+		sig = &builtinBitOrSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_BitOrSig)
+	case opcode.Xor:
+		sig = &builtinBitXorSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_BitXorSig)
+	case opcode.LeftShift:
+		sig = &builtinLeftShiftSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeftShift)
+	case opcode.RightShift:
+		sig = &builtinRightShiftSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_RightShift)
+	default:
+		return nil, errors.Errorf("unexpected bit operator %v", c.op)
+	}
+	return sig, nil
+}
+
+// evalBitOpArgs evaluates both arguments of a binary bit operator, the result is
+// null if either of them is null.
+func evalBitOpArgs(b *baseBuiltinFunc, row chunk.Row) (arg0, arg1 int64, isNull bool, err error) {
+	arg0, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, 0, true, err
+	}
+	arg1, isNull, err = b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, 0, true, err
+	}
+	return arg0, arg1, false, nil
+}
+
+type builtinBitAndSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitAndSig) Clone() builtinFunc {
+	newSig := &builtinBitAndSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitAndSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, arg1, isNull, err := evalBitOpArgs(&b.baseBuiltinFunc, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return arg0 & arg1, false, nil
+}
+
+type builtinBitOrSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitOrSig) Clone() builtinFunc {
+	newSig := &builtinBitOrSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitOrSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, arg1, isNull, err := evalBitOpArgs(&b.baseBuiltinFunc, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return arg0 | arg1, false, nil
+}
+
+type builtinBitXorSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitXorSig) Clone() builtinFunc {
+	newSig := &builtinBitXorSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitXorSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, arg1, isNull, err := evalBitOpArgs(&b.baseBuiltinFunc, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return arg0 ^ arg1, false, nil
+}
+
+type builtinLeftShiftSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeftShiftSig) Clone() builtinFunc {
+	newSig := &builtinLeftShiftSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinLeftShiftSig.
+// Shifting by 64 or more bits produces 0, as in MySQL.
+func (b *builtinLeftShiftSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, arg1, isNull, err := evalBitOpArgs(&b.baseBuiltinFunc, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return int64(uint64(arg0) << uint64(arg1)), false, nil
+}
+
+type builtinRightShiftSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRightShiftSig) Clone() builtinFunc {
+	newSig := &builtinRightShiftSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinRightShiftSig.
+// The shift is logical, the operand is treated as an unsigned integer.
+func (b *builtinRightShiftSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, arg1, isNull, err := evalBitOpArgs(&b.baseBuiltinFunc, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return int64(uint64(arg0) >> uint64(arg1)), false, nil
+}
+
+type bitNegFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitNegFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	args, err := wrapBitOpArgs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt)
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinBitNegSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitNegSig)
+	return sig, nil
+}
+
+type builtinBitNegSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitNegSig) Clone() builtinFunc {
+	newSig := &builtinBitNegSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitNegSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return ^arg, false, nil
+}
+
+type bitCountFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitCountFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	args, err := wrapBitOpArgs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt)
+	bf.tp.Flen = 2
+	sig := &builtinBitCountSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitCount)
+	return sig, nil
+}
+
+type builtinBitCountSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitCountSig) Clone() builtinFunc {
+	newSig := &builtinBitCountSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals BIT_COUNT(N).
+// See https://dev.mysql.com/doc/refman/5.7/en/bit-functions.html#function_bit-count
+func (b *builtinBitCountSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return int64(bits.OnesCount64(uint64(arg))), false, nil
+}
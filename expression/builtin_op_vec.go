@@ -16,6 +16,7 @@ package expression
 import (
 	"fmt"
 	"math"
+	"math/bits"
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
@@ -265,3 +266,198 @@ func (b *builtinUnaryNotIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Col
 	}
 	return nil
 }
+
+// vecEvalIntIsTrueOrFalse evaluates IS TRUE or IS FALSE over an int arg in a vectorized manner.
+func vecEvalIntIsTrueOrFalse(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, isTrue bool) error {
+	numRows := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, numRows)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+
+	if err := b.args[0].VecEvalInt(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(numRows, false)
+	args := buf.Int64s()
+	i64s := result.Int64s()
+	for i := 0; i < numRows; i++ {
+		if !buf.IsNull(i) && (args[i] != 0) == isTrue {
+			i64s[i] = 1
+		} else {
+			i64s[i] = 0
+		}
+	}
+	return nil
+}
+
+// vecEvalRealIsTrueOrFalse evaluates IS TRUE or IS FALSE over a real arg in a vectorized manner.
+func vecEvalRealIsTrueOrFalse(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, isTrue bool) error {
+	numRows := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETReal, numRows)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+
+	if err := b.args[0].VecEvalReal(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(numRows, false)
+	args := buf.Float64s()
+	i64s := result.Int64s()
+	for i := 0; i < numRows; i++ {
+		if !buf.IsNull(i) && (args[i] != 0) == isTrue {
+			i64s[i] = 1
+		} else {
+			i64s[i] = 0
+		}
+	}
+	return nil
+}
+
+func (b *builtinIntIsTrueSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinIntIsTrueSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalIntIsTrueOrFalse(&b.baseBuiltinFunc, input, result, true)
+}
+
+func (b *builtinRealIsTrueSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRealIsTrueSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalRealIsTrueOrFalse(&b.baseBuiltinFunc, input, result, true)
+}
+
+func (b *builtinIntIsFalseSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinIntIsFalseSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalIntIsTrueOrFalse(&b.baseBuiltinFunc, input, result, false)
+}
+
+func (b *builtinRealIsFalseSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRealIsFalseSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalRealIsTrueOrFalse(&b.baseBuiltinFunc, input, result, false)
+}
+
+// vecEvalBitOp evaluates a binary bit operator, the result is null if either of
+// the arguments is null.
+func vecEvalBitOp(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, op func(arg0, arg1 int64) int64) error {
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[1].VecEvalInt(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.MergeNulls(buf)
+	i64s := result.Int64s()
+	arg1s := buf.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		i64s[i] = op(i64s[i], arg1s[i])
+	}
+	return nil
+}
+
+func (b *builtinBitAndSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinBitAndSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalBitOp(&b.baseBuiltinFunc, input, result, func(arg0, arg1 int64) int64 {
+		return arg0 & arg1
+	})
+}
+
+func (b *builtinBitOrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinBitOrSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalBitOp(&b.baseBuiltinFunc, input, result, func(arg0, arg1 int64) int64 {
+		return arg0 | arg1
+	})
+}
+
+func (b *builtinBitXorSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinBitXorSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalBitOp(&b.baseBuiltinFunc, input, result, func(arg0, arg1 int64) int64 {
+		return arg0 ^ arg1
+	})
+}
+
+func (b *builtinLeftShiftSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeftShiftSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalBitOp(&b.baseBuiltinFunc, input, result, func(arg0, arg1 int64) int64 {
+		return int64(uint64(arg0) << uint64(arg1))
+	})
+}
+
+func (b *builtinRightShiftSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRightShiftSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalBitOp(&b.baseBuiltinFunc, input, result, func(arg0, arg1 int64) int64 {
+		return int64(uint64(arg0) >> uint64(arg1))
+	})
+}
+
+func (b *builtinBitNegSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinBitNegSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	i64s := result.Int64s()
+	for i := range i64s {
+		i64s[i] = ^i64s[i]
+	}
+	return nil
+}
+
+func (b *builtinBitCountSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinBitCountSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	if err := b.args[0].VecEvalInt(b.ctx, input, result); err != nil {
+		return err
+	}
+	i64s := result.Int64s()
+	for i := range i64s {
+		if result.IsNull(i) {
+			continue
+		}
+		i64s[i] = int64(bits.OnesCount64(uint64(i64s[i])))
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ var (
 	_ functionClass = &setVarFunctionClass{}
 	_ functionClass = &getVarFunctionClass{}
 	_ functionClass = &valuesFunctionClass{}
+	_ functionClass = &groupingFunctionClass{}
 )
 
 var (
@@ -43,6 +44,7 @@ var (
 	_ builtinFunc = &builtinValuesIntSig{}
 	_ builtinFunc = &builtinValuesRealSig{}
 	_ builtinFunc = &builtinValuesStringSig{}
+	_ builtinFunc = &builtinGroupingSig{}
 )
 
 type inFunctionClass struct {
@@ -431,3 +433,56 @@ func (b *builtinValuesStringSig) evalString(_ chunk.Row) (string, bool, error) {
 
 	return row.GetString(b.offset), false, nil
 }
+
+type groupingFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *groupingFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	for i := range argTps {
+		argTps[i] = types.ETInt
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, argTps...)
+	bf.tp.Flen = len(args) - 1
+	sig := &builtinGroupingSig{bf}
+	return sig, nil
+}
+
+// builtinGroupingSig evaluates GROUPING() for GROUP BY ... WITH ROLLUP. The
+// planner rewrites GROUPING(a, b, ...) to grouping(gid, mask_a, mask_b, ...),
+// where gid is the grouping ID produced by the Expand operator and each mask is
+// the bit of the grouping ID which is set when that column is rolled up.
+// See https://dev.mysql.com/doc/refman/8.0/en/miscellaneous-functions.html#function_grouping
+type builtinGroupingSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGroupingSig) Clone() builtinFunc {
+	newSig := &builtinGroupingSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGroupingSig) evalInt(row chunk.Row) (int64, bool, error) {
+	groupingID, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	// The first argument of GROUPING() is the most significant bit of the result.
+	var res int64
+	for _, arg := range b.args[1:] {
+		mask, isNull, err := arg.EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return 0, isNull, err
+		}
+		res <<= 1
+		if groupingID&mask != 0 {
+			res |= 1
+		}
+	}
+	return res, false, nil
+}
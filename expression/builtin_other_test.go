@@ -194,3 +194,29 @@ func (s *testEvaluatorSuite) TestSetVarFromColumn(c *C) {
 	defer sessionVars.UsersLock.RUnlock()
 	c.Assert(sessionVars.Users["a"], Equals, "a")
 }
+
+func (s *testEvaluatorSuite) TestGrouping(c *C) {
+	fc := funcs[ast.Grouping]
+	testCases := []struct {
+		args []interface{}
+		res  interface{}
+	}{
+		{[]interface{}{0, 1}, int64(0)},
+		{[]interface{}{1, 1}, int64(1)},
+		{[]interface{}{1, 2}, int64(0)},
+		{[]interface{}{3, 2}, int64(1)},
+		{[]interface{}{1, 2, 1}, int64(1)},
+		{[]interface{}{3, 2, 1}, int64(3)},
+		{[]interface{}{3, 4, 2, 1}, int64(3)},
+		{[]interface{}{nil, 1}, nil},
+	}
+	for _, tc := range testCases {
+		fn, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(tc.args...)))
+		c.Assert(err, IsNil)
+		d, err := evalBuiltinFunc(fn, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d.GetValue(), Equals, tc.res, Commentf("%v", tc.args))
+	}
+	_, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(1)))
+	c.Assert(err, NotNil)
+}
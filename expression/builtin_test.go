@@ -14,6 +14,8 @@
 package expression
 
 import (
+	"math"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
@@ -66,6 +68,75 @@ func (s *testEvaluatorSuite) TestIsNullFunc(c *C) {
 	c.Assert(v.GetInt64(), Equals, int64(1))
 }
 
+func (s *testEvaluatorSuite) TestIsTrueOrFalse(c *C) {
+	tests := []struct {
+		arg     interface{}
+		isTrue  int64
+		isFalse int64
+	}{
+		{int64(-12), 1, 0},
+		{int64(0), 0, 1},
+		{int64(1), 1, 0},
+		{float64(0.1), 1, 0},
+		{float64(0), 0, 1},
+		{nil, 0, 0},
+	}
+
+	for _, t := range tests {
+		args := s.datumsToConstants(types.MakeDatums(t.arg))
+		f, err := funcs[ast.IsTruth].getFunction(s.ctx, args)
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v.GetInt64(), Equals, t.isTrue, Commentf("%v is true", t.arg))
+
+		f, err = funcs[ast.IsFalsity].getFunction(s.ctx, args)
+		c.Assert(err, IsNil)
+		v, err = evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v.GetInt64(), Equals, t.isFalse, Commentf("%v is false", t.arg))
+	}
+}
+
+func (s *testEvaluatorSuite) TestBitOperators(c *C) {
+	tests := []struct {
+		funcName string
+		args     []interface{}
+		expected interface{}
+	}{
+		{ast.And, []interface{}{int64(6), int64(3)}, uint64(2)},
+		{ast.And, []interface{}{int64(-1), float64(2.6)}, uint64(3)},
+		{ast.Or, []interface{}{int64(6), int64(3)}, uint64(7)},
+		{ast.Or, []interface{}{"12", int64(1)}, uint64(13)},
+		{ast.Xor, []interface{}{int64(6), int64(3)}, uint64(5)},
+		{ast.Xor, []interface{}{nil, int64(3)}, nil},
+		{ast.LeftShift, []interface{}{int64(1), int64(3)}, uint64(8)},
+		{ast.LeftShift, []interface{}{int64(1), int64(64)}, uint64(0)},
+		{ast.RightShift, []interface{}{int64(-1), int64(60)}, uint64(15)},
+		{ast.RightShift, []interface{}{int64(8), nil}, nil},
+		{ast.BitNeg, []interface{}{int64(0)}, uint64(math.MaxUint64)},
+		{ast.BitNeg, []interface{}{nil}, nil},
+		{ast.BitCount, []interface{}{int64(-1)}, int64(64)},
+		{ast.BitCount, []interface{}{int64(11)}, int64(3)},
+		{ast.BitCount, []interface{}{nil}, nil},
+	}
+	for _, t := range tests {
+		f, err := newFunctionForTest(s.ctx, t.funcName, s.datumsToConstants(types.MakeDatums(t.args...))...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue, Commentf("%s%v", t.funcName, t.args))
+			continue
+		}
+		if u, ok := t.expected.(uint64); ok {
+			c.Assert(uint64(d.GetInt64()), Equals, u, Commentf("%s%v", t.funcName, t.args))
+		} else {
+			c.Assert(d.GetInt64(), Equals, t.expected, Commentf("%s%v", t.funcName, t.args))
+		}
+	}
+}
+
 // newFunctionForTest creates a new ScalarFunction using funcName and arguments,
 // it is different from expression.NewFunction which needs an additional retType argument.
 func newFunctionForTest(ctx sessionctx.Context, funcName string, args ...Expression) (Expression, error) {
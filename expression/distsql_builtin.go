@@ -113,6 +113,46 @@ func getSignatureByPB(ctx sessionctx.Context, sigCode tipb.ScalarFuncSig, tp *ti
 		f = &builtinStringIsNullSig{base}
 	case tipb.ScalarFuncSig_IntIsNull:
 		f = &builtinIntIsNullSig{base}
+	case tipb.ScalarFuncSig_CastIntAsInt:
+		f = &builtinCastIntAsIntSig{base}
+	case tipb.ScalarFuncSig_CastIntAsReal:
+		f = &builtinCastIntAsRealSig{base}
+	case tipb.ScalarFuncSig_CastIntAsString:
+		f = &builtinCastIntAsStringSig{base}
+	case tipb.ScalarFuncSig_CastRealAsInt:
+		f = &builtinCastRealAsIntSig{base}
+	case tipb.ScalarFuncSig_CastRealAsReal:
+		f = &builtinCastRealAsRealSig{base}
+	case tipb.ScalarFuncSig_CastRealAsString:
+		f = &builtinCastRealAsStringSig{base}
+	case tipb.ScalarFuncSig_CastStringAsInt:
+		f = &builtinCastStringAsIntSig{base}
+	case tipb.ScalarFuncSig_CastStringAsReal:
+		f = &builtinCastStringAsRealSig{base}
+	case tipb.ScalarFuncSig_CastStringAsString:
+		f = &builtinCastStringAsStringSig{base}
+	case tipb.ScalarFuncSig_IntIsTrue:
+		f = &builtinIntIsTrueSig{base}
+	case tipb.ScalarFuncSig_RealIsTrue:
+		f = &builtinRealIsTrueSig{base}
+	case tipb.ScalarFuncSig_IntIsFalse:
+		f = &builtinIntIsFalseSig{base}
+	case tipb.ScalarFuncSig_RealIsFalse:
+		f = &builtinRealIsFalseSig{base}
+	case tipb.ScalarFuncSig_BitAndSig:
+		f = &builtinBitAndSig{base}
+	case tipb.ScalarFuncSig_BitOrSig:
+		f = &builtinBitOrSig{base}
+	case tipb.ScalarFuncSig_BitXorSig:
+		f = &builtinBitXorSig{base}
+	case tipb.ScalarFuncSig_LeftShift:
+		f = &builtinLeftShiftSig{base}
+	case tipb.ScalarFuncSig_RightShift:
+		f = &builtinRightShiftSig{base}
+	case tipb.ScalarFuncSig_BitNegSig:
+		f = &builtinBitNegSig{base}
+	case tipb.ScalarFuncSig_BitCount:
+		f = &builtinBitCountSig{base}
 	case tipb.ScalarFuncSig_GetVar:
 		f = &builtinGetVarSig{base}
 	case tipb.ScalarFuncSig_SetVar:
@@ -135,10 +175,52 @@ func getSignatureByPB(ctx sessionctx.Context, sigCode tipb.ScalarFuncSig, tp *ti
 		f = &builtinIfRealSig{base}
 	case tipb.ScalarFuncSig_IfString:
 		f = &builtinIfStringSig{base}
+	case tipb.ScalarFuncSig_CaseWhenInt:
+		f = &builtinCaseWhenIntSig{base}
+	case tipb.ScalarFuncSig_CaseWhenReal:
+		f = &builtinCaseWhenRealSig{base}
+	case tipb.ScalarFuncSig_CaseWhenString:
+		f = &builtinCaseWhenStringSig{base}
+	case tipb.ScalarFuncSig_CoalesceInt:
+		f = &builtinCoalesceIntSig{base}
+	case tipb.ScalarFuncSig_CoalesceReal:
+		f = &builtinCoalesceRealSig{base}
+	case tipb.ScalarFuncSig_CoalesceString:
+		f = &builtinCoalesceStringSig{base}
+	case tipb.ScalarFuncSig_GreatestInt:
+		f = &builtinGreatestIntSig{base}
+	case tipb.ScalarFuncSig_GreatestReal:
+		f = &builtinGreatestRealSig{base}
+	case tipb.ScalarFuncSig_GreatestString:
+		f = &builtinGreatestStringSig{base}
+	case tipb.ScalarFuncSig_LeastInt:
+		f = &builtinLeastIntSig{base}
+	case tipb.ScalarFuncSig_LeastReal:
+		f = &builtinLeastRealSig{base}
+	case tipb.ScalarFuncSig_LeastString:
+		f = &builtinLeastStringSig{base}
+	case tipb.ScalarFuncSig_IntervalInt:
+		f = &builtinIntervalIntSig{base}
+	case tipb.ScalarFuncSig_IntervalReal:
+		f = &builtinIntervalRealSig{base}
 	case tipb.ScalarFuncSig_Length:
 		f = &builtinLengthSig{base}
 	case tipb.ScalarFuncSig_Strcmp:
 		f = &builtinStrcmpSig{base}
+	case tipb.ScalarFuncSig_MD5:
+		f = &builtinMD5Sig{base}
+	case tipb.ScalarFuncSig_SHA1:
+		f = &builtinSHA1Sig{base}
+	case tipb.ScalarFuncSig_SHA2:
+		f = &builtinSHA2Sig{base}
+	case tipb.ScalarFuncSig_CRC32:
+		f = &builtinCRC32Sig{base}
+	case tipb.ScalarFuncSig_LikeSig:
+		f = &builtinLikeSig{baseBuiltinFunc: base}
+	case tipb.ScalarFuncSig_RegexpSig:
+		f = &builtinRegexpSig{builtinRegexpSharedSig{baseBuiltinFunc: base}}
+	case tipb.ScalarFuncSig_RegexpUTF8Sig:
+		f = &builtinRegexpUTF8Sig{builtinRegexpSharedSig{baseBuiltinFunc: base}}
 
 	default:
 		e = errFunctionNotExists.GenWithStackByArgs("FUNCTION", sigCode)
@@ -284,6 +284,8 @@ func (pc PbConverter) canFuncBePushed(sf *ScalarFunction) bool {
 		ast.LogicAnd,
 		ast.LogicOr,
 		ast.UnaryNot,
+		ast.IsTruth,
+		ast.IsFalsity,
 
 		// compare functions.
 		ast.LT,
@@ -294,6 +296,10 @@ func (pc PbConverter) canFuncBePushed(sf *ScalarFunction) bool {
 		ast.GT,
 		ast.In,
 		ast.IsNull,
+		ast.Coalesce,
+		ast.Greatest,
+		ast.Least,
+		ast.Interval,
 
 		// arithmetical functions.
 		ast.Plus,
@@ -301,13 +307,37 @@ func (pc PbConverter) canFuncBePushed(sf *ScalarFunction) bool {
 		ast.Mul,
 		ast.Div,
 
+		// bit functions.
+		ast.And,
+		ast.Or,
+		ast.Xor,
+		ast.LeftShift,
+		ast.RightShift,
+		ast.BitNeg,
+		ast.BitCount,
+
+		// cast functions.
+		ast.Cast,
+
 		// control flow functions.
 		ast.If,
 		ast.Ifnull,
+		ast.Case,
 
 		// string functions.
-		ast.Length:
+		ast.Length,
+
+		// encryption functions.
+		ast.MD5,
+		ast.SHA1,
+		ast.SHA,
+		ast.SHA2,
+		ast.CRC32:
 		return true
+	case ast.Like, ast.Regexp:
+		// The coprocessor only knows the binary collations of columns,
+		// so the case-insensitive pattern matching can't be pushed down.
+		return !IsPatternMatchCaseInsensitive(sf.GetArgs())
 	}
 	return false
 }
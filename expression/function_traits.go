@@ -22,6 +22,24 @@ var unFoldableFunctions = map[string]struct{}{
 	ast.RowFunc: {},
 	ast.SetVar:  {},
 	ast.GetVar:  {},
+
+	// information functions read the session state, which may be changed
+	// between the plan being built and executed.
+	ast.ConnectionID: {},
+	ast.CurrentUser:  {},
+	ast.Database:     {},
+	ast.Schema:       {},
+	ast.FoundRows:    {},
+	ast.LastInsertId: {},
+	ast.User:         {},
+	ast.SessionUser:  {},
+	ast.SystemUser:   {},
+	ast.Version:      {},
+	ast.RowCount:     {},
+
+	// non-deterministic functions return a different value on each call.
+	ast.RandomBytes: {},
+	ast.UUID:        {},
 }
 
 // inequalFunctions stores functions which cannot be propagated from column equal condition.
@@ -32,6 +50,9 @@ var inequalFunctions = map[string]struct{}{
 // mutableEffectsFunctions stores functions which are mutable or have side effects, specifically,
 // we cannot remove them from filter even if they have duplicates.
 var mutableEffectsFunctions = map[string]struct{}{
-	ast.SetVar: {},
-	ast.GetVar: {},
+	ast.SetVar:       {},
+	ast.GetVar:       {},
+	ast.LastInsertId: {},
+	ast.RandomBytes:  {},
+	ast.UUID:         {},
 }
@@ -103,6 +103,137 @@ func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vectorized()
 }
 `))
 
+var builtinCoalesceCompareVecTpl = template.Must(template.New("").Parse(`
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vecEval{{ .type.TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+{{- if .type.Fixed }}
+	result.Resize{{ .type.TypeNameInColumn }}(n, true)
+	rs := result.{{ .type.TypeNameInColumn }}s()
+	buf1, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+	for j := 0; j < len(b.args); j++ {
+		if err := b.args[j].VecEval{{ .type.TypeName }}(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args := buf1.{{ .type.TypeNameInColumn }}s()
+		for i := 0; i < n; i++ {
+			if !buf1.IsNull(i) && result.IsNull(i) {
+				rs[i] = args[i]
+				result.SetNull(i, false)
+			}
+		}
+	}
+{{- else }}
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[i].VecEval{{ .type.TypeName }}(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+	result.Reserve{{ .type.TypeNameInColumn }}(n)
+	for i := 0; i < n; i++ {
+		isNull := true
+		for j := 0; j < argLen; j++ {
+			if !bufs[j].IsNull(i) {
+				result.Append{{ .type.TypeNameInColumn }}(bufs[j].Get{{ .type.TypeNameInColumn }}(i))
+				isNull = false
+				break
+			}
+		}
+		if isNull {
+			result.AppendNull()
+		}
+	}
+{{- end }}
+	return nil
+}
+
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vectorized() bool {
+	return true
+}
+`))
+
+var builtinMinMaxCompareVecTpl = template.Must(template.New("").Parse(`
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vecEval{{ .type.TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+{{- if .type.Fixed }}
+	buf, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEval{{ .type.TypeName }}(b.ctx, input, result); err != nil {
+		return err
+	}
+	rs := result.{{ .type.TypeNameInColumn }}s()
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEval{{ .type.TypeName }}(b.ctx, input, buf); err != nil {
+			return err
+		}
+		result.MergeNulls(buf)
+		args := buf.{{ .type.TypeNameInColumn }}s()
+		for i := 0; i < n; i++ {
+			if result.IsNull(i) {
+				continue
+			}
+			if args[i] {{ .compare.Operator }} rs[i] {
+				rs[i] = args[i]
+			}
+		}
+	}
+{{- else }}
+	argLen := len(b.args)
+	bufs := make([]*chunk.Column, argLen)
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		if err := b.args[i].VecEval{{ .type.TypeName }}(b.ctx, input, buf); err != nil {
+			return err
+		}
+		bufs[i] = buf
+	}
+	result.Reserve{{ .type.TypeNameInColumn }}(n)
+	for i := 0; i < n; i++ {
+		isNull := false
+		var val string
+		for j := 0; j < argLen; j++ {
+			if bufs[j].IsNull(i) {
+				isNull = true
+				break
+			}
+			v := bufs[j].GetString(i)
+			if j == 0 || types.CompareString(v, val) {{ .compare.Operator }} 0 {
+				val = v
+			}
+		}
+		if isNull {
+			result.AppendNull()
+		} else {
+			result.AppendString(val)
+		}
+	}
+{{- end }}
+	return nil
+}
+
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vectorized() bool {
+	return true
+}
+`))
+
 const builtinCompareVecTestHeader = `import (
 	"testing"
 
@@ -120,6 +251,9 @@ var builtinCompareVecTestFuncHeader = template.Must(template.New("").Parse(`	ast
 var builtinCompareVecTestCase = template.Must(template.New("").Parse(`		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ET{{ .ETName }}, types.ET{{ .ETName }}}},
 `))
 
+var builtinCompareVecTestCaseOfRetType = template.Must(template.New("").Parse(`		{retEvalType: types.ET{{ .ETName }}, childrenTypes: []types.EvalType{types.ET{{ .ETName }}, types.ET{{ .ETName }}, types.ET{{ .ETName }}}},
+`))
+
 var builtinCompareVecTestFuncTail = `	},
 `
 
@@ -158,6 +292,15 @@ var comparesMap = []CompareContext{
 	{CompareName: "NE", Operator: "!="},
 }
 
+var coalesceMap = []CompareContext{
+	{CompareName: "Coalesce"},
+}
+
+var minMaxMap = []CompareContext{
+	{CompareName: "Greatest", Operator: ">"},
+	{CompareName: "Least", Operator: "<"},
+}
+
 var typesMap = []TypeContext{
 	TypeInt,
 	TypeReal,
@@ -184,6 +327,26 @@ func generateDotGo(fileName string, compares []CompareContext, types []TypeConte
 			}
 		}
 	}
+	for _, compareCtx := range coalesceMap {
+		for _, typeCtx := range types {
+			ctx["compare"] = compareCtx
+			ctx["type"] = typeCtx
+			err := builtinCoalesceCompareVecTpl.Execute(w, ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, compareCtx := range minMaxMap {
+		for _, typeCtx := range types {
+			ctx["compare"] = compareCtx
+			ctx["type"] = typeCtx
+			err := builtinMinMaxCompareVecTpl.Execute(w, ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	data, err := format.Source(w.Bytes())
 	if err != nil {
 		log.Println("[Warn]", fileName+": gofmt failed", err)
@@ -213,6 +376,19 @@ func generateTestDotGo(fileName string, compares []CompareContext, types []TypeC
 		}
 		w.WriteString(builtinCompareVecTestFuncTail)
 	}
+	for _, compareCtx := range append(coalesceMap, minMaxMap...) {
+		err := builtinCompareVecTestFuncHeader.Execute(w, compareCtx)
+		if err != nil {
+			return err
+		}
+		for _, typeCtx := range types {
+			err := builtinCompareVecTestCaseOfRetType.Execute(w, typeCtx)
+			if err != nil {
+				return err
+			}
+		}
+		w.WriteString(builtinCompareVecTestFuncTail)
+	}
 	w.WriteString(builtinCompareVecTestTail)
 
 	data, err := format.Source(w.Bytes())
@@ -49,6 +49,94 @@ import (
 )
 `
 
+var builtinCaseWhenVec = template.Must(template.New("builtinCaseWhenVec").Parse(`
+{{ range .Sigs }}{{ with .Arg0 }}
+func (b *builtinCaseWhen{{ .TypeName }}Sig) vecEval{{ .TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	args, l := b.getArgs(), len(b.getArgs())
+	bufs := make([]*chunk.Column, l)
+	var err error
+	for j := 0; j < l-1; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ETInt, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEvalInt(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	for j := 1; j < l; j += 2 {
+		if bufs[j], err = b.bufAllocator.get(types.ET{{ .ETName }}, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[j])
+		if err = args[j].VecEval{{ .TypeName }}(b.ctx, input, bufs[j]); err != nil {
+			return err
+		}
+	}
+	if l%2 == 1 { // else part
+		if bufs[l-1], err = b.bufAllocator.get(types.ET{{ .ETName }}, n); err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(bufs[l-1])
+		if err = args[l-1].VecEval{{ .TypeName }}(b.ctx, input, bufs[l-1]); err != nil {
+			return err
+		}
+	}
+
+{{- if .Fixed }}
+	result.Resize{{ .TypeNameInColumn }}(n, false)
+	resultSlice := result.{{ .TypeNameInColumn }}s()
+{{- else }}
+	result.Reserve{{ .TypeNameInColumn }}(n)
+{{- end }}
+ROW:
+	for i := 0; i < n; i++ {
+		for j := 0; j < l-1; j += 2 {
+			if bufs[j].IsNull(i) || bufs[j].GetInt64(i) == 0 {
+				continue
+			}
+{{- if .Fixed }}
+			resultSlice[i] = bufs[j+1].Get{{ .TypeNameInColumn }}(i)
+			result.SetNull(i, bufs[j+1].IsNull(i))
+{{- else }}
+			if bufs[j+1].IsNull(i) {
+				result.AppendNull()
+			} else {
+				result.Append{{ .TypeNameInColumn }}(bufs[j+1].Get{{ .TypeNameInColumn }}(i))
+			}
+{{- end }}
+			continue ROW
+		}
+		if l%2 == 1 { // else part
+{{- if .Fixed }}
+			resultSlice[i] = bufs[l-1].Get{{ .TypeNameInColumn }}(i)
+			result.SetNull(i, bufs[l-1].IsNull(i))
+{{- else }}
+			if bufs[l-1].IsNull(i) {
+				result.AppendNull()
+			} else {
+				result.Append{{ .TypeNameInColumn }}(bufs[l-1].Get{{ .TypeNameInColumn }}(i))
+			}
+{{- end }}
+		} else {
+{{- if .Fixed }}
+			result.SetNull(i, true)
+{{- else }}
+			result.AppendNull()
+{{- end }}
+		}
+	}
+	return nil
+}
+
+func (b *builtinCaseWhen{{ .TypeName }}Sig) vectorized() bool {
+	return true
+}
+{{ end }}{{/* with */}}
+{{ end }}{{/* range .Sigs */}}
+`))
+
 var builtinIfNullVec = template.Must(template.New("builtinIfNullVec").Parse(`
 {{ range .Sigs }}{{ with .Arg0 }}
 func (b *builtinIfNull{{ .TypeName }}Sig) vecEval{{ .TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
@@ -255,6 +343,16 @@ var vecBuiltin{{.Category}}Cases = map[string][]vecExprBenchCase{
 	{{ end }}
 	},
 {{ end }}
+
+{{ with index .Functions 2 }}
+	ast.Case: {
+	{{ range .Sigs }}
+		{retEvalType: types.ET{{ .Arg0.ETName }}, childrenTypes: []types.EvalType{types.ETInt, types.ET{{ .Arg0.ETName }}}, geners: []dataGenerator{defaultControlIntGener}},
+		{retEvalType: types.ET{{ .Arg0.ETName }}, childrenTypes: []types.EvalType{types.ETInt, types.ET{{ .Arg0.ETName }}, types.ET{{ .Arg0.ETName }}}, geners: []dataGenerator{defaultControlIntGener}},
+		{retEvalType: types.ET{{ .Arg0.ETName }}, childrenTypes: []types.EvalType{types.ETInt, types.ET{{ .Arg0.ETName }}, types.ETInt, types.ET{{ .Arg0.ETName }}, types.ET{{ .Arg0.ETName }}}, geners: []dataGenerator{defaultControlIntGener, nil, defaultControlIntGener}},
+	{{ end }}
+	},
+{{ end }}
 }
 
 func (s *testEvaluatorSuite) TestVectorizedBuiltin{{.Category}}EvalOneVecGenerated(c *C) {
@@ -301,6 +399,12 @@ var ifSigs = []sig{
 	{Arg0: TypeString},
 }
 
+var caseWhenSigs = []sig{
+	{Arg0: TypeInt},
+	{Arg0: TypeReal},
+	{Arg0: TypeString},
+}
+
 type sig struct {
 	Arg0 TypeContext
 }
@@ -319,6 +423,7 @@ var tmplVal = struct {
 	Functions: []function{
 		{FuncName: "Ifnull", Sigs: ifNullSigs, Tmpl: builtinIfNullVec},
 		{FuncName: "If", Sigs: ifSigs, Tmpl: builtinIfVec},
+		{FuncName: "Case", Sigs: caseWhenSigs, Tmpl: builtinCaseWhenVec},
 	},
 }
 
@@ -21,6 +21,8 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
@@ -200,8 +202,12 @@ func (s *testIntegrationSuite) TestForeignKeyVar(c *C) {
 
 	tk := testkit.NewTestKit(c, s.store)
 
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("1"))
+	tk.MustExec("SET FOREIGN_KEY_CHECKS=0")
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("0"))
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("1"))
 }
 
 func (s *testIntegrationSuite) TestIssue10804(c *C) {
@@ -298,3 +304,356 @@ func (s *testIntegrationSuite) TestDecodetoChunkReuse(c *C) {
 	c.Assert(count, Equals, 200)
 	rs.Close()
 }
+
+func (s *testIntegrationSuite) TestControlBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c varchar(10))")
+	tk.MustExec("insert into t values(1, 10, 'x'), (2, null, 'y'), (3, 30, null)")
+
+	tk.MustQuery("select case a when 1 then 'one' when 2 then 'two' else 'many' end from t").Check(testkit.Rows("one", "two", "many"))
+	tk.MustQuery("select case when b > 20 then 'big' when b > 5 then 'small' end from t").Check(testkit.Rows("small", "<nil>", "big"))
+	tk.MustQuery("select a from t where case when b is null then 1 else 0 end = 1").Check(testkit.Rows("2"))
+	tk.MustQuery("select coalesce(b, a), coalesce(c, 'z') from t").Check(testkit.Rows("10 x", "2 y", "30 z"))
+	tk.MustQuery("select nullif(a, 2), nullif(c, 'y') from t").Check(testkit.Rows("1 x", "<nil> <nil>", "3 <nil>"))
+	tk.MustQuery("select greatest(a, 2), least(a, 2) from t").Check(testkit.Rows("2 1", "2 2", "3 2"))
+	tk.MustQuery("select greatest(a, b), least(c, 'xx') from t").Check(testkit.Rows("10 x", "<nil> xx", "30 <nil>"))
+	tk.MustQuery("select interval(a, 1, 2, 3), interval(b, 5, 20) from t").Check(testkit.Rows("1 1", "2 -1", "3 2"))
+	tk.MustQuery("select a from t where coalesce(b, 0) > 15").Check(testkit.Rows("3"))
+}
+
+func (s *testIntegrationSuite) TestPatternMatchBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a varchar(20), b varchar(20) collate utf8mb4_general_ci, index idx_a(a), index idx_b(b))")
+	tk.MustExec("insert into t values('abc', 'abc'), ('ABC', 'ABC'), ('abd', 'abd'), ('a%c', 'a%c'), (null, null)")
+
+	tk.MustQuery("select a from t where a like 'ab%' order by a").Check(testkit.Rows("abc", "abd"))
+	tk.MustQuery("select a from t where a like 'ab_' order by a").Check(testkit.Rows("abc", "abd"))
+	tk.MustQuery("select a from t where a like 'abc' order by a").Check(testkit.Rows("abc"))
+	tk.MustQuery("select a from t where a not like 'ab%' order by a").Check(testkit.Rows("ABC", "a%c"))
+	tk.MustQuery("select a from t where a like 'a\\%%' order by a").Check(testkit.Rows("a%c"))
+	tk.MustQuery("select a from t where a like 'a|%%' escape '|' order by a").Check(testkit.Rows("a%c"))
+	tk.MustQuery("select b, b like 'ab%', b like 'abc' from t order by a").Check(testkit.Rows("<nil> <nil> <nil>", "ABC 1 1", "a%c 0 0", "abc 1 1", "abd 1 0"))
+	tk.MustQuery("select a like 'a%', a like null, null like 'a' from t where a = 'abc'").Check(testkit.Rows("1 <nil> <nil>"))
+
+	tk.MustQuery("select a from t where a regexp '^ab' order by a").Check(testkit.Rows("abc", "abd"))
+	tk.MustQuery("select a from t where a rlike 'c$' order by a").Check(testkit.Rows("a%c", "abc"))
+	tk.MustQuery("select a from t where a not regexp '^ab' order by a").Check(testkit.Rows("ABC", "a%c"))
+	tk.MustQuery("select b, b regexp '^ab' from t order by a").Check(testkit.Rows("<nil> <nil>", "ABC 1", "a%c 0", "abc 1", "abd 1"))
+	err := tk.QueryToErr("select a from t where a regexp '('")
+	c.Assert(err, ErrorMatches, ".*Got error .* from regexp")
+
+	tk.MustQuery("explain select a from t where a like 'ab%'").Check(testkit.Rows(
+		"IndexReader_9 250.00 root index:IndexScan_8",
+		"└─IndexScan_8 250.00 cop table:t, index:a, range:[\"ab\",\"ac\"), keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestPredicateBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b double)")
+	tk.MustExec("insert into t values(1, 0, 0), (2, 1, 0.5), (3, -2, -1), (4, null, null)")
+
+	tk.MustQuery("select id from t where a is true").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select id from t where a is not true").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where b is false").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where b is not false").Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select a is true, a is false, b is true, b is false from t where id = 4").Check(testkit.Rows("0 0 0 0"))
+	tk.MustQuery("select id from t where a between -2 and 0").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t where a not between -2 and 0").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where a is not null").Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select id from t where id in (4, 2, 9, 2)").Check(testkit.Rows("2", "4"))
+	tk.MustQuery("select id from t where id = 3 or id = 1 or id = 3").Check(testkit.Rows("1", "3"))
+
+	tk.MustQuery("explain select * from t where a is true").Check(testkit.Rows(
+		"TableReader_7 8000.00 root data:Selection_6",
+		"└─Selection_6 8000.00 cop istrue(test.t.a)",
+		"  └─TableScan_5 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select * from t where id in (5, 1, 3) or id = 2").Check(testkit.Rows(
+		"TableReader_6 4.00 root data:TableScan_5",
+		"└─TableScan_5 4.00 cop table:t, range:[1,1], [2,2], [3,3], [5,5], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestCastFuncs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b double, c varchar(20), d int unsigned)")
+	tk.MustExec("insert into t values(-1, 1.5, '12abc', 3), (null, null, null, null)")
+
+	tk.MustQuery("select cast(1.5 as signed), cast(-1 as unsigned), cast(123 as char(2)), cast(1.25 as char), convert('123', signed) + 1").Check(
+		testkit.Rows("2 18446744073709551615 12 1.25 124"))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1406 Data Too Long, field len 2, data len 3"))
+	tk.MustQuery("select cast('12abc' as signed), cast('1.5e1x' as double), cast('-5' as unsigned)").Check(
+		testkit.Rows("12 15 18446744073709551611"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect INTEGER value: '12abc'",
+		"Warning 1292 Truncated incorrect FLOAT value: '1.5e1x'",
+		"Warning 8031 Cast to unsigned converted negative integer to it's positive complement",
+	))
+	tk.MustQuery("select cast(a as unsigned), cast(b as signed), cast(c as signed), cast(c as double), cast(a as char), cast(b as char), cast(d as double) from t").Check(
+		testkit.Rows("18446744073709551615 2 12 12 -1 1.5 3", "<nil> <nil> <nil> <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select cast('abc' as binary(5)) = 'abc\\0\\0'").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where cast(c as signed) = 12").Check(testkit.Rows("-1"))
+
+	_, err := tk.Exec("select cast(a as decimal) from t")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*doesn't yet support 'CAST AS DECIMAL.*")
+	_, err = tk.Exec("select cast(c as datetime) from t")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*doesn't yet support 'CAST AS DATETIME.*")
+
+	tk.MustQuery("explain select * from t where cast(c as signed) = 12").Check(testkit.Rows(
+		"TableReader_7 8000.00 root data:Selection_6",
+		"└─Selection_6 8000.00 cop eq(cast(test.t.c), 12)",
+		"  └─TableScan_5 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestBitFuncs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b double, c varchar(20))")
+	tk.MustExec("insert into t values(1, 6, 1.5, '12'), (2, 3, null, '-1'), (3, null, 2.4, null)")
+
+	tk.MustQuery("select a & 4, a | 1, a ^ 5, ~a, a << 2, a >> 1, bit_count(a), b & 3, c | 0 from t").Check(testkit.Rows(
+		"4 7 3 18446744073709551609 24 3 2 2 12",
+		"0 3 6 18446744073709551612 12 1 2 <nil> 18446744073709551615",
+		"<nil> <nil> <nil> <nil> <nil> <nil> <nil> 2 <nil>",
+	))
+	tk.MustQuery("select 1 << 64, 1 << 63, -1 >> 63, bit_count(-1), bit_count(null), ~0").Check(
+		testkit.Rows("0 9223372036854775808 1 64 <nil> 18446744073709551615"))
+	tk.MustQuery("select id from t where a & 4 = 4").Check(testkit.Rows("1"))
+	tk.MustQuery("select id, bit_and(a), bit_or(a), bit_xor(a) from t group by id").Check(testkit.Rows(
+		"1 6 6 6",
+		"2 3 3 3",
+		"3 18446744073709551615 0 0",
+	))
+
+	tk.MustQuery("explain select * from t where a & 4 = 4").Check(testkit.Rows(
+		"TableReader_7 8000.00 root data:Selection_6",
+		"└─Selection_6 8000.00 cop eq(bitand(test.t.a, 4), 4)",
+		"  └─TableScan_5 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select bit_and(a), bit_or(a), bit_xor(a) from t").Check(testkit.Rows(
+		"HashAgg_9 1.00 root funcs:bit_and(Column#8)->Column#5, funcs:bit_or(Column#9)->Column#6, funcs:bit_xor(Column#10)->Column#7",
+		"└─TableReader_10 1.00 root data:HashAgg_5",
+		"  └─HashAgg_5 1.00 cop funcs:bit_and(test.t.a)->Column#8, funcs:bit_or(test.t.a)->Column#9, funcs:bit_xor(test.t.a)->Column#10",
+		"    └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestInfoBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustQuery("select database(), schema(), user(), current_user(), current_user").Check(testkit.Rows("<nil> <nil> <nil> <nil> <nil>"))
+	tk.MustExec("use test")
+	tk.Se.GetSessionVars().User = &auth.UserIdentity{Username: "root", Hostname: "127.0.0.1", AuthUsername: "root", AuthHostname: "%"}
+	tk.MustQuery("select database(), schema(), user(), session_user(), system_user(), current_user(), current_user").Check(
+		testkit.Rows("test test root@127.0.0.1 root@127.0.0.1 root@127.0.0.1 root@% root@%"))
+	tk.Se.GetSessionVars().ConnectionID = 42
+	tk.MustQuery("select connection_id(), version()").Check(testkit.Rows("42 " + mysql.ServerVersion))
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key auto_increment, a int)")
+	tk.MustExec("insert into t(a) values(1)")
+	tk.MustQuery("select row_count(), last_insert_id()").Check(testkit.Rows("1 1"))
+	tk.MustExec("insert into t(a) values(2), (3)")
+	tk.MustQuery("select row_count(), last_insert_id()").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select row_count()").Check(testkit.Rows("-1"))
+	tk.MustQuery("select last_insert_id(10), last_insert_id()").Check(testkit.Rows("10 2"))
+	tk.MustQuery("select last_insert_id()").Check(testkit.Rows("10"))
+	tk.MustQuery("select a from t where id > 1").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select found_rows()").Check(testkit.Rows("2"))
+
+	tk.MustQuery("explain select * from t where a = connection_id()").Check(testkit.Rows(
+		"Selection_5 8000.00 root eq(test.t.a, connection_id())",
+		"└─TableReader_7 10000.00 root data:TableScan_6",
+		"  └─TableScan_6 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestEncryptionBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b varchar(20))")
+	tk.MustExec("insert into t values(1, 'pingcap'), (2, null), (3, '')")
+
+	tk.MustQuery("select md5(b), sha1(b), sha(b), crc32(b) from t").Check(testkit.Rows(
+		"144653a024bda5fe1ace260bad67aff9 73bf9ef43a44f42e2ea2894d62f0917af149a006 73bf9ef43a44f42e2ea2894d62f0917af149a006 3095267826",
+		"<nil> <nil> <nil> <nil>",
+		"d41d8cd98f00b204e9800998ecf8427e da39a3ee5e6b4b0d3255bfef95601890afd80709 da39a3ee5e6b4b0d3255bfef95601890afd80709 0",
+	))
+	tk.MustQuery("select sha2(b, 224), sha2(b, 1) from t where a = 1").Check(testkit.Rows(
+		"cd036dc9bec69e758401379c522454ea24a6327b48724b449b40c6b7 <nil>"))
+	tk.MustQuery("select to_base64(b), from_base64(to_base64(b)), from_base64('@') from t").Check(testkit.Rows(
+		"cGluZ2NhcA== pingcap <nil>",
+		"<nil> <nil> <nil>",
+		"  <nil>",
+	))
+
+	tk.MustExec("set @@block_encryption_mode='aes-128-ecb'")
+	tk.MustQuery("select to_base64(aes_encrypt(b, '1234567890123456')), aes_decrypt(aes_encrypt(b, 'key'), 'key') from t").Check(testkit.Rows(
+		"aXv+mz+MLyid2CyIx7yVxA== pingcap",
+		"<nil> <nil>",
+		"BQGHoM3lqYcsurCRq3PlUw== ",
+	))
+	tk.MustQuery("select to_base64(aes_encrypt('pingcap', '1234567890123456', '1234567890123456'))").Check(testkit.Rows("aXv+mz+MLyid2CyIx7yVxA=="))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1618 <IV> option ignored"))
+	tk.MustExec("set @@block_encryption_mode='aes-128-cbc'")
+	tk.MustQuery("select to_base64(aes_encrypt('pingcap', '1234567890123456', '1234567890123456')), aes_decrypt(aes_encrypt('pingcap', 'k', '1234567890123456'), 'k', '1234567890123456')").Check(
+		testkit.Rows("LsoAd8XqV2igSFqlIndHkg== pingcap"))
+	tk.MustGetErrCode("select aes_encrypt('pingcap', '1234567890123456')", mysql.ErrWrongParamcountToNativeFct)
+	tk.MustExec("set @@block_encryption_mode='aes-128-ecb'")
+
+	tk.MustQuery("select random_bytes(16) is null, random_bytes(16) = random_bytes(16), uuid() = uuid()").Check(testkit.Rows("0 0 0"))
+	err := tk.QueryToErr("select random_bytes(1025)")
+	c.Assert(err, NotNil)
+
+	tk.MustQuery("explain select * from t where md5(b) = 'a' and crc32(b) > 1").Check(testkit.Rows(
+		"TableReader_7 8000.00 root data:Selection_6",
+		"└─Selection_6 8000.00 cop eq(md5(test.t.b), \"a\"), gt(crc32(test.t.b), 1)",
+		"  └─TableScan_5 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select * from t where b = uuid()").Check(testkit.Rows(
+		"Selection_5 8000.00 root eq(test.t.b, uuid())",
+		"└─TableReader_7 10000.00 root data:TableScan_6",
+		"  └─TableScan_6 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select * from t where b = md5('a')").Check(testkit.Rows(
+		"TableReader_7 10.00 root data:Selection_6",
+		"└─Selection_6 10.00 cop eq(test.t.b, \"0cc175b9c0f1b6a831c399e269772661\")",
+		"  └─TableScan_5 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestGroupConcatAndVarianceFuncs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b double, c varchar(20))")
+	tk.MustExec("insert into t values(1, 1, 1.5, 'x'), (2, null, null, 'y'), (3, 2, 4, null)")
+
+	tk.MustQuery("select id, group_concat(c), group_concat(c order by a desc separator '-'), var_pop(b), var_samp(b), stddev_pop(b), stddev_samp(b), approx_count_distinct(a, b) from t group by id").Check(testkit.Rows(
+		"1 x x 0 <nil> 0 <nil> 1",
+		"2 y y <nil> <nil> <nil> <nil> 0",
+		"3 <nil> <nil> 0 <nil> 0 <nil> 1",
+	))
+
+	tk.MustQuery("explain select var_pop(b), group_concat(c separator '-') from t").Check(testkit.Rows(
+		"HashAgg_9 1.00 root funcs:var_pop(Column#7, Column#8, Column#9)->Column#5, funcs:group_concat(Column#10, \"-\")->Column#6",
+		"└─TableReader_10 1.00 root data:HashAgg_5",
+		"  └─HashAgg_5 1.00 cop funcs:var_pop(test.t.b)->Column#7, funcs:group_concat(test.t.c, \"-\")->Column#8",
+		"    └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	// APPROX_COUNT_DISTINCT is not supported by the coprocessor.
+	tk.MustQuery("explain select approx_count_distinct(a) from t").Check(testkit.Rows(
+		"HashAgg_5 1.00 root funcs:approx_count_distinct(test.t.a)->Column#5",
+		"└─TableReader_9 10000.00 root data:TableScan_8",
+		"  └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	// GROUP_CONCAT with ORDER BY is not pushed down.
+	tk.MustQuery("explain select group_concat(c order by b desc) from t").Check(testkit.Rows(
+		"HashAgg_5 1.00 root funcs:group_concat(test.t.c, \",\" order by test.t.b desc)->Column#5",
+		"└─TableReader_9 10000.00 root data:TableScan_8",
+		"  └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestDistinctAggFuncs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b int, c varchar(20))")
+	tk.MustExec("insert into t values(1, 1, 2, 'x'), (2, null, 2, 'y')")
+	tk.MustQuery("select id, count(distinct a), count(distinct a, b), sum(distinct b), avg(distinct b), max(distinct a) from t group by id").Check(testkit.Rows(
+		"1 1 1 2 2 1",
+		"2 0 0 2 2 <nil>",
+	))
+
+	// Split the distinct aggregation into a two-phase group-by, it doesn't depend on tidb_opt_agg_push_down.
+	tk.MustQuery("explain select a, count(distinct b), sum(distinct b), max(a) from t group by a").Check(testkit.Rows(
+		"Projection_5 8000.00 root test.t.a, Column#5, Column#6, Column#7",
+		"└─HashAgg_8 8000.00 root group by:test.t.a, funcs:count(test.t.b)->Column#5, funcs:sum(test.t.b)->Column#6, funcs:max(test.t.a)->Column#7, funcs:firstrow(test.t.a)->test.t.a",
+		"  └─HashAgg_11 8000.00 root group by:test.t.a, test.t.b, funcs:firstrow(test.t.a)->test.t.a, funcs:firstrow(test.t.b)->test.t.b",
+		"    └─TableReader_16 10000.00 root data:TableScan_15",
+		"      └─TableScan_15 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select count(distinct a, b) from t").Check(testkit.Rows(
+		"HashAgg_8 1.00 root funcs:count(test.t.a, test.t.b)->Column#5",
+		"└─HashAgg_11 8000.00 root group by:test.t.a, test.t.b, funcs:firstrow(test.t.a)->test.t.a, funcs:firstrow(test.t.b)->test.t.b",
+		"  └─TableReader_16 10000.00 root data:TableScan_15",
+		"    └─TableScan_15 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	// The distinct aggregate functions with different arguments can't be split.
+	tk.MustQuery("explain select count(distinct a), count(distinct b) from t").Check(testkit.Rows(
+		"HashAgg_5 1.00 root funcs:count(distinct test.t.a)->Column#5, funcs:count(distinct test.t.b)->Column#6",
+		"└─TableReader_9 10000.00 root data:TableScan_8",
+		"  └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	// max(b) depends on rows dropped by grouping on the distinct argument.
+	tk.MustQuery("explain select count(distinct a), max(b) from t").Check(testkit.Rows(
+		"HashAgg_5 1.00 root funcs:count(distinct test.t.a)->Column#5, funcs:max(test.t.b)->Column#6",
+		"└─TableReader_9 10000.00 root data:TableScan_8",
+		"  └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+}
+
+func (s *testIntegrationSuite) TestRollup(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b int, c int)")
+
+	// The aggregate functions see the original group-by columns, while the
+	// group-by items are the copies which are rolled up to NULL.
+	tk.MustQuery("explain select a, b, sum(c), grouping(a), grouping(a, b) from t group by a, b with rollup").Check(testkit.Rows(
+		"Projection_6 8001.00 root Column#6, Column#7, Column#5, grouping(Column#8, 2)->Column#15, grouping(Column#8, 2, 1)->Column#16",
+		"└─HashAgg_9 8001.00 root group by:Column#6, Column#7, Column#8, funcs:sum(test.t.c)->Column#5, funcs:firstrow(Column#6)->Column#6, funcs:firstrow(Column#7)->Column#7, funcs:firstrow(Column#8)->Column#8",
+		"  └─Expand_10 30000.00 root level-projection:[test.t.c, test.t.a, test.t.b, 0->Column#8]; [test.t.c, test.t.a, <nil>->Column#7, 1->Column#8]; [test.t.c, <nil>->Column#6, <nil>->Column#7, 3->Column#8]",
+		"    └─TableReader_12 10000.00 root data:TableScan_11",
+		"      └─TableScan_11 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain select a, sum(a) from t group by a with rollup having grouping(a) = 0 order by grouping(a), a").Check(testkit.Rows(
+		"Projection_8 6400.80 root Column#6, Column#5",
+		"└─Projection_17 6400.80 root Column#5, Column#6, Column#7",
+		"  └─Sort_9 6400.80 root Column#11:asc, Column#6:asc",
+		"    └─Projection_18 6400.80 root Column#5, Column#6, Column#7, grouping(Column#7, 1)->Column#11",
+		"      └─Selection_10 6400.80 root eq(grouping(Column#7, 1), 0)",
+		"        └─HashAgg_13 8001.00 root group by:Column#6, Column#7, funcs:sum(test.t.a)->Column#5, funcs:firstrow(Column#6)->Column#6, funcs:firstrow(Column#7)->Column#7",
+		"          └─Expand_14 20000.00 root level-projection:[test.t.a, test.t.a, 0->Column#7]; [test.t.a, <nil>->Column#6, 1->Column#7]",
+		"            └─TableReader_16 10000.00 root data:TableScan_15",
+		"              └─TableScan_15 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+	// The aggregation on the primary key can't be eliminated.
+	tk.MustQuery("explain select id, count(*) from t group by id with rollup").Check(testkit.Rows(
+		"Projection_6 8001.00 root Column#6, Column#5",
+		"└─HashAgg_9 8001.00 root group by:Column#6, Column#7, funcs:count(1)->Column#5, funcs:firstrow(Column#6)->Column#6",
+		"  └─Expand_10 20000.00 root level-projection:[test.t.id, 0->Column#7]; [<nil>->Column#6, 1->Column#7]",
+		"    └─TableReader_12 10000.00 root data:TableScan_11",
+		"      └─TableScan_11 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo",
+	))
+
+	tk.MustGetErrCode("select grouping(a) from t group by a", mysql.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select a from t where grouping(a) = 1 group by a with rollup", mysql.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select sum(grouping(a)) from t group by a with rollup", mysql.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select grouping(b) from t group by a with rollup", mysql.ErrWrongArguments)
+	tk.MustGetErrCode("select a + 1 from t group by a + 1 with rollup", mysql.ErrNotSupportedYet)
+}
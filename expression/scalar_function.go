@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
@@ -94,6 +95,10 @@ func (sf *ScalarFunction) String() string {
 			buffer.WriteString(", ")
 		}
 	}
+	if sf.FuncName.L == ast.Cast {
+		buffer.WriteString(", ")
+		buffer.WriteString(sf.RetType.String())
+	}
 	buffer.WriteString(")")
 	return buffer.String()
 }
@@ -108,6 +113,9 @@ func newFunctionImpl(ctx sessionctx.Context, fold bool, funcName string, retType
 	if retType == nil {
 		return nil, errors.Errorf("RetType cannot be nil for ScalarFunction.")
 	}
+	if funcName == ast.Cast {
+		return BuildCastFunction(ctx, args[0], retType)
+	}
 	fc, ok := funcs[funcName]
 	if !ok {
 		return nil, errFunctionNotExists.GenWithStackByArgs("FUNCTION", funcName)
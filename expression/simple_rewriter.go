@@ -0,0 +1,49 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// ParseSimpleExprWithTableInfo parses simple expression string to Expression.
+// The expression string must only reference columns of tblInfo.
+func ParseSimpleExprWithTableInfo(ctx sessionctx.Context, exprStr string, tblInfo *model.TableInfo) (Expression, error) {
+	schema, names := TableInfo2SchemaAndNames(ctx, model.CIStr{}, tblInfo)
+	return ParseSimpleExprWithSchema(ctx, exprStr, schema, names)
+}
+
+// ParseSimpleExprWithSchema parses simple expression string to Expression.
+// The expression string must only reference columns in schema.
+func ParseSimpleExprWithSchema(ctx sessionctx.Context, exprStr string, schema *Schema, names types.NameSlice) (Expression, error) {
+	exprNode, err := parseExpr(exprStr)
+	if err != nil {
+		return nil, err
+	}
+	return RewriteAstExpr(ctx, exprNode, schema, names)
+}
+
+func parseExpr(exprStr string) (ast.ExprNode, error) {
+	stmts, _, err := parser.New().Parse("select "+exprStr, "", "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fields := stmts[0].(*ast.SelectStmt).Fields.Fields
+	return fields[0].Expr, nil
+}
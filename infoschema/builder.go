@@ -43,6 +43,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return tblIDs, nil
 	} else if diff.Type == model.ActionModifySchemaCharsetAndCollate {
 		return nil, b.applyModifySchemaCharsetAndCollate(m, diff)
+	} else if diff.Type == model.ActionRenameTables {
+		return b.applyRenameTables(m, diff)
 	}
 	roDBInfo, ok := b.is.SchemaByID(diff.SchemaID)
 	if !ok {
@@ -59,6 +61,10 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 	case model.ActionDropTable:
 		oldTableID = diff.TableID
 		tblIDs = append(tblIDs, oldTableID)
+	case model.ActionTruncateTable:
+		oldTableID = diff.OldTableID
+		newTableID = diff.TableID
+		tblIDs = append(tblIDs, oldTableID, newTableID)
 	default:
 		oldTableID = diff.TableID
 		newTableID = diff.TableID
@@ -70,10 +76,22 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 	// We try to reuse the old allocator, so the cached auto ID can be reused.
 	var alloc autoid.Allocator
 	if tableIDIsValid(oldTableID) {
-		if oldTableID == newTableID && diff.Type != model.ActionRebaseAutoID {
+		if oldTableID == newTableID && diff.Type != model.ActionRebaseAutoID &&
+			!(diff.Type == model.ActionRenameTable && diff.OldSchemaID != diff.SchemaID) {
 			alloc, _ = b.is.AllocByID(oldTableID)
 		}
-		b.applyDropTable(dbInfo, oldTableID)
+		if diff.Type == model.ActionRenameTable && diff.OldSchemaID != diff.SchemaID {
+			oldRoDBInfo, ok := b.is.SchemaByID(diff.OldSchemaID)
+			if !ok {
+				return nil, ErrDatabaseNotExists.GenWithStackByArgs(
+					fmt.Sprintf("(Schema ID %d)", diff.OldSchemaID),
+				)
+			}
+			oldDBInfo := b.copySchemaTables(oldRoDBInfo.Name.L)
+			b.applyDropTable(oldDBInfo, oldTableID)
+		} else {
+			b.applyDropTable(dbInfo, oldTableID)
+		}
 	}
 	if tableIDIsValid(newTableID) {
 		// All types except DropTableOrView.
@@ -85,6 +103,63 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 	return tblIDs, nil
 }
 
+// applyRenameTables drops all the renamed tables before creating any of them with
+// their new names, since the renames of one statement may swap table names.
+func (b *Builder) applyRenameTables(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	// A table may be renamed more than once, only its first old schema and
+	// its last new schema matter.
+	tblIDs := make([]int64, 0, len(diff.AffectedOpts))
+	oldSchemaIDs := make(map[int64]int64, len(diff.AffectedOpts))
+	newSchemaIDs := make(map[int64]int64, len(diff.AffectedOpts))
+	for _, opt := range diff.AffectedOpts {
+		if _, ok := oldSchemaIDs[opt.TableID]; !ok {
+			tblIDs = append(tblIDs, opt.TableID)
+			oldSchemaIDs[opt.TableID] = opt.OldSchemaID
+		}
+		newSchemaIDs[opt.TableID] = opt.SchemaID
+	}
+
+	dbInfos := make(map[int64]*model.DBInfo)
+	getDBInfo := func(schemaID int64) (*model.DBInfo, error) {
+		if dbInfo, ok := dbInfos[schemaID]; ok {
+			return dbInfo, nil
+		}
+		roDBInfo, ok := b.is.SchemaByID(schemaID)
+		if !ok {
+			return nil, ErrDatabaseNotExists.GenWithStackByArgs(
+				fmt.Sprintf("(Schema ID %d)", schemaID),
+			)
+		}
+		dbInfo := b.copySchemaTables(roDBInfo.Name.L)
+		dbInfos[schemaID] = dbInfo
+		return dbInfo, nil
+	}
+
+	allocs := make(map[int64]autoid.Allocator, len(tblIDs))
+	for _, id := range tblIDs {
+		oldDBInfo, err := getDBInfo(oldSchemaIDs[id])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		b.copySortedTables(id, id)
+		if oldSchemaIDs[id] == newSchemaIDs[id] {
+			allocs[id], _ = b.is.AllocByID(id)
+		}
+		b.applyDropTable(oldDBInfo, id)
+	}
+	for _, id := range tblIDs {
+		newDBInfo, err := getDBInfo(newSchemaIDs[id])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		err = b.applyCreateTable(m, newDBInfo, id, allocs[id], diff.Type)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return tblIDs, nil
+}
+
 // copySortedTables copies sortedTables for old table and new table for later modification.
 func (b *Builder) copySortedTables(oldTableID, newTableID int64) {
 	if tableIDIsValid(oldTableID) {
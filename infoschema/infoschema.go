@@ -16,10 +16,13 @@ package infoschema
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
@@ -49,6 +52,8 @@ var (
 	ErrTooManyKeyParts = terror.ClassSchema.New(mysql.ErrTooManyKeyParts, mysql.MySQLErrName[mysql.ErrTooManyKeyParts])
 	// ErrTableNotExists returns for table not exists.
 	ErrTableNotExists = terror.ClassSchema.New(mysql.ErrNoSuchTable, mysql.MySQLErrName[mysql.ErrNoSuchTable])
+	// ErrKeyNotExists returns for index not exists.
+	ErrKeyNotExists = terror.ClassSchema.New(mysql.ErrKeyDoesNotExist, mysql.MySQLErrName[mysql.ErrKeyDoesNotExist])
 )
 
 // InfoSchema is the interface used to retrieve the schema information.
@@ -69,6 +74,8 @@ type InfoSchema interface {
 	Clone() (result []*model.DBInfo)
 	SchemaTables(schema model.CIStr) []table.Table
 	SchemaMetaVersion() int64
+	// ReferredFKs returns the foreign keys which reference the table, including the self-referencing ones.
+	ReferredFKs(schema, table model.CIStr) []*ReferredFKInfo
 }
 
 // Information Schema Name.
@@ -116,6 +123,11 @@ type infoSchema struct {
 
 	// schemaMetaVersion is the version of schema, and we should check version when change schema.
 	schemaMetaVersion int64
+
+	// referredFKs maps "schema.table" in lower case to the foreign keys which reference the table,
+	// it's built on the first use since the InfoSchema of a schema version is read-only.
+	referredFKsOnce sync.Once
+	referredFKs     map[string][]*ReferredFKInfo
 }
 
 // MockInfoSchema only serves for test.
@@ -357,6 +369,73 @@ func HasAutoIncrementColumn(tbInfo *model.TableInfo) (bool, string) {
 	return false, ""
 }
 
+// ReferredFKInfo is a foreign key of ChildTable which references another table.
+type ReferredFKInfo struct {
+	ChildSchema model.CIStr
+	ChildTable  table.Table
+	FK          *model.FKInfo
+}
+
+// String returns the description of the foreign key used in error messages.
+func (r *ReferredFKInfo) String() string {
+	return FormatForeignKey(r.ChildSchema, r.ChildTable.Meta().Name, r.FK)
+}
+
+// FormatForeignKey formats the foreign key of a table like MySQL, e.g.
+// "`test`.`child`, CONSTRAINT `child_ibfk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`)".
+func FormatForeignKey(schema, tbl model.CIStr, fk *model.FKInfo) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES ", schema.O, tbl.O, fk.Name.O, joinQuotedNames(fk.Cols))
+	if fk.RefSchema.L != "" && fk.RefSchema.L != schema.L {
+		fmt.Fprintf(&buf, "`%s`.", fk.RefSchema.O)
+	}
+	fmt.Fprintf(&buf, "`%s` (%s)", fk.RefTable.O, joinQuotedNames(fk.RefCols))
+	if onDelete := ast.ReferOptionType(fk.OnDelete); onDelete != ast.ReferOptionNoOption {
+		fmt.Fprintf(&buf, " ON DELETE %s", onDelete)
+	}
+	if onUpdate := ast.ReferOptionType(fk.OnUpdate); onUpdate != ast.ReferOptionNoOption {
+		fmt.Fprintf(&buf, " ON UPDATE %s", onUpdate)
+	}
+	return buf.String()
+}
+
+func joinQuotedNames(names []model.CIStr) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "`"+name.O+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func referredFKKey(schema, tbl model.CIStr) string {
+	return schema.L + "." + tbl.L
+}
+
+func (is *infoSchema) ReferredFKs(schema, tbl model.CIStr) []*ReferredFKInfo {
+	is.referredFKsOnce.Do(is.buildReferredFKs)
+	return is.referredFKs[referredFKKey(schema, tbl)]
+}
+
+func (is *infoSchema) buildReferredFKs() {
+	is.referredFKs = make(map[string][]*ReferredFKInfo)
+	for _, db := range is.AllSchemas() {
+		for _, tblInfo := range db.Tables {
+			for _, fk := range tblInfo.ForeignKeys {
+				refSchema := fk.RefSchema
+				if refSchema.L == "" {
+					refSchema = db.Name
+				}
+				t, ok := is.TableByID(tblInfo.ID)
+				if !ok {
+					continue
+				}
+				key := referredFKKey(refSchema, fk.RefTable)
+				is.referredFKs[key] = append(is.referredFKs[key], &ReferredFKInfo{ChildSchema: db.Name, ChildTable: t, FK: fk})
+			}
+		}
+	}
+}
+
 // GetInfoSchema gets TxnCtx InfoSchema.
 func GetInfoSchema(ctx sessionctx.Context) InfoSchema {
 	sessVar := ctx.GetSessionVars()
@@ -17,19 +17,25 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/tikv/oracle"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/admin"
 	"github.com/pingcap/tidb/util/sqlexec"
 )
 
@@ -65,6 +71,10 @@ const (
 	tableOptimizerTrace                     = "OPTIMIZER_TRACE"
 	tableTableSpaces                        = "TABLESPACES"
 	tableCollationCharacterSetApplicability = "COLLATION_CHARACTER_SET_APPLICABILITY"
+	tableTiDBIndexes                        = "TIDB_INDEXES"
+	tableDDLJobs                            = "DDL_JOBS"
+	tableProcesslist                        = "PROCESSLIST"
+	tableApplicableRoles                    = "APPLICABLE_ROLES"
 )
 
 var tableIDMap = map[string]int64{
@@ -99,6 +109,10 @@ var tableIDMap = map[string]int64{
 	tableOptimizerTrace:                     autoid.InformationSchemaDBID + 30,
 	tableTableSpaces:                        autoid.InformationSchemaDBID + 31,
 	tableCollationCharacterSetApplicability: autoid.InformationSchemaDBID + 32,
+	tableTiDBIndexes:                        autoid.InformationSchemaDBID + 33,
+	tableDDLJobs:                            autoid.InformationSchemaDBID + 34,
+	tableProcesslist:                        autoid.InformationSchemaDBID + 35,
+	tableApplicableRoles:                    autoid.InformationSchemaDBID + 36,
 }
 
 type columnInfo struct {
@@ -533,7 +547,60 @@ var tableCollationCharacterSetApplicabilityCols = []columnInfo{
 	{"CHARACTER_SET_NAME", mysql.TypeVarchar, 32, mysql.NotNullFlag, nil, nil},
 }
 
-func dataForCharacterSets() (records [][]types.Datum) {
+var tableTiDBIndexesCols = []columnInfo{
+	{"TABLE_SCHEMA", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"TABLE_NAME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"NON_UNIQUE", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"KEY_NAME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"SEQ_IN_INDEX", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"COLUMN_NAME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"SUB_PART", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"INDEX_COMMENT", mysql.TypeVarchar, 2048, 0, nil, nil},
+	{"INDEX_ID", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"CARDINALITY", mysql.TypeLonglong, 21, 0, nil, nil},
+}
+
+var tableDDLJobsCols = []columnInfo{
+	{"JOB_ID", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"DB_NAME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"TABLE_NAME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"JOB_TYPE", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"SCHEMA_STATE", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"SCHEMA_ID", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"TABLE_ID", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"ROW_COUNT", mysql.TypeLonglong, 21, 0, nil, nil},
+	{"START_TIME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"END_TIME", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"STATE", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"QUERY", mysql.TypeVarchar, 64, 0, nil, nil},
+}
+
+var tableProcesslistCols = []columnInfo{
+	{"ID", mysql.TypeLonglong, 21, mysql.NotNullFlag | mysql.UnsignedFlag, 0, nil},
+	{"USER", mysql.TypeVarchar, 16, mysql.NotNullFlag, "", nil},
+	{"HOST", mysql.TypeVarchar, 64, mysql.NotNullFlag, "", nil},
+	{"DB", mysql.TypeVarchar, 64, 0, nil, nil},
+	{"COMMAND", mysql.TypeVarchar, 16, mysql.NotNullFlag, "", nil},
+	{"TIME", mysql.TypeLong, 7, mysql.NotNullFlag, 0, nil},
+	{"STATE", mysql.TypeVarchar, 16, 0, nil, nil},
+	{"INFO", mysql.TypeString, 512, 0, nil, nil},
+	{"TxnStart", mysql.TypeVarchar, 64, mysql.NotNullFlag, "", nil},
+}
+
+var tableApplicableRolesCols = []columnInfo{
+	{"USER", mysql.TypeVarchar, 32, mysql.NotNullFlag, "", nil},
+	{"HOST", mysql.TypeVarchar, 60, mysql.NotNullFlag, "", nil},
+	{"GRANTEE", mysql.TypeVarchar, 32, mysql.NotNullFlag, "", nil},
+	{"GRANTEE_HOST", mysql.TypeVarchar, 60, mysql.NotNullFlag, "", nil},
+	{"ROLE_NAME", mysql.TypeVarchar, 32, mysql.NotNullFlag, "", nil},
+	{"ROLE_HOST", mysql.TypeVarchar, 60, mysql.NotNullFlag, "", nil},
+	{"IS_GRANTABLE", mysql.TypeVarchar, 3, mysql.NotNullFlag, "", nil},
+	{"IS_DEFAULT", mysql.TypeVarchar, 3, 0, nil, nil},
+	{"IS_MANDATORY", mysql.TypeVarchar, 3, mysql.NotNullFlag, "", nil},
+}
+
+// DataForCharacterSets returns the rows of information_schema.character_sets.
+func DataForCharacterSets() (records [][]types.Datum) {
 
 	charsets := charset.GetSupportedCharsets()
 
@@ -549,7 +616,8 @@ func dataForCharacterSets() (records [][]types.Datum) {
 
 }
 
-func dataForCollations() (records [][]types.Datum) {
+// DataForCollations returns the rows of information_schema.collations.
+func DataForCollations() (records [][]types.Datum) {
 
 	collations := charset.GetSupportedCollations()
 
@@ -601,10 +669,15 @@ func dataForSessionVar(ctx sessionctx.Context) (records [][]types.Datum, err err
 }
 
 func dataForUserPrivileges(ctx sessionctx.Context) [][]types.Datum {
-	return [][]types.Datum{}
+	pm := privilege.GetPrivilegeManager(ctx)
+	if pm == nil {
+		return [][]types.Datum{}
+	}
+	return pm.UserPrivilegesTable()
 }
 
-func dataForEngines() (records [][]types.Datum) {
+// DataForEngines returns the rows of information_schema.engines.
+func DataForEngines() (records [][]types.Datum) {
 	records = append(records,
 		types.MakeDatums(
 			"InnoDB",  // Engine
@@ -704,24 +777,31 @@ func getRowCountAllTable(ctx sessionctx.Context) (map[int64]uint64, error) {
 type tableHistID struct {
 	tableID int64
 	histID  int64
+	isIndex bool
 }
 
-func getColLengthAllTables(ctx sessionctx.Context) (map[tableHistID]uint64, error) {
-	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL("select table_id, hist_id, tot_col_size from mysql.stats_histograms where is_index = 0")
+// getHistStatsAllTables returns the total column size of every column histogram
+// and the distinct count of every column and index histogram.
+func getHistStatsAllTables(ctx sessionctx.Context) (map[tableHistID]uint64, map[tableHistID]uint64, error) {
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL("select table_id, is_index, hist_id, tot_col_size, distinct_count from mysql.stats_histograms")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	colLengthMap := make(map[tableHistID]uint64, len(rows))
+	ndvMap := make(map[tableHistID]uint64, len(rows))
 	for _, row := range rows {
-		tableID := row.GetInt64(0)
-		histID := row.GetInt64(1)
-		totalSize := row.GetInt64(2)
+		id := tableHistID{tableID: row.GetInt64(0), isIndex: row.GetInt64(1) == 1, histID: row.GetInt64(2)}
+		ndvMap[id] = uint64(row.GetInt64(4))
+		if id.isIndex {
+			continue
+		}
+		totalSize := row.GetInt64(3)
 		if totalSize < 0 {
 			totalSize = 0
 		}
-		colLengthMap[tableHistID{tableID: tableID, histID: histID}] = uint64(totalSize)
+		colLengthMap[id] = uint64(totalSize)
 	}
-	return colLengthMap, nil
+	return colLengthMap, ndvMap, nil
 }
 
 func getDataAndIndexLength(info *model.TableInfo, physicalID int64, rowCount uint64, columnLengthMap map[tableHistID]uint64) (uint64, uint64) {
@@ -763,6 +843,7 @@ type statsCache struct {
 	modifyTime time.Time
 	tableRows  map[int64]uint64
 	colLength  map[tableHistID]uint64
+	ndv        map[tableHistID]uint64
 }
 
 var tableStatsCache = &statsCache{}
@@ -776,12 +857,12 @@ func (c *statsCache) setLoading(loading bool) {
 	c.mu.Unlock()
 }
 
-func (c *statsCache) get(ctx sessionctx.Context) (map[int64]uint64, map[tableHistID]uint64, error) {
+func (c *statsCache) get(ctx sessionctx.Context) (map[int64]uint64, map[tableHistID]uint64, map[tableHistID]uint64, error) {
 	c.mu.Lock()
 	if time.Since(c.modifyTime) < TableStatsCacheExpiry || c.loading {
-		tableRows, colLength := c.tableRows, c.colLength
+		tableRows, colLength, ndv := c.tableRows, c.colLength, c.ndv
 		c.mu.Unlock()
-		return tableRows, colLength, nil
+		return tableRows, colLength, ndv, nil
 	}
 	c.loading = true
 	c.mu.Unlock()
@@ -789,21 +870,22 @@ func (c *statsCache) get(ctx sessionctx.Context) (map[int64]uint64, map[tableHis
 	tableRows, err := getRowCountAllTable(ctx)
 	if err != nil {
 		c.setLoading(false)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	colLength, err := getColLengthAllTables(ctx)
+	colLength, ndv, err := getHistStatsAllTables(ctx)
 	if err != nil {
 		c.setLoading(false)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	c.mu.Lock()
 	c.loading = false
 	c.tableRows = tableRows
 	c.colLength = colLength
+	c.ndv = ndv
 	c.modifyTime = time.Now()
 	c.mu.Unlock()
-	return tableRows, colLength, nil
+	return tableRows, colLength, ndv, nil
 }
 
 func getAutoIncrementID(ctx sessionctx.Context, schema *model.DBInfo, tblInfo *model.TableInfo) (int64, error) {
@@ -815,8 +897,9 @@ func getAutoIncrementID(ctx sessionctx.Context, schema *model.DBInfo, tblInfo *m
 	return tbl.Allocator(ctx).Base() + 1, nil
 }
 
-func dataForTables(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.Datum, error) {
-	tableRowsMap, colLengthMap, err := tableStatsCache.get(ctx)
+// DataForTables returns the rows of information_schema.tables for the given schemas.
+func DataForTables(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.Datum, error) {
+	tableRowsMap, colLengthMap, _, err := tableStatsCache.get(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -830,6 +913,9 @@ func dataForTables(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.D
 			}
 
 			createOptions := ""
+			if table.GetPartitionInfo() != nil {
+				createOptions = "partitioned"
+			}
 
 			var autoIncID interface{}
 			hasAutoIncID, _ := HasAutoIncrementColumn(table)
@@ -841,8 +927,17 @@ func dataForTables(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.D
 			}
 
 			var rowCount, dataLength, indexLength uint64
-			rowCount = tableRowsMap[table.ID]
-			dataLength, indexLength = getDataAndIndexLength(table, table.ID, rowCount, colLengthMap)
+			if table.GetPartitionInfo() == nil {
+				rowCount = tableRowsMap[table.ID]
+				dataLength, indexLength = getDataAndIndexLength(table, table.ID, rowCount, colLengthMap)
+			} else {
+				for _, def := range table.GetPartitionInfo().Definitions {
+					rowCount += tableRowsMap[def.ID]
+					parDataLen, parIndexLen := getDataAndIndexLength(table, def.ID, tableRowsMap[def.ID], colLengthMap)
+					dataLength += parDataLen
+					indexLength += parIndexLen
+				}
+			}
 			avgRowLength := uint64(0)
 			if rowCount != 0 {
 				avgRowLength = dataLength / rowCount
@@ -880,6 +975,112 @@ func dataForTables(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.D
 	return rows, nil
 }
 
+func dataForPartitions(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.Datum, error) {
+	tableRowsMap, colLengthMap, _, err := tableStatsCache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			pi := table.GetPartitionInfo()
+			if pi == nil {
+				rowCount := tableRowsMap[table.ID]
+				dataLength, indexLength := getDataAndIndexLength(table, table.ID, rowCount, colLengthMap)
+				avgRowLength := uint64(0)
+				if rowCount != 0 {
+					avgRowLength = dataLength / rowCount
+				}
+				record := types.MakeDatums(
+					catalogVal,    // TABLE_CATALOG
+					schema.Name.O, // TABLE_SCHEMA
+					table.Name.O,  // TABLE_NAME
+					nil,           // PARTITION_NAME
+					nil,           // SUBPARTITION_NAME
+					nil,           // PARTITION_ORDINAL_POSITION
+					nil,           // SUBPARTITION_ORDINAL_POSITION
+					nil,           // PARTITION_METHOD
+					nil,           // SUBPARTITION_METHOD
+					nil,           // PARTITION_EXPRESSION
+					nil,           // SUBPARTITION_EXPRESSION
+					nil,           // PARTITION_DESCRIPTION
+					rowCount,      // TABLE_ROWS
+					avgRowLength,  // AVG_ROW_LENGTH
+					dataLength,    // DATA_LENGTH
+					uint64(0),     // MAX_DATA_LENGTH
+					indexLength,   // INDEX_LENGTH
+					uint64(0),     // DATA_FREE
+					nil,           // CREATE_TIME
+					nil,           // UPDATE_TIME
+					nil,           // CHECK_TIME
+					nil,           // CHECKSUM
+					"",            // PARTITION_COMMENT
+					nil,           // NODEGROUP
+					nil,           // TABLESPACE_NAME
+				)
+				rows = append(rows, record)
+				continue
+			}
+			partitionExpr := pi.Expr
+			if len(pi.Columns) > 0 {
+				names := make([]string, 0, len(pi.Columns))
+				for _, col := range pi.Columns {
+					names = append(names, "`"+col.O+"`")
+				}
+				partitionExpr = strings.Join(names, ",")
+			}
+			method := pi.Type.String()
+			if len(pi.Columns) > 0 {
+				method += " COLUMNS"
+			}
+			for i, def := range pi.Definitions {
+				rowCount := tableRowsMap[def.ID]
+				dataLength, indexLength := getDataAndIndexLength(table, def.ID, rowCount, colLengthMap)
+				avgRowLength := uint64(0)
+				if rowCount != 0 {
+					avgRowLength = dataLength / rowCount
+				}
+				var description interface{}
+				switch pi.Type {
+				case model.PartitionTypeRange:
+					description = strings.Join(def.LessThan, ",")
+				case model.PartitionTypeList:
+					description = strings.Join(def.InValues, ",")
+				}
+				record := types.MakeDatums(
+					catalogVal,    // TABLE_CATALOG
+					schema.Name.O, // TABLE_SCHEMA
+					table.Name.O,  // TABLE_NAME
+					def.Name.O,    // PARTITION_NAME
+					nil,           // SUBPARTITION_NAME
+					i+1,           // PARTITION_ORDINAL_POSITION
+					nil,           // SUBPARTITION_ORDINAL_POSITION
+					method,        // PARTITION_METHOD
+					nil,           // SUBPARTITION_METHOD
+					partitionExpr, // PARTITION_EXPRESSION
+					nil,           // SUBPARTITION_EXPRESSION
+					description,   // PARTITION_DESCRIPTION
+					rowCount,      // TABLE_ROWS
+					avgRowLength,  // AVG_ROW_LENGTH
+					dataLength,    // DATA_LENGTH
+					uint64(0),     // MAX_DATA_LENGTH
+					indexLength,   // INDEX_LENGTH
+					uint64(0),     // DATA_FREE
+					nil,           // CREATE_TIME
+					nil,           // UPDATE_TIME
+					nil,           // CHECK_TIME
+					nil,           // CHECKSUM
+					def.Comment,   // PARTITION_COMMENT
+					nil,           // NODEGROUP
+					nil,           // TABLESPACE_NAME
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	return rows, nil
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //  - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -906,14 +1107,15 @@ func dataForColumns(ctx sessionctx.Context, schemas []*model.DBInfo) [][]types.D
 	var rows [][]types.Datum
 	for _, schema := range schemas {
 		for _, table := range schema.Tables {
-			rs := dataForColumnsInTable(schema, table)
+			rs := DataForColumnsInTable(schema, table)
 			rows = append(rows, rs...)
 		}
 	}
 	return rows
 }
 
-func dataForColumnsInTable(schema *model.DBInfo, tbl *model.TableInfo) [][]types.Datum {
+// DataForColumnsInTable returns the rows of information_schema.columns for the given table.
+func DataForColumnsInTable(schema *model.DBInfo, tbl *model.TableInfo) [][]types.Datum {
 	rows := make([][]types.Datum, 0, len(tbl.Columns))
 	for i, col := range tbl.Columns {
 		if col.Hidden {
@@ -998,22 +1200,63 @@ func dataForColumnsInTable(schema *model.DBInfo, tbl *model.TableInfo) [][]types
 	return rows
 }
 
-func dataForStatistics(ctx sessionctx.Context, schemas []*model.DBInfo) [][]types.Datum {
+func dataForStatistics(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.Datum, error) {
+	_, _, ndvMap, err := tableStatsCache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var rows [][]types.Datum
 	for _, schema := range schemas {
 		for _, table := range schema.Tables {
-			rs := dataForStatisticsInTable(schema, table)
+			rs := dataForStatisticsInTable(schema, table, ndvMap)
 			rows = append(rows, rs...)
 		}
 	}
-	return rows
+	return rows, nil
 }
 
-func dataForStatisticsInTable(schema *model.DBInfo, table *model.TableInfo) [][]types.Datum {
+// getIndexCardinality returns the estimated number of distinct values of the
+// key prefix ending at the offset-th column of the index, or nil if unknown.
+// Only the whole key has an index histogram, so the leading column falls back
+// to its column histogram and the other prefixes are left unknown.
+func getIndexCardinality(ndvMap map[tableHistID]uint64, table *model.TableInfo, index *model.IndexInfo, offset int) interface{} {
+	id := tableHistID{tableID: table.ID, histID: index.ID, isIndex: true}
+	if offset != len(index.Columns)-1 {
+		if offset != 0 {
+			return nil
+		}
+		id = tableHistID{tableID: table.ID, histID: table.Columns[index.Columns[0].Offset].ID}
+	}
+	if ndv, ok := ndvMap[id]; ok {
+		return ndv
+	}
+	return nil
+}
+
+// getHandleCardinality returns the estimated number of distinct values of the
+// integer primary key which is used as the row handle, or nil if unknown.
+func getHandleCardinality(ndvMap map[tableHistID]uint64, table *model.TableInfo, col *model.ColumnInfo) interface{} {
+	if ndv, ok := ndvMap[tableHistID{tableID: table.ID, histID: col.ID}]; ok {
+		return ndv
+	}
+	return nil
+}
+
+// DataForStatisticsInTable returns the rows of information_schema.statistics for the given table.
+func DataForStatisticsInTable(ctx sessionctx.Context, schema *model.DBInfo, table *model.TableInfo) ([][]types.Datum, error) {
+	_, _, ndvMap, err := tableStatsCache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dataForStatisticsInTable(schema, table, ndvMap), nil
+}
+
+func dataForStatisticsInTable(schema *model.DBInfo, table *model.TableInfo, ndvMap map[tableHistID]uint64) [][]types.Datum {
 	var rows [][]types.Datum
 	if table.PKIsHandle {
 		for _, col := range table.Columns {
 			if mysql.HasPriKeyFlag(col.Flag) {
+				cardinality := getHandleCardinality(ndvMap, table, col)
 				record := types.MakeDatums(
 					catalogVal,    // TABLE_CATALOG
 					schema.Name.O, // TABLE_SCHEMA
@@ -1024,7 +1267,7 @@ func dataForStatisticsInTable(schema *model.DBInfo, table *model.TableInfo) [][]
 					1,             // SEQ_IN_INDEX
 					col.Name.O,    // COLUMN_NAME
 					"A",           // COLLATION
-					nil,           // CARDINALITY
+					cardinality,   // CARDINALITY
 					nil,           // SUB_PART
 					nil,           // PACKED
 					"",            // NULLABLE
@@ -1047,6 +1290,7 @@ func dataForStatisticsInTable(schema *model.DBInfo, table *model.TableInfo) [][]
 		}
 		for i, key := range index.Columns {
 			col := nameToCol[key.Name.L]
+			cardinality := getIndexCardinality(ndvMap, table, index, i)
 			nullable := "YES"
 			if mysql.HasNotNullFlag(col.Flag) {
 				nullable = ""
@@ -1061,7 +1305,7 @@ func dataForStatisticsInTable(schema *model.DBInfo, table *model.TableInfo) [][]
 				i+1,           // SEQ_IN_INDEX
 				key.Name.O,    // COLUMN_NAME
 				"A",           // COLLATION
-				nil,           // CARDINALITY
+				cardinality,   // CARDINALITY
 				nil,           // SUB_PART
 				nil,           // PACKED
 				nullable,      // NULLABLE
@@ -1079,6 +1323,7 @@ const (
 	primaryKeyType    = "PRIMARY KEY"
 	primaryConstraint = "PRIMARY"
 	uniqueKeyType     = "UNIQUE"
+	foreignKeyType    = "FOREIGN KEY"
 )
 
 // dataForTableConstraints constructs data for table information_schema.constraints.See https://dev.mysql.com/doc/refman/5.7/en/table-constraints-table.html
@@ -1120,6 +1365,18 @@ func dataForTableConstraints(ctx sessionctx.Context, schemas []*model.DBInfo) []
 				)
 				rows = append(rows, record)
 			}
+
+			for _, fk := range tbl.ForeignKeys {
+				record := types.MakeDatums(
+					catalogVal,     // CONSTRAINT_CATALOG
+					schema.Name.O,  // CONSTRAINT_SCHEMA
+					fk.Name.O,      // CONSTRAINT_NAME
+					schema.Name.O,  // TABLE_SCHEMA
+					tbl.Name.O,     // TABLE_NAME
+					foreignKeyType, // CONSTRAINT_TYPE
+				)
+				rows = append(rows, record)
+			}
 		}
 	}
 	return rows
@@ -1220,9 +1477,275 @@ func keyColumnUsageInTable(schema *model.DBInfo, table *model.TableInfo) [][]typ
 			rows = append(rows, record)
 		}
 	}
+	for _, fk := range table.ForeignKeys {
+		refSchema := fk.RefSchema
+		if refSchema.L == "" {
+			refSchema = schema.Name
+		}
+		for i, col := range fk.Cols {
+			record := types.MakeDatums(
+				catalogVal,      // CONSTRAINT_CATALOG
+				schema.Name.O,   // CONSTRAINT_SCHEMA
+				fk.Name.O,       // CONSTRAINT_NAME
+				catalogVal,      // TABLE_CATALOG
+				schema.Name.O,   // TABLE_SCHEMA
+				table.Name.O,    // TABLE_NAME
+				col.O,           // COLUMN_NAME
+				i+1,             // ORDINAL_POSITION
+				i+1,             // POSITION_IN_UNIQUE_CONSTRAINT
+				refSchema.O,     // REFERENCED_TABLE_SCHEMA
+				fk.RefTable.O,   // REFERENCED_TABLE_NAME
+				fk.RefCols[i].O, // REFERENCED_COLUMN_NAME
+			)
+			rows = append(rows, record)
+		}
+	}
+	return rows
+}
+
+// dataForReferentialConstraints constructs data for table information_schema.referential_constraints.
+func dataForReferentialConstraints(schemas []*model.DBInfo) [][]types.Datum {
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			for _, fk := range tbl.ForeignKeys {
+				refSchema := fk.RefSchema
+				if refSchema.L == "" {
+					refSchema = schema.Name
+				}
+				var uniqueName interface{}
+				if refTbl := findTableInSchemas(schemas, refSchema, fk.RefTable); refTbl != nil {
+					if refTbl.IsHandleColumns(fk.RefCols) {
+						uniqueName = primaryConstraint
+					} else if idx := refTbl.FindIndexByColumns(fk.RefCols); idx != nil {
+						uniqueName = idx.Name.O
+						if idx.Primary {
+							uniqueName = primaryConstraint
+						}
+					}
+				}
+				record := types.MakeDatums(
+					catalogVal,                 // CONSTRAINT_CATALOG
+					schema.Name.O,              // CONSTRAINT_SCHEMA
+					fk.Name.O,                  // CONSTRAINT_NAME
+					catalogVal,                 // UNIQUE_CONSTRAINT_CATALOG
+					refSchema.O,                // UNIQUE_CONSTRAINT_SCHEMA
+					uniqueName,                 // UNIQUE_CONSTRAINT_NAME
+					"NONE",                     // MATCH_OPTION
+					referRuleName(fk.OnUpdate), // UPDATE_RULE
+					referRuleName(fk.OnDelete), // DELETE_RULE
+					tbl.Name.O,                 // TABLE_NAME
+					fk.RefTable.O,              // REFERENCED_TABLE_NAME
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
 	return rows
 }
 
+func findTableInSchemas(schemas []*model.DBInfo, schema, tbl model.CIStr) *model.TableInfo {
+	for _, db := range schemas {
+		if db.Name.L != schema.L {
+			continue
+		}
+		for _, tblInfo := range db.Tables {
+			if tblInfo.Name.L == tbl.L {
+				return tblInfo
+			}
+		}
+	}
+	return nil
+}
+
+// referRuleName returns the rule of a foreign key action, it's RESTRICT if the action is not specified.
+func referRuleName(opt int) string {
+	if ast.ReferOptionType(opt) == ast.ReferOptionNoOption {
+		return ast.ReferOptionRestrict.String()
+	}
+	return ast.ReferOptionType(opt).String()
+}
+
+func dataForTiDBIndexes(ctx sessionctx.Context, schemas []*model.DBInfo) ([][]types.Datum, error) {
+	_, _, ndvMap, err := tableStatsCache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			if tbl.PKIsHandle {
+				for _, col := range tbl.Columns {
+					if !mysql.HasPriKeyFlag(col.Flag) {
+						continue
+					}
+					record := types.MakeDatums(
+						schema.Name.O,                          // TABLE_SCHEMA
+						tbl.Name.O,                             // TABLE_NAME
+						0,                                      // NON_UNIQUE
+						primaryConstraint,                      // KEY_NAME
+						1,                                      // SEQ_IN_INDEX
+						col.Name.O,                             // COLUMN_NAME
+						nil,                                    // SUB_PART
+						"",                                     // INDEX_COMMENT
+						0,                                      // INDEX_ID
+						getHandleCardinality(ndvMap, tbl, col), // CARDINALITY
+					)
+					rows = append(rows, record)
+					break
+				}
+			}
+			for _, idx := range tbl.Indices {
+				if idx.State != model.StatePublic {
+					continue
+				}
+				nonUnique := 1
+				if idx.Unique {
+					nonUnique = 0
+				}
+				for i, col := range idx.Columns {
+					var subPart interface{}
+					if col.Length != types.UnspecifiedLength {
+						subPart = col.Length
+					}
+					record := types.MakeDatums(
+						schema.Name.O,                            // TABLE_SCHEMA
+						tbl.Name.O,                               // TABLE_NAME
+						nonUnique,                                // NON_UNIQUE
+						idx.Name.O,                               // KEY_NAME
+						i+1,                                      // SEQ_IN_INDEX
+						col.Name.O,                               // COLUMN_NAME
+						subPart,                                  // SUB_PART
+						idx.Comment,                              // INDEX_COMMENT
+						idx.ID,                                   // INDEX_ID
+						getIndexCardinality(ndvMap, tbl, idx, i), // CARDINALITY
+					)
+					rows = append(rows, record)
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// maxDDLJobsHistory is the maximum number of the history jobs in information_schema.ddl_jobs,
+// the latest ones are returned.
+const maxDDLJobsHistory = 1024
+
+// dataForDDLJobs returns the running DDL jobs followed by the history DDL jobs,
+// the most recent first. The rows are read lazily after the statement may have
+// released its transaction, so the jobs are loaded from a fresh snapshot.
+func dataForDDLJobs(ctx sessionctx.Context, is InfoSchema) ([][]types.Datum, error) {
+	txn, err := ctx.GetStore().Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer terror.Call(txn.Rollback)
+	jobs, err := admin.GetDDLJobs(txn)
+	if err != nil {
+		return nil, err
+	}
+	historyJobs, err := admin.GetHistoryDDLJobs(txn, maxDDLJobsHistory)
+	if err != nil {
+		return nil, err
+	}
+	jobs = append(jobs, historyJobs...)
+	rows := make([][]types.Datum, 0, len(jobs))
+	for _, job := range jobs {
+		dbName, tableName := getDDLJobNames(is, job)
+		var startTime, endTime interface{}
+		if job.StartTS != 0 {
+			startTime = formatTS(job.StartTS)
+		}
+		if job.BinlogInfo != nil && job.BinlogInfo.FinishedTS != 0 {
+			endTime = formatTS(job.BinlogInfo.FinishedTS)
+		}
+		record := types.MakeDatums(
+			job.ID,                   // JOB_ID
+			dbName,                   // DB_NAME
+			tableName,                // TABLE_NAME
+			job.Type.String(),        // JOB_TYPE
+			job.SchemaState.String(), // SCHEMA_STATE
+			job.SchemaID,             // SCHEMA_ID
+			job.TableID,              // TABLE_ID
+			job.RowCount,             // ROW_COUNT
+			startTime,                // START_TIME
+			endTime,                  // END_TIME
+			job.State.String(),       // STATE
+			job.Query,                // QUERY
+		)
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
+
+// getDDLJobNames returns the schema and table names of a DDL job. Dropped
+// objects are no longer in the information schema, so the snapshot kept for
+// the binlog is preferred.
+func getDDLJobNames(is InfoSchema, job *model.Job) (dbName, tableName string) {
+	dbName = job.SchemaName
+	if job.BinlogInfo != nil {
+		if job.BinlogInfo.DBInfo != nil {
+			dbName = job.BinlogInfo.DBInfo.Name.O
+		}
+		if job.BinlogInfo.TableInfo != nil {
+			tableName = job.BinlogInfo.TableInfo.Name.O
+		}
+	}
+	if dbName == "" {
+		if schema, ok := is.SchemaByID(job.SchemaID); ok {
+			dbName = schema.Name.O
+		}
+	}
+	if tableName == "" && job.TableID != 0 {
+		if tbl, ok := is.TableByID(job.TableID); ok {
+			tableName = tbl.Meta().Name.O
+		}
+	}
+	return dbName, tableName
+}
+
+func formatTS(ts uint64) string {
+	return oracle.GetTimeFromTS(ts).Format("2006-01-02 15:04:05")
+}
+
+// dataForApplicableRoles returns the roles granted to the current user.
+func dataForApplicableRoles(ctx sessionctx.Context) [][]types.Datum {
+	pm := privilege.GetPrivilegeManager(ctx)
+	user := ctx.GetSessionVars().User
+	if pm == nil || user == nil {
+		return nil
+	}
+	return pm.ApplicableRolesTable(user.AuthUsername, user.AuthHostname)
+}
+
+func dataForProcesslist(ctx sessionctx.Context) [][]types.Datum {
+	sm := ctx.GetSessionManager()
+	if sm == nil {
+		return nil
+	}
+	// The users without the PROCESS or SUPER privilege can only see their own sessions.
+	loginUser, activeRoles := ctx.GetSessionVars().User, ctx.GetSessionVars().ActiveRoles
+	hasProcessPriv := true
+	if pm := privilege.GetPrivilegeManager(ctx); pm != nil && loginUser != nil {
+		hasProcessPriv = pm.RequestVerification(activeRoles, "", "", "", mysql.ProcessPriv) ||
+			pm.RequestVerification(activeRoles, "", "", "", mysql.SuperPriv)
+	}
+	pl := sm.ShowProcessList()
+	ids := make([]uint64, 0, len(pl))
+	for id, pi := range pl {
+		if hasProcessPriv || pi.User == loginUser.Username {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	records := make([][]types.Datum, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, types.MakeDatums(pl[id].ToRow()...))
+	}
+	return records
+}
+
 // ServerInfo represents the basic server information of single cluster component
 type ServerInfo struct {
 	ServerType string
@@ -1262,6 +1785,10 @@ var tableNameToColumns = map[string][]columnInfo{
 	tableOptimizerTrace:                     tableOptimizerTraceCols,
 	tableTableSpaces:                        tableTableSpacesCols,
 	tableCollationCharacterSetApplicability: tableCollationCharacterSetApplicabilityCols,
+	tableTiDBIndexes:                        tableTiDBIndexesCols,
+	tableDDLJobs:                            tableDDLJobsCols,
+	tableProcesslist:                        tableProcesslistCols,
+	tableApplicableRoles:                    tableApplicableRolesCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocator, meta *model.TableInfo) (table.Table, error) {
@@ -1302,15 +1829,15 @@ func (it *infoschemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 	case tableSchemata:
 		fullRows = dataForSchemata(ctx, dbs)
 	case tableTables:
-		fullRows, err = dataForTables(ctx, dbs)
+		fullRows, err = DataForTables(ctx, dbs)
 	case tableColumns:
 		fullRows = dataForColumns(ctx, dbs)
 	case tableStatistics:
-		fullRows = dataForStatistics(ctx, dbs)
+		fullRows, err = dataForStatistics(ctx, dbs)
 	case tableCharacterSets:
-		fullRows = dataForCharacterSets()
+		fullRows = DataForCharacterSets()
 	case tableCollations:
-		fullRows = dataForCollations()
+		fullRows = DataForCollations()
 	case tableSessionVar:
 		fullRows, err = dataForSessionVar(ctx)
 	case tableConstraints:
@@ -1321,14 +1848,16 @@ func (it *infoschemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 			fullRows = dataForPseudoProfiling()
 		}
 	case tablePartitions:
+		fullRows, err = dataForPartitions(ctx, dbs)
 	case tableKeyColumm:
 		fullRows = dataForKeyColumnUsage(dbs)
 	case tableReferConst:
+		fullRows = dataForReferentialConstraints(dbs)
 	case tableTriggers:
 	case tableUserPrivileges:
 		fullRows = dataForUserPrivileges(ctx)
 	case tableEngines:
-		fullRows = dataForEngines()
+		fullRows = DataForEngines()
 	case tableRoutines:
 	// TODO: Fill the following tables.
 	case tableSchemaPrivileges:
@@ -1343,6 +1872,14 @@ func (it *infoschemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 	case tableTableSpaces:
 	case tableCollationCharacterSetApplicability:
 		fullRows = dataForCollationCharacterSetApplicability()
+	case tableTiDBIndexes:
+		fullRows, err = dataForTiDBIndexes(ctx, dbs)
+	case tableDDLJobs:
+		fullRows, err = dataForDDLJobs(ctx, is)
+	case tableProcesslist:
+		fullRows = dataForProcesslist(ctx)
+	case tableApplicableRoles:
+		fullRows = dataForApplicableRoles(ctx)
 	}
 	if err != nil {
 		return nil, err
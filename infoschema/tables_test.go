@@ -14,14 +14,19 @@
 package infoschema_test
 
 import (
+	"time"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/testkit"
 	"github.com/pingcap/tidb/util/testleak"
 )
@@ -72,3 +77,161 @@ func (s *testTableSuite) TestReloadDropDatabase(c *C) {
 	_, ok := is.TableByID(t2.Meta().ID)
 	c.Assert(ok, IsFalse)
 }
+
+func (s *testTableSuite) TestTiDBIndexes(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	// Schemas are listed in name order, so the table comes before the system tables.
+	tk.MustExec("create database a_idx")
+	defer tk.MustExec("drop database a_idx")
+	tk.MustExec("use a_idx")
+	tk.MustExec("create table t_idx (a int primary key, b int, c varchar(20), unique key k_bc(b, c(5)) comment 'bc')")
+	tk.MustQuery("select table_name, non_unique, key_name, seq_in_index, column_name, sub_part, index_comment, cardinality from information_schema.tidb_indexes limit 3").Check(testkit.Rows(
+		"t_idx 0 PRIMARY 1 a <nil>  <nil>",
+		"t_idx 0 k_bc 1 b <nil> bc <nil>",
+		"t_idx 0 k_bc 2 c 5 bc <nil>",
+	))
+
+	tk.MustExec("insert into t_idx values (1, 1, 'a'), (2, 1, 'b'), (3, 2, 'b')")
+	tk.MustExec("analyze table t_idx")
+	expiry := infoschema.TableStatsCacheExpiry
+	infoschema.TableStatsCacheExpiry = 0
+	defer func() { infoschema.TableStatsCacheExpiry = expiry }()
+	tk.MustQuery("select key_name, seq_in_index, cardinality from information_schema.tidb_indexes limit 3").Check(testkit.Rows(
+		"PRIMARY 1 3",
+		"k_bc 1 2",
+		"k_bc 2 3",
+	))
+	tk.MustQuery("select index_name, seq_in_index, cardinality from information_schema.statistics limit 3").Check(testkit.Rows(
+		"PRIMARY 1 3",
+		"k_bc 1 2",
+		"k_bc 2 3",
+	))
+	tk.MustQuery("select table_rows, data_length, index_length from information_schema.tables limit 1").Check(testkit.Rows(
+		"3 54 39",
+	))
+}
+
+func (s *testTableSuite) TestPartitions(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database a_part")
+	defer tk.MustExec("drop database a_part")
+	tk.MustExec("use a_part")
+	tk.MustExec("create table t_part (a int, b int) partition by range (a) (partition p0 values less than (10) comment 'first', partition p1 values less than maxvalue)")
+	tk.MustQuery("select table_name, partition_name, partition_ordinal_position, partition_method, partition_expression, partition_description, partition_comment from information_schema.partitions limit 2").Check(testkit.Rows(
+		"t_part p0 1 RANGE `a` 10 first",
+		"t_part p1 2 RANGE `a` MAXVALUE ",
+	))
+	tk.MustQuery("select table_name, create_options from information_schema.tables limit 1").Check(testkit.Rows(
+		"t_part partitioned",
+	))
+
+	tk.MustExec("drop table t_part")
+	tk.MustExec("create table t_list (a int, b int) partition by list columns (a) (partition p0 values in (1, 3, NULL))")
+	tk.MustQuery("select table_name, partition_name, partition_method, partition_expression, partition_description from information_schema.partitions limit 1").Check(testkit.Rows(
+		"t_list p0 LIST COLUMNS `a` 1,3,NULL",
+	))
+	tk.MustExec("drop table t_list")
+	tk.MustExec("create table t_normal (a int)")
+	tk.MustQuery("select table_name, partition_name, partition_method from information_schema.partitions limit 1").Check(testkit.Rows(
+		"t_normal <nil> <nil>",
+	))
+}
+
+func (s *testTableSuite) TestForeignKeys(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database a_fk")
+	defer tk.MustExec("drop database a_fk")
+	tk.MustExec("use a_fk")
+	// The referenced indexes are not unique, so only the foreign keys are listed in the schema.
+	tk.MustExec("create table t_parent (id int, a int, b int, key k_id(id), key k_ab(a, b))")
+	tk.MustExec("create table t_child (id int, a int, b int, constraint fk_id foreign key (id) references t_parent (id) on delete cascade, " +
+		"constraint fk_ab foreign key (a, b) references t_parent (a, b) on delete set null)")
+	tk.MustQuery("select constraint_name, unique_constraint_schema, unique_constraint_name, match_option, update_rule, delete_rule, table_name, referenced_table_name " +
+		"from information_schema.referential_constraints limit 2").Check(testkit.Rows(
+		"fk_id a_fk k_id NONE RESTRICT CASCADE t_child t_parent",
+		"fk_ab a_fk k_ab NONE RESTRICT SET NULL t_child t_parent",
+	))
+	tk.MustQuery("select constraint_name, table_name, constraint_type from information_schema.table_constraints limit 2").Check(testkit.Rows(
+		"fk_id t_child FOREIGN KEY",
+		"fk_ab t_child FOREIGN KEY",
+	))
+	tk.MustQuery("select constraint_name, column_name, ordinal_position, position_in_unique_constraint, referenced_table_schema, referenced_table_name, referenced_column_name " +
+		"from information_schema.key_column_usage limit 3").Check(testkit.Rows(
+		"fk_id id 1 1 a_fk t_parent id",
+		"fk_ab a 1 1 a_fk t_parent a",
+		"fk_ab b 2 2 a_fk t_parent b",
+	))
+}
+
+func (s *testTableSuite) TestDDLJobs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database test_ddl_jobs")
+	tk.MustExec("use test_ddl_jobs")
+	tk.MustExec("create table t (a int)")
+	tk.MustQuery("select db_name, table_name, job_type, schema_state, state, query from information_schema.ddl_jobs limit 2").Check(testkit.Rows(
+		"test_ddl_jobs t create table public synced create table t (a int)",
+		"test_ddl_jobs  create schema public synced create database test_ddl_jobs",
+	))
+	tk.MustExec("drop database test_ddl_jobs")
+}
+
+type mockSessionManager struct {
+	processInfoMap map[uint64]*util.ProcessInfo
+}
+
+func (sm *mockSessionManager) ShowProcessList() map[uint64]*util.ProcessInfo {
+	return sm.processInfoMap
+}
+
+func (sm *mockSessionManager) GetProcessInfo(id uint64) (*util.ProcessInfo, bool) {
+	rs, ok := sm.processInfoMap[id]
+	return rs, ok
+}
+
+func (sm *mockSessionManager) Kill(connectionID uint64, query bool) {}
+
+func (s *testTableSuite) TestProcesslist(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustQuery("select * from information_schema.processlist").Check(testkit.Rows())
+
+	sm := &mockSessionManager{make(map[uint64]*util.ProcessInfo, 2)}
+	sm.processInfoMap[2] = &util.ProcessInfo{
+		ID:      2,
+		User:    "root",
+		Host:    "127.0.0.1",
+		Command: mysql.ComSleep,
+		Time:    time.Now(),
+		State:   mysql.ServerStatusInTrans,
+	}
+	sm.processInfoMap[1] = &util.ProcessInfo{
+		ID:      1,
+		User:    "root",
+		Host:    "localhost",
+		DB:      "test",
+		Command: mysql.ComQuery,
+		Time:    time.Now(),
+		Info:    "select 1",
+	}
+	tk.Se.SetSessionManager(sm)
+	tk.MustQuery("select id, user, host, db, command, state, info, txnstart from information_schema.processlist").Check(testkit.Rows(
+		"1 root localhost test Query autocommit select 1 ",
+		"2 root 127.0.0.1 <nil> Sleep in transaction <nil> ",
+	))
+
+	// A user without the PROCESS privilege only sees its own sessions.
+	tk.MustExec("create user 'pl_user'@'localhost'")
+	defer tk.MustExec("drop user 'pl_user'@'localhost'")
+	sm.processInfoMap[3] = &util.ProcessInfo{
+		ID:      3,
+		User:    "pl_user",
+		Host:    "localhost",
+		Command: mysql.ComSleep,
+		Time:    time.Now(),
+	}
+	tk1 := testkit.NewTestKitWithInit(c, s.store)
+	c.Assert(tk1.Se.Auth(&auth.UserIdentity{Username: "pl_user", Hostname: "localhost"}, nil, nil), IsTrue)
+	tk1.Se.SetSessionManager(sm)
+	tk1.MustQuery("select id, user from information_schema.processlist").Check(testkit.Rows("3 pl_user"))
+	tk.MustExec("grant process on *.* to 'pl_user'@'localhost'")
+	tk1.MustQuery("select id, user from information_schema.processlist").Check(testkit.Rows("1 root", "2 root", "3 pl_user"))
+}
@@ -44,7 +44,8 @@ func (d RequestTypeSupportedChecker) supportExpr(exprType tipb.ExprType) bool {
 		return true
 	// aggregate functions.
 	case tipb.ExprType_Count, tipb.ExprType_First, tipb.ExprType_Max, tipb.ExprType_Min, tipb.ExprType_Sum, tipb.ExprType_Avg,
-		tipb.ExprType_Agg_BitXor, tipb.ExprType_Agg_BitAnd, tipb.ExprType_Agg_BitOr:
+		tipb.ExprType_Agg_BitXor, tipb.ExprType_Agg_BitAnd, tipb.ExprType_Agg_BitOr, tipb.ExprType_GroupConcat,
+		tipb.ExprType_VarPop, tipb.ExprType_VarSamp, tipb.ExprType_StddevPop, tipb.ExprType_StddevSamp:
 		return true
 	case ReqSubTypeDesc:
 		return true
@@ -18,6 +18,7 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tipb/go-tipb"
 )
 
 type checkerSuite struct{}
@@ -36,4 +37,7 @@ func (s checkerSuite) TestIsRequestTypeSupported(c *C) {
 	c.Assert(checker(kv.ReqTypeDAG, kv.ReqSubTypeSignature), IsTrue)
 	c.Assert(checker(kv.ReqTypeDAG, kv.ReqSubTypeAnalyzeIdx), IsFalse)
 	c.Assert(checker(kv.ReqTypeAnalyze, 0), IsTrue)
+	c.Assert(checker(kv.ReqTypeDAG, int64(tipb.ExprType_GroupConcat)), IsTrue)
+	c.Assert(checker(kv.ReqTypeDAG, int64(tipb.ExprType_StddevSamp)), IsTrue)
+	c.Assert(checker(kv.ReqTypeDAG, int64(tipb.ExprType_JsonArrayAgg)), IsFalse)
 }
@@ -233,6 +233,8 @@ type Request struct {
 	SyncLog bool
 	// ReplicaRead is used for reading data from replicas, only follower is supported at this time.
 	ReplicaRead ReplicaReadType
+	// Deadline is the time by which the request must finish, zero means no deadline.
+	Deadline time.Time
 }
 
 // ResultSubset represents a result subset from a single storage unit.
@@ -26,6 +26,7 @@ var (
 	_ DDLNode = &DropDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
+	_ DDLNode = &RenameTableStmt{}
 	_ DDLNode = &TruncateTableStmt{}
 
 	_ Node = &AlterTableSpec{}
@@ -33,6 +34,7 @@ var (
 	_ Node = &ColumnOption{}
 	_ Node = &Constraint{}
 	_ Node = &IndexPartSpecification{}
+	_ Node = &TableToTable{}
 )
 
 // CharsetOpt is used for parsing charset option from SQL.
@@ -231,6 +233,108 @@ func (n *IndexOption) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ReferOptionType is the type for refer options.
+type ReferOptionType int
+
+// Refer option types.
+const (
+	ReferOptionNoOption ReferOptionType = iota
+	ReferOptionRestrict
+	ReferOptionCascade
+	ReferOptionSetNull
+	ReferOptionNoAction
+)
+
+// String implements fmt.Stringer interface.
+func (r ReferOptionType) String() string {
+	switch r {
+	case ReferOptionRestrict:
+		return "RESTRICT"
+	case ReferOptionCascade:
+		return "CASCADE"
+	case ReferOptionSetNull:
+		return "SET NULL"
+	case ReferOptionNoAction:
+		return "NO ACTION"
+	}
+	return ""
+}
+
+// OnDeleteOpt is used for optional on delete clause.
+type OnDeleteOpt struct {
+	node
+	ReferOpt ReferOptionType
+}
+
+// Accept implements Node Accept interface.
+func (n *OnDeleteOpt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OnDeleteOpt)
+	return v.Leave(n)
+}
+
+// OnUpdateOpt is used for optional on update clause.
+type OnUpdateOpt struct {
+	node
+	ReferOpt ReferOptionType
+}
+
+// Accept implements Node Accept interface.
+func (n *OnUpdateOpt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OnUpdateOpt)
+	return v.Leave(n)
+}
+
+// ReferenceDef is used for parsing foreign key reference option from SQL.
+// See http://dev.mysql.com/doc/refman/5.7/en/create-table-foreign-keys.html
+type ReferenceDef struct {
+	node
+
+	Table                   *TableName
+	IndexPartSpecifications []*IndexPartSpecification
+	OnDelete                *OnDeleteOpt
+	OnUpdate                *OnUpdateOpt
+}
+
+// Accept implements Node Accept interface.
+func (n *ReferenceDef) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ReferenceDef)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	for i, val := range n.IndexPartSpecifications {
+		node, ok = val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.IndexPartSpecifications[i] = node.(*IndexPartSpecification)
+	}
+	onDelete, ok := n.OnDelete.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.OnDelete = onDelete.(*OnDeleteOpt)
+	onUpdate, ok := n.OnUpdate.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.OnUpdate = onUpdate.(*OnUpdateOpt)
+	return v.Leave(n)
+}
+
 // ConstraintType is the type for Constraint.
 type ConstraintType int
 
@@ -261,6 +365,8 @@ type Constraint struct {
 
 	Keys []*IndexPartSpecification // Used for PRIMARY KEY, UNIQUE, ......
 
+	Refer *ReferenceDef // Used for foreign key.
+
 	Option *IndexOption // Index Options
 
 	Expr ExprNode // Used for Check
@@ -282,6 +388,13 @@ func (n *Constraint) Accept(v Visitor) (Node, bool) {
 		}
 		n.Keys[i] = node.(*IndexPartSpecification)
 	}
+	if n.Refer != nil {
+		node, ok := n.Refer.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Refer = node.(*ReferenceDef)
+	}
 	if n.Option != nil {
 		node, ok := n.Option.Accept(v)
 		if !ok {
@@ -351,6 +464,7 @@ type CreateTableStmt struct {
 	ReferTable  *TableName
 	Cols        []*ColumnDef
 	Constraints []*Constraint
+	Partition   *PartitionOptions
 }
 
 // Accept implements Node Accept interface.
@@ -390,6 +504,28 @@ func (n *CreateTableStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// PartitionDefinition defines a single partition.
+type PartitionDefinition struct {
+	Name model.CIStr
+	// LessThan is only used by RANGE partitions, MAXVALUE is represented by MaxValueExpr.
+	LessThan []ExprNode
+	// InValues is only used by LIST partitions.
+	InValues []ExprNode
+	Comment  string
+}
+
+// PartitionOptions specifies the partition options.
+// See https://dev.mysql.com/doc/refman/5.7/en/partitioning-types.html
+type PartitionOptions struct {
+	Tp model.PartitionType
+	// Expr is nil if the table is partitioned by RANGE COLUMNS or LIST COLUMNS.
+	Expr        ExprNode
+	ColumnNames []*ColumnName
+	Definitions []*PartitionDefinition
+	// Num is the number of partitions specified by the PARTITIONS clause, it is 0 if omitted.
+	Num uint64
+}
+
 // DropTableStmt is a statement to drop one or more tables.
 // See https://dev.mysql.com/doc/refman/5.7/en/drop-table.html
 type DropTableStmt struct {
@@ -534,6 +670,10 @@ const (
 	AlterTableIndexInvisible
 	// TODO: Add more actions
 	AlterTableOrderByColumns
+	AlterTableRenameTable
+	AlterTableAddPartitions
+	AlterTableDropPartition
+	AlterTableTruncatePartition
 )
 
 // AlterTableSpec represents alter table specification.
@@ -564,6 +704,10 @@ type AlterTableSpec struct {
 	WithValidation bool
 	Num            uint64
 	Visibility     IndexVisibility
+	// PartDefinitions is used by ADD PARTITION.
+	PartDefinitions []*PartitionDefinition
+	// PartitionNames is used by DROP PARTITION and TRUNCATE PARTITION.
+	PartitionNames []model.CIStr
 }
 
 // Accept implements Node Accept interface.
@@ -642,6 +786,60 @@ func (n *AlterTableStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// RenameTableStmt is a statement to rename tables.
+// All the renames in TableToTables are applied atomically, from left to right.
+// See https://dev.mysql.com/doc/refman/5.7/en/rename-table.html
+type RenameTableStmt struct {
+	ddlNode
+
+	TableToTables []*TableToTable
+}
+
+// Accept implements Node Accept interface.
+func (n *RenameTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RenameTableStmt)
+	for i, t := range n.TableToTables {
+		node, ok := t.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableToTables[i] = node.(*TableToTable)
+	}
+	return v.Leave(n)
+}
+
+// TableToTable represents renaming old table to new table used in RenameTableStmt.
+type TableToTable struct {
+	node
+
+	OldTable *TableName
+	NewTable *TableName
+}
+
+// Accept implements Node Accept interface.
+func (n *TableToTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*TableToTable)
+	node, ok := n.OldTable.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.OldTable = node.(*TableName)
+	node, ok = n.NewTable.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.NewTable = node.(*TableName)
+	return v.Leave(n)
+}
+
 // TruncateTableStmt is a statement to empty a table completely.
 // See https://dev.mysql.com/doc/refman/5.7/en/truncate-table.html
 type TruncateTableStmt struct {
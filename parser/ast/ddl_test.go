@@ -39,6 +39,7 @@ func (ts *testDDLSuite) TestDDLVisitorCover(c *C) {
 		{&DropIndexStmt{Table: &TableName{}}, 0, 0},
 		{&DropTableStmt{Tables: []*TableName{{}, {}}}, 0, 0},
 		{&TruncateTableStmt{Table: &TableName{}}, 0, 0},
+		{&RenameTableStmt{TableToTables: []*TableToTable{{OldTable: &TableName{}, NewTable: &TableName{}}}}, 0, 0},
 
 		// TODO: cover children
 		{&AlterTableStmt{Table: &TableName{}, Specs: []*AlterTableSpec{alterTableSpec}}, 0, 0},
@@ -14,6 +14,7 @@
 package ast
 
 import (
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 )
@@ -324,6 +325,9 @@ func (n *ByItem) Accept(v Visitor) (Node, bool) {
 type GroupByClause struct {
 	node
 	Items []*ByItem
+	// Rollup is true for "GROUP BY ... WITH ROLLUP", which also produces
+	// the super-aggregate rows of every prefix of Items.
+	Rollup bool
 }
 
 // Accept implements Node Accept interface.
@@ -691,6 +695,18 @@ const (
 	ShowProcessList
 	ShowCreateDatabase
 	ShowErrors
+	ShowColumns
+	ShowIndex
+	ShowTableStatus
+	ShowStatus
+	ShowCharset
+	ShowCollation
+	ShowEngines
+	ShowStatsMeta
+	ShowStatsHistograms
+	ShowStatsBuckets
+	ShowStatsHealthy
+	ShowGrants
 )
 
 // ShowStmt is a statement to provide information about databases, tables, columns and so on.
@@ -711,6 +727,10 @@ type ShowStmt struct {
 	// GlobalScope is used by `show variables` and `show bindings`
 	GlobalScope bool
 	Where       ExprNode
+	// User is used by `show grants`, it is nil for the current user.
+	User *auth.UserIdentity
+	// Roles is used by `show grants for ... using ...`.
+	Roles []*auth.RoleIdentity
 }
 
 // Accept implements Node Accept interface.
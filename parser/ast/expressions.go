@@ -25,17 +25,23 @@ import (
 var (
 	_ ExprNode = &BetweenExpr{}
 	_ ExprNode = &BinaryOperationExpr{}
+	_ ExprNode = &CaseExpr{}
 	_ ExprNode = &ColumnNameExpr{}
 	_ ExprNode = &DefaultExpr{}
 	_ ExprNode = &IsNullExpr{}
+	_ ExprNode = &IsTruthExpr{}
+	_ ExprNode = &MaxValueExpr{}
 	_ ExprNode = &ParenthesesExpr{}
 	_ ExprNode = &PatternInExpr{}
+	_ ExprNode = &PatternLikeExpr{}
+	_ ExprNode = &PatternRegexpExpr{}
 	_ ExprNode = &RowExpr{}
 	_ ExprNode = &UnaryOperationExpr{}
 	_ ExprNode = &ValuesExpr{}
 	_ ExprNode = &VariableExpr{}
 
 	_ Node = &ColumnName{}
+	_ Node = &WhenClause{}
 )
 
 // ValueExpr define a interface for ValueExpr.
@@ -150,6 +156,100 @@ func (n *BinaryOperationExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// WhenClause is the when clause in Case expression for "when condition then result".
+type WhenClause struct {
+	node
+	// Expr is the condition expression in WhenClause.
+	Expr ExprNode
+	// Result is the result expression in WhenClause.
+	Result ExprNode
+}
+
+// Accept implements Node Accept interface.
+func (n *WhenClause) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+
+	n = newNode.(*WhenClause)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+
+	node, ok = n.Result.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Result = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// CaseExpr is the case expression.
+type CaseExpr struct {
+	exprNode
+	// Value is the compare value expression.
+	Value ExprNode
+	// WhenClauses is the condition check expression.
+	WhenClauses []*WhenClause
+	// ElseClause is the else result expression.
+	ElseClause ExprNode
+}
+
+// Format the ExprNode into a Writer.
+func (n *CaseExpr) Format(w io.Writer) {
+	fmt.Fprint(w, "CASE")
+	if n.Value != nil {
+		fmt.Fprint(w, " ")
+		n.Value.Format(w)
+	}
+	for _, clause := range n.WhenClauses {
+		fmt.Fprint(w, " WHEN ")
+		clause.Expr.Format(w)
+		fmt.Fprint(w, " THEN ")
+		clause.Result.Format(w)
+	}
+	if n.ElseClause != nil {
+		fmt.Fprint(w, " ELSE ")
+		n.ElseClause.Format(w)
+	}
+	fmt.Fprint(w, " END")
+}
+
+// Accept implements Node Accept interface.
+func (n *CaseExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+
+	n = newNode.(*CaseExpr)
+	if n.Value != nil {
+		node, ok := n.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Value = node.(ExprNode)
+	}
+	for i, val := range n.WhenClauses {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.WhenClauses[i] = node.(*WhenClause)
+	}
+	if n.ElseClause != nil {
+		node, ok := n.ElseClause.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.ElseClause = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
 // ColumnName represents column name.
 type ColumnName struct {
 	node
@@ -306,6 +406,100 @@ func (n *PatternInExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// PatternLikeExpr is the expression for like operator, e.g, expr like "%123%"
+type PatternLikeExpr struct {
+	exprNode
+	// Expr is the expression to be checked.
+	Expr ExprNode
+	// Pattern is the like expression.
+	Pattern ExprNode
+	// Not is true, the expression is "not like".
+	Not bool
+	// Escape is the escape character of the pattern, default to '\\'.
+	Escape byte
+}
+
+// Format the ExprNode into a Writer.
+func (n *PatternLikeExpr) Format(w io.Writer) {
+	n.Expr.Format(w)
+	if n.Not {
+		fmt.Fprint(w, " NOT LIKE ")
+	} else {
+		fmt.Fprint(w, " LIKE ")
+	}
+	n.Pattern.Format(w)
+	if n.Escape != '\\' {
+		fmt.Fprint(w, " ESCAPE ")
+		fmt.Fprintf(w, "'%c'", n.Escape)
+	}
+}
+
+// Accept implements Node Accept interface.
+func (n *PatternLikeExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PatternLikeExpr)
+	if n.Expr != nil {
+		node, ok := n.Expr.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Expr = node.(ExprNode)
+	}
+	if n.Pattern != nil {
+		node, ok := n.Pattern.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Pattern = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// PatternRegexpExpr is the pattern expression for pattern match.
+type PatternRegexpExpr struct {
+	exprNode
+	// Expr is the expression to be checked.
+	Expr ExprNode
+	// Pattern is the expression for pattern.
+	Pattern ExprNode
+	// Not is true, the expression is "not rlike",
+	Not bool
+}
+
+// Format the ExprNode into a Writer.
+func (n *PatternRegexpExpr) Format(w io.Writer) {
+	n.Expr.Format(w)
+	if n.Not {
+		fmt.Fprint(w, " NOT REGEXP ")
+	} else {
+		fmt.Fprint(w, " REGEXP ")
+	}
+	n.Pattern.Format(w)
+}
+
+// Accept implements Node Accept interface.
+func (n *PatternRegexpExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PatternRegexpExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	node, ok = n.Pattern.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Pattern = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // IsNullExpr is the expression for null check.
 type IsNullExpr struct {
 	exprNode
@@ -340,6 +534,46 @@ func (n *IsNullExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// IsTruthExpr is the expression for true/false check.
+type IsTruthExpr struct {
+	exprNode
+	// Expr is the expression to be checked.
+	Expr ExprNode
+	// Not is true, the expression is "is not true/false".
+	Not bool
+	// True indicates checking true or false.
+	True int64
+}
+
+// Format the ExprNode into a Writer.
+func (n *IsTruthExpr) Format(w io.Writer) {
+	n.Expr.Format(w)
+	fmt.Fprint(w, " IS")
+	if n.Not {
+		fmt.Fprint(w, " NOT")
+	}
+	if n.True > 0 {
+		fmt.Fprint(w, " TRUE")
+	} else {
+		fmt.Fprint(w, " FALSE")
+	}
+}
+
+// Accept implements Node Accept interface.
+func (n *IsTruthExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*IsTruthExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // ParenthesesExpr is the parentheses expression.
 type ParenthesesExpr struct {
 	exprNode
@@ -498,3 +732,22 @@ func (n *VariableExpr) Accept(v Visitor) (Node, bool) {
 	n.Value = node.(ExprNode)
 	return v.Leave(n)
 }
+
+// MaxValueExpr is the expression for "maxvalue" used in partition.
+type MaxValueExpr struct {
+	exprNode
+}
+
+// Format the ExprNode into a Writer.
+func (n *MaxValueExpr) Format(w io.Writer) {
+	fmt.Fprint(w, "MAXVALUE")
+}
+
+// Accept implements Node Accept interface.
+func (n *MaxValueExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	return v.Leave(newNode)
+}
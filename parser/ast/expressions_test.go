@@ -75,6 +75,7 @@ func (tc *testExpressionsSuite) TestExpresionsVisitorCover(c *C) {
 			{&ColumnNameExpr{Name: &ColumnName{}}, 0, 0},
 			{&DefaultExpr{Name: &ColumnName{}}, 0, 0},
 			{&IsNullExpr{Expr: ce}, 1, 1},
+			{&IsTruthExpr{Expr: ce}, 1, 1},
 			{&ParenthesesExpr{Expr: ce}, 1, 1},
 			{&RowExpr{Values: []ExprNode{ce, ce}}, 2, 2},
 			{&UnaryOperationExpr{V: ce}, 1, 1},
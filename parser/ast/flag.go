@@ -45,12 +45,20 @@ func (f *flagSetter) Leave(in Node) (Node, bool) {
 		x.SetFlag(FlagHasDefault)
 	case *FuncCallExpr:
 		f.funcCall(x)
+	case *FuncCastExpr:
+		x.SetFlag(FlagHasFunc | x.Expr.GetFlag())
 	case *IsNullExpr:
 		x.SetFlag(x.Expr.GetFlag())
+	case *IsTruthExpr:
+		x.SetFlag(x.Expr.GetFlag())
 	case *ParenthesesExpr:
 		x.SetFlag(x.Expr.GetFlag())
 	case *PatternInExpr:
 		f.patternIn(x)
+	case *PatternLikeExpr:
+		x.SetFlag(x.Expr.GetFlag() | x.Pattern.GetFlag())
+	case *PatternRegexpExpr:
+		x.SetFlag(x.Expr.GetFlag() | x.Pattern.GetFlag())
 	case *RowExpr:
 		f.row(x)
 	case *UnaryOperationExpr:
@@ -18,21 +18,28 @@ import (
 	"io"
 
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
 	_ FuncNode = &AggregateFuncExpr{}
 	_ FuncNode = &FuncCallExpr{}
+	_ FuncNode = &FuncCastExpr{}
 )
 
 // List scalar function names.
 const (
 	IsNull      = "isnull"
+	Cast        = "cast"
+	IsTruth     = "istrue"
+	IsFalsity   = "isfalse"
 	Length      = "length"
 	Strcmp      = "strcmp"
 	OctetLength = "octet_length"
 	If          = "if"
 	Ifnull      = "ifnull"
+	Case        = "case"
+	Nullif      = "nullif"
 	LogicAnd    = "and"
 	LogicOr     = "or"
 	GE          = "ge"
@@ -41,17 +48,57 @@ const (
 	NE          = "ne"
 	LT          = "lt"
 	GT          = "gt"
+	Coalesce    = "coalesce"
+	Greatest    = "greatest"
+	Least       = "least"
+	Interval    = "interval"
 	Plus        = "plus"
 	Minus       = "minus"
+	And         = "bitand"
+	Or          = "bitor"
+	Xor         = "bitxor"
+	BitNeg      = "bitneg"
+	LeftShift   = "leftshift"
+	RightShift  = "rightshift"
+	BitCount    = "bit_count"
 	Div         = "div"
 	Mul         = "mul"
 	UnaryNot    = "not"
 	UnaryMinus  = "unaryminus"
 	In          = "in"
+	Like        = "like"
+	Regexp      = "regexp"
 	RowFunc     = "row"
 	SetVar      = "setvar"
 	GetVar      = "getvar"
 	Values      = "values"
+	Grouping    = "grouping"
+
+	// information functions
+	ConnectionID = "connection_id"
+	CurrentUser  = "current_user"
+	Database     = "database"
+	FoundRows    = "found_rows"
+	LastInsertId = "last_insert_id"
+	RowCount     = "row_count"
+	Schema       = "schema"
+	SessionUser  = "session_user"
+	SystemUser   = "system_user"
+	User         = "user"
+	Version      = "version"
+
+	// encryption and compression functions
+	AesDecrypt  = "aes_decrypt"
+	AesEncrypt  = "aes_encrypt"
+	CRC32       = "crc32"
+	FromBase64  = "from_base64"
+	MD5         = "md5"
+	RandomBytes = "random_bytes"
+	SHA1        = "sha1"
+	SHA         = "sha"
+	SHA2        = "sha2"
+	ToBase64    = "to_base64"
+	UUID        = "uuid"
 )
 
 // FuncCallExpr is for function expression.
@@ -92,6 +139,60 @@ func (n *FuncCallExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CastFunctionType is the type for cast function.
+type CastFunctionType int
+
+// CastFunction types
+const (
+	CastFunction CastFunctionType = iota + 1
+	CastConvertFunction
+)
+
+// FuncCastExpr is the cast function converting value to another type, e.g, cast(expr AS signed).
+// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html
+type FuncCastExpr struct {
+	funcNode
+	// Expr is the expression to be converted.
+	Expr ExprNode
+	// Tp is the conversion type.
+	Tp *types.FieldType
+	// FunctionType is either Cast or Convert.
+	FunctionType CastFunctionType
+}
+
+// Format the ExprNode into a Writer.
+func (n *FuncCastExpr) Format(w io.Writer) {
+	switch n.FunctionType {
+	case CastFunction:
+		fmt.Fprint(w, "CAST(")
+		n.Expr.Format(w)
+		fmt.Fprint(w, " AS ")
+		n.Tp.FormatAsCastType(w)
+		fmt.Fprint(w, ")")
+	case CastConvertFunction:
+		fmt.Fprint(w, "CONVERT(")
+		n.Expr.Format(w)
+		fmt.Fprint(w, ", ")
+		n.Tp.FormatAsCastType(w)
+		fmt.Fprint(w, ")")
+	}
+}
+
+// Accept implements Node Accept interface.
+func (n *FuncCastExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FuncCastExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 const (
 	// AggFuncCount is the name of Count function.
 	AggFuncCount = "count"
@@ -105,6 +206,24 @@ const (
 	AggFuncMax = "max"
 	// AggFuncMin is the name of min function.
 	AggFuncMin = "min"
+	// AggFuncBitOr is the name of bit_or function.
+	AggFuncBitOr = "bit_or"
+	// AggFuncBitXor is the name of bit_xor function.
+	AggFuncBitXor = "bit_xor"
+	// AggFuncBitAnd is the name of bit_and function.
+	AggFuncBitAnd = "bit_and"
+	// AggFuncGroupConcat is the name of group_concat function.
+	AggFuncGroupConcat = "group_concat"
+	// AggFuncStddevPop is the name of stddev_pop function.
+	AggFuncStddevPop = "stddev_pop"
+	// AggFuncStddevSamp is the name of stddev_samp function.
+	AggFuncStddevSamp = "stddev_samp"
+	// AggFuncVarPop is the name of var_pop function.
+	AggFuncVarPop = "var_pop"
+	// AggFuncVarSamp is the name of var_samp function.
+	AggFuncVarSamp = "var_samp"
+	// AggFuncApproxCountDistinct is the name of approx_count_distinct function.
+	AggFuncApproxCountDistinct = "approx_count_distinct"
 )
 
 // AggregateFuncExpr represents aggregate function expression.
@@ -114,6 +233,12 @@ type AggregateFuncExpr struct {
 	F string
 	// Args is the function args.
 	Args []ExprNode
+	// Distinct is true, function hence only aggregate distinct values.
+	// For example, column c1 values are "1", "2", "2",  "sum(c1)" is "5",
+	// but "sum(distinct c1)" is "3".
+	Distinct bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 }
 
 // Format the ExprNode into a Writer.
@@ -135,5 +260,12 @@ func (n *AggregateFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	return v.Leave(n)
 }
@@ -14,7 +14,10 @@
 package ast_test
 
 import (
+	"strings"
+
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser"
 	. "github.com/pingcap/tidb/parser/ast"
 )
 
@@ -28,6 +31,7 @@ func (ts *testFunctionsSuite) TestFunctionsVisitorCover(c *C) {
 	stmts := []Node{
 		&AggregateFuncExpr{Args: []ExprNode{valueExpr}},
 		&FuncCallExpr{Args: []ExprNode{valueExpr}},
+		&FuncCastExpr{Expr: valueExpr},
 	}
 
 	for _, stmt := range stmts {
@@ -35,3 +39,31 @@ func (ts *testFunctionsSuite) TestFunctionsVisitorCover(c *C) {
 		stmt.Accept(visitor1{})
 	}
 }
+
+func (ts *testFunctionsSuite) TestFuncCastExprFormat(c *C) {
+	cases := []struct {
+		sql    string
+		expect string
+	}{
+		{"select cast(a as signed)", "CAST(`a` AS SIGNED)"},
+		{"select cast(a as unsigned integer)", "CAST(`a` AS UNSIGNED)"},
+		{"select cast(a as double)", "CAST(`a` AS DOUBLE)"},
+		{"select cast(a as char)", "CAST(`a` AS CHAR)"},
+		{"select cast(a as char(3) binary)", "CAST(`a` AS CHAR(3) BINARY)"},
+		{"select cast(a as char charset latin1)", "CAST(`a` AS CHAR CHARSET latin1)"},
+		{"select cast(a as binary(4))", "CAST(`a` AS BINARY(4))"},
+		{"select cast(a as decimal(10, 2))", "CAST(`a` AS DECIMAL(10, 2))"},
+		{"select cast(a as datetime(3))", "CAST(`a` AS DATETIME(3))"},
+		{"select convert(a, signed)", "CONVERT(`a`, SIGNED)"},
+	}
+	p := parser.New()
+	for _, t := range cases {
+		stmt, err := p.ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil)
+		expr := stmt.(*SelectStmt).Fields.Fields[0].Expr
+		c.Assert(expr, FitsTypeOf, &FuncCastExpr{})
+		var sb strings.Builder
+		expr.Format(&sb)
+		c.Assert(sb.String(), Equals, t.expect, Commentf("for %s", t.sql))
+	}
+}
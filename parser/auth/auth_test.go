@@ -0,0 +1,78 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/sha1"
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+func TestT(t *testing.T) {
+	CustomVerboseFlag = true
+	TestingT(t)
+}
+
+var _ = Suite(&testAuthSuite{})
+
+type testAuthSuite struct {
+}
+
+func (s *testAuthSuite) TestEncodePassword(c *C) {
+	c.Assert(EncodePassword(""), Equals, "")
+	c.Assert(EncodePassword("123"), Equals, "*23AE809DDACAF96AF0FD78ED04B6A265E05AA257")
+	hpwd, err := DecodePassword(EncodePassword("123"))
+	c.Assert(err, IsNil)
+	c.Assert(hpwd, DeepEquals, Sha1Hash(Sha1Hash([]byte("123"))))
+}
+
+func (s *testAuthSuite) TestCheckScrambledPassword(c *C) {
+	salt := []byte("12345678901234567890")
+	hpwd, err := DecodePassword(EncodePassword("secret"))
+	c.Assert(err, IsNil)
+
+	// The client computes xor(sha1(password), sha1(salt, sha1(sha1(password)))).
+	stage1 := Sha1Hash([]byte("secret"))
+	crypt := sha1.New()
+	crypt.Write(salt)
+	crypt.Write(Sha1Hash(stage1))
+	scramble := crypt.Sum(nil)
+	for i := range scramble {
+		scramble[i] ^= stage1[i]
+	}
+	c.Assert(CheckScrambledPassword(salt, hpwd, scramble), IsTrue)
+	scramble[0]++
+	c.Assert(CheckScrambledPassword(salt, hpwd, scramble), IsFalse)
+	c.Assert(CheckScrambledPassword(salt, hpwd, scramble[:10]), IsFalse)
+}
+
+func (s *testAuthSuite) TestSha256Crypt(c *C) {
+	// The same as `openssl passwd -5 -salt saltstring "Hello world!"`.
+	c.Assert(string(sha256Crypt("Hello world!", []byte("saltstring"), 5000)), Equals, "5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5")
+}
+
+func (s *testAuthSuite) TestCheckShaPassword(c *C) {
+	pwhash := NewSha2Password("secret")
+	c.Assert(pwhash, HasLen, shaPasswordLength)
+	c.Assert(pwhash[:7], Equals, "$A$005$")
+	c.Assert(CheckShaPassword([]byte(pwhash), "secret"), IsTrue)
+	c.Assert(CheckShaPassword([]byte(pwhash), "secreT"), IsFalse)
+	c.Assert(CheckShaPassword([]byte(pwhash[1:]), "secret"), IsFalse)
+	c.Assert(NewSha2Password("secret"), Not(Equals), pwhash)
+
+	c.Assert(NewSha2Password(""), Equals, "")
+	c.Assert(CheckShaPassword(nil, ""), IsTrue)
+	c.Assert(CheckShaPassword(nil, "secret"), IsFalse)
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+// Resources:
+// - https://dev.mysql.com/doc/refman/8.0/en/caching-sha2-pluggable-authentication.html
+// - https://dev.mysql.com/doc/dev/mysql-server/latest/page_caching_sha2_authentication_exchanges.html
+// - https://www.akkadia.org/drepper/SHA-crypt.txt
+//
+// The stored hash is "$A$" + rounds + "$" + salt + hash, where rounds is the
+// number of iterations divided by 1000 in 3 digits, the salt has 20 bytes and
+// the hash is the base64-like encoding of the SHA-crypt digest.
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+)
+
+const (
+	mixChars            = 32
+	saltLength          = 20
+	iterationMultiplier = 1000
+	// defaultIterations is the number of rounds used by MySQL.
+	defaultIterations = 5000
+	// hashPrefixLength is the length of "$A$005$".
+	hashPrefixLength = 7
+	// shaPasswordLength is the length of the stored hash.
+	shaPasswordLength = hashPrefixLength + saltLength + 43
+)
+
+func b64From24bit(b []byte, n int, buf *bytes.Buffer) {
+	b64t := []byte("./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+	w := (int64(b[0]) << 16) | (int64(b[1]) << 8) | int64(b[2])
+	for n > 0 {
+		n--
+		buf.WriteByte(b64t[w&0x3f])
+		w >>= 6
+	}
+}
+
+func sha256Hash(input []byte) []byte {
+	res := sha256.Sum256(input)
+	return res[:]
+}
+
+// sha256Crypt calculates the SHA-crypt digest of the plaintext with the salt,
+// the numbers in the comments refer to the steps of the algorithm description.
+func sha256Crypt(plaintext string, salt []byte, iterations int) []byte {
+	// 1, 2, 3
+	bufA := bytes.NewBuffer(make([]byte, 0, 4096))
+	bufA.WriteString(plaintext)
+	bufA.Write(salt)
+
+	// 4, 5, 6, 7, 8
+	bufB := bytes.NewBuffer(make([]byte, 0, 4096))
+	bufB.WriteString(plaintext)
+	bufB.Write(salt)
+	bufB.WriteString(plaintext)
+	sumB := sha256Hash(bufB.Bytes())
+
+	// 9, 10
+	var i int
+	for i = len(plaintext); i > mixChars; i -= mixChars {
+		bufA.Write(sumB[:mixChars])
+	}
+	bufA.Write(sumB[:i])
+
+	// 11
+	for i = len(plaintext); i > 0; i >>= 1 {
+		if i%2 == 0 {
+			bufA.WriteString(plaintext)
+		} else {
+			bufA.Write(sumB)
+		}
+	}
+
+	// 12
+	sumA := sha256Hash(bufA.Bytes())
+
+	// 13, 14, 15
+	bufDP := bytes.NewBuffer(make([]byte, 0, 4096))
+	for range []byte(plaintext) {
+		bufDP.WriteString(plaintext)
+	}
+	sumDP := sha256Hash(bufDP.Bytes())
+
+	// 16
+	p := make([]byte, 0, sha256.Size)
+	for i = len(plaintext); i > 0; i -= mixChars {
+		if i > mixChars {
+			p = append(p, sumDP...)
+		} else {
+			p = append(p, sumDP[0:i]...)
+		}
+	}
+
+	// 17, 18, 19
+	bufDS := bytes.NewBuffer(make([]byte, 0, 4096))
+	for i = 0; i < 16+int(sumA[0]); i++ {
+		bufDS.Write(salt)
+	}
+	sumDS := sha256Hash(bufDS.Bytes())
+
+	// 20
+	s := make([]byte, 0, mixChars)
+	for i = len(salt); i > 0; i -= mixChars {
+		if i > mixChars {
+			s = append(s, sumDS...)
+		} else {
+			s = append(s, sumDS[0:i]...)
+		}
+	}
+
+	// 21
+	bufC := bytes.NewBuffer(make([]byte, 0, 4096))
+	sumC := sumA
+	for i = 0; i < iterations; i++ {
+		bufC.Reset()
+		if i&1 != 0 {
+			bufC.Write(p)
+		} else {
+			bufC.Write(sumC)
+		}
+		if i%3 != 0 {
+			bufC.Write(s)
+		}
+		if i%7 != 0 {
+			bufC.Write(p)
+		}
+		if i&1 != 0 {
+			bufC.Write(sumC)
+		} else {
+			bufC.Write(p)
+		}
+		sumC = sha256Hash(bufC.Bytes())
+	}
+
+	// 22
+	buf := bytes.NewBuffer(make([]byte, 0, 43))
+	b64From24bit([]byte{sumC[0], sumC[10], sumC[20]}, 4, buf)
+	b64From24bit([]byte{sumC[21], sumC[1], sumC[11]}, 4, buf)
+	b64From24bit([]byte{sumC[12], sumC[22], sumC[2]}, 4, buf)
+	b64From24bit([]byte{sumC[3], sumC[13], sumC[23]}, 4, buf)
+	b64From24bit([]byte{sumC[24], sumC[4], sumC[14]}, 4, buf)
+	b64From24bit([]byte{sumC[15], sumC[25], sumC[5]}, 4, buf)
+	b64From24bit([]byte{sumC[6], sumC[16], sumC[26]}, 4, buf)
+	b64From24bit([]byte{sumC[27], sumC[7], sumC[17]}, 4, buf)
+	b64From24bit([]byte{sumC[18], sumC[28], sumC[8]}, 4, buf)
+	b64From24bit([]byte{sumC[9], sumC[19], sumC[29]}, 4, buf)
+	b64From24bit([]byte{0, sumC[31], sumC[30]}, 3, buf)
+	return buf.Bytes()
+}
+
+func hashCrypt(plaintext string, salt []byte, iterations int) string {
+	buf := bytes.NewBuffer(make([]byte, 0, shaPasswordLength))
+	buf.WriteString("$A$")
+	buf.WriteString(fmt.Sprintf("%03X", iterations/iterationMultiplier))
+	buf.WriteByte('$')
+	buf.Write(salt)
+	buf.Write(sha256Crypt(plaintext, salt, iterations))
+	return buf.String()
+}
+
+// CheckShaPassword checks the plaintext password against the hash stored by
+// caching_sha2_password.
+func CheckShaPassword(pwhash []byte, password string) bool {
+	if len(pwhash) == 0 {
+		return password == ""
+	}
+	if len(pwhash) != shaPasswordLength || !bytes.HasPrefix(pwhash, []byte("$A$")) || pwhash[6] != '$' {
+		return false
+	}
+	rounds, err := strconv.ParseUint(string(pwhash[3:6]), 16, 16)
+	if err != nil {
+		return false
+	}
+	salt := pwhash[hashPrefixLength : hashPrefixLength+saltLength]
+	return hashCrypt(password, salt, int(rounds)*iterationMultiplier) == string(pwhash)
+}
+
+// NewSha2Password creates the hash of the plaintext password for
+// caching_sha2_password with a random salt.
+func NewSha2Password(pwd string) string {
+	if len(pwd) == 0 {
+		return ""
+	}
+	salt := make([]byte, saltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		panic(err)
+	}
+	// Restrict the salt to printable 7-bit characters, the '$' is the field
+	// separator and the quotes are avoided to keep the hash easy to quote.
+	for i := range salt {
+		for {
+			salt[i] = salt[i]&0x7f%94 + 33
+			if salt[i] != '$' && salt[i] != '\'' && salt[i] != '"' && salt[i] != '\\' {
+				break
+			}
+			_, err = rand.Read(salt[i : i+1])
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+	return hashCrypt(pwd, salt, defaultIterations)
+}
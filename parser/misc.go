@@ -548,6 +548,7 @@ var tokenMap = map[string]int{
 	"RLIKE":                    rlike,
 	"ROLE":                     role,
 	"ROLLBACK":                 rollback,
+	"ROLLUP":                   rollup,
 	"ROUTINE":                  routine,
 	"ROW":                      row,
 	"ROW_COUNT":                rowCount,
@@ -737,38 +738,39 @@ var tokenMap = map[string]int{
 
 // See https://dev.mysql.com/doc/refman/5.7/en/function-resolution.html for details
 var btFuncTokenMap = map[string]int{
-	"ADDDATE":      builtinAddDate,
-	"BIT_AND":      builtinBitAnd,
-	"BIT_OR":       builtinBitOr,
-	"BIT_XOR":      builtinBitXor,
-	"CAST":         builtinCast,
-	"COUNT":        builtinCount,
-	"CURDATE":      builtinCurDate,
-	"CURTIME":      builtinCurTime,
-	"DATE_ADD":     builtinDateAdd,
-	"DATE_SUB":     builtinDateSub,
-	"EXTRACT":      builtinExtract,
-	"GROUP_CONCAT": builtinGroupConcat,
-	"MAX":          builtinMax,
-	"MID":          builtinSubstring,
-	"MIN":          builtinMin,
-	"NOW":          builtinNow,
-	"POSITION":     builtinPosition,
-	"SESSION_USER": builtinUser,
-	"STD":          builtinStddevPop,
-	"STDDEV":       builtinStddevPop,
-	"STDDEV_POP":   builtinStddevPop,
-	"STDDEV_SAMP":  builtinStddevSamp,
-	"SUBDATE":      builtinSubDate,
-	"SUBSTR":       builtinSubstring,
-	"SUBSTRING":    builtinSubstring,
-	"SUM":          builtinSum,
-	"SYSDATE":      builtinSysDate,
-	"SYSTEM_USER":  builtinUser,
-	"TRIM":         builtinTrim,
-	"VARIANCE":     builtinVarPop,
-	"VAR_POP":      builtinVarPop,
-	"VAR_SAMP":     builtinVarSamp,
+	"ADDDATE":               builtinAddDate,
+	"APPROX_COUNT_DISTINCT": builtinApproxCountDistinct,
+	"BIT_AND":               builtinBitAnd,
+	"BIT_OR":                builtinBitOr,
+	"BIT_XOR":               builtinBitXor,
+	"CAST":                  builtinCast,
+	"COUNT":                 builtinCount,
+	"CURDATE":               builtinCurDate,
+	"CURTIME":               builtinCurTime,
+	"DATE_ADD":              builtinDateAdd,
+	"DATE_SUB":              builtinDateSub,
+	"EXTRACT":               builtinExtract,
+	"GROUP_CONCAT":          builtinGroupConcat,
+	"MAX":                   builtinMax,
+	"MID":                   builtinSubstring,
+	"MIN":                   builtinMin,
+	"NOW":                   builtinNow,
+	"POSITION":              builtinPosition,
+	"SESSION_USER":          builtinUser,
+	"STD":                   builtinStddevPop,
+	"STDDEV":                builtinStddevPop,
+	"STDDEV_POP":            builtinStddevPop,
+	"STDDEV_SAMP":           builtinStddevSamp,
+	"SUBDATE":               builtinSubDate,
+	"SUBSTR":                builtinSubstring,
+	"SUBSTRING":             builtinSubstring,
+	"SUM":                   builtinSum,
+	"SYSDATE":               builtinSysDate,
+	"SYSTEM_USER":           builtinUser,
+	"TRIM":                  builtinTrim,
+	"VARIANCE":              builtinVarPop,
+	"VAR_POP":               builtinVarPop,
+	"VAR_SAMP":              builtinVarSamp,
 }
 
 // aliases are strings directly map to another string and use the same token.
@@ -192,6 +192,7 @@ type TableInfo struct {
 	AutoIncID   int64         `json:"auto_inc_id"`
 	MaxColumnID int64         `json:"max_col_id"`
 	MaxIndexID  int64         `json:"max_idx_id"`
+	MaxFKID     int64         `json:"max_fk_id"`
 	// UpdateTS is used to record the timestamp of updating the table's schema information.
 	// These changing schema operations don't include 'truncate table' and 'rename table'.
 	UpdateTS uint64 `json:"update_timestamp"`
@@ -224,6 +225,9 @@ type TableInfo struct {
 
 	// TiFlashReplica means the TiFlash replica info.
 	TiFlashReplica *TiFlashReplicaInfo `json:"tiflash_replica"`
+
+	// Partition is nil for the tables which are not partitioned.
+	Partition *PartitionInfo `json:"partition"`
 }
 
 // TableLockInfo provides meta data describing a table lock.
@@ -318,6 +322,99 @@ type TiFlashReplicaInfo struct {
 	Available      bool
 }
 
+// PartitionType is the type for PartitionInfo
+type PartitionType int
+
+// Partition types.
+const (
+	PartitionTypeRange PartitionType = 1
+	PartitionTypeHash  PartitionType = 2
+	PartitionTypeList  PartitionType = 3
+)
+
+// String implements fmt.Stringer interface.
+func (p PartitionType) String() string {
+	switch p {
+	case PartitionTypeRange:
+		return "RANGE"
+	case PartitionTypeHash:
+		return "HASH"
+	case PartitionTypeList:
+		return "LIST"
+	default:
+		return ""
+	}
+}
+
+// PartitionInfo provides table partition info.
+type PartitionInfo struct {
+	Type PartitionType `json:"type"`
+	// Expr is the partitioning expression, it is empty when the table is
+	// partitioned by COLUMNS.
+	Expr    string  `json:"expr"`
+	Columns []CIStr `json:"columns"`
+
+	Definitions []PartitionDefinition `json:"definitions"`
+	Num         uint64                `json:"num"`
+}
+
+// Clone clones PartitionInfo.
+func (pi *PartitionInfo) Clone() *PartitionInfo {
+	newPi := *pi
+	newPi.Columns = append([]CIStr(nil), pi.Columns...)
+	newPi.Definitions = make([]PartitionDefinition, len(pi.Definitions))
+	for i := range pi.Definitions {
+		newPi.Definitions[i] = pi.Definitions[i].Clone()
+	}
+	return &newPi
+}
+
+// GetNameByID gets the partition name by ID.
+func (pi *PartitionInfo) GetNameByID(id int64) string {
+	for _, def := range pi.Definitions {
+		if id == def.ID {
+			return def.Name.L
+		}
+	}
+	return ""
+}
+
+// FindPartitionDefinitionByName finds the offset of the partition definition by name,
+// it returns -1 if the partition doesn't exist.
+func (pi *PartitionInfo) FindPartitionDefinitionByName(partitionDefinitionName string) int {
+	lowConstrName := strings.ToLower(partitionDefinitionName)
+	for i, def := range pi.Definitions {
+		if def.Name.L == lowConstrName {
+			return i
+		}
+	}
+	return -1
+}
+
+// PartitionDefinition defines a single partition.
+type PartitionDefinition struct {
+	ID   int64 `json:"id"`
+	Name CIStr `json:"name"`
+	// LessThan is the upper bound of a RANGE partition, "MAXVALUE" means unbounded.
+	LessThan []string `json:"less_than"`
+	// InValues is the values of a LIST partition.
+	InValues []string `json:"in_values"`
+	Comment  string   `json:"comment,omitempty"`
+}
+
+// Clone clones PartitionDefinition.
+func (def PartitionDefinition) Clone() PartitionDefinition {
+	newDef := def
+	newDef.LessThan = append([]string(nil), def.LessThan...)
+	newDef.InValues = append([]string(nil), def.InValues...)
+	return newDef
+}
+
+// GetPartitionInfo returns the partition information.
+func (t *TableInfo) GetPartitionInfo() *PartitionInfo {
+	return t.Partition
+}
+
 // GetUpdateTime gets the table's updating time.
 func (t *TableInfo) GetUpdateTime() time.Time {
 	return TSConvert2Time(t.UpdateTS)
@@ -351,6 +448,9 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if t.Partition != nil {
+		nt.Partition = t.Partition.Clone()
+	}
 	return &nt
 }
 
@@ -431,6 +531,47 @@ func (t *TableInfo) FindIndexByName(idxName string) *IndexInfo {
 	return nil
 }
 
+// IsHandleColumns checks whether cols is the integer primary key column which is used as the row handle.
+func (t *TableInfo) IsHandleColumns(cols []CIStr) bool {
+	if !t.PKIsHandle || len(cols) != 1 {
+		return false
+	}
+	pkCol := t.GetPkColInfo()
+	return pkCol != nil && pkCol.Name.L == cols[0].L
+}
+
+// FindIndexByColumns finds a public index whose leading columns are cols. Indices built on
+// column prefixes are skipped because they can't be used to look up rows by full values.
+func (t *TableInfo) FindIndexByColumns(cols []CIStr) *IndexInfo {
+	for _, idx := range t.Indices {
+		if idx.State != StatePublic || len(idx.Columns) < len(cols) {
+			continue
+		}
+		match := true
+		for i, col := range cols {
+			idxCol := idx.Columns[i]
+			if idxCol.Name.L != col.L || idxCol.Length != types.UnspecifiedLength {
+				match = false
+				break
+			}
+		}
+		if match {
+			return idx
+		}
+	}
+	return nil
+}
+
+// FindForeignKeyByName finds foreign key by name.
+func (t *TableInfo) FindForeignKeyByName(fkName string) *FKInfo {
+	for _, fk := range t.ForeignKeys {
+		if fk.Name.L == fkName {
+			return fk
+		}
+	}
+	return nil
+}
+
 // IsLocked checks whether the table was locked.
 func (t *TableInfo) IsLocked() bool {
 	return t.Lock != nil && len(t.Lock.Sessions) > 0
@@ -546,6 +687,8 @@ type FKInfo struct {
 	OnDelete int         `json:"on_delete"`
 	OnUpdate int         `json:"on_update"`
 	State    SchemaState `json:"state"`
+	// RefSchema is empty if the referenced table is in the same schema.
+	RefSchema CIStr `json:"ref_schema"`
 }
 
 // Clone clones FKInfo.
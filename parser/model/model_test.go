@@ -183,6 +183,32 @@ func (*testModelSuite) TestJobCodec(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(isDependent, IsTrue)
 
+	// job3: rename tables, moves table 5 from schema 4 to schema 1
+	// job4: drop schema, schema ID is 4
+	job3 := &Job{
+		ID:         4,
+		TableID:    5,
+		SchemaID:   1,
+		Type:       ActionRenameTables,
+		BinlogInfo: &HistoryInfo{},
+		Args:       []interface{}{[]int64{4}, []int64{1}, []CIStr{NewCIStr("t5")}, []int64{5}},
+	}
+	job3.RawArgs, err = json.Marshal(job3.Args)
+	c.Assert(err, IsNil)
+	job4 := &Job{
+		ID:         5,
+		SchemaID:   4,
+		Type:       ActionDropSchema,
+		BinlogInfo: &HistoryInfo{},
+	}
+	isDependent, err = job4.IsDependentOn(job3)
+	c.Assert(err, IsNil)
+	c.Assert(isDependent, IsTrue)
+	job4.SchemaID = 6
+	isDependent, err = job4.IsDependentOn(job3)
+	c.Assert(err, IsNil)
+	c.Assert(isDependent, IsFalse)
+
 	c.Assert(job.IsCancelled(), Equals, false)
 	b, err := job.Encode(false)
 	c.Assert(err, IsNil)
@@ -273,6 +299,7 @@ func (testModelSuite) TestString(c *C) {
 		{ActionTruncateTable, "truncate table"},
 		{ActionModifyColumn, "modify column"},
 		{ActionRenameTable, "rename table"},
+		{ActionRenameTables, "rename tables"},
 		{ActionSetDefaultValue, "set default value"},
 		{ActionCreateSchema, "create schema"},
 		{ActionDropSchema, "drop schema"},
@@ -39,6 +39,19 @@ const (
 	LocalInFileHeader byte = 0xfb
 )
 
+// Authentication exchange headers.
+const (
+	AuthSwitchRequest byte = 0xfe
+	AuthMoreData      byte = 0x01
+
+	// CachingSha2RequestPublicKey is sent by the client to request the RSA public key.
+	CachingSha2RequestPublicKey byte = 0x02
+	// CachingSha2FastAuthSuccess tells the client the fast authentication succeeded.
+	CachingSha2FastAuthSuccess byte = 0x03
+	// CachingSha2PerformFullAuthentication asks the client to send the password.
+	CachingSha2PerformFullAuthentication byte = 0x04
+)
+
 // Server information.
 const (
 	ServerStatusInTrans            uint16 = 0x0001
@@ -158,7 +171,9 @@ const (
 
 // Auth name information.
 const (
-	AuthName = "mysql_native_password"
+	AuthName                = "mysql_native_password"
+	AuthNativePassword      = "mysql_native_password"
+	AuthCachingSha2Password = "caching_sha2_password"
 )
 
 // MySQL database and tables.
@@ -30,6 +30,7 @@ import (
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/parser/charset"
@@ -440,6 +441,7 @@ import (
 	reverse		"REVERSE"
 	role		"ROLE"
 	rollback	"ROLLBACK"
+	rollup		"ROLLUP"
 	routine		"ROUTINE"
 	rowCount	"ROW_COUNT"
 	rowFormat	"ROW_FORMAT"
@@ -637,6 +639,7 @@ import (
 	region          "REGION"
 
 	builtinAddDate
+	builtinApproxCountDistinct
 	builtinBitAnd
 	builtinBitOr
 	builtinBitXor
@@ -705,14 +708,17 @@ import (
 	UserVariable			"User defined variable name"
 	StringLiteral			"text literal"
 	ExpressionOpt			"Optional expression"
+	ElseOpt				"Optional else clause"
 	SignedLiteral			"Literal or NumLiteral with sign"
 	DefaultValueExpr		"DefaultValueExpr(Now or Signed Literal)"
 	NowSymOptionFraction		"NowSym with optional fraction part"
 	CharsetNameOrDefault		"Character set name or default"
+	MaxValueOrExpression		"MAXVALUE or expression"
 
 %type	<statement>
 	AdminStmt			"Check table statement or show ddl statement"
 	AlterTableStmt			"Alter table statement"
+	AlterUserStmt			"Alter user statement"
 	AnalyzeTableStmt		"Analyze table statement"
 	BeginTransactionStmt		"BEGIN TRANSACTION statement"
 
@@ -720,20 +726,32 @@ import (
 	CreateTableStmt			"CREATE TABLE statement"
 	CreateDatabaseStmt		"Create Database Statement"
 	CreateIndexStmt			"CREATE INDEX statement"
+	CreateUserStmt			"CREATE User statement"
+	CreateRoleStmt			"CREATE Role statement"
 	DropDatabaseStmt		"DROP DATABASE statement"
 	DropIndexStmt			"DROP INDEX statement"
 	DropTableStmt			"DROP TABLE statement"
+	DropUserStmt			"DROP USER"
+	DropRoleStmt			"DROP ROLE"
 	DeleteFromStmt			"DELETE FROM statement"
 	EmptyStmt			"empty statement"
 	ExplainStmt			"EXPLAIN statement"
 	ExplainableStmt			"explainable statement"
+	GrantStmt			"Grant statement"
+	GrantRoleStmt			"Grant role statement"
 	InsertIntoStmt			"INSERT INTO statement"
+	KillStmt			"Kill statement"
 	SelectStmt			"SELECT statement"
 	ReplaceIntoStmt			"REPLACE INTO statement"
+	RevokeStmt			"Revoke statement"
+	RevokeRoleStmt			"Revoke role statement"
 	RollbackStmt			"ROLLBACK statement"
 	SetStmt				"Set variable statement"
+	SetRoleStmt			"Set active role statement"
+	SetDefaultRoleStmt		"Set default role statement"
 	ShowStmt			"Show engines/databases/tables/user/columns/warnings/status statement"
 	Statement			"statement"
+	RenameTableStmt			"rename table statement"
 	TruncateTableStmt		"TRUNCATE TABLE statement"
 	UseStmt				"USE statement"
 
@@ -745,6 +763,7 @@ import (
 	Assignment			"assignment"
 	AssignmentList			"assignment list"
 	AssignmentListOpt		"assignment list opt"
+	AuthOption			"User auth option"
 	OptionalBraces			"optional braces"
 	CastType			"Cast function target type"
 	CharsetName			"Character set name"
@@ -817,8 +836,27 @@ import (
 	LimitClause			"LIMIT clause"
 	LimitOption			"Limit option could be integer or parameter marker."
 	NumLiteral			"Num/Int/Float/Decimal Literal"
+	OnDelete			"ON DELETE clause"
+	OnDeleteUpdateOpt		"optional ON DELETE and UPDATE clause"
+	OnUpdate			"ON UPDATE clause"
 	OptFull				"Full or empty"
 	OptTemporary			"TEMPORARY or empty"
+	OptWithRollup			"WITH ROLLUP or empty"
+	MaxValueOrExpressionList	"MAXVALUE or expression list"
+	PartDefCommentOpt		"partition definition comment optional"
+	PartDefValuesOpt		"partition definition values optional"
+	PartitionDefinition		"partition definition"
+	PartitionDefinitionList		"partition definition list"
+	PartitionDefinitionListOpt	"partition definition list optional"
+	PartitionMethod			"partition method"
+	PartitionNameList		"partition name list"
+	PartitionNameListOpt		"partition name list optional"
+	PartitionNumOpt			"PARTITIONS num optional"
+	PartitionOpt			"partition option"
+	PrivElem			"Privilege element"
+	PrivElemList			"Privilege element list"
+	PrivLevel			"Privilege scope"
+	PrivType			"Privilege type"
 	Order				"ORDER BY clause optional collation specification"
 	OrderBy				"ORDER BY clause"
 	ByItem				"BY item"
@@ -826,6 +864,8 @@ import (
 	ByList				"BY list"
 	QuickOptional			"QUICK or empty"
 	QueryBlockOpt			"Query block identifier optional"
+	ReferDef			"Reference definition"
+	ReferOpt			"reference option"
 	PriorityOpt			"Statement priority option"
 	OptGConcatSeparator		"optional GROUP_CONCAT SEPARATOR"
 	RowValue			"Row value"
@@ -861,8 +901,20 @@ import (
 	TableNameOptWild		"Table name with optional wildcard"
 	TableNameList			"Table name list"
 	TableNameListOpt		"Table name list opt"
+	TableToTable 			"rename table to table"
+	TableToTableList 		"rename table to table by list"
 	TableRef 			"table reference"
 	TableRefs 			"table references"
+	Username			"Username"
+	UsernameList			"UsernameList"
+	Rolename			"Rolename"
+	RolenameList			"RolenameList"
+	RoleSpec			"Rolename as a user specification"
+	RoleSpecList			"Rolename list as user specifications"
+	SetRoleOpt			"Set role options"
+	SetDefaultRoleOpt		"Set default role options"
+	UserSpec			"Username and auth option"
+	UserSpecList			"Username and auth option list"
 
 	Values			"values"
 	ValuesList		"values list"
@@ -871,6 +923,9 @@ import (
 	VariableAssignmentList	"set variable value list"
 	WhereClause		"WHERE clause"
 	WhereClauseOptional	"Optional WHERE clause"
+	WhenClause		"When clause"
+	WhenClauseList		"When clause list"
+	WithGrantOptionOpt	"With Grant Option opt"
 	WithValidation		"with validation"
 	WithValidationOpt	"optional with validation"
 	Type			"Types"
@@ -880,6 +935,8 @@ import (
 	BetweenOrNotOp		"Between predicate"
 	IsOrNotOp		"Is predicate"
 	InOrNotOp		"In predicate"
+	LikeOrNotOp		"Like predicate"
+	RegexpOrNotOp		"Regexp predicate"
 
 	NumericType		"Numeric types"
 	IntegerType		"Integer Types types"
@@ -943,6 +1000,7 @@ import (
 	ShowIndexKwd		"Show index/indexs/key keyword"
 	DistinctKwd		"DISTINCT/DISTINCTROW keyword"
 	FromOrIn		"From or In"
+	FieldsOrColumns		"Fields or columns"
 	OptTable		"Optional table keyword"
 	OptInteger		"Optional Integer keyword"
 	CharsetKw		"charset or charater set"
@@ -950,6 +1008,10 @@ import (
 	logAnd			"logical and operator"
 	logOr			"logical or operator"
 	StorageMedia		"{DISK|MEMORY|DEFAULT}"
+	AuthPlugin		"Authentication plugin name"
+	AuthString		"Password string value"
+	HashString		"Hashed string"
+	RolenameString		"Role name string"
 
 %type	<ident>
 	Identifier			"identifier or unreserved keyword"
@@ -1101,6 +1163,28 @@ AlterTableSpec:
 	{
 		$$ = &ast.AlterTableSpec{Tp: ast.AlterTableDropPrimaryKey}
 	}
+|	"ADD" "PARTITION" '(' PartitionDefinitionList ')'
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableAddPartitions,
+			PartDefinitions: $4.([]*ast.PartitionDefinition),
+		}
+	}
+|	"DROP" "PARTITION" IfExists PartitionNameList %prec lowerThanComma
+	{
+		$$ = &ast.AlterTableSpec{
+			IfExists: $3.(bool),
+			Tp: ast.AlterTableDropPartition,
+			PartitionNames: $4.([]model.CIStr),
+		}
+	}
+|	"TRUNCATE" "PARTITION" PartitionNameList %prec lowerThanComma
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableTruncatePartition,
+			PartitionNames: $3.([]model.CIStr),
+		}
+	}
 |	"IMPORT" "TABLESPACE"
     {
         ret := &ast.AlterTableSpec{
@@ -1198,6 +1282,27 @@ AlterTableSpec:
 			NewColumns:	[]*ast.ColumnDef{colDef},
 		}
 	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp:    	  ast.AlterTableRenameTable,
+			NewTable: $3.(*ast.TableName),
+		}
+	}
+|	"RENAME" "AS" TableName
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp:    	  ast.AlterTableRenameTable,
+			NewTable: $3.(*ast.TableName),
+		}
+	}
+|	"RENAME" TableName
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp:    	  ast.AlterTableRenameTable,
+			NewTable: $2.(*ast.TableName),
+		}
+	}
 |	"RENAME" "COLUMN" ColumnName "TO" ColumnName
 	{
 		$$ = &ast.AlterTableSpec{
@@ -1749,6 +1854,15 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"FOREIGN" "KEY" IndexName '(' IndexPartSpecificationList ')' ReferDef
+	{
+		$$ = &ast.Constraint{
+			Tp:	ast.ConstraintForeignKey,
+			Keys:	$5.([]*ast.IndexPartSpecification),
+			Name:	$3.(string),
+			Refer:	$7.(*ast.ReferenceDef),
+		}
+	}
 |	"CHECK" '(' Expression ')' EnforcedOrNotOpt
 	{
 		$$ = &ast.Constraint{
@@ -1760,6 +1874,69 @@ ConstraintElem:
 		parser.lastErrorAsWarn()
 	}
 
+ReferDef:
+	"REFERENCES" TableName '(' IndexPartSpecificationList ')' OnDeleteUpdateOpt
+	{
+		onDeleteUpdate := $6.([2]interface{})
+		$$ = &ast.ReferenceDef{
+			Table:				$2.(*ast.TableName),
+			IndexPartSpecifications:	$4.([]*ast.IndexPartSpecification),
+			OnDelete:			onDeleteUpdate[0].(*ast.OnDeleteOpt),
+			OnUpdate:			onDeleteUpdate[1].(*ast.OnUpdateOpt),
+		}
+	}
+
+OnDelete:
+	"ON" "DELETE" ReferOpt
+	{
+		$$ = &ast.OnDeleteOpt{ReferOpt: $3.(ast.ReferOptionType)}
+	}
+
+OnUpdate:
+	"ON" "UPDATE" ReferOpt
+	{
+		$$ = &ast.OnUpdateOpt{ReferOpt: $3.(ast.ReferOptionType)}
+	}
+
+OnDeleteUpdateOpt:
+	{
+		$$ = [2]interface{}{&ast.OnDeleteOpt{}, &ast.OnUpdateOpt{}}
+	}
+|	OnDelete
+	{
+		$$ = [2]interface{}{$1, &ast.OnUpdateOpt{}}
+	}
+|	OnUpdate
+	{
+		$$ = [2]interface{}{&ast.OnDeleteOpt{}, $1}
+	}
+|	OnDelete OnUpdate
+	{
+		$$ = [2]interface{}{$1, $2}
+	}
+|	OnUpdate OnDelete
+	{
+		$$ = [2]interface{}{$2, $1}
+	}
+
+ReferOpt:
+	"RESTRICT"
+	{
+		$$ = ast.ReferOptionRestrict
+	}
+|	"CASCADE"
+	{
+		$$ = ast.ReferOptionCascade
+	}
+|	"SET" "NULL"
+	{
+		$$ = ast.ReferOptionSetNull
+	}
+|	"NO" "ACTION"
+	{
+		$$ = ast.ReferOptionNoAction
+	}
+
 /*
  * The DEFAULT clause specifies a default value for a column.
  * With one exception, the default value must be a constant;
@@ -1994,12 +2171,15 @@ DatabaseOptionList:
  *******************************************************************/
 
 CreateTableStmt:
-	"CREATE" OptTemporary "TABLE" IfNotExists TableName TableElementListOpt AsOpt
+	"CREATE" OptTemporary "TABLE" IfNotExists TableName TableElementListOpt PartitionOpt AsOpt
 	{
 		stmt := $6.(*ast.CreateTableStmt)
 		stmt.Table = $5.(*ast.TableName)
 		stmt.IfNotExists = $4.(bool)
 		stmt.IsTemporary = $2.(bool)
+		if $7 != nil {
+			stmt.Partition = $7.(*ast.PartitionOptions)
+		}
 		$$ = stmt
 	}
 |	"CREATE" OptTemporary "TABLE" IfNotExists TableName LikeTableWithOrWithoutParen
@@ -2012,6 +2192,158 @@ CreateTableStmt:
 		}
 	}
 
+/*******************************************************************
+ *
+ *  Partition Options
+ *
+ *  Example:
+ *      PARTITION BY RANGE (a) (
+ *          PARTITION p0 VALUES LESS THAN (10),
+ *          PARTITION p1 VALUES LESS THAN MAXVALUE
+ *      )
+ *      PARTITION BY LIST (a) (PARTITION p0 VALUES IN (1, 3), PARTITION p1 VALUES IN (2, 4))
+ *      PARTITION BY HASH (a) PARTITIONS 4
+ *
+ *******************************************************************/
+PartitionOpt:
+	{
+		$$ = nil
+	}
+|	"PARTITION" "BY" PartitionMethod PartitionNumOpt PartitionDefinitionListOpt
+	{
+		opt := $3.(*ast.PartitionOptions)
+		opt.Num = $4.(uint64)
+		if $5 != nil {
+			opt.Definitions = $5.([]*ast.PartitionDefinition)
+		}
+		$$ = opt
+	}
+
+PartitionMethod:
+	"RANGE" '(' Expression ')'
+	{
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeRange, Expr: $3}
+	}
+|	"RANGE" "COLUMNS" '(' ColumnNameList ')'
+	{
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeRange, ColumnNames: $4.([]*ast.ColumnName)}
+	}
+|	"LIST" '(' Expression ')'
+	{
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeList, Expr: $3}
+	}
+|	"LIST" "COLUMNS" '(' ColumnNameList ')'
+	{
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeList, ColumnNames: $4.([]*ast.ColumnName)}
+	}
+|	"HASH" '(' Expression ')'
+	{
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeHash, Expr: $3}
+	}
+
+PartitionNumOpt:
+	{
+		$$ = uint64(0)
+	}
+|	"PARTITIONS" LengthNum
+	{
+		$$ = $2
+	}
+
+PartitionDefinitionListOpt:
+	/* empty */ %prec lowerThanCreateTableSelect
+	{
+		$$ = nil
+	}
+|	'(' PartitionDefinitionList ')'
+	{
+		$$ = $2
+	}
+
+PartitionDefinitionList:
+	PartitionDefinition
+	{
+		$$ = []*ast.PartitionDefinition{$1.(*ast.PartitionDefinition)}
+	}
+|	PartitionDefinitionList ',' PartitionDefinition
+	{
+		$$ = append($1.([]*ast.PartitionDefinition), $3.(*ast.PartitionDefinition))
+	}
+
+PartitionDefinition:
+	"PARTITION" Identifier PartDefValuesOpt PartDefCommentOpt
+	{
+		partDef := $3.(*ast.PartitionDefinition)
+		partDef.Name = model.NewCIStr($2)
+		partDef.Comment = $4.(string)
+		$$ = partDef
+	}
+
+PartDefValuesOpt:
+	{
+		$$ = &ast.PartitionDefinition{}
+	}
+|	"VALUES" "LESS" "THAN" "MAXVALUE"
+	{
+		$$ = &ast.PartitionDefinition{LessThan: []ast.ExprNode{&ast.MaxValueExpr{}}}
+	}
+|	"VALUES" "LESS" "THAN" '(' MaxValueOrExpressionList ')'
+	{
+		$$ = &ast.PartitionDefinition{LessThan: $5.([]ast.ExprNode)}
+	}
+|	"VALUES" "IN" '(' ExpressionList ')'
+	{
+		$$ = &ast.PartitionDefinition{InValues: $4.([]ast.ExprNode)}
+	}
+
+MaxValueOrExpressionList:
+	MaxValueOrExpression
+	{
+		$$ = []ast.ExprNode{$1}
+	}
+|	MaxValueOrExpressionList ',' MaxValueOrExpression
+	{
+		$$ = append($1.([]ast.ExprNode), $3)
+	}
+
+MaxValueOrExpression:
+	"MAXVALUE"
+	{
+		$$ = &ast.MaxValueExpr{}
+	}
+|	BitExpr
+	{
+		$$ = $1
+	}
+
+PartDefCommentOpt:
+	{
+		$$ = ""
+	}
+|	"COMMENT" EqOpt stringLit
+	{
+		$$ = $3
+	}
+
+PartitionNameList:
+	Identifier
+	{
+		$$ = []model.CIStr{model.NewCIStr($1)}
+	}
+|	PartitionNameList ',' Identifier
+	{
+		$$ = append($1.([]model.CIStr), model.NewCIStr($3))
+	}
+
+PartitionNameListOpt:
+	{
+		$$ = []model.CIStr{}
+	}
+|	"PARTITION" '(' PartitionNameList ')'
+	{
+		$$ = $3
+	}
+
 DefaultKwdOpt:
 	%prec lowerThanCharsetKwd
 	{}
@@ -2249,6 +2581,14 @@ BoolPri:
 	{
 		$$ = &ast.IsNullExpr{Expr: $1, Not: !$2.(bool)}
 	}
+|	BoolPri IsOrNotOp trueKwd %prec is
+	{
+		$$ = &ast.IsTruthExpr{Expr: $1, Not: !$2.(bool), True: int64(1)}
+	}
+|	BoolPri IsOrNotOp falseKwd %prec is
+	{
+		$$ = &ast.IsTruthExpr{Expr: $1, Not: !$2.(bool), True: int64(0)}
+	}
 |	BoolPri CompareOp PredicateExpr %prec eq
 	{
 		$$ = &ast.BinaryOperationExpr{Op: $2.(opcode.Op), L: $1, R: $3}
@@ -2331,6 +2671,26 @@ InOrNotOp:
 		$$ = false
 	}
 
+LikeOrNotOp:
+	"LIKE"
+	{
+		$$ = true
+	}
+|	"NOT" "LIKE"
+	{
+		$$ = false
+	}
+
+RegexpOrNotOp:
+	RegexpSym
+	{
+		$$ = true
+	}
+|	"NOT" RegexpSym
+	{
+		$$ = false
+	}
+
 AnyOrAll:
 	"ANY"
 	{
@@ -2359,8 +2719,31 @@ PredicateExpr:
 			Not:	!$2.(bool),
 		}
 	}
+|	BitExpr LikeOrNotOp SimpleExpr LikeEscapeOpt
+	{
+		escape := $4.(string)
+		if len(escape) > 1 {
+			yylex.AppendError(ErrWrongArguments.GenWithStackByArgs("ESCAPE"))
+			return 1
+		} else if len(escape) == 0 {
+			escape = "\\"
+		}
+		$$ = &ast.PatternLikeExpr{
+			Expr:		$1,
+			Pattern:	$3,
+			Not: 		!$2.(bool),
+			Escape: 	escape[0],
+		}
+	}
+|	BitExpr RegexpOrNotOp SimpleExpr
+	{
+		$$ = &ast.PatternRegexpExpr{Expr: $1, Pattern: $3, Not: !$2.(bool)}
+	}
 |	BitExpr
 
+RegexpSym:
+	"REGEXP" | "RLIKE"
+
 LikeEscapeOpt:
 	%prec empty
 	{
@@ -2453,9 +2836,18 @@ FieldList:
 	}
 
 GroupByClause:
-	"GROUP" "BY" ByList
+	"GROUP" "BY" ByList OptWithRollup
+	{
+		$$ = &ast.GroupByClause{Items: $3.([]*ast.ByItem), Rollup: $4.(bool)}
+	}
+
+OptWithRollup:
+	{
+		$$ = false
+	}
+|	"WITH" "ROLLUP"
 	{
-		$$ = &ast.GroupByClause{Items: $3.([]*ast.ByItem)}
+		$$ = true
 	}
 
 HavingClause:
@@ -2641,7 +3033,7 @@ UnReservedKeyword:
 | "COLUMNS" | "COMMIT" | "COMPACT" | "COMPRESSED" | "CONSISTENT" | "CURRENT" | "DATA" | "DATE" %prec lowerThanStringLitToken| "DATETIME" | "DAY" | "DEALLOCATE" | "DO" | "DUPLICATE"
 | "DYNAMIC" | "ENCRYPTION" | "END" | "ENFORCED" | "ENGINE" | "ENGINES" | "ENUM" | "ERRORS" | "ESCAPE" | "EVOLVE" | "EXECUTE" | "EXTENDED" | "FIELDS" | "FIRST" | "FIXED" | "FLUSH" | "FOLLOWING" | "FORMAT" | "FULL" |"GLOBAL"
 | "HASH" | "HOUR" | "INSERT_METHOD" | "LESS" | "LOCAL" | "LAST" | "NAMES" | "OFFSET" | "PASSWORD" %prec lowerThanEq | "PREPARE" | "QUICK" | "REBUILD" | "REDUNDANT" | "REORGANIZE"
-| "ROLE" |"ROLLBACK" | "SESSION" | "SIGNED" | "SHUTDOWN" | "SNAPSHOT" | "START" | "STATUS" | "OPEN"| "SUBPARTITIONS" | "SUBPARTITION" | "TABLES" | "TABLESPACE" | "TEXT" | "THAN" | "TIME" %prec lowerThanStringLitToken
+| "ROLE" |"ROLLBACK" | "ROLLUP" | "SESSION" | "SIGNED" | "SHUTDOWN" | "SNAPSHOT" | "START" | "STATUS" | "OPEN"| "SUBPARTITIONS" | "SUBPARTITION" | "TABLES" | "TABLESPACE" | "TEXT" | "THAN" | "TIME" %prec lowerThanStringLitToken
 | "TIMESTAMP" %prec lowerThanStringLitToken | "TRACE" | "TRANSACTION" | "TRUNCATE" | "UNBOUNDED" | "UNKNOWN" | "VALUE" | "WARNINGS" | "YEAR" | "MODE"  | "WEEK"  | "ANY" | "SOME" | "USER" | "IDENTIFIED"
 | "COLLATION" | "COMMENT" | "AVG_ROW_LENGTH" | "CONNECTION" | "CHECKSUM" | "COMPRESSION" | "KEY_BLOCK_SIZE" | "MASTER" | "MAX_ROWS"
 | "MIN_ROWS" | "NATIONAL" | "NCHAR" | "ROW_FORMAT" | "QUARTER" | "GRANTS" | "TRIGGERS" | "DELAY_KEY_WRITE" | "ISOLATION" | "JSON"
@@ -3058,6 +3450,40 @@ SimpleExpr:
 		values := append($3.([]ast.ExprNode), $5)
 		$$ = &ast.RowExpr{Values: values}
 	}
+|	builtinCast '(' Expression "AS" CastType ')'
+	{
+		/* See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_cast */
+		tp := $5.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
+		if tp.Flen == types.UnspecifiedLength {
+			tp.Flen = defaultFlen
+		}
+		if tp.Decimal == types.UnspecifiedLength {
+			tp.Decimal = defaultDecimal
+		}
+		$$ = &ast.FuncCastExpr{
+			Expr: $3,
+			Tp: tp,
+			FunctionType: ast.CastFunction,
+		}
+	}
+|	"CONVERT" '(' Expression ',' CastType ')'
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_convert
+		tp := $5.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
+		if tp.Flen == types.UnspecifiedLength {
+			tp.Flen = defaultFlen
+		}
+		if tp.Decimal == types.UnspecifiedLength {
+			tp.Decimal = defaultDecimal
+		}
+		$$ = &ast.FuncCastExpr{
+			Expr: $3,
+			Tp: tp,
+			FunctionType: ast.CastConvertFunction,
+		}
+	}
 |	"CONVERT" '(' Expression "USING" CharsetName ')'
 	{
 		// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_convert
@@ -3075,6 +3501,43 @@ SimpleExpr:
 	{
 		$$ = &ast.ValuesExpr{Column: $3.(*ast.ColumnNameExpr)}
 	}
+|	"CASE" ExpressionOpt WhenClauseList ElseOpt "END"
+	{
+		$$ = &ast.CaseExpr{
+			Value:		$2,
+			WhenClauses:	$3.([]*ast.WhenClause),
+			ElseClause:	$4,
+		}
+	}
+
+WhenClauseList:
+	WhenClause
+	{
+		$$ = []*ast.WhenClause{$1.(*ast.WhenClause)}
+	}
+|	WhenClauseList WhenClause
+	{
+		$$ = append($1.([]*ast.WhenClause), $2.(*ast.WhenClause))
+	}
+
+WhenClause:
+	"WHEN" Expression "THEN" Expression
+	{
+		$$ = &ast.WhenClause{
+			Expr: $2,
+			Result: $4,
+		}
+	}
+
+ElseOpt:
+	/* empty */
+	{
+		$$ = nil
+	}
+|	"ELSE" Expression
+	{
+		$$ = $2
+	}
 
 DistinctKwd:
 	"DISTINCT"
@@ -3247,7 +3710,19 @@ FunctionNameDateArithMultiForms:
 |	builtinSubDate
 
 SumExpr:
-	"AVG" '(' Expression ')'
+	"AVG" '(' DefaultFalseDistinctOpt Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
+	}
+|	builtinBitAnd '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+	}
+|	builtinBitOr '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+	}
+|	builtinBitXor '(' Expression ')'
 	{
 		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
 	}
@@ -3255,22 +3730,60 @@ SumExpr:
 	{
 		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
 	}
+|	builtinCount '(' "ALL" Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
+	}
+|	builtinCount '(' DistinctKwd ExpressionList ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+	}
 |	builtinCount '(' '*' ')'
 	{
 		args := []ast.ExprNode{ast.NewValueExpr(1)}
 		$$ = &ast.AggregateFuncExpr{F: $1, Args: args,}
 	}
-|	builtinMax '(' Expression ')'
+|	builtinMax '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
 	}
-|	builtinMin '(' Expression ')'
+|	builtinMin '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
 	}
-|	builtinSum '(' Expression ')'
+|	builtinSum '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
+	}
+|	builtinGroupConcat '(' ExpressionList OrderByOptional OptGConcatSeparator ')'
+	{
+		args := $3.([]ast.ExprNode)
+		args = append(args, $5.(ast.ExprNode))
+		agg := &ast.AggregateFuncExpr{F: ast.AggFuncGroupConcat, Args: args}
+		if $4 != nil {
+			agg.Order = $4.(*ast.OrderByClause)
+		}
+		$$ = agg
+	}
+|	builtinStddevPop '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncStddevPop, Args: []ast.ExprNode{$3}}
+	}
+|	builtinStddevSamp '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncStddevSamp, Args: []ast.ExprNode{$3}}
+	}
+|	builtinVarPop '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncVarPop, Args: []ast.ExprNode{$3}}
+	}
+|	builtinVarSamp '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncVarSamp, Args: []ast.ExprNode{$3}}
+	}
+|	builtinApproxCountDistinct '(' ExpressionList ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncApproxCountDistinct, Args: $3.([]ast.ExprNode)}
 	}
 
 OptGConcatSeparator:
@@ -3682,11 +4195,12 @@ TableRef:
 	}
 
 TableFactor:
-	TableName TableAsNameOpt IndexHintListOpt
+	TableName PartitionNameListOpt TableAsNameOpt IndexHintListOpt
 	{
 		tn := $1.(*ast.TableName)
-		tn.IndexHints = $3.([]*ast.IndexHint)
-		$$ = &ast.TableSource{Source: tn, AsName: $2.(model.CIStr)}
+		tn.PartitionNames = $2.([]model.CIStr)
+		tn.IndexHints = $4.([]*ast.IndexHint)
+		$$ = &ast.TableSource{Source: tn, AsName: $3.(model.CIStr)}
 	}
 |	'(' SelectStmt ')' TableAsName
 	{
@@ -4423,15 +4937,38 @@ ShowStmt:
 			Full:	$2.(bool),
 		}
 	}
-
-ShowIndexKwd:
-	"INDEX"
-|	"INDEXES"
-|	"KEYS"
-
+|	"SHOW" "GRANTS"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-grants.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowGrants}
+	}
+|	"SHOW" "GRANTS" "FOR" Username
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowGrants,
+			User:	$4.(*auth.UserIdentity),
+		}
+	}
+|	"SHOW" "GRANTS" "FOR" Username "USING" RolenameList
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowGrants,
+			User:	$4.(*auth.UserIdentity),
+			Roles:	$6.([]*auth.RoleIdentity),
+		}
+	}
+
+ShowIndexKwd:
+	"INDEX"
+|	"INDEXES"
+|	"KEYS"
+
 FromOrIn:
 "FROM" | "IN"
 
+FieldsOrColumns:
+"FIELDS" | "COLUMNS"
+
 ShowTargetFilterable:
 	"DATABASES"
 	{
@@ -4460,6 +4997,71 @@ ShowTargetFilterable:
 			GlobalScope: $1.(bool),
 		}
 	}
+|	GlobalScope "STATUS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp: ast.ShowStatus,
+			GlobalScope: $1.(bool),
+		}
+	}
+|	OptFull FieldsOrColumns ShowTableAliasOpt ShowDatabaseNameOpt
+	{
+		tbl := $3.(*ast.TableName)
+		if db := $4.(string); db != "" {
+			tbl.Schema = model.NewCIStr(db)
+		}
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowColumns,
+			Table:	tbl,
+			Full:	$1.(bool),
+		}
+	}
+|	ShowIndexKwd ShowTableAliasOpt ShowDatabaseNameOpt
+	{
+		tbl := $2.(*ast.TableName)
+		if db := $3.(string); db != "" {
+			tbl.Schema = model.NewCIStr(db)
+		}
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowIndex,
+			Table:	tbl,
+		}
+	}
+|	"TABLE" "STATUS" ShowDatabaseNameOpt
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowTableStatus,
+			DBName:	$3.(string),
+		}
+	}
+|	CharsetKw
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowCharset}
+	}
+|	"COLLATION"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowCollation}
+	}
+|	"ENGINES"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowEngines}
+	}
+|	"STATS_META"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsMeta}
+	}
+|	"STATS_HISTOGRAMS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsHistograms}
+	}
+|	"STATS_BUCKETS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsBuckets}
+	}
+|	"STATS_HEALTHY"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsHealthy}
+	}
 
 ShowLikeOrWhereOpt:
 	{
@@ -4522,6 +5124,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterTableStmt
+|	AlterUserStmt
 |	AnalyzeTableStmt
 |	BeginTransactionStmt
 |	CommitStmt
@@ -4530,14 +5133,26 @@ Statement:
 |	CreateDatabaseStmt
 |	CreateIndexStmt
 |	CreateTableStmt
+|	CreateUserStmt
+|	CreateRoleStmt
 |	DropDatabaseStmt
 |	DropIndexStmt
 |	DropTableStmt
+|	DropUserStmt
+|	DropRoleStmt
+|	GrantStmt
+|	GrantRoleStmt
 |	InsertIntoStmt
+|	KillStmt
+|	RevokeStmt
+|	RevokeRoleStmt
 |	RollbackStmt
 |	ReplaceIntoStmt
 |	SelectStmt
 |	SetStmt
+|	SetRoleStmt
+|	SetDefaultRoleStmt
+|	RenameTableStmt
 |	ShowStmt
 |	TruncateTableStmt
 |	UseStmt
@@ -4641,6 +5256,41 @@ OptTable:
 	{}
 |	"TABLE"
 
+/*******************************************************************
+ *
+ *  Rename Table Statement
+ *
+ *  Example:
+ *      RENAME TABLE t1 TO t2, db1.t3 TO db2.t4
+ *
+ *******************************************************************/
+RenameTableStmt:
+	"RENAME" "TABLE" TableToTableList
+	{
+		$$ = &ast.RenameTableStmt{
+			TableToTables: $3.([]*ast.TableToTable),
+		}
+	}
+
+TableToTableList:
+	TableToTable
+	{
+		$$ = []*ast.TableToTable{$1.(*ast.TableToTable)}
+	}
+|	TableToTableList ',' TableToTable
+	{
+		$$ = append($1.([]*ast.TableToTable), $3.(*ast.TableToTable))
+	}
+
+TableToTable:
+	TableName "TO" TableName
+	{
+		$$ = &ast.TableToTable{
+			OldTable: $1.(*ast.TableName),
+			NewTable: $3.(*ast.TableName),
+		}
+	}
+
 TruncateTableStmt:
 	"TRUNCATE" OptTable TableName
 	{
@@ -5272,6 +5922,481 @@ StringName:
 		$$ = $1
 	}
 
+/********************************************************************
+ * Kill Statement
+ * See https://dev.mysql.com/doc/refman/5.7/en/kill.html
+ *******************************************************************/
+KillStmt:
+	"KILL" NUM
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID: getUint64FromNUM($2),
+		}
+	}
+|	"KILL" "CONNECTION" NUM
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID: getUint64FromNUM($3),
+		}
+	}
+|	"KILL" "QUERY" NUM
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID: getUint64FromNUM($3),
+			Query:        true,
+		}
+	}
+
+/************************************************************************************
+ *
+ *  Create User Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/create-user.html
+ *
+ ***********************************************************************************/
+CreateUserStmt:
+	"CREATE" "USER" IfNotExists UserSpecList
+	{
+		$$ = &ast.CreateUserStmt{
+			IfNotExists: $3.(bool),
+			Specs: $4.([]*ast.UserSpec),
+		}
+	}
+
+/************************************************************************************
+ *
+ *  Alter User Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/alter-user.html
+ *
+ ***********************************************************************************/
+AlterUserStmt:
+	"ALTER" "USER" IfExists UserSpecList
+	{
+		$$ = &ast.AlterUserStmt{
+			IfExists: $3.(bool),
+			Specs: $4.([]*ast.UserSpec),
+		}
+	}
+|	"ALTER" "USER" IfExists "USER" '(' ')' "IDENTIFIED" "BY" AuthString
+	{
+		auth := &ast.AuthOption{
+			AuthString: $9,
+			ByAuthString: true,
+		}
+		$$ = &ast.AlterUserStmt{
+			IfExists: $3.(bool),
+			CurrentAuth: auth,
+		}
+	}
+
+/************************************************************************************
+ *
+ *  Drop User Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/drop-user.html
+ *
+ ***********************************************************************************/
+DropUserStmt:
+	"DROP" "USER" IfExists UsernameList
+	{
+		$$ = &ast.DropUserStmt{IfExists: $3.(bool), UserList: $4.([]*auth.UserIdentity)}
+	}
+
+UserSpec:
+	Username AuthOption
+	{
+		userSpec := &ast.UserSpec{
+			User: $1.(*auth.UserIdentity),
+		}
+		if $2 != nil {
+			userSpec.AuthOpt = $2.(*ast.AuthOption)
+		}
+		$$ = userSpec
+	}
+
+UserSpecList:
+	UserSpec
+	{
+		$$ = []*ast.UserSpec{$1.(*ast.UserSpec)}
+	}
+|	UserSpecList ',' UserSpec
+	{
+		$$ = append($1.([]*ast.UserSpec), $3.(*ast.UserSpec))
+	}
+
+AuthOption:
+	{
+		$$ = nil
+	}
+|	"IDENTIFIED" "BY" AuthString
+	{
+		$$ = &ast.AuthOption {
+			AuthString: $3,
+			ByAuthString: true,
+		}
+	}
+|	"IDENTIFIED" "WITH" AuthPlugin
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3,
+		}
+	}
+|	"IDENTIFIED" "WITH" AuthPlugin "BY" AuthString
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3,
+			AuthString: $5,
+			ByAuthString: true,
+		}
+	}
+|	"IDENTIFIED" "WITH" AuthPlugin "AS" HashString
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3,
+			HashString: $5,
+		}
+	}
+|	"IDENTIFIED" "BY" "PASSWORD" HashString
+	{
+		$$ = &ast.AuthOption{
+			HashString: $4,
+		}
+	}
+
+AuthPlugin:
+	Identifier
+|	stringLit
+
+AuthString:
+	stringLit
+
+HashString:
+	stringLit
+
+Username:
+	StringName
+	{
+		$$ = &auth.UserIdentity{Username: $1.(string), Hostname: "%"}
+	}
+|	StringName singleAtIdentifier
+	{
+		$$ = &auth.UserIdentity{Username: $1.(string), Hostname: strings.TrimPrefix($2, "@")}
+	}
+|	"CURRENT_USER" OptionalBraces
+	{
+		$$ = &auth.UserIdentity{CurrentUser: true}
+	}
+
+UsernameList:
+	Username
+	{
+		$$ = []*auth.UserIdentity{$1.(*auth.UserIdentity)}
+	}
+|	UsernameList ',' Username
+	{
+		$$ = append($1.([]*auth.UserIdentity), $3.(*auth.UserIdentity))
+	}
+
+/* Role names which are keywords must be quoted, they can't be told apart from privileges and role options. */
+RolenameString:
+	stringLit
+|	identifier
+
+Rolename:
+	RolenameString
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: "%"}
+	}
+|	RolenameString singleAtIdentifier
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: strings.TrimPrefix($2, "@")}
+	}
+
+RolenameList:
+	Rolename
+	{
+		$$ = []*auth.RoleIdentity{$1.(*auth.RoleIdentity)}
+	}
+|	RolenameList ',' Rolename
+	{
+		$$ = append($1.([]*auth.RoleIdentity), $3.(*auth.RoleIdentity))
+	}
+
+RoleSpec:
+	Rolename
+	{
+		role := $1.(*auth.RoleIdentity)
+		$$ = &ast.UserSpec{User: &auth.UserIdentity{Username: role.Username, Hostname: role.Hostname}}
+	}
+
+RoleSpecList:
+	RoleSpec
+	{
+		$$ = []*ast.UserSpec{$1.(*ast.UserSpec)}
+	}
+|	RoleSpecList ',' RoleSpec
+	{
+		$$ = append($1.([]*ast.UserSpec), $3.(*ast.UserSpec))
+	}
+
+/************************************************************************************
+ *
+ *  Role Statements
+ *  See https://dev.mysql.com/doc/refman/8.0/en/roles.html
+ *
+ ***********************************************************************************/
+CreateRoleStmt:
+	"CREATE" "ROLE" IfNotExists RoleSpecList
+	{
+		$$ = &ast.CreateUserStmt{
+			IsCreateRole: true,
+			IfNotExists: $3.(bool),
+			Specs: $4.([]*ast.UserSpec),
+		}
+	}
+
+DropRoleStmt:
+	"DROP" "ROLE" IfExists RolenameList
+	{
+		var users []*auth.UserIdentity
+		for _, role := range $4.([]*auth.RoleIdentity) {
+			users = append(users, &auth.UserIdentity{Username: role.Username, Hostname: role.Hostname})
+		}
+		$$ = &ast.DropUserStmt{IsDropRole: true, IfExists: $3.(bool), UserList: users}
+	}
+
+GrantRoleStmt:
+	"GRANT" RolenameList "TO" UsernameList
+	{
+		$$ = &ast.GrantRoleStmt{
+			Roles: $2.([]*auth.RoleIdentity),
+			Users: $4.([]*auth.UserIdentity),
+		}
+	}
+
+RevokeRoleStmt:
+	"REVOKE" RolenameList "FROM" UsernameList
+	{
+		$$ = &ast.RevokeRoleStmt{
+			Roles: $2.([]*auth.RoleIdentity),
+			Users: $4.([]*auth.UserIdentity),
+		}
+	}
+
+SetRoleStmt:
+	"SET" "ROLE" SetRoleOpt
+	{
+		$$ = $3.(*ast.SetRoleStmt)
+	}
+
+SetRoleOpt:
+	SetDefaultRoleOpt
+	{
+		$$ = $1.(*ast.SetRoleStmt)
+	}
+|	"DEFAULT"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleDefault}
+	}
+|	"ALL" "EXCEPT" RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAllExcept, RoleList: $3.([]*auth.RoleIdentity)}
+	}
+
+SetDefaultRoleOpt:
+	"NONE"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleNone}
+	}
+|	"ALL"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAll}
+	}
+|	RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleRegular, RoleList: $1.([]*auth.RoleIdentity)}
+	}
+
+SetDefaultRoleStmt:
+	"SET" "DEFAULT" "ROLE" SetDefaultRoleOpt "TO" UsernameList
+	{
+		opt := $4.(*ast.SetRoleStmt)
+		$$ = &ast.SetDefaultRoleStmt{
+			SetRoleOpt: opt.SetRoleOpt,
+			RoleList: opt.RoleList,
+			UserList: $6.([]*auth.UserIdentity),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Grant Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/grant.html
+ *
+ *******************************************************************/
+GrantStmt:
+	"GRANT" PrivElemList "ON" PrivLevel "TO" UserSpecList WithGrantOptionOpt
+	{
+		$$ = &ast.GrantStmt{
+			Privs: $2.([]*ast.PrivElem),
+			Level: $4.(*ast.GrantLevel),
+			Users: $6.([]*ast.UserSpec),
+			WithGrant: $7.(bool),
+		}
+	}
+
+WithGrantOptionOpt:
+	{
+		$$ = false
+	}
+|	"WITH" "GRANT" "OPTION"
+	{
+		$$ = true
+	}
+
+PrivElem:
+	PrivType
+	{
+		$$ = &ast.PrivElem{
+			Priv: $1.(mysql.PrivilegeType),
+		}
+	}
+
+PrivElemList:
+	PrivElem
+	{
+		$$ = []*ast.PrivElem{$1.(*ast.PrivElem)}
+	}
+|	PrivElemList ',' PrivElem
+	{
+		$$ = append($1.([]*ast.PrivElem), $3.(*ast.PrivElem))
+	}
+
+PrivType:
+	"ALL"
+	{
+		$$ = mysql.AllPriv
+	}
+|	"ALL" "PRIVILEGES"
+	{
+		$$ = mysql.AllPriv
+	}
+|	"ALTER"
+	{
+		$$ = mysql.AlterPriv
+	}
+|	"CREATE"
+	{
+		$$ = mysql.CreatePriv
+	}
+|	"CREATE" "USER"
+	{
+		$$ = mysql.CreateUserPriv
+	}
+|	"DELETE"
+	{
+		$$ = mysql.DeletePriv
+	}
+|	"DROP"
+	{
+		$$ = mysql.DropPriv
+	}
+|	"PROCESS"
+	{
+		$$ = mysql.ProcessPriv
+	}
+|	"EXECUTE"
+	{
+		$$ = mysql.ExecutePriv
+	}
+|	"INDEX"
+	{
+		$$ = mysql.IndexPriv
+	}
+|	"INSERT"
+	{
+		$$ = mysql.InsertPriv
+	}
+|	"SELECT"
+	{
+		$$ = mysql.SelectPriv
+	}
+|	"SUPER"
+	{
+		$$ = mysql.SuperPriv
+	}
+|	"SHOW" "DATABASES"
+	{
+		$$ = mysql.ShowDBPriv
+	}
+|	"UPDATE"
+	{
+		$$ = mysql.UpdatePriv
+	}
+|	"GRANT" "OPTION"
+	{
+		$$ = mysql.GrantPriv
+	}
+|	"REFERENCES"
+	{
+		$$ = mysql.ReferencesPriv
+	}
+|	"CREATE" "VIEW"
+	{
+		$$ = mysql.CreateViewPriv
+	}
+|	"SHOW" "VIEW"
+	{
+		$$ = mysql.ShowViewPriv
+	}
+
+PrivLevel:
+	'*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelDB,
+		}
+	}
+|	'*' '.' '*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelGlobal,
+		}
+	}
+|	Identifier '.' '*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelDB,
+			DBName: $1,
+		}
+	}
+|	Identifier '.' Identifier
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelTable,
+			DBName: $1,
+			TableName: $3,
+		}
+	}
+|	Identifier
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelTable,
+			TableName: $1,
+		}
+	}
+
+/**************************************RevokeStmt*******************************************
+ * See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+ *******************************************************************************************/
+RevokeStmt:
+	"REVOKE" PrivElemList "ON" PrivLevel "FROM" UserSpecList
+	{
+		$$ = &ast.RevokeStmt{
+			Privs: $2.([]*ast.PrivElem),
+			Level: $4.(*ast.GrantLevel),
+			Users: $6.([]*ast.UserSpec),
+		}
+	}
+
 UseStmt:
 	"USE" DBName
 	{
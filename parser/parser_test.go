@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/types"
@@ -510,6 +511,33 @@ func (s *testParserSuite) TestExpression(c *C) {
 		{"select {ts123 '1989-09-10 11:11:11'}", true, "SELECT '1989-09-10 11:11:11'"},
 		{"select {ts123 123}", true, "SELECT 123"},
 		{"select {ts123 1 xor 1}", true, "SELECT 1 XOR 1"},
+
+		// for case expression
+		{"select case c1 when 1 then 2 end", true, "SELECT CASE `c1` WHEN 1 THEN 2 END"},
+		{"select case c1 when 1 then 2 when 2 then 3 else 4 end", true, "SELECT CASE `c1` WHEN 1 THEN 2 WHEN 2 THEN 3 ELSE 4 END"},
+		{"select case when c1 > 1 then 'a' else 'b' end", true, "SELECT CASE WHEN `c1`>1 THEN 'a' ELSE 'b' END"},
+		{"select case c1 else 1 end", false, ""},
+		{"select case when c1 then 1", false, ""},
+
+		// for like and regexp
+		{"select c1 like 'abc%'", true, "SELECT `c1` LIKE 'abc%'"},
+		{"select c1 not like 'abc%'", true, "SELECT `c1` NOT LIKE 'abc%'"},
+		{"select c1 like 'a|%' escape '|'", true, "SELECT `c1` LIKE 'a|%' ESCAPE '|'"},
+		{"select c1 like 'a%' escape ''", true, "SELECT `c1` LIKE 'a%'"},
+		{"select c1 like 'a%' escape 'ab'", false, ""},
+		{"select c1 regexp '^a.*'", true, "SELECT `c1` REGEXP '^a.*'"},
+		{"select c1 rlike '^a.*'", true, "SELECT `c1` REGEXP '^a.*'"},
+		{"select c1 not regexp '^a.*'", true, "SELECT `c1` NOT REGEXP '^a.*'"},
+		{"select c1 not rlike '^a.*'", true, "SELECT `c1` NOT REGEXP '^a.*'"},
+
+		// for is true/false
+		{"select c1 is true", true, "SELECT `c1` IS TRUE"},
+		{"select c1 is not true", true, "SELECT `c1` IS NOT TRUE"},
+		{"select c1 is false", true, "SELECT `c1` IS FALSE"},
+		{"select c1 is not false", true, "SELECT `c1` IS NOT FALSE"},
+		{"select c1 > 1 is true", true, "SELECT `c1`>1 IS TRUE"},
+		{"select c1 is true is false", true, "SELECT `c1` IS TRUE IS FALSE"},
+		{"select c1 is null is not true", true, "SELECT `c1` IS NULL IS NOT TRUE"},
 	}
 	s.RunTest(c, table)
 }
@@ -527,6 +555,21 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{"SELECT MOD(10, 2);", true, "SELECT 10%2"},
 		{"SELECT ROUND(-1.23);", true, "SELECT ROUND(-1.23)"},
 		{"SELECT ROUND(1.23, 1);", true, "SELECT ROUND(1.23, 1)"},
+
+		// for cast and convert
+		{"SELECT CAST(1 AS SIGNED)", true, "SELECT CAST(1 AS SIGNED)"},
+		{"SELECT CAST(1 AS SIGNED INTEGER)", true, "SELECT CAST(1 AS SIGNED)"},
+		{"SELECT CAST(-1 AS UNSIGNED)", true, "SELECT CAST(-1 AS UNSIGNED)"},
+		{"SELECT CAST('1.5' AS DOUBLE)", true, "SELECT CAST('1.5' AS DOUBLE)"},
+		{"SELECT CAST(123 AS CHAR(2))", true, "SELECT CAST(123 AS CHAR(2))"},
+		{"SELECT CAST('abc' AS BINARY(5))", true, "SELECT CAST('abc' AS BINARY(5))"},
+		{"SELECT CAST(1 AS DECIMAL(10, 2))", true, "SELECT CAST(1 AS DECIMAL(10, 2))"},
+		{"SELECT CAST('2020-01-01' AS DATETIME)", true, "SELECT CAST('2020-01-01' AS DATETIME)"},
+		{"SELECT CAST(1 AS FLOAT(60))", false, ""},
+		{"SELECT CAST(1)", false, ""},
+		{"SELECT CONVERT(1, SIGNED)", true, "SELECT CONVERT(1, SIGNED)"},
+		{"SELECT CONVERT('abc', CHAR(2))", true, "SELECT CONVERT('abc', CHAR(2))"},
+		{"SELECT CONVERT('abc' USING utf8mb4)", true, "SELECT CONVERT('abc' USING 'utf8mb4')"},
 		{"SELECT ROUND(1.23, 1, 1);", true, "SELECT ROUND(1.23, 1, 1)"},
 		{"SELECT CEIL(-1.23);", true, "SELECT CEIL(-1.23)"},
 		{"SELECT CEILING(1.23);", true, "SELECT CEILING(1.23)"},
@@ -545,6 +588,10 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{"SELECT ABS();", true, "SELECT ABS()"},
 		{"SELECT CONV(10+'10'+'10'+X'0a',10,10);", true, "SELECT CONV(10+'10'+'10'+x'0a', 10, 10)"},
 		{"SELECT CONV();", true, "SELECT CONV()"},
+		{"SELECT COALESCE(NULL, 1, 2);", true, "SELECT COALESCE(NULL, 1, 2)"},
+		{"SELECT NULLIF(1, 2);", true, "SELECT NULLIF(1, 2)"},
+		{"SELECT GREATEST(1, 2, 3), LEAST(1, 2, 3);", true, "SELECT GREATEST(1, 2, 3),LEAST(1, 2, 3)"},
+		{"SELECT INTERVAL(23, 1, 15, 17, 30);", true, "SELECT INTERVAL(23, 1, 15, 17, 30)"},
 		{"SELECT CRC32('MySQL');", true, "SELECT CRC32('MySQL')"},
 		{"SELECT CRC32();", true, "SELECT CRC32()"},
 		{"SELECT SIGN();", true, "SELECT SIGN()"},
@@ -863,6 +910,27 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{`select count(c1) from t;`, true, "SELECT COUNT(`c1`) FROM `t`"},
 		{`select count(*) from t;`, true, "SELECT COUNT(1) FROM `t`"},
 		{`select count(c1, c2) from t;`, false, ""},
+		{`select count(distinct c1), count(distinctrow c1, c2), count(all c1) from t;`, true, ""},
+		{`select count(distinct *) from t;`, false, ""},
+		{`select count(all c1, c2) from t;`, false, ""},
+		{`select sum(distinct c1), avg(distinct c1), max(distinct c1), min(all c1) from t;`, true, ""},
+		{`select sum(distinct c1, c2) from t;`, false, ""},
+		{`select bit_and(c1), bit_or(c1), bit_xor(c1) from t;`, true, "SELECT BIT_AND(`c1`),BIT_OR(`c1`),BIT_XOR(`c1`) FROM `t`"},
+		{`select bit_and(c1, c2) from t;`, false, ""},
+		{`select bit_or() from t;`, false, ""},
+		{`select group_concat(c1), group_concat(c1, c2 separator ';') from t;`, true, ""},
+		{`select group_concat(c1 order by c2 desc, c1 separator '') from t group by c3;`, true, ""},
+		{`select group_concat() from t;`, false, ""},
+		{`select group_concat(c1 separator c2) from t;`, false, ""},
+		{`select std(c1), stddev(c1), stddev_pop(c1), stddev_samp(c1) from t;`, true, ""},
+		{`select variance(c1), var_pop(c1), var_samp(c1) from t;`, true, ""},
+		{`select var_pop(c1, c2) from t;`, false, ""},
+		{`select approx_count_distinct(c1), approx_count_distinct(c1, c2) from t;`, true, ""},
+		{`select approx_count_distinct() from t;`, false, ""},
+		{`select approx_count_distinct from t;`, true, ""},
+
+		// for bit operators
+		{`select c1 & 4, c1 | c2, c1 ^ 1, ~c1, c1 << 2, c1 >> 1 from t;`, true, "SELECT `c1`&4,`c1`|`c2`,`c1`^1,~`c1`,`c1`<<2,`c1`>>1 FROM `t`"},
 	}
 	s.RunTest(c, table)
 }
@@ -1021,6 +1089,25 @@ func (s *testParserSuite) TestDDL(c *C) {
 		{"ALTER TABLE t CHANGE COLUMN IF EXISTS a b varchar(255)", true, "ALTER TABLE `t` CHANGE COLUMN IF EXISTS `a` `b` VARCHAR(255)"},
 		// For alter table rename column statement.
 		{"ALTER TABLE t RENAME COLUMN a TO b", true, "ALTER TABLE `t` RENAME COLUMN `a` TO `b`"},
+		// For partitioned table statements.
+		{"create table t (a int) partition by range (a) (partition p0 values less than (10), partition p1 values less than maxvalue)", true, "CREATE TABLE `t` (`a` INT) PARTITION BY RANGE (`a`) (PARTITION `p0` VALUES LESS THAN (10),PARTITION `p1` VALUES LESS THAN (MAXVALUE))"},
+		{"create table t (a int) partition by range (a + 1) (partition p0 values less than (10) comment 'p0', partition p1 values less than (maxvalue))", true, "CREATE TABLE `t` (`a` INT) PARTITION BY RANGE (`a`+1) (PARTITION `p0` VALUES LESS THAN (10) COMMENT = 'p0',PARTITION `p1` VALUES LESS THAN (MAXVALUE))"},
+		{"create table t (a varchar(10)) partition by range columns (a) (partition p0 values less than ('x'))", true, "CREATE TABLE `t` (`a` VARCHAR(10)) PARTITION BY RANGE COLUMNS (`a`) (PARTITION `p0` VALUES LESS THAN ('x'))"},
+		{"create table t (a int) partition by list (a) (partition p0 values in (1, 3, null), partition p1 values in (2))", true, "CREATE TABLE `t` (`a` INT) PARTITION BY LIST (`a`) (PARTITION `p0` VALUES IN (1,3,NULL),PARTITION `p1` VALUES IN (2))"},
+		{"create table t (a int) partition by list columns (a) (partition p0 values in (1))", true, "CREATE TABLE `t` (`a` INT) PARTITION BY LIST COLUMNS (`a`) (PARTITION `p0` VALUES IN (1))"},
+		{"create table t (a int) partition by hash (a) partitions 4", true, "CREATE TABLE `t` (`a` INT) PARTITION BY HASH (`a`) PARTITIONS 4"},
+		{"create table t (a int) partition by hash (a) (partition p0, partition p1)", true, "CREATE TABLE `t` (`a` INT) PARTITION BY HASH (`a`) (PARTITION `p0`,PARTITION `p1`)"},
+		{"create table t (a int) partition by range (a)", true, "CREATE TABLE `t` (`a` INT) PARTITION BY RANGE (`a`)"},
+		{"create table t (a int) partition by key (a) partitions 4", false, ""},
+		{"create table t (a int) partition by range (a) ()", false, ""},
+		{"create table t (a int) partition by range (a) (partition p0 values less than 10)", false, ""},
+		{"ALTER TABLE t ADD PARTITION (PARTITION p2 VALUES LESS THAN (20), PARTITION p3 VALUES LESS THAN MAXVALUE)", true, "ALTER TABLE `t` ADD PARTITION (PARTITION `p2` VALUES LESS THAN (20),PARTITION `p3` VALUES LESS THAN (MAXVALUE))"},
+		{"ALTER TABLE t ADD PARTITION (PARTITION p2 VALUES IN (5, 6))", true, "ALTER TABLE `t` ADD PARTITION (PARTITION `p2` VALUES IN (5,6))"},
+		{"ALTER TABLE t DROP PARTITION p1", true, "ALTER TABLE `t` DROP PARTITION `p1`"},
+		{"ALTER TABLE t DROP PARTITION IF EXISTS p1, p2", true, "ALTER TABLE `t` DROP PARTITION IF EXISTS `p1`,`p2`"},
+		{"ALTER TABLE t TRUNCATE PARTITION p1, p2", true, "ALTER TABLE `t` TRUNCATE PARTITION `p1`,`p2`"},
+		{"ALTER TABLE t DROP PARTITION", false, ""},
+		{"ALTER TABLE t TRUNCATE PARTITION", false, ""},
 
 		{"ALTER TABLE t ALTER COLUMN a SET DEFAULT 1", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT 1"},
 		{"ALTER TABLE t ALTER a SET DEFAULT 1", true, "ALTER TABLE `t` ALTER COLUMN `a` SET DEFAULT 1"},
@@ -1102,6 +1189,16 @@ func (s *testParserSuite) TestDDL(c *C) {
 		{"drop index if exists a on db.t", true, "DROP INDEX IF EXISTS `a` ON `db`.`t`"},
 		{"drop index if exists a on db.`tb-ttb`", true, "DROP INDEX IF EXISTS `a` ON `db`.`tb-ttb`"},
 
+		// for rename table statement
+		{"RENAME TABLE t TO t1", true, "RENAME TABLE `t` TO `t1`"},
+		{"RENAME TABLE t t1", false, ""},
+		{"RENAME TABLE d.t TO d1.t1", true, "RENAME TABLE `d`.`t` TO `d1`.`t1`"},
+		{"RENAME TABLE t1 TO t2, t2 TO t1", true, "RENAME TABLE `t1` TO `t2`, `t2` TO `t1`"},
+		{"ALTER TABLE t RENAME TO t1", true, "ALTER TABLE `t` RENAME AS `t1`"},
+		{"ALTER TABLE t RENAME AS t1", true, "ALTER TABLE `t` RENAME AS `t1`"},
+		{"ALTER TABLE t RENAME t1", true, "ALTER TABLE `t` RENAME AS `t1`"},
+		{"ALTER TABLE t RENAME db1.t1", true, "ALTER TABLE `t` RENAME AS `db1`.`t1`"},
+
 		// for truncate statement
 		{"TRUNCATE TABLE t1", true, "TRUNCATE TABLE `t1`"},
 		{"TRUNCATE t1", true, "TRUNCATE TABLE `t1`"},
@@ -1254,6 +1351,104 @@ func (s *testParserSuite) TestDDL(c *C) {
 	s.RunTest(c, table)
 }
 
+func (s *testParserSuite) TestPartition(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("create table t (a int) partition by range (a) (partition p0 values less than (10) comment 'x', partition p1 values less than maxvalue)", "", "")
+	c.Assert(err, IsNil)
+	pi := stmt.(*ast.CreateTableStmt).Partition
+	c.Assert(pi, NotNil)
+	c.Assert(pi.Tp, Equals, model.PartitionTypeRange)
+	c.Assert(pi.Expr, NotNil)
+	c.Assert(pi.Definitions, HasLen, 2)
+	c.Assert(pi.Definitions[0].Name.L, Equals, "p0")
+	c.Assert(pi.Definitions[0].Comment, Equals, "x")
+	c.Assert(pi.Definitions[0].LessThan, HasLen, 1)
+	_, ok := pi.Definitions[1].LessThan[0].(*ast.MaxValueExpr)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("create table t (a int) partition by list columns (a) (partition p0 values in (1, 2))", "", "")
+	c.Assert(err, IsNil)
+	pi = stmt.(*ast.CreateTableStmt).Partition
+	c.Assert(pi.Tp, Equals, model.PartitionTypeList)
+	c.Assert(pi.Expr, IsNil)
+	c.Assert(pi.ColumnNames, HasLen, 1)
+	c.Assert(pi.Definitions[0].InValues, HasLen, 2)
+
+	stmt, err = parser.ParseOneStmt("create table t (a int) partition by hash (a) partitions 3", "", "")
+	c.Assert(err, IsNil)
+	pi = stmt.(*ast.CreateTableStmt).Partition
+	c.Assert(pi.Tp, Equals, model.PartitionTypeHash)
+	c.Assert(pi.Num, Equals, uint64(3))
+	c.Assert(pi.Definitions, HasLen, 0)
+
+	stmt, err = parser.ParseOneStmt("create table t (a int)", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.CreateTableStmt).Partition, IsNil)
+
+	stmt, err = parser.ParseOneStmt("alter table t drop partition if exists p0, p1", "", "")
+	c.Assert(err, IsNil)
+	spec := stmt.(*ast.AlterTableStmt).Specs[0]
+	c.Assert(spec.Tp, Equals, ast.AlterTableDropPartition)
+	c.Assert(spec.IfExists, IsTrue)
+	c.Assert(spec.PartitionNames, DeepEquals, []model.CIStr{model.NewCIStr("p0"), model.NewCIStr("p1")})
+
+	// for partition selection
+	stmt, err = parser.ParseOneStmt("select * from t partition (p0, p1) as t1 use index (idx) where a > 1", "", "")
+	c.Assert(err, IsNil)
+	ts := stmt.(*ast.SelectStmt).From.TableRefs.Left.(*ast.TableSource)
+	tn := ts.Source.(*ast.TableName)
+	c.Assert(tn.PartitionNames, DeepEquals, []model.CIStr{model.NewCIStr("p0"), model.NewCIStr("p1")})
+	c.Assert(tn.IndexHints, HasLen, 1)
+	c.Assert(ts.AsName.L, Equals, "t1")
+	stmt, err = parser.ParseOneStmt("select * from t", "", "")
+	c.Assert(err, IsNil)
+	tn = stmt.(*ast.SelectStmt).From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName)
+	c.Assert(tn.PartitionNames, HasLen, 0)
+	_, err = parser.ParseOneStmt("select * from t partition ()", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestForeignKey(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("create table t (a int, b int, constraint fk foreign key (a, b) references p (x, y) on delete cascade on update restrict)", "", "")
+	c.Assert(err, IsNil)
+	cst := stmt.(*ast.CreateTableStmt).Constraints[0]
+	c.Assert(cst.Tp, Equals, ast.ConstraintForeignKey)
+	c.Assert(cst.Name, Equals, "fk")
+	c.Assert(cst.Keys, HasLen, 2)
+	c.Assert(cst.Refer.Table.Name.L, Equals, "p")
+	c.Assert(cst.Refer.IndexPartSpecifications, HasLen, 2)
+	c.Assert(cst.Refer.IndexPartSpecifications[1].Column.Name.L, Equals, "y")
+	c.Assert(cst.Refer.OnDelete.ReferOpt, Equals, ast.ReferOptionCascade)
+	c.Assert(cst.Refer.OnUpdate.ReferOpt, Equals, ast.ReferOptionRestrict)
+
+	stmt, err = parser.ParseOneStmt("create table t (a int, foreign key idx (a) references test.p (x) on update no action on delete set null)", "", "")
+	c.Assert(err, IsNil)
+	cst = stmt.(*ast.CreateTableStmt).Constraints[0]
+	c.Assert(cst.Name, Equals, "idx")
+	c.Assert(cst.Refer.Table.Schema.L, Equals, "test")
+	c.Assert(cst.Refer.OnDelete.ReferOpt, Equals, ast.ReferOptionSetNull)
+	c.Assert(cst.Refer.OnUpdate.ReferOpt, Equals, ast.ReferOptionNoAction)
+	c.Assert(cst.Refer.OnUpdate.ReferOpt.String(), Equals, "NO ACTION")
+
+	stmt, err = parser.ParseOneStmt("alter table t add foreign key (a) references p (x)", "", "")
+	c.Assert(err, IsNil)
+	spec := stmt.(*ast.AlterTableStmt).Specs[0]
+	c.Assert(spec.Tp, Equals, ast.AlterTableAddConstraint)
+	c.Assert(spec.Constraint.Tp, Equals, ast.ConstraintForeignKey)
+	c.Assert(spec.Constraint.Name, Equals, "")
+	c.Assert(spec.Constraint.Refer.OnDelete.ReferOpt, Equals, ast.ReferOptionNoOption)
+
+	for _, src := range []string{
+		"create table t (a int, foreign key (a) references p)",
+		"create table t (a int, foreign key (a) references p (x) on delete)",
+		"create table t (a int, foreign key (a) references p (x) on delete set default)",
+	} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %v", src))
+	}
+}
+
 func (s *testParserSuite) TestType(c *C) {
 	table := []testCase{
 		// for time fsp
@@ -1313,6 +1508,221 @@ func (s *testParserSuite) TestSQLResult(c *C) {
 	s.RunTest(c, table)
 }
 
+func (s *testParserSuite) TestGroupByWithRollup(c *C) {
+	table := []testCase{
+		{`select a, sum(b) from t group by a with rollup`, true, ""},
+		{`select a, b, grouping(a), grouping(a, b) from t group by a, b with rollup having grouping(b) = 1`, true, ""},
+		{`select a from t group by a desc with rollup order by a`, true, ""},
+		{`select rollup from t group by rollup`, true, ""},
+		{`select a from t group by a with`, false, ""},
+		{`select a from t group by with rollup`, false, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("select a from t group by a, b with rollup", "", "")
+	c.Assert(err, IsNil)
+	groupBy := stmt.(*ast.SelectStmt).GroupBy
+	c.Assert(groupBy.Items, HasLen, 2)
+	c.Assert(groupBy.Rollup, IsTrue)
+	stmt, err = parser.ParseOneStmt("select a from t group by a, b", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.SelectStmt).GroupBy.Rollup, IsFalse)
+}
+
+func (s *testParserSuite) TestShow(c *C) {
+	table := []testCase{
+		{`show columns from t`, true, ""},
+		{`show full columns from t`, true, ""},
+		{`show fields in t from test`, true, ""},
+		{`show columns from test.t`, true, ""},
+		{`show columns`, false, ""},
+		{`show index from t`, true, ""},
+		{`show indexes in t from test`, true, ""},
+		{`show keys from test.t`, true, ""},
+		{`show full index from t`, false, ""},
+		{`show table status`, true, ""},
+		{`show table status from test`, true, ""},
+		{`show status`, true, ""},
+		{`show session status`, true, ""},
+		{`show global status`, true, ""},
+		{`show charset`, true, ""},
+		{`show character set`, true, ""},
+		{`show collation`, true, ""},
+		{`show engines`, true, ""},
+		{`show stats_meta`, true, ""},
+		{`show stats_histograms where table_name = 't'`, true, ""},
+		{`show stats_buckets where column_name = 'a'`, true, ""},
+		{`show stats_healthy`, true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("show full columns from t from test", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowColumns))
+	c.Assert(show.Full, IsTrue)
+	c.Assert(show.Table.Schema.L, Equals, "test")
+	c.Assert(show.Table.Name.L, Equals, "t")
+	stmt, err = parser.ParseOneStmt("show global status", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowStatus))
+	c.Assert(show.GlobalScope, IsTrue)
+	stmt, err = parser.ParseOneStmt("show table status in test", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ShowStmt).DBName, Equals, "test")
+}
+
+func (s *testParserSuite) TestKill(c *C) {
+	table := []testCase{
+		{`kill 23123`, true, ""},
+		{`kill connection 23123`, true, ""},
+		{`kill query 23123`, true, ""},
+		{`kill`, false, ""},
+		{`kill query`, false, ""},
+		{`kill 'a'`, false, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("kill query 23123", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.KillStmt).Query, IsTrue)
+	c.Assert(stmt.(*ast.KillStmt).ConnectionID, Equals, uint64(23123))
+	stmt, err = parser.ParseOneStmt("kill connection 1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.KillStmt).Query, IsFalse)
+}
+
+func (s *testParserSuite) TestUser(c *C) {
+	table := []testCase{
+		{`create user 'test'`, true, ""},
+		{`create user if not exists 'test'@'localhost' identified by 'new-password'`, true, ""},
+		{`create user 'test'@'%' identified with 'caching_sha2_password' by 'new-password'`, true, ""},
+		{`create user 'test'@'%' identified with mysql_native_password as '*23AE809DDACAF96AF0FD78ED04B6A265E05AA257'`, true, ""},
+		{`create user 'test'@'%' identified by password '*23AE809DDACAF96AF0FD78ED04B6A265E05AA257'`, true, ""},
+		{`create user 'a'@'%', 'b'@'localhost' identified by 'x'`, true, ""},
+		{`create user`, false, ""},
+		{`alter user 'test'@'localhost' identified by 'new-password'`, true, ""},
+		{`alter user if exists 'test'@'localhost' identified by 'new-password', 'root'`, true, ""},
+		{`alter user user() identified by 'new-password'`, true, ""},
+		{`drop user 'root'@'localhost', 'root1'@'localhost'`, true, ""},
+		{`drop user if exists 'root'@'localhost'`, true, ""},
+		{`drop user current_user()`, true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("create user if not exists 'test'@'localhost' identified with 'caching_sha2_password' by 'pwd'", "", "")
+	c.Assert(err, IsNil)
+	create := stmt.(*ast.CreateUserStmt)
+	c.Assert(create.IfNotExists, IsTrue)
+	c.Assert(create.Specs, HasLen, 1)
+	c.Assert(create.Specs[0].User.Username, Equals, "test")
+	c.Assert(create.Specs[0].User.Hostname, Equals, "localhost")
+	c.Assert(create.Specs[0].AuthOpt.AuthPlugin, Equals, "caching_sha2_password")
+	c.Assert(create.Specs[0].AuthOpt.AuthString, Equals, "pwd")
+	c.Assert(create.Specs[0].AuthOpt.ByAuthString, IsTrue)
+	stmt, err = parser.ParseOneStmt("drop user root", "", "")
+	c.Assert(err, IsNil)
+	drop := stmt.(*ast.DropUserStmt)
+	c.Assert(drop.UserList[0].Hostname, Equals, "%")
+	stmt, err = parser.ParseOneStmt("alter user user() identified by 'x'", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.AlterUserStmt).CurrentAuth.AuthString, Equals, "x")
+}
+
+func (s *testParserSuite) TestPrivilege(c *C) {
+	table := []testCase{
+		{`grant all on *.* to 'test'@'%'`, true, ""},
+		{`grant all privileges on * to 'test'`, true, ""},
+		{`grant select, insert on test.* to 'test'@'localhost' with grant option`, true, ""},
+		{`grant select, create user, show databases, grant option on *.* to 'a', 'b'`, true, ""},
+		{`grant delete, drop, index, alter, create, create view, show view on test.t to 'test'@'%'`, true, ""},
+		{`grant update, references, process, super, execute on t to 'test'`, true, ""},
+		{`grant select on *.* to 'test' identified by 'pwd'`, true, ""},
+		{`grant select to 'test'`, false, ""},
+		{`revoke all on *.* from 'test'@'%'`, true, ""},
+		{`revoke select, insert on test.t from 'test'@'localhost', 'b'`, true, ""},
+		{`revoke select on test.* to 'test'`, false, ""},
+		{`show grants`, true, ""},
+		{`show grants for 'test'@'localhost'`, true, ""},
+		{`show grants for current_user()`, true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("grant select, insert on test.* to 'test'@'localhost' with grant option", "", "")
+	c.Assert(err, IsNil)
+	grant := stmt.(*ast.GrantStmt)
+	c.Assert(grant.Privs, HasLen, 2)
+	c.Assert(grant.Privs[0].Priv, Equals, mysql.SelectPriv)
+	c.Assert(grant.Privs[1].Priv, Equals, mysql.InsertPriv)
+	c.Assert(grant.Level.Level, Equals, ast.GrantLevelDB)
+	c.Assert(grant.Level.DBName, Equals, "test")
+	c.Assert(grant.WithGrant, IsTrue)
+	stmt, err = parser.ParseOneStmt("revoke all on test.t from 'test'", "", "")
+	c.Assert(err, IsNil)
+	revoke := stmt.(*ast.RevokeStmt)
+	c.Assert(revoke.Privs[0].Priv, Equals, mysql.AllPriv)
+	c.Assert(revoke.Level.Level, Equals, ast.GrantLevelTable)
+	c.Assert(revoke.Level.TableName, Equals, "t")
+	stmt, err = parser.ParseOneStmt("show grants for 'test'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowGrants))
+	c.Assert(show.User.Hostname, Equals, "localhost")
+}
+
+func (s *testParserSuite) TestRole(c *C) {
+	table := []testCase{
+		{`create role r1`, true, ""},
+		{`create role if not exists 'r1'@'localhost', r2`, true, ""},
+		{`create role r1 identified by 'pwd'`, false, ""},
+		{`drop role r1, 'r2'@'%'`, true, ""},
+		{`drop role if exists r1`, true, ""},
+		{`grant r1, 'r2'@'localhost' to 'test'@'%', current_user`, true, ""},
+		{`grant r1 to 'test' with grant option`, false, ""},
+		{`revoke r1 from 'test'@'%'`, true, ""},
+		{`set role default`, true, ""},
+		{`set role none`, true, ""},
+		{`set role all`, true, ""},
+		{`set role all except r1, r2`, true, ""},
+		{`set role r1, 'r2'@'%'`, true, ""},
+		{`set role = 1`, true, ""},
+		{`set default role none to 'test'`, true, ""},
+		{`set default role all to 'test'@'%', 'b'`, true, ""},
+		{`set default role r1, r2 to 'test'`, true, ""},
+		{`set default role default to 'test'`, false, ""},
+		{`show grants for 'test'@'%' using r1, 'r2'@'%'`, true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("grant r1, 'r2'@'localhost' to 'test'", "", "")
+	c.Assert(err, IsNil)
+	grant := stmt.(*ast.GrantRoleStmt)
+	c.Assert(grant.Roles, HasLen, 2)
+	c.Assert(grant.Roles[0].String(), Equals, "'r1'@'%'")
+	c.Assert(grant.Roles[1].String(), Equals, "'r2'@'localhost'")
+	c.Assert(grant.Users[0].Username, Equals, "test")
+	stmt, err = parser.ParseOneStmt("set role all except r1", "", "")
+	c.Assert(err, IsNil)
+	setRole := stmt.(*ast.SetRoleStmt)
+	c.Assert(setRole.SetRoleOpt, Equals, ast.SetRoleAllExcept)
+	c.Assert(setRole.RoleList, HasLen, 1)
+	stmt, err = parser.ParseOneStmt("set default role none to 'test'", "", "")
+	c.Assert(err, IsNil)
+	setDefault := stmt.(*ast.SetDefaultRoleStmt)
+	c.Assert(setDefault.SetRoleOpt, Equals, ast.SetRoleNone)
+	c.Assert(setDefault.UserList, HasLen, 1)
+	stmt, err = parser.ParseOneStmt("create role r1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.CreateUserStmt).IsCreateRole, IsTrue)
+}
+
 func (s *testParserSuite) TestSQLNoCache(c *C) {
 	table := []testCase{
 		{`select SQL_NO_CACHE * from t`, false, ""},
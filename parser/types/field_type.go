@@ -15,6 +15,7 @@ package types
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/pingcap/tidb/parser/charset"
@@ -196,6 +197,58 @@ func (ft *FieldType) String() string {
 	return strings.Join(strs, " ")
 }
 
+// FormatAsCastType is used for write AST back to string.
+func (ft *FieldType) FormatAsCastType(w io.Writer) {
+	switch ft.Tp {
+	case mysql.TypeVarString, mysql.TypeString:
+		if ft.Charset == charset.CharsetBin && ft.Collate == charset.CollationBin {
+			fmt.Fprint(w, "BINARY")
+		} else {
+			fmt.Fprint(w, "CHAR")
+		}
+		if ft.Flen != UnspecifiedLength {
+			fmt.Fprintf(w, "(%d)", ft.Flen)
+		}
+		if ft.Charset != charset.CharsetBin && mysql.HasBinaryFlag(ft.Flag) {
+			fmt.Fprint(w, " BINARY")
+		}
+		if ft.Charset != charset.CharsetBin && ft.Charset != mysql.DefaultCharset {
+			fmt.Fprintf(w, " CHARSET %s", ft.Charset)
+		}
+	case mysql.TypeDate:
+		fmt.Fprint(w, "DATE")
+	case mysql.TypeDatetime:
+		fmt.Fprint(w, "DATETIME")
+		if ft.Decimal > 0 {
+			fmt.Fprintf(w, "(%d)", ft.Decimal)
+		}
+	case mysql.TypeNewDecimal:
+		fmt.Fprint(w, "DECIMAL")
+		if ft.Flen > 0 && ft.Decimal > 0 {
+			fmt.Fprintf(w, "(%d, %d)", ft.Flen, ft.Decimal)
+		} else if ft.Flen > 0 {
+			fmt.Fprintf(w, "(%d)", ft.Flen)
+		}
+	case mysql.TypeDuration:
+		fmt.Fprint(w, "TIME")
+		if ft.Decimal > 0 {
+			fmt.Fprintf(w, "(%d)", ft.Decimal)
+		}
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(ft.Flag) {
+			fmt.Fprint(w, "UNSIGNED")
+		} else {
+			fmt.Fprint(w, "SIGNED")
+		}
+	case mysql.TypeJSON:
+		fmt.Fprint(w, "JSON")
+	case mysql.TypeDouble:
+		fmt.Fprint(w, "DOUBLE")
+	case mysql.TypeFloat:
+		fmt.Fprint(w, "FLOAT")
+	}
+}
+
 // VarStorageLen indicates this column is a variable length column.
 const VarStorageLen = -1
 
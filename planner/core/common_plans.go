@@ -81,6 +81,7 @@ type Delete struct {
 type analyzeInfo struct {
 	DBName          string
 	TableName       string
+	PartitionName   string
 	PhysicalTableID int64
 }
 
@@ -30,6 +30,20 @@ func (p *LogicalUnionScan) exhaustPhysicalPlans(prop *property.PhysicalProperty)
 	return []PhysicalPlan{us}
 }
 
+func (p *LogicalUnionAll) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
+	// UnionAll can not keep any order of its children.
+	if !prop.IsEmpty() {
+		return nil
+	}
+	chReqProps := make([]*property.PhysicalProperty, 0, len(p.children))
+	for range p.children {
+		chReqProps = append(chReqProps, &property.PhysicalProperty{ExpectedCnt: prop.ExpectedCnt})
+	}
+	ua := PhysicalUnionAll{}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), chReqProps...)
+	ua.SetSchema(p.Schema())
+	return []PhysicalPlan{ua}
+}
+
 func getMaxSortPrefix(sortCols, allCols []*expression.Column) []int {
 	tmpSchema := expression.NewSchema(allCols...)
 	sortColOffsets := make([]int, 0, len(sortCols))
@@ -325,6 +339,18 @@ func (p *LogicalProjection) exhaustPhysicalPlans(prop *property.PhysicalProperty
 	return []PhysicalPlan{proj}
 }
 
+func (p *LogicalExpand) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
+	// The rows of different levels are interleaved, so no order can be kept.
+	if !prop.IsEmpty() {
+		return nil
+	}
+	expand := PhysicalExpand{
+		LevelExprs: p.LevelExprs,
+	}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), &property.PhysicalProperty{ExpectedCnt: math.MaxFloat64})
+	expand.SetSchema(p.schema)
+	return []PhysicalPlan{expand}
+}
+
 func (lt *LogicalTopN) getPhysTopN() []PhysicalPlan {
 	ret := make([]PhysicalPlan, 0, 3)
 	for _, tp := range wholeTaskTypes {
@@ -35,6 +35,11 @@ func (p *PhysicalIndexScan) explainInfo(normalized bool) string {
 		tblName = p.TableAsName.O
 	}
 	fmt.Fprintf(buffer, "table:%s", tblName)
+	if p.isPartition {
+		if pi := p.Table.GetPartitionInfo(); pi != nil {
+			fmt.Fprintf(buffer, ", partition:%s", pi.GetNameByID(p.physicalTableID))
+		}
+	}
 	if len(p.Index.Columns) > 0 {
 		buffer.WriteString(", index:")
 		for i, idxCol := range p.Index.Columns {
@@ -89,6 +94,11 @@ func (p *PhysicalTableScan) explainInfo(normalized bool) string {
 		tblName = p.TableAsName.O
 	}
 	fmt.Fprintf(buffer, "table:%s", tblName)
+	if p.isPartition {
+		if pi := p.Table.GetPartitionInfo(); pi != nil {
+			fmt.Fprintf(buffer, ", partition:%s", pi.GetNameByID(p.physicalTableID))
+		}
+	}
 	if p.pkCol != nil {
 		fmt.Fprintf(buffer, ", pk col:%s", p.pkCol.ExplainInfo())
 	}
@@ -165,6 +175,23 @@ func (p *PhysicalProjection) ExplainNormalizedInfo() string {
 	return string(expression.SortedExplainNormalizedExpressionList(p.Exprs))
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalExpand) ExplainInfo() string {
+	return explainLevelExprs(p.LevelExprs, p.schema)
+}
+
+// explainLevelExprs generates explain information for the levels of Expand.
+func explainLevelExprs(levelExprs [][]expression.Expression, schema *expression.Schema) string {
+	buffer := bytes.NewBufferString("level-projection:")
+	for i, exprs := range levelExprs {
+		if i > 0 {
+			buffer.WriteString("; ")
+		}
+		fmt.Fprintf(buffer, "[%s]", expression.ExplainExpressionList(exprs, schema))
+	}
+	return buffer.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalTableDual) ExplainInfo() string {
 	return fmt.Sprintf("rows:%v", p.RowCount)
@@ -356,6 +383,11 @@ func (p *LogicalProjection) ExplainInfo() string {
 	return expression.ExplainExpressionList(p.Exprs, p.schema)
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalExpand) ExplainInfo() string {
+	return explainLevelExprs(p.LevelExprs, p.schema)
+}
+
 // ExplainInfo implements Plan interface.
 func (p *LogicalSelection) ExplainInfo() string {
 	return string(expression.SortedExplainExpressionList(p.Conditions))
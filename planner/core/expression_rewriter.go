@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // evalAstExpr evaluates ast expression directly.
@@ -50,6 +51,25 @@ func evalAstExpr(sctx sessionctx.Context, expr ast.ExprNode) (types.Datum, error
 	return newExpr.Eval(chunk.Row{})
 }
 
+// rewriteAstExpr rewrites ast expr to expression.Expression against the given schema.
+func rewriteAstExpr(sctx sessionctx.Context, expr ast.ExprNode, schema *expression.Schema, names types.NameSlice) (expression.Expression, error) {
+	var is infoschema.InfoSchema
+	if sctx.GetSessionVars().TxnCtx.InfoSchema != nil {
+		is = sctx.GetSessionVars().TxnCtx.InfoSchema.(infoschema.InfoSchema)
+	}
+	b := NewPlanBuilder(sctx, is)
+	fakePlan := LogicalTableDual{}.Init(sctx)
+	if schema != nil {
+		fakePlan.schema = schema
+		fakePlan.names = names
+	}
+	newExpr, _, err := b.rewrite(context.TODO(), expr, fakePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return newExpr, nil
+}
+
 // rewrite function rewrites ast expr to expression.Expression.
 // aggMapper maps ast.AggregateFuncExpr to the columns offset in p's output schema.
 // asScalar means whether this expression must be treated as a scalar expression.
@@ -300,7 +320,7 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		inNode = er.preprocess(inNode)
 	}
 	switch v := inNode.(type) {
-	case *ast.AggregateFuncExpr, *ast.ColumnNameExpr, *ast.ParenthesesExpr, *ast.ValuesExpr:
+	case *ast.AggregateFuncExpr, *ast.ColumnNameExpr, *ast.ParenthesesExpr, *ast.WhenClause, *ast.ValuesExpr:
 	case *driver.ValueExpr:
 		value := &expression.Constant{Value: v.Datum, RetType: &v.Type}
 		er.ctxStackAppend(value, types.EmptyName)
@@ -308,6 +328,8 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.rewriteVariable(v)
 	case *ast.FuncCallExpr:
 		er.funcCallToExpression(v)
+	case *ast.FuncCastExpr:
+		er.castToScalarFunc(v)
 	case *ast.ColumnName:
 		er.toColumn(v)
 	case *ast.UnaryOperationExpr:
@@ -316,12 +338,20 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.binaryOpToExpression(v)
 	case *ast.BetweenExpr:
 		er.betweenToExpression(v)
+	case *ast.CaseExpr:
+		er.caseToExpression(v)
 	case *ast.RowExpr:
 		er.rowToScalarFunc(v)
 	case *ast.PatternInExpr:
 		er.inToExpression(len(v.List), v.Not, &v.Type)
+	case *ast.PatternLikeExpr:
+		er.patternLikeToExpression(v)
+	case *ast.PatternRegexpExpr:
+		er.regexpToScalarFunc(v)
 	case *ast.IsNullExpr:
 		er.isNullToExpression(v)
+	case *ast.IsTruthExpr:
+		er.isTrueToScalarFunc(v)
 	case *ast.DefaultExpr:
 		er.evalDefaultExpr(v)
 	default:
@@ -404,6 +434,8 @@ func (er *expressionRewriter) unaryOpToExpression(v *ast.UnaryOperationExpr) {
 		op = ast.UnaryMinus
 	case opcode.Not:
 		op = ast.UnaryNot
+	case opcode.BitNeg:
+		op = ast.BitNeg
 	default:
 		er.err = errors.Errorf("Unknown Unary Op %T", v.Op)
 		return
@@ -469,6 +501,104 @@ func (er *expressionRewriter) isNullToExpression(v *ast.IsNullExpr) {
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+// castToScalarFunc converts CAST and CONVERT to the cast function. Only the targets
+// which can be represented by the supported eval types are allowed.
+func (er *expressionRewriter) castToScalarFunc(v *ast.FuncCastExpr) {
+	stkLen := len(er.ctxStack)
+	arg := er.ctxStack[stkLen-1]
+	er.err = expression.CheckArgsNotMultiColumnRow(arg)
+	if er.err != nil {
+		return
+	}
+	if !expression.IsCastableEvalType(v.Tp) {
+		var sb strings.Builder
+		v.Tp.FormatAsCastType(&sb)
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("CAST AS " + sb.String())
+		return
+	}
+	castFunc, err := expression.BuildCastFunction(er.sctx, arg, v.Tp)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(1)
+	er.ctxStackAppend(castFunc, types.EmptyName)
+}
+
+// isTrueToScalarFunc converts "expr IS [NOT] TRUE/FALSE" to the istrue or isfalse function,
+// which is never null, unlike comparing the expression with true or false.
+func (er *expressionRewriter) isTrueToScalarFunc(v *ast.IsTruthExpr) {
+	stkLen := len(er.ctxStack)
+	op := ast.IsTruth
+	if v.True == 0 {
+		op = ast.IsFalsity
+	}
+	if expression.GetRowLen(er.ctxStack[stkLen-1]) != 1 {
+		er.err = expression.ErrOperandColumns.GenWithStackByArgs(1)
+		return
+	}
+	function := er.notToExpression(v.Not, op, &v.Type, er.ctxStack[stkLen-1])
+	er.ctxStackPop(1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// patternLikeToExpression converts like expression to a scalar function.
+// A pattern without any wildcard is treated the same way as predicate '=',
+// unless the comparison ignores case which '=' is not aware of.
+func (er *expressionRewriter) patternLikeToExpression(v *ast.PatternLikeExpr) {
+	l := len(er.ctxStack)
+	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[l-2:]...)
+	if er.err != nil {
+		return
+	}
+
+	var function expression.Expression
+	isPatternExactMatch := false
+	if patExpression, ok := er.ctxStack[l-1].(*expression.Constant); ok && !expression.IsPatternMatchCaseInsensitive(er.ctxStack[l-2:]) {
+		patString, isNull, err := patExpression.EvalString(er.sctx, chunk.Row{})
+		if err != nil {
+			er.err = err
+			return
+		}
+		if !isNull {
+			patValue, patTypes := stringutil.CompilePattern(patString, v.Escape)
+			if stringutil.IsExactMatch(patTypes) && er.ctxStack[l-2].GetType().EvalType() == types.ETString {
+				op := ast.EQ
+				if v.Not {
+					op = ast.NE
+				}
+				fieldType := &types.FieldType{}
+				types.DefaultTypeForValue(string(patValue), fieldType)
+				function, er.err = er.constructBinaryOpFunction(er.ctxStack[l-2],
+					&expression.Constant{Value: types.NewStringDatum(string(patValue)), RetType: fieldType},
+					op)
+				isPatternExactMatch = true
+			}
+		}
+	}
+	if !isPatternExactMatch {
+		fieldType := &types.FieldType{}
+		types.DefaultTypeForValue(int64(v.Escape), fieldType)
+		function = er.notToExpression(v.Not, ast.Like, &v.Type,
+			er.ctxStack[l-2], er.ctxStack[l-1], &expression.Constant{Value: types.NewIntDatum(int64(v.Escape)), RetType: fieldType})
+	}
+
+	er.ctxStackPop(2)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// regexpToScalarFunc converts regexp expression to a scalar function.
+func (er *expressionRewriter) regexpToScalarFunc(v *ast.PatternRegexpExpr) {
+	l := len(er.ctxStack)
+	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[l-2:]...)
+	if er.err != nil {
+		return
+	}
+	function := er.notToExpression(v.Not, ast.Regexp, &v.Type, er.ctxStack[l-2], er.ctxStack[l-1])
+	er.ctxStackPop(2)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 // inToExpression converts in expression to a scalar function. The argument lLen means the length of in list.
 // The argument not means if the expression is not in. The tp stands for the expression type, which is always bool.
 // a in (b, c, d) will be rewritten as `(a = b) or (a = c) or (a = d)`.
@@ -574,6 +704,57 @@ func (er *expressionRewriter) betweenToExpression(v *ast.BetweenExpr) {
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+func (er *expressionRewriter) caseToExpression(v *ast.CaseExpr) {
+	stkLen := len(er.ctxStack)
+	argsLen := 2 * len(v.WhenClauses)
+	if v.ElseClause != nil {
+		argsLen++
+	}
+	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[stkLen-argsLen:]...)
+	if er.err != nil {
+		return
+	}
+
+	// value                          -> ctxStack[stkLen-argsLen-1]
+	// when clause(condition, result) -> ctxStack[stkLen-argsLen:stkLen-1];
+	// else clause                    -> ctxStack[stkLen-1]
+	var args []expression.Expression
+	if v.Value != nil {
+		// args:  eq scalar func(args: value, condition1), result1,
+		//        eq scalar func(args: value, condition2), result2,
+		//        ...
+		//        else clause
+		value := er.ctxStack[stkLen-argsLen-1]
+		args = make([]expression.Expression, 0, argsLen)
+		for i := stkLen - argsLen; i < stkLen-1; i += 2 {
+			arg, err := er.newFunction(ast.EQ, types.NewFieldType(mysql.TypeTiny), value, er.ctxStack[i])
+			if err != nil {
+				er.err = err
+				return
+			}
+			args = append(args, arg)
+			args = append(args, er.ctxStack[i+1])
+		}
+		if v.ElseClause != nil {
+			args = append(args, er.ctxStack[stkLen-1])
+		}
+		argsLen++ // for trimming the value element later
+	} else {
+		// args:  condition1, result1,
+		//        condition2, result2,
+		//        ...
+		//        else clause
+		args = er.ctxStack[stkLen-argsLen:]
+	}
+	function, err := er.newFunction(ast.Case, &v.Type, args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(argsLen)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 // rewriteFuncCall handles a FuncCallExpr and generates a customized function.
 // It should return true if for the given FuncCallExpr a rewrite is performed so that original behavior is skipped.
 // Otherwise it should return false to indicate (the caller) that original behavior needs to be performed.
@@ -599,11 +780,77 @@ func (er *expressionRewriter) rewriteFuncCall(v *ast.FuncCallExpr) bool {
 		}
 
 		return false
+	case ast.Nullif:
+		if len(v.Args) != 2 {
+			er.err = expression.ErrIncorrectParameterCount.GenWithStackByArgs(v.FnName.O)
+			return true
+		}
+		stackLen := len(er.ctxStack)
+		param1 := er.ctxStack[stackLen-2]
+		param2 := er.ctxStack[stackLen-1]
+		// param1 = param2
+		funcCompare, err := er.constructBinaryOpFunction(param1, param2, ast.EQ)
+		if err != nil {
+			er.err = err
+			return true
+		}
+		// NULL
+		nullTp := types.NewFieldType(mysql.TypeNull)
+		nullTp.Flen, nullTp.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeNull)
+		paramNull := &expression.Constant{
+			Value:   types.NewDatum(nil),
+			RetType: nullTp,
+		}
+		// if(param1 = param2, NULL, param1)
+		funcIf, err := er.newFunction(ast.If, &v.Type, funcCompare, paramNull, param1)
+		if err != nil {
+			er.err = err
+			return true
+		}
+		er.ctxStackPop(len(v.Args))
+		er.ctxStackAppend(funcIf, types.EmptyName)
+		return true
+	case ast.Grouping:
+		er.groupingToExpression(v)
+		return true
 	default:
 		return false
 	}
 }
 
+// groupingToExpression rewrites GROUPING(a, b, ...) to the grouping function
+// on the grouping ID and the bits of its arguments, see rollupInfo.
+func (er *expressionRewriter) groupingToExpression(v *ast.FuncCallExpr) {
+	rollup := er.b.rollup
+	if rollup == nil {
+		er.err = ErrInvalidGroupFuncUse
+		return
+	}
+	stackLen := len(er.ctxStack)
+	args := make([]expression.Expression, 0, len(v.Args)+1)
+	args = append(args, rollup.groupingID)
+	for _, arg := range er.ctxStack[stackLen-len(v.Args):] {
+		col, isColumn := arg.(*expression.Column)
+		if !isColumn {
+			er.err = ErrWrongArguments.GenWithStackByArgs(v.FnName.O)
+			return
+		}
+		mask, ok := rollup.groupingMasks[col.UniqueID]
+		if !ok {
+			er.err = ErrWrongArguments.GenWithStackByArgs(v.FnName.O)
+			return
+		}
+		args = append(args, &expression.Constant{Value: types.NewIntDatum(mask), RetType: types.NewFieldType(mysql.TypeLonglong)})
+	}
+	function, err := er.newFunction(ast.Grouping, types.NewFieldType(mysql.TypeLonglong), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(len(v.Args))
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	stackLen := len(er.ctxStack)
 	args := er.ctxStack[stackLen-len(v.Args):]
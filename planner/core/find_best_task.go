@@ -416,9 +416,11 @@ func (ds *DataSource) convertToIndexScan(prop *property.PhysicalProperty, candid
 	if !candidate.isSingleScan {
 		// On this way, it's double read case.
 		ts := PhysicalTableScan{
-			Columns:     ds.Columns,
-			Table:       is.Table,
-			TableAsName: ds.TableAsName,
+			Columns:         ds.Columns,
+			Table:           is.Table,
+			TableAsName:     ds.TableAsName,
+			isPartition:     ds.isPartition,
+			physicalTableID: ds.physicalTableID,
 		}.Init(ds.ctx)
 		ts.SetSchema(ds.schema.Clone())
 		cop.tablePlan = ts
@@ -561,6 +563,8 @@ func (s *LogicalTableScan) GetPhysicalScan(schema *expression.Schema, stats *pro
 		DBName:          ds.DBName,
 		Ranges:          s.Ranges,
 		AccessCondition: s.AccessConds,
+		isPartition:     ds.isPartition,
+		physicalTableID: ds.physicalTableID,
 	}.Init(s.ctx)
 	ts.stats = stats
 	ts.SetSchema(schema.Clone())
@@ -581,6 +585,8 @@ func (s *LogicalIndexScan) GetPhysicalIndexScan(schema *expression.Schema, stats
 		AccessCondition:  s.AccessConds,
 		Ranges:           s.Ranges,
 		dataSourceSchema: ds.schema,
+		isPartition:      ds.isPartition,
+		physicalTableID:  ds.physicalTableID,
 	}.Init(ds.ctx)
 	is.stats = stats
 	is.initSchema(s.Index, s.FullIdxCols, s.IsDoubleRead)
@@ -636,6 +642,8 @@ func (ds *DataSource) getOriginalPhysicalTableScan(prop *property.PhysicalProper
 		Ranges:          path.Ranges,
 		AccessCondition: path.AccessConds,
 		filterCondition: path.TableFilters,
+		isPartition:     ds.isPartition,
+		physicalTableID: ds.physicalTableID,
 	}.Init(ds.ctx)
 	ts.SetSchema(ds.schema.Clone())
 	rowCount := path.CountAfterAccess
@@ -679,6 +687,8 @@ func (ds *DataSource) getOriginalPhysicalIndexScan(prop *property.PhysicalProper
 		AccessCondition:  path.AccessConds,
 		Ranges:           path.Ranges,
 		dataSourceSchema: ds.schema,
+		isPartition:      ds.isPartition,
+		physicalTableID:  ds.physicalTableID,
 	}.Init(ds.ctx)
 	rowCount := path.CountAfterAccess
 	is.initSchema(idx, path.FullIdxCols, !isSingleScan)
@@ -25,6 +25,8 @@ const (
 	TypeProj = "Projection"
 	// TypeAgg is the type of Aggregation.
 	TypeAgg = "Aggregation"
+	// TypeExpand is the type of Expand.
+	TypeExpand = "Expand"
 	// TypeHashAgg is the type of HashAgg.
 	TypeHashAgg = "HashAgg"
 	// TypeShow is the type of show.
@@ -35,6 +37,8 @@ const (
 	TypeTableScan = "TableScan"
 	// TypeMemTableScan is the type of TableScan.
 	TypeMemTableScan = "MemTableScan"
+	// TypeUnion is the type of UnionAll.
+	TypeUnion = "Union"
 	// TypeUnionScan is the type of UnionScan.
 	TypeUnionScan = "UnionScan"
 	// TypeIdxScan is the type of IndexScan.
@@ -123,6 +127,20 @@ func (p PhysicalSelection) Init(ctx sessionctx.Context, stats *property.StatsInf
 	return &p
 }
 
+// Init initializes LogicalUnionAll.
+func (p LogicalUnionAll) Init(ctx sessionctx.Context) *LogicalUnionAll {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, TypeUnion, &p)
+	return &p
+}
+
+// Init initializes PhysicalUnionAll.
+func (p PhysicalUnionAll) Init(ctx sessionctx.Context, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalUnionAll {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, TypeUnion, &p)
+	p.childrenReqProps = props
+	p.stats = stats
+	return &p
+}
+
 // Init initializes LogicalUnionScan.
 func (p LogicalUnionScan) Init(ctx sessionctx.Context) *LogicalUnionScan {
 	p.baseLogicalPlan = newBaseLogicalPlan(ctx, TypeUnionScan, &p)
@@ -143,6 +161,20 @@ func (p PhysicalProjection) Init(ctx sessionctx.Context, stats *property.StatsIn
 	return &p
 }
 
+// Init initializes LogicalExpand.
+func (p LogicalExpand) Init(ctx sessionctx.Context) *LogicalExpand {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, TypeExpand, &p)
+	return &p
+}
+
+// Init initializes PhysicalExpand.
+func (p PhysicalExpand) Init(ctx sessionctx.Context, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalExpand {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, TypeExpand, &p)
+	p.childrenReqProps = props
+	p.stats = stats
+	return &p
+}
+
 // Init initializes LogicalSort.
 func (ls LogicalSort) Init(ctx sessionctx.Context) *LogicalSort {
 	ls.baseLogicalPlan = newBaseLogicalPlan(ctx, TypeSort, &ls)
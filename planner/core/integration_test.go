@@ -88,3 +88,47 @@ func (s *testIntegrationSuite) TestPushLimitDownIndexLookUpReader(c *C) {
 		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
 	}
 }
+
+func (s *testIntegrationSuite) TestPartitionPruning(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_range, t_list, t_hash, t_expr, t_normal")
+	tk.MustExec("create table t_range (a int, b int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than maxvalue)")
+	tk.MustExec("create table t_list (a int, b int) partition by list (a) (partition p0 values in (1, 3, null), partition p1 values in (2, 4))")
+	tk.MustExec("create table t_hash (a int, b int) partition by hash (a) partitions 4")
+	tk.MustExec("create table t_expr (a int, b int) partition by range (a + 1) (partition p0 values less than (10), partition p1 values less than maxvalue)")
+	tk.MustExec("insert into t_range values (null, 0), (1, 1), (15, 15), (25, 25)")
+	tk.MustExec("insert into t_list values (null, 0), (1, 1), (2, 2), (4, 4)")
+	tk.MustExec("insert into t_hash values (null, 0), (1, 1), (5, 5), (6, 6)")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+
+	tk.MustQuery("select a from t_range where a is null").Check(testkit.Rows("<nil>"))
+	tk.MustQuery("select a from t_range where a >= 10 and a < 20").Check(testkit.Rows("15"))
+	tk.MustQuery("select a from t_range where a = 25 or a = 1 order by a").Check(testkit.Rows("1", "25"))
+	tk.MustQuery("select a from t_range where a > 30 and a < 10").Check(testkit.Rows())
+	tk.MustQuery("select a from t_list where a in (2, 4) order by a").Check(testkit.Rows("2", "4"))
+	tk.MustQuery("select a from t_list where a is null").Check(testkit.Rows("<nil>"))
+	tk.MustQuery("select a from t_hash where a in (1, 5) order by a").Check(testkit.Rows("1", "5"))
+	tk.MustQuery("select a from t_hash where a is null").Check(testkit.Rows("<nil>"))
+	tk.MustQuery("select a from t_range partition (p0, p2) order by a").Check(testkit.Rows("<nil>", "1", "25"))
+	tk.MustQuery("select a from t_range partition (P1) t where t.a > 10").Check(testkit.Rows("15"))
+	tk.MustQuery("select a from t_hash partition (p1) order by a").Check(testkit.Rows("1", "5"))
+
+	tk.MustExec("create table t_normal (a int)")
+	tk.MustGetErrCode("select * from t_normal partition (p0)", mysql.ErrPartitionClauseOnNonpartitioned)
+	tk.MustGetErrCode("select * from t_range partition (p0, p9)", mysql.ErrUnknownPartition)
+}
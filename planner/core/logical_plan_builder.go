@@ -63,7 +63,7 @@ func (la *LogicalAggregation) collectGroupByColumns() {
 	}
 }
 
-func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFuncList []*ast.AggregateFuncExpr, gbyItems []expression.Expression) (LogicalPlan, map[int]int, error) {
+func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFuncList []*ast.AggregateFuncExpr, gbyItems []expression.Expression, rollup bool) (LogicalPlan, map[int]int, error) {
 	b.optFlag = b.optFlag | flagBuildKeyInfo
 	b.optFlag = b.optFlag | flagPushDownAgg
 	// We may apply aggregation eliminate optimization.
@@ -91,10 +91,22 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 			p = np
 			newArgList = append(newArgList, newArg)
 		}
-		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, aggFunc.F, newArgList)
+		// DISTINCT makes no difference to MAX and MIN.
+		hasDistinct := aggFunc.Distinct && aggFunc.F != ast.AggFuncMax && aggFunc.F != ast.AggFuncMin
+		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, aggFunc.F, newArgList, hasDistinct)
 		if err != nil {
 			return nil, nil, err
 		}
+		if aggFunc.Order != nil {
+			for _, byItem := range aggFunc.Order.Items {
+				newByItem, np, err := b.rewrite(ctx, byItem.Expr, p, nil, true)
+				if err != nil {
+					return nil, nil, err
+				}
+				p = np
+				newFunc.OrderByItems = append(newFunc.OrderByItems, &aggregation.ByItems{Expr: newByItem, Desc: byItem.Desc})
+			}
+		}
 		combined := false
 		for j, oldFunc := range plan4Agg.AggFuncs {
 			if oldFunc.Equal(b.ctx, newFunc) {
@@ -114,8 +126,15 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 			names = append(names, types.EmptyName)
 		}
 	}
+	if rollup {
+		var err error
+		p, gbyItems, err = b.buildExpand(p, gbyItems)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	for i, col := range p.Schema().Columns {
-		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -133,6 +152,101 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 	return plan4Agg, aggIndexMap, nil
 }
 
+// rollupInfo records the grouping ID of GROUP BY ... WITH ROLLUP.
+type rollupInfo struct {
+	// groupingID is the column of the grouping ID, which has the bit of every
+	// rolled up group-by column set.
+	groupingID *expression.Column
+	// groupingMasks maps the UniqueID of a group-by column to its bit in the
+	// grouping ID.
+	groupingMasks map[int64]int64
+}
+
+// buildExpand builds the LogicalExpand for GROUP BY ... WITH ROLLUP. The
+// group-by columns are copied, so the aggregate functions still see the
+// original values while the copies are rolled up to NULL. It returns the new
+// group-by items, which are the copies and the grouping ID.
+func (b *PlanBuilder) buildExpand(p LogicalPlan, gbyItems []expression.Expression) (LogicalPlan, []expression.Expression, error) {
+	gbyCols := make([]*expression.Column, 0, len(gbyItems))
+	for _, item := range gbyItems {
+		col, ok := item.(*expression.Column)
+		if !ok {
+			return nil, nil, ErrNotSupportedYet.GenWithStackByArgs("WITH ROLLUP on non-column GROUP BY items")
+		}
+		if !expression.ExprFromSchema(col, expression.NewSchema(gbyCols...)) {
+			gbyCols = append(gbyCols, col)
+		}
+	}
+	if len(gbyCols) > 64 {
+		return nil, nil, ErrNotSupportedYet.GenWithStackByArgs("WITH ROLLUP on more than 64 GROUP BY columns")
+	}
+
+	childCols := p.Schema().Columns
+	schema := expression.NewSchema(make([]*expression.Column, 0, len(childCols)+len(gbyCols)+1)...)
+	names := make(types.NameSlice, 0, len(childCols)+len(gbyCols)+1)
+	for i, col := range childCols {
+		schema.Append(col.Clone().(*expression.Column))
+		names = append(names, p.OutputNames()[i])
+	}
+	info := &rollupInfo{groupingMasks: make(map[int64]int64, len(gbyCols))}
+	copies := make([]*expression.Column, 0, len(gbyCols))
+	for i, col := range gbyCols {
+		tp := col.RetType.Clone()
+		tp.Flag &^= mysql.NotNullFlag
+		newCol := &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  tp,
+		}
+		// The clauses after the aggregation refer to the copy by the name of the
+		// group-by column.
+		name := types.EmptyName
+		if idx := p.Schema().ColumnIndex(col); idx >= 0 {
+			name, names[idx] = names[idx], types.EmptyName
+		}
+		schema.Append(newCol)
+		names = append(names, name)
+		copies = append(copies, newCol)
+		// The first group-by column is the most significant bit.
+		info.groupingMasks[newCol.UniqueID] = 1 << uint(len(gbyCols)-1-i)
+	}
+	info.groupingID = &expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  types.NewFieldType(mysql.TypeLonglong),
+	}
+	info.groupingID.RetType.Flag |= mysql.NotNullFlag
+	schema.Append(info.groupingID)
+	names = append(names, types.EmptyName)
+
+	// The i-th level rolls up the last i group-by columns.
+	levelExprs := make([][]expression.Expression, 0, len(gbyCols)+1)
+	for i := 0; i <= len(gbyCols); i++ {
+		exprs := make([]expression.Expression, 0, schema.Len())
+		for _, col := range childCols {
+			exprs = append(exprs, col)
+		}
+		for j, col := range gbyCols {
+			if j < len(gbyCols)-i {
+				exprs = append(exprs, col)
+			} else {
+				exprs = append(exprs, &expression.Constant{Value: types.NewDatum(nil), RetType: copies[j].RetType})
+			}
+		}
+		groupingID := &expression.Constant{
+			Value:   types.NewIntDatum(int64(1)<<uint(i) - 1),
+			RetType: types.NewFieldType(mysql.TypeLonglong),
+		}
+		exprs = append(exprs, groupingID)
+		levelExprs = append(levelExprs, exprs)
+	}
+
+	expand := LogicalExpand{LevelExprs: levelExprs}.Init(b.ctx)
+	expand.SetChildren(p)
+	expand.SetSchema(schema)
+	expand.names = names
+	b.rollup = info
+	return expand, append(expression.Column2Exprs(copies), info.groupingID), nil
+}
+
 func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSetNode) (p LogicalPlan, err error) {
 	switch x := node.(type) {
 	case *ast.Join:
@@ -594,6 +708,25 @@ func (b *PlanBuilder) buildProjection(ctx context.Context, p LogicalPlan, fields
 		}
 		schema.Append(col)
 		newNames = append(newNames, name)
+		if b.rollup != nil {
+			if c, ok := newExpr.(*expression.Column); ok {
+				if mask, ok := b.rollup.groupingMasks[c.UniqueID]; ok {
+					b.rollup.groupingMasks[col.UniqueID] = mask
+				}
+			}
+		}
+	}
+	if b.rollup != nil {
+		// Keep the grouping ID for the GROUPING() functions in HAVING and ORDER BY,
+		// it's removed with the auxiliary fields at the end of buildSelect.
+		col := &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  b.rollup.groupingID.RetType,
+		}
+		proj.Exprs = append(proj.Exprs, b.rollup.groupingID)
+		schema.Append(col)
+		newNames = append(newNames, types.EmptyName)
+		b.rollup.groupingID = col
 	}
 	proj.SetSchema(schema)
 	proj.SetChildren(p)
@@ -610,7 +743,7 @@ func (b *PlanBuilder) buildDistinct(child LogicalPlan, length int) (*LogicalAggr
 	}.Init(b.ctx)
 	plan4Agg.collectGroupByColumns()
 	for _, col := range child.Schema().Columns {
-		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 		if err != nil {
 			return nil, err
 		}
@@ -1189,6 +1322,10 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 		defer func() { b.inStraightJoin = origin }()
 	}
 
+	// GROUPING() can only refer to the WITH ROLLUP of its own SELECT.
+	defer func(origin *rollupInfo) { b.rollup = origin }(b.rollup)
+	b.rollup = nil
+
 	var (
 		aggFuncs                      []*ast.AggregateFuncExpr
 		havingMap, orderMap, totalMap map[*ast.AggregateFuncExpr]int
@@ -1239,7 +1376,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	if hasAgg {
 		aggFuncs, totalMap = b.extractAggFuncs(sel.Fields.Fields)
 		var aggIndexMap map[int]int
-		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, gbyCols)
+		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, gbyCols, sel.GroupBy != nil && sel.GroupBy.Rollup)
 		if err != nil {
 			return nil, err
 		}
@@ -1360,11 +1497,24 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 
 	tableInfo := tbl.Meta()
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, dbName.L, tableInfo.Name.L, "",
+		b.tableAccessDenied("SELECT", tableInfo.Name.L))
 
 	if tbl.Type().IsVirtualTable() {
 		return b.buildMemTable(ctx, dbName, tableInfo)
 	}
 
+	if pi := tableInfo.GetPartitionInfo(); pi != nil {
+		b.optFlag = b.optFlag | flagPartitionProcessor
+		for _, name := range tn.PartitionNames {
+			if pi.FindPartitionDefinitionByName(name.L) < 0 {
+				return nil, table.ErrUnknownPartition.GenWithStackByArgs(name.O, tableInfo.Name.O)
+			}
+		}
+	} else if len(tn.PartitionNames) != 0 {
+		return nil, ErrPartitionClauseOnNonpartitioned
+	}
+
 	tblName := *asName
 	if tblName.L == "" {
 		tblName = tn.Name
@@ -1382,6 +1532,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		tableInfo:           tableInfo,
 		statisticTable:      getStatsTable(b.ctx, tbl.Meta(), tbl.Meta().ID),
 		indexHints:          tn.IndexHints,
+		partitionNames:      tn.PartitionNames,
 		possibleAccessPaths: possiblePaths,
 		Columns:             make([]*model.ColumnInfo, 0, len(columns)),
 		TblCols:             make([]*expression.Column, 0, len(columns)),
@@ -1630,6 +1781,14 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 	p = proj
 
 	del := Delete{}.Init(b.ctx)
+	// Only the target table needs the DELETE privilege, the other tables, such as the ones in the
+	// subqueries of WHERE, need the SELECT privilege which is required when their DataSources are built.
+	tn, err := getDeleteTargetTable(delete)
+	if err != nil {
+		return nil, err
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, tn.Schema.L, tn.Name.L, "",
+		b.tableAccessDenied("DELETE", tn.Name.L))
 
 	del.names = p.OutputNames()
 	del.SelectPlan, err = DoOptimize(ctx, b.optFlag, p)
@@ -1649,6 +1808,18 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 	return del, err
 }
 
+// getDeleteTargetTable returns the table whose rows are deleted. Only the single-table syntax is
+// supported, so the target is the only table source of TableRefs.
+func getDeleteTargetTable(delete *ast.DeleteStmt) (*ast.TableName, error) {
+	join := delete.TableRefs.TableRefs
+	if ts, ok := join.Left.(*ast.TableSource); ok && join.Right == nil {
+		if tn, ok := ts.Source.(*ast.TableName); ok {
+			return tn, nil
+		}
+	}
+	return nil, errors.New("multiple-table delete is not supported")
+}
+
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]*expression.Column, schema *expression.Schema) (map[int64][]*expression.Column, error) {
 	newMap := make(map[int64][]*expression.Column, len(tblID2Handle))
 	for i, cols := range tblID2Handle {
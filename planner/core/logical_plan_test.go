@@ -312,6 +312,30 @@ func (s *testPlanSuite) TestEagerAggregation(c *C) {
 	s.ctx.GetSessionVars().AllowAggPushDown = false
 }
 
+func (s *testPlanSuite) TestSplitDistinctAgg(c *C) {
+	defer testleak.AfterTest(c)()
+	var input []string
+	var output []string
+	s.testData.GetTestCases(c, &input, &output)
+	ctx := context.Background()
+	// The distinct aggregations are split even if the aggregation push down is off.
+	s.ctx.GetSessionVars().AllowAggPushDown = false
+	for ith, tt := range input {
+		comment := Commentf("for %s", tt)
+		stmt, err := s.ParseOneStmt(tt, "", "")
+		c.Assert(err, IsNil, comment)
+
+		p, _, err := BuildLogicalPlan(ctx, s.ctx, stmt, s.is)
+		c.Assert(err, IsNil)
+		p, err = logicalOptimize(context.TODO(), flagBuildKeyInfo|flagPredicatePushDown|flagPrunColumns|flagPushDownAgg, p.(LogicalPlan))
+		c.Assert(err, IsNil)
+		s.testData.OnRecord(func() {
+			output[ith] = ToString(p)
+		})
+		c.Assert(ToString(p), Equals, output[ith], Commentf("for %s %d", tt, ith))
+	}
+}
+
 func (s *testPlanSuite) TestColumnPruning(c *C) {
 	defer testleak.AfterTest(c)()
 	var (
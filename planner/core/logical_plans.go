@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
@@ -35,6 +36,7 @@ var (
 	_ LogicalPlan = &LogicalJoin{}
 	_ LogicalPlan = &LogicalAggregation{}
 	_ LogicalPlan = &LogicalProjection{}
+	_ LogicalPlan = &LogicalExpand{}
 	_ LogicalPlan = &LogicalSelection{}
 	_ LogicalPlan = &LogicalTableDual{}
 	_ LogicalPlan = &DataSource{}
@@ -127,6 +129,18 @@ type LogicalProjection struct {
 	Exprs []expression.Expression
 }
 
+// LogicalExpand replicates every input row once for each grouping set of
+// GROUP BY ... WITH ROLLUP, so that the aggregation above it can compute the
+// super-aggregate rows. Each grouping set is a level of expressions evaluated
+// on the input row, which keeps the child columns, copies the group-by columns
+// of the grouping set, replaces the other group-by columns by NULL and sets the
+// grouping ID.
+type LogicalExpand struct {
+	logicalSchemaProducer
+
+	LevelExprs [][]expression.Expression
+}
+
 // LogicalAggregation represents an aggregate plan.
 type LogicalAggregation struct {
 	logicalSchemaProducer
@@ -181,6 +195,12 @@ type LogicalMemTable struct {
 	tableInfo *model.TableInfo
 }
 
+// LogicalUnionAll represents LogicalUnionAll plan. It is only used to union
+// the DataSources of the partitions of a partitioned table for now.
+type LogicalUnionAll struct {
+	logicalSchemaProducer
+}
+
 // LogicalUnionScan is only used in non read-only txn.
 type LogicalUnionScan struct {
 	baseLogicalPlan
@@ -222,6 +242,13 @@ type DataSource struct {
 	// TblColHists contains the Histogram of all original table columns,
 	// it is converted from statisticTable, and used for IO/network cost estimating.
 	TblColHists *statistics.HistColl
+
+	// isPartition is true if the DataSource reads a single partition of a
+	// partitioned table, physicalTableID is the ID of that partition then.
+	isPartition     bool
+	physicalTableID int64
+	// partitionNames are the partitions explicitly selected by `PARTITION (p0, p1)`.
+	partitionNames []model.CIStr
 }
 
 // TiKVSingleGather is a leaf logical operator of TiDB layer to gather
@@ -584,7 +611,9 @@ type ShowContents struct {
 	Full        bool
 	IfNotExists bool // Used for `show create database if not exists`.
 
-	GlobalScope bool // Used by show variables.
+	GlobalScope bool                 // Used by show variables.
+	User        *auth.UserIdentity   // Used for show grants.
+	Roles       []*auth.RoleIdentity // Used for show grants.
 }
 
 // LogicalShow represents a show plan.
@@ -36,6 +36,7 @@ const (
 	flagMaxMinEliminate
 	flagPredicatePushDown
 	flagEliminateOuterJoin
+	flagPartitionProcessor
 	flagPushDownAgg
 	flagPushDownTopN
 	flagJoinReOrder
@@ -49,6 +50,7 @@ var optRuleList = []logicalOptRule{
 	&maxMinEliminator{},
 	&ppdSolver{},
 	&outerJoinEliminator{},
+	&partitionProcessor{},
 	&aggregationPushDownSolver{},
 	&pushDownTopNOptimizer{},
 	&joinReOrderSolver{},
@@ -135,4 +137,5 @@ func physicalOptimize(logic LogicalPlan) (PhysicalPlan, error) {
 
 func init() {
 	expression.EvalAstExpr = evalAstExpr
+	expression.RewriteAstExpr = rewriteAstExpr
 }
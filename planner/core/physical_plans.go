@@ -26,6 +26,7 @@ import (
 var (
 	_ PhysicalPlan = &PhysicalSelection{}
 	_ PhysicalPlan = &PhysicalProjection{}
+	_ PhysicalPlan = &PhysicalExpand{}
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalTableDual{}
 	_ PhysicalPlan = &PhysicalSort{}
@@ -148,6 +149,10 @@ type PhysicalIndexScan struct {
 	// DoubleRead means if the index executor will read kv two times.
 	// If the query requires the columns that don't belong to index, DoubleRead will be true.
 	DoubleRead bool
+
+	// The index scan may be on a partition.
+	isPartition     bool
+	physicalTableID int64
 }
 
 // PhysicalMemTable reads memory table.
@@ -177,11 +182,21 @@ type PhysicalTableScan struct {
 
 	HandleIdx int
 
+	// The table scan may be a partition, rather than a real table.
+	isPartition bool
+	// If the table is partitioned, physicalTableID is the partition ID.
+	physicalTableID int64
+
 	// KeepOrder is true, if sort data by scanning pkcol,
 	KeepOrder bool
 	Desc      bool
 }
 
+// IsPartition returns true and partition ID if it's actually a partition.
+func (ts *PhysicalTableScan) IsPartition() (bool, int64) {
+	return ts.isPartition, ts.physicalTableID
+}
+
 // PhysicalProjection is the physical operator of projection.
 type PhysicalProjection struct {
 	physicalSchemaProducer
@@ -189,6 +204,13 @@ type PhysicalProjection struct {
 	Exprs []expression.Expression
 }
 
+// PhysicalExpand is the physical operator of Expand.
+type PhysicalExpand struct {
+	physicalSchemaProducer
+
+	LevelExprs [][]expression.Expression
+}
+
 // PhysicalTopN is the physical operator of topN.
 type PhysicalTopN struct {
 	basePhysicalPlan
@@ -307,6 +329,11 @@ type NominalSort struct {
 	basePhysicalPlan
 }
 
+// PhysicalUnionAll is the physical operator of UnionAll.
+type PhysicalUnionAll struct {
+	physicalSchemaProducer
+}
+
 // PhysicalUnionScan represents a union scan operator.
 type PhysicalUnionScan struct {
 	basePhysicalPlan
@@ -316,6 +343,11 @@ type PhysicalUnionScan struct {
 	HandleCol *expression.Column
 }
 
+// IsPartition returns true and partition ID if it works on a partition.
+func (p *PhysicalIndexScan) IsPartition() (bool, int64) {
+	return p.isPartition, p.physicalTableID
+}
+
 // IsPointGetByUniqueKey checks whether is a point get by unique key.
 func (p *PhysicalIndexScan) IsPointGetByUniqueKey(sc *stmtctx.StatementContext) bool {
 	return len(p.Ranges) == 1 &&
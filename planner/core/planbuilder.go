@@ -23,11 +23,13 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
@@ -157,6 +159,8 @@ var clauseMsg = map[clauseCode]string{
 type PlanBuilder struct {
 	ctx sessionctx.Context
 	is  infoschema.InfoSchema
+	// visitInfo is used for privilege check.
+	visitInfo []visitInfo
 	// colMapper stores the column that must be pre-resolved.
 	colMapper map[*ast.ColumnNameExpr]int
 
@@ -175,6 +179,10 @@ type PlanBuilder struct {
 	// "STRAIGHT_JOIN" option.
 	inStraightJoin bool
 
+	// rollup records the grouping ID of the current GROUP BY ... WITH ROLLUP,
+	// it's used to rewrite the GROUPING() functions.
+	rollup *rollupInfo
+
 	// handleHelper records the handle column position for tables. Delete/Update/SelectLock/UnionScan may need this information.
 	// It collects the information by the following procedure:
 	//   Since we build the plan tree from bottom to top, we maintain a stack to record the current handle information.
@@ -224,6 +232,57 @@ func (hch *handleColHelper) tailMap() map[int64][]*expression.Column {
 	return hch.id2HandleMapStack[hch.stackTail-1]
 }
 
+// visitInfo records a privilege the statement needs on an object.
+type visitInfo struct {
+	privilege mysql.PrivilegeType
+	db        string
+	table     string
+	column    string
+	err       error
+}
+
+func appendVisitInfo(vi []visitInfo, priv mysql.PrivilegeType, db, tbl, col string, err error) []visitInfo {
+	return append(vi, visitInfo{
+		privilege: priv,
+		db:        db,
+		table:     tbl,
+		column:    col,
+		err:       err,
+	})
+}
+
+// GetVisitInfo gets the visitInfo of the PlanBuilder.
+func (b *PlanBuilder) GetVisitInfo() []visitInfo {
+	return b.visitInfo
+}
+
+// CheckPrivilege checks the privilege for a user with the active roles.
+func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs []visitInfo) error {
+	for _, v := range vs {
+		if !pm.RequestVerification(activeRoles, v.db, v.table, v.column, v.privilege) {
+			if v.err == nil {
+				return ErrPrivilegeCheckFail
+			}
+			return v.err
+		}
+	}
+	return nil
+}
+
+// userAndHost returns the account of the current user used in the error messages.
+func (b *PlanBuilder) userAndHost() (string, string) {
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		return user.AuthUsername, user.AuthHostname
+	}
+	return "", ""
+}
+
+// tableAccessDenied returns the error of the command denied on the table.
+func (b *PlanBuilder) tableAccessDenied(command, table string) error {
+	user, host := b.userAndHost()
+	return ErrTableaccessDenied.GenWithStackByArgs(command, user, host, table)
+}
+
 // GetOptFlag gets the optFlag of the PlanBuilder.
 func (b *PlanBuilder) GetOptFlag() uint64 {
 	return b.optFlag
@@ -259,7 +318,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		return b.buildSet(ctx, x)
 	case *ast.AnalyzeTableStmt:
 		return b.buildAnalyze(x)
-	case *ast.UseStmt, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+	case *ast.UseStmt, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.KillStmt,
+		*ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.GrantStmt, *ast.RevokeStmt,
+		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt:
 		return b.buildSimple(node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -270,6 +331,10 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 func (b *PlanBuilder) buildSet(ctx context.Context, v *ast.SetStmt) (Plan, error) {
 	p := &Set{}
 	for _, vars := range v.Variables {
+		if vars.IsGlobal {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		}
 		assign := &expression.VarAssignment{
 			Name:     vars.Name,
 			IsGlobal: vars.IsGlobal,
@@ -480,28 +545,53 @@ func getColsInfo(tn *ast.TableName) (indicesInfo []*model.IndexInfo, colsInfo []
 func (b *PlanBuilder) buildAnalyze(as *ast.AnalyzeTableStmt) (Plan, error) {
 	p := &Analyze{}
 	for _, tbl := range as.TableNames {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tbl.Schema.L, tbl.Name.L, "",
+			b.tableAccessDenied("INSERT", tbl.Name.L))
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, tbl.Schema.L, tbl.Name.L, "",
+			b.tableAccessDenied("SELECT", tbl.Name.L))
 		idxInfo, colInfo, pkInfo := getColsInfo(tbl)
+		physicalIDs, names := getPhysicalIDsAndPartitionNames(tbl.TableInfo)
 		for _, idx := range idxInfo {
-			info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: tbl.TableInfo.ID}
-			p.IdxTasks = append(p.IdxTasks, AnalyzeIndexTask{
-				IndexInfo:   idx,
-				analyzeInfo: info,
-				TblInfo:     tbl.TableInfo,
-			})
+			for i, id := range physicalIDs {
+				info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PartitionName: names[i], PhysicalTableID: id}
+				p.IdxTasks = append(p.IdxTasks, AnalyzeIndexTask{
+					IndexInfo:   idx,
+					analyzeInfo: info,
+					TblInfo:     tbl.TableInfo,
+				})
+			}
 		}
 		if len(colInfo) > 0 || pkInfo != nil {
-			info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: tbl.TableInfo.ID}
-			p.ColTasks = append(p.ColTasks, AnalyzeColumnsTask{
-				PKInfo:      pkInfo,
-				ColsInfo:    colInfo,
-				analyzeInfo: info,
-				TblInfo:     tbl.TableInfo,
-			})
+			for i, id := range physicalIDs {
+				info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PartitionName: names[i], PhysicalTableID: id}
+				p.ColTasks = append(p.ColTasks, AnalyzeColumnsTask{
+					PKInfo:      pkInfo,
+					ColsInfo:    colInfo,
+					analyzeInfo: info,
+					TblInfo:     tbl.TableInfo,
+				})
+			}
 		}
 	}
 	return p, nil
 }
 
+// getPhysicalIDsAndPartitionNames returns the IDs of the physical tables to analyze,
+// they are the partitions of a partitioned table or the table itself.
+func getPhysicalIDsAndPartitionNames(tblInfo *model.TableInfo) ([]int64, []string) {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return []int64{tblInfo.ID}, []string{""}
+	}
+	ids := make([]int64, 0, len(pi.Definitions))
+	names := make([]string, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		ids = append(ids, def.ID)
+		names = append(names, def.Name.O)
+	}
+	return ids, names
+}
+
 func buildShowDDLFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(6)
 	schema.Append(buildColumnWithName("", "SCHEMA_VER", mysql.TypeLonglong, 4))
@@ -607,10 +697,29 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 		},
 	}.Init(b.ctx)
 	switch show.Tp {
-	case ast.ShowTables:
+	case ast.ShowTables, ast.ShowTableStatus:
 		if p.DBName == "" {
 			return nil, ErrNoDB
 		}
+	case ast.ShowGrants:
+		user := b.ctx.GetSessionVars().User
+		roles := show.Roles
+		if show.User == nil || show.User.CurrentUser {
+			if user != nil {
+				show.User = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+			}
+			// The grants of the current user include the privileges of the active roles.
+			if roles == nil {
+				roles = b.ctx.GetSessionVars().ActiveRoles
+			}
+		} else if user == nil || show.User.Username != user.AuthUsername || show.User.Hostname != user.AuthHostname {
+			// Showing the grants of other users needs to read the mysql database.
+			userName, host := b.userAndHost()
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, mysql.SystemDB, "", "",
+				ErrDBaccessDenied.GenWithStackByArgs(userName, host, mysql.SystemDB))
+		}
+		p.User = show.User
+		p.Roles = roles
 	}
 	schema, names := buildShowSchema(show)
 	p.SetSchema(schema)
@@ -654,10 +763,99 @@ func (b *PlanBuilder) buildSimple(node ast.StmtNode) (Plan, error) {
 		if raw.DBName == "" {
 			return nil, ErrNoDB
 		}
+	case *ast.CreateUserStmt:
+		if raw.IsCreateRole {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE ROLE")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRolePriv, "", "", "", err)
+		} else {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
+		}
+	case *ast.DropUserStmt:
+		if raw.IsDropRole {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("DROP ROLE")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropRolePriv, "", "", "", err)
+		} else {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
+		}
+	case *ast.GrantRoleStmt, *ast.RevokeRoleStmt:
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	case *ast.SetDefaultRoleStmt:
+		// Users can always change their own default roles.
+		if !b.isCurrentUsers(raw.UserList) {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
+		}
+	case *ast.AlterUserStmt:
+		// Users can always change their own passwords.
+		if !b.isCurrentUserSpecs(raw.Specs) {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
+		}
+	case *ast.GrantStmt:
+		b.visitInfo = b.collectVisitInfoFromGrantStmt(raw.Level, raw.Privs)
+	case *ast.RevokeStmt:
+		b.visitInfo = b.collectVisitInfoFromGrantStmt(raw.Level, raw.Privs)
 	}
 	return p, nil
 }
 
+func (b *PlanBuilder) isCurrentUserSpecs(specs []*ast.UserSpec) bool {
+	users := make([]*auth.UserIdentity, 0, len(specs))
+	for _, spec := range specs {
+		users = append(users, spec.User)
+	}
+	return b.isCurrentUsers(users)
+}
+
+func (b *PlanBuilder) isCurrentUsers(users []*auth.UserIdentity) bool {
+	user := b.ctx.GetSessionVars().User
+	for _, u := range users {
+		if u.CurrentUser {
+			continue
+		}
+		if user == nil || u.Username != user.AuthUsername || u.Hostname != user.AuthHostname {
+			return false
+		}
+	}
+	return true
+}
+
+// collectVisitInfoFromGrantStmt collects the privileges needed by GRANT and
+// REVOKE: the GRANT OPTION and the privileges themselves at the level.
+func (b *PlanBuilder) collectVisitInfoFromGrantStmt(level *ast.GrantLevel, privs []*ast.PrivElem) []visitInfo {
+	vi := b.visitInfo
+	dbName := level.DBName
+	if dbName == "" && level.Level != ast.GrantLevelGlobal {
+		dbName = b.ctx.GetSessionVars().CurrentDB
+	}
+	tableName := level.TableName
+	err := ErrSpecificAccessDenied.GenWithStackByArgs("GRANT OPTION")
+	vi = appendVisitInfo(vi, mysql.GrantPriv, dbName, tableName, "", err)
+
+	var allPrivs []mysql.PrivilegeType
+	switch level.Level {
+	case ast.GrantLevelGlobal:
+		allPrivs = mysql.AllGlobalPrivs
+	case ast.GrantLevelDB:
+		allPrivs = mysql.AllDBPrivs
+	case ast.GrantLevelTable:
+		allPrivs = mysql.AllTablePrivs
+	}
+	for _, item := range privs {
+		if item.Priv == mysql.AllPriv {
+			for _, priv := range allPrivs {
+				vi = appendVisitInfo(vi, priv, dbName, tableName, "", err)
+			}
+			continue
+		}
+		vi = appendVisitInfo(vi, item.Priv, dbName, tableName, "", err)
+	}
+	return vi
+}
+
 func (b *PlanBuilder) getDefaultValue(col *table.Column) (*expression.Constant, error) {
 	value, err := table.GetColDefaultValue(b.ctx, col.ToInfo())
 	if err != nil {
@@ -685,6 +883,12 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		return nil, infoschema.ErrTableNotExists.GenWithStackByArgs()
 	}
 	tableInfo := tn.TableInfo
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tn.Schema.L, tableInfo.Name.L, "",
+		b.tableAccessDenied("INSERT", tableInfo.Name.L))
+	if insert.IsReplace {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, tn.Schema.L, tableInfo.Name.L, "",
+			b.tableAccessDenied("DELETE", tableInfo.Name.L))
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names := expression.TableInfo2SchemaAndNames(b.ctx, tn.Schema, tableInfo)
 	tableInPlan, ok := b.is.TableByID(tableInfo.ID)
@@ -907,10 +1111,63 @@ func (b *PlanBuilder) buildSelectPlanOfInsert(ctx context.Context, insert *ast.I
 }
 
 func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, error) {
+	switch v := node.(type) {
+	case *ast.AlterTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("ALTER", v.Table.Name.L))
+		for _, spec := range v.Specs {
+			if spec.Tp == ast.AlterTableRenameTable {
+				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.Table.Schema.L, v.Table.Name.L, "",
+					b.tableAccessDenied("DROP", v.Table.Name.L))
+				b.visitInfo = appendRenameTargetVisitInfo(b, spec.NewTable)
+			}
+		}
+	case *ast.CreateDatabaseStmt:
+		user, host := b.userAndHost()
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name, "", "",
+			ErrDBaccessDenied.GenWithStackByArgs(user, host, v.Name))
+	case *ast.CreateIndexStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.IndexPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("INDEX", v.Table.Name.L))
+	case *ast.CreateTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("CREATE", v.Table.Name.L))
+	case *ast.DropDatabaseStmt:
+		user, host := b.userAndHost()
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.Name, "", "",
+			ErrDBaccessDenied.GenWithStackByArgs(user, host, v.Name))
+	case *ast.DropIndexStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.IndexPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("INDEX", v.Table.Name.L))
+	case *ast.DropTableStmt:
+		for _, tableVal := range v.Tables {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, tableVal.Schema.L, tableVal.Name.L, "",
+				b.tableAccessDenied("DROP", tableVal.Name.L))
+		}
+	case *ast.RenameTableStmt:
+		for _, t := range v.TableToTables {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, t.OldTable.Schema.L, t.OldTable.Name.L, "",
+				b.tableAccessDenied("ALTER", t.OldTable.Name.L))
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, t.OldTable.Schema.L, t.OldTable.Name.L, "",
+				b.tableAccessDenied("DROP", t.OldTable.Name.L))
+			b.visitInfo = appendRenameTargetVisitInfo(b, t.NewTable)
+		}
+	case *ast.TruncateTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("DROP", v.Table.Name.L))
+	}
 	p := &DDL{Statement: node}
 	return p, nil
 }
 
+// appendRenameTargetVisitInfo requires the privileges to create and fill the table a table is renamed to.
+func appendRenameTargetVisitInfo(b *PlanBuilder, newTable *ast.TableName) []visitInfo {
+	vi := appendVisitInfo(b.visitInfo, mysql.CreatePriv, newTable.Schema.L, newTable.Name.L, "",
+		b.tableAccessDenied("CREATE", newTable.Name.L))
+	return appendVisitInfo(vi, mysql.InsertPriv, newTable.Schema.L, newTable.Name.L, "",
+		b.tableAccessDenied("INSERT", newTable.Name.L))
+}
+
 func (b *PlanBuilder) buildExplainPlan(targetPlan Plan, format string, execStmt ast.StmtNode) (Plan, error) {
 	p := &Explain{
 		TargetPlan: targetPlan,
@@ -962,6 +1219,59 @@ func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema, outputNames []
 		names = []string{"Table", "Create Table"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
+	case ast.ShowColumns:
+		names = []string{"Field", "Type", "Null", "Key", "Default", "Extra"}
+		if s.Full {
+			names = []string{"Field", "Type", "Collation", "Null", "Key", "Default", "Extra", "Privileges", "Comment"}
+		}
+	case ast.ShowIndex:
+		names = []string{"Table", "Non_unique", "Key_name", "Seq_in_index",
+			"Column_name", "Collation", "Cardinality", "Sub_part", "Packed",
+			"Null", "Index_type", "Comment", "Index_comment"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeLonglong,
+			mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong,
+			mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowTableStatus:
+		names = []string{"Name", "Engine", "Version", "Row_format", "Rows", "Avg_row_length",
+			"Data_length", "Max_data_length", "Index_length", "Data_free", "Auto_increment",
+			"Create_time", "Update_time", "Check_time", "Collation", "Checksum",
+			"Create_options", "Comment"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeLonglong,
+			mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowStatus:
+		names = []string{"Variable_name", "Value"}
+	case ast.ShowCharset:
+		names = []string{"Charset", "Description", "Default collation", "Maxlen"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}
+	case ast.ShowCollation:
+		names = []string{"Collation", "Charset", "Id", "Default", "Compiled", "Sortlen"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong,
+			mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}
+	case ast.ShowEngines:
+		names = []string{"Engine", "Support", "Comment", "Transactions", "XA", "Savepoints"}
+	case ast.ShowStatsMeta:
+		names = []string{"Db_name", "Table_name", "Partition_name", "Update_time", "Modify_count", "Row_count"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong}
+	case ast.ShowStatsHistograms:
+		names = []string{"Db_name", "Table_name", "Partition_name", "Column_name", "Is_index", "Update_time", "Distinct_count", "Null_count", "Avg_col_size"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTiny, mysql.TypeVarchar,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeDouble}
+	case ast.ShowStatsBuckets:
+		names = []string{"Db_name", "Table_name", "Partition_name", "Column_name", "Is_index", "Bucket_id", "Count",
+			"Repeats", "Lower_Bound", "Upper_Bound"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTiny, mysql.TypeLonglong,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowGrants:
+		if s.User != nil {
+			names = []string{fmt.Sprintf("Grants for %s@%s", s.User.Username, s.User.Hostname)}
+		} else {
+			names = []string{"Grants for User"}
+		}
+	case ast.ShowStatsHealthy:
+		names = []string{"Db_name", "Table_name", "Partition_name", "Healthy"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}
 	}
 
 	schema = expression.NewSchema(make([]*expression.Column, 0, len(names))...)
@@ -78,6 +78,9 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	case *ast.AlterTableStmt:
 		p.resolveAlterTableStmt(node)
 		p.checkAlterTableGrammar(node)
+	case *ast.RenameTableStmt:
+		p.flag |= inCreateOrDropTable
+		p.checkRenameTableGrammar(node)
 	case *ast.CreateDatabaseStmt:
 		p.checkCreateDatabaseGrammar(node)
 	case *ast.DropDatabaseStmt:
@@ -98,7 +101,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.DropTableStmt, *ast.AlterTableStmt:
+	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.ExplainStmt:
 		if _, ok := x.Stmt.(*ast.ShowStmt); ok {
@@ -270,6 +273,17 @@ func (p *preprocessor) checkDropTableGrammar(stmt *ast.DropTableStmt) {
 	}
 }
 
+func (p *preprocessor) checkRenameTableGrammar(stmt *ast.RenameTableStmt) {
+	for _, t := range stmt.TableToTables {
+		for _, tName := range []string{t.OldTable.Name.String(), t.NewTable.Name.String()} {
+			if isIncorrectName(tName) {
+				p.err = ddl.ErrWrongTableName.GenWithStackByArgs(tName)
+				return
+			}
+		}
+	}
+}
+
 func (p *preprocessor) checkNonUniqTableAlias(stmt *ast.Join) {
 	if p.flag&parentIsJoin == 0 {
 		p.tableAliasInJoin = append(p.tableAliasInJoin, make(map[string]interface{}))
@@ -368,6 +382,17 @@ func (p *preprocessor) checkAlterTableGrammar(stmt *ast.AlterTableStmt) {
 			default:
 				// Nothing to do now.
 			}
+		case ast.AlterTableRenameColumn:
+			cName := spec.NewColumnName.Name.String()
+			if isIncorrectName(cName) {
+				p.err = ddl.ErrWrongColumnName.GenWithStackByArgs(cName)
+				return
+			}
+		case ast.AlterTableRenameIndex:
+			if isIncorrectName(spec.ToKey.String()) {
+				p.err = ddl.ErrWrongNameForIndex.GenWithStackByArgs(spec.ToKey.String())
+				return
+			}
 		default:
 			// Nothing to do now.
 		}
@@ -536,4 +561,17 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	}
 }
 
-func (p *preprocessor) resolveAlterTableStmt(node *ast.AlterTableStmt) {}
+func (p *preprocessor) resolveAlterTableStmt(node *ast.AlterTableStmt) {
+	for _, spec := range node.Specs {
+		if spec.Tp == ast.AlterTableRenameTable {
+			// The new table doesn't exist yet.
+			p.flag |= inCreateOrDropTable
+			break
+		}
+		if spec.Tp == ast.AlterTableAddConstraint && spec.Constraint.Tp == ast.ConstraintForeignKey {
+			// The referenced table may not exist when foreign_key_checks is off, it's checked by DDL.
+			p.flag |= inCreateOrDropTable
+			break
+		}
+	}
+}
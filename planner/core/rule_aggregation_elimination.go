@@ -35,6 +35,13 @@ type aggregationEliminateChecker struct {
 // For count(expr), sum(expr), avg(expr), count(distinct expr, [expr...]) we may need to rewrite the expr. Details are shown below.
 // If we can eliminate agg successful, we return a projection. Else we return a nil pointer.
 func (a *aggregationEliminateChecker) tryToEliminateAggregation(agg *LogicalAggregation) *LogicalProjection {
+	for _, af := range agg.AggFuncs {
+		// GROUP_CONCAT of several arguments can't be rewritten since there
+		// is no CONCAT function.
+		if af.Name == ast.AggFuncGroupConcat && len(af.Args) > 2 {
+			return nil
+		}
+	}
 	schemaByGroupby := expression.NewSchema(agg.groupByCols...)
 	coveredByUniqueKey := false
 	for _, key := range agg.children[0].Schema().Keys {
@@ -74,6 +81,14 @@ func (a *aggregationEliminateChecker) rewriteExpr(ctx sessionctx.Context, aggFun
 		return a.rewriteCount(ctx, aggFunc.Args, aggFunc.RetTp)
 	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin:
 		return aggFunc.Args[0]
+	case ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor:
+		return a.rewriteBitFunc(ctx, aggFunc)
+	case ast.AggFuncGroupConcat:
+		return aggFunc.Args[0]
+	case ast.AggFuncApproxCountDistinct:
+		return a.rewriteCount(ctx, aggFunc.Args, aggFunc.RetTp)
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		return a.rewriteVarianceFunc(ctx, aggFunc)
 	default:
 		panic("Unsupported function")
 	}
@@ -98,6 +113,27 @@ func (a *aggregationEliminateChecker) rewriteCount(ctx sessionctx.Context, exprs
 	return newExpr
 }
 
+// rewriteBitFunc rewrites bit_and(expr), bit_or(expr) and bit_xor(expr) upon a
+// single row to ifnull(expr, default), where the default is the result of the
+// function when its input is null.
+func (a *aggregationEliminateChecker) rewriteBitFunc(ctx sessionctx.Context, aggFunc *aggregation.AggFuncDesc) expression.Expression {
+	defaultValue := &expression.Constant{Value: aggFunc.GetDefaultValue(), RetType: aggFunc.RetTp}
+	return expression.NewFunctionInternal(ctx, ast.Ifnull, aggFunc.RetTp, aggFunc.Args[0], defaultValue)
+}
+
+// rewriteVarianceFunc rewrites the variance functions upon a single row, the
+// population variance and standard deviation are 0 if the input is not null,
+// while the sample ones are always null.
+func (a *aggregationEliminateChecker) rewriteVarianceFunc(ctx sessionctx.Context, aggFunc *aggregation.AggFuncDesc) expression.Expression {
+	null := &expression.Constant{Value: types.Datum{}, RetType: aggFunc.RetTp}
+	if aggFunc.Name == ast.AggFuncVarSamp || aggFunc.Name == ast.AggFuncStddevSamp {
+		return null
+	}
+	zero := &expression.Constant{Value: types.NewFloat64Datum(0), RetType: aggFunc.RetTp}
+	isNullExpr := expression.NewFunctionInternal(ctx, ast.IsNull, types.NewFieldType(mysql.TypeTiny), aggFunc.Args[0])
+	return expression.NewFunctionInternal(ctx, ast.If, aggFunc.RetTp, isNullExpr, null, zero)
+}
+
 func (a *aggregationEliminator) optimize(ctx context.Context, p LogicalPlan) (LogicalPlan, error) {
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
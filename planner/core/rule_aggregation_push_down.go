@@ -31,6 +31,10 @@ type aggregationPushDownSolver struct {
 // if there exist aggregation functions F_1 and F_2 such that F(S_1 union all S_2) = F_2(F_1(S_1),F_1(S_2)),
 // where S_1 and S_2 are two sets of values. We call S_1 and S_2 partial groups.
 func (a *aggregationPushDownSolver) isDecomposable(fun *aggregation.AggFuncDesc) bool {
+	if fun.HasDistinct {
+		// The distinct values of the partial groups may be duplicated.
+		return false
+	}
 	switch fun.Name {
 	case ast.AggFuncAvg:
 		// TODO: Support avg push down.
@@ -47,6 +51,9 @@ func (a *aggregationPushDownSolver) getAggFuncChildIdx(aggFunc *aggregation.AggF
 	fromLeft, fromRight := false, false
 	var cols []*expression.Column
 	cols = expression.ExtractColumnsFromExpressions(cols, aggFunc.Args, nil)
+	for _, byItem := range aggFunc.OrderByItems {
+		cols = append(cols, expression.ExtractColumns(byItem.Expr)...)
+	}
 	for _, col := range cols {
 		if schema.Contains(col) {
 			fromLeft = true
@@ -253,7 +260,7 @@ func (a *aggregationPushDownSolver) makeNewAgg(ctx sessionctx.Context, aggFuncs
 		newAggFuncDescs = append(newAggFuncDescs, newFuncs...)
 	}
 	for _, gbyCol := range gbyCols {
-		firstRow, err := aggregation.NewAggFuncDesc(agg.ctx, ast.AggFuncFirstRow, []expression.Expression{gbyCol})
+		firstRow, err := aggregation.NewAggFuncDesc(agg.ctx, ast.AggFuncFirstRow, []expression.Expression{gbyCol}, false)
 		if err != nil {
 			return nil, err
 		}
@@ -267,13 +274,121 @@ func (a *aggregationPushDownSolver) makeNewAgg(ctx sessionctx.Context, aggFuncs
 	return agg, nil
 }
 
+// splitDistinctAgg splits an aggregation with distinct aggregate functions into a two-phase
+// group-by, e.g. "SELECT a, COUNT(DISTINCT b), SUM(DISTINCT b) FROM t GROUP BY a" is rewritten
+// to "SELECT a, COUNT(b), SUM(b) FROM (SELECT a, b FROM t GROUP BY a, b) t GROUP BY a". Unlike the distinct aggregation, the inner group-by can be pushed down to the
+// coprocessor. It only works if all the distinct aggregate functions have the same arguments,
+// and the others are duplicate agnostic, i.e. MAX, MIN upon the group-by items and FIRST_ROW.
+func (a *aggregationPushDownSolver) splitDistinctAgg(agg *LogicalAggregation) (bool, error) {
+	var distinctArgs []expression.Expression
+	for _, aggFunc := range agg.AggFuncs {
+		if !aggFunc.HasDistinct {
+			continue
+		}
+		if distinctArgs == nil {
+			distinctArgs = aggFunc.Args
+			continue
+		}
+		if len(distinctArgs) != len(aggFunc.Args) {
+			return false, nil
+		}
+		for i, arg := range aggFunc.Args {
+			if !arg.Equal(agg.ctx, distinctArgs[i]) {
+				return false, nil
+			}
+		}
+	}
+	if distinctArgs == nil {
+		return false, nil
+	}
+	ctx := agg.ctx
+	innerGbyItems := make([]expression.Expression, 0, len(agg.GroupByItems)+len(distinctArgs))
+	innerGbyItems = append(innerGbyItems, agg.GroupByItems...)
+	for _, item := range distinctArgs {
+		duplicate := false
+		for _, gbyItem := range innerGbyItems {
+			if item.Equal(ctx, gbyItem) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			innerGbyItems = append(innerGbyItems, item)
+		}
+	}
+	var innerGbyCols []*expression.Column
+	for _, item := range innerGbyItems {
+		if col, ok := item.(*expression.Column); ok {
+			innerGbyCols = append(innerGbyCols, col)
+		}
+	}
+	innerGbySchema := expression.NewSchema(innerGbyCols...)
+	// Collect all the columns used by the outer aggregation, which are output by the inner
+	// one with first_row functions.
+	usedCols := expression.ExtractColumnsFromExpressions(nil, agg.GroupByItems, nil)
+	for _, aggFunc := range agg.AggFuncs {
+		switch {
+		case aggFunc.HasDistinct, aggFunc.Name == ast.AggFuncFirstRow:
+		case aggFunc.Name == ast.AggFuncMax || aggFunc.Name == ast.AggFuncMin:
+			// The argument should be unique in each inner group.
+			for _, col := range expression.ExtractColumnsFromExpressions(nil, aggFunc.Args, nil) {
+				if !innerGbySchema.Contains(col) {
+					return false, nil
+				}
+			}
+		default:
+			return false, nil
+		}
+		usedCols = expression.ExtractColumnsFromExpressions(usedCols, aggFunc.Args, nil)
+	}
+	usedCols = a.addGbyCol(ctx, nil, usedCols...)
+
+	innerAgg := LogicalAggregation{GroupByItems: innerGbyItems}.Init(ctx)
+	innerAgg.collectGroupByColumns()
+	innerSchema := expression.NewSchema(make([]*expression.Column, 0, len(usedCols))...)
+	for _, col := range usedCols {
+		firstRow, err := aggregation.NewAggFuncDesc(ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
+		if err != nil {
+			return false, err
+		}
+		newCol, _ := col.Clone().(*expression.Column)
+		newCol.RetType = firstRow.RetTp
+		innerAgg.AggFuncs = append(innerAgg.AggFuncs, firstRow)
+		innerSchema.Append(newCol)
+	}
+	innerAgg.SetSchema(innerSchema)
+	innerAgg.SetChildren(agg.children[0])
+	for _, aggFunc := range agg.AggFuncs {
+		aggFunc.HasDistinct = false
+	}
+	agg.SetChildren(innerAgg)
+	return true, nil
+}
+
 func (a *aggregationPushDownSolver) optimize(ctx context.Context, p LogicalPlan) (LogicalPlan, error) {
 	if !p.SCtx().GetSessionVars().AllowAggPushDown {
-		return p, nil
+		// Pushing the aggregations down to the join paths is optional, but splitting the
+		// distinct aggregations is always done.
+		return p, a.splitDistinctAggs(p)
 	}
 	return a.aggPushDown(p)
 }
 
+// splitDistinctAggs splits the distinct aggregations in the plan tree, see splitDistinctAgg.
+func (a *aggregationPushDownSolver) splitDistinctAggs(p LogicalPlan) error {
+	if agg, ok := p.(*LogicalAggregation); ok {
+		if _, err := a.splitDistinctAgg(agg); err != nil {
+			return err
+		}
+	}
+	for _, child := range p.Children() {
+		if err := a.splitDistinctAggs(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // aggPushDown tries to push down aggregate functions to join paths.
 func (a *aggregationPushDownSolver) aggPushDown(p LogicalPlan) (_ LogicalPlan, err error) {
 	if agg, ok := p.(*LogicalAggregation); ok {
@@ -281,6 +396,9 @@ func (a *aggregationPushDownSolver) aggPushDown(p LogicalPlan) (_ LogicalPlan, e
 		if proj != nil {
 			p = proj
 		} else {
+			if _, err = a.splitDistinctAgg(agg); err != nil {
+				return nil, err
+			}
 			child := agg.children[0]
 			if join, ok1 := child.(*LogicalJoin); ok1 && a.checkValidJoin(join) {
 				if valid, leftAggFuncs, rightAggFuncs, leftGbyCols, rightGbyCols := a.splitAggFuncsAndGbyCols(agg, join); valid {
@@ -326,6 +444,9 @@ func (a *aggregationPushDownSolver) aggPushDown(p LogicalPlan) (_ LogicalPlan, e
 						newArgs = append(newArgs, expression.ColumnSubstitute(arg, proj.schema, proj.Exprs))
 					}
 					aggFunc.Args = newArgs
+					for _, byItem := range aggFunc.OrderByItems {
+						byItem.Expr = expression.ColumnSubstitute(byItem.Expr, proj.schema, proj.Exprs)
+					}
 				}
 				projChild := proj.children[0]
 				agg.SetChildren(projChild)
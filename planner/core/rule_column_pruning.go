@@ -85,6 +85,26 @@ func (p *LogicalProjection) PruneColumns(parentUsedCols []*expression.Column) er
 	return child.PruneColumns(selfUsedCols)
 }
 
+// PruneColumns implements LogicalPlan interface.
+func (p *LogicalExpand) PruneColumns(parentUsedCols []*expression.Column) error {
+	child := p.children[0]
+	used := getUsedList(parentUsedCols, p.schema)
+
+	for i := len(used) - 1; i >= 0; i-- {
+		if !used[i] {
+			p.schema.Columns = append(p.schema.Columns[:i], p.schema.Columns[i+1:]...)
+			for j, exprs := range p.LevelExprs {
+				p.LevelExprs[j] = append(exprs[:i], exprs[i+1:]...)
+			}
+		}
+	}
+	var selfUsedCols []*expression.Column
+	for _, exprs := range p.LevelExprs {
+		selfUsedCols = expression.ExtractColumnsFromExpressions(selfUsedCols, exprs, nil)
+	}
+	return child.PruneColumns(selfUsedCols)
+}
+
 // PruneColumns implements LogicalPlan interface.
 func (p *LogicalSelection) PruneColumns(parentUsedCols []*expression.Column) error {
 	child := p.children[0]
@@ -106,10 +126,13 @@ func (la *LogicalAggregation) PruneColumns(parentUsedCols []*expression.Column)
 	var selfUsedCols []*expression.Column
 	for _, aggrFunc := range la.AggFuncs {
 		selfUsedCols = expression.ExtractColumnsFromExpressions(selfUsedCols, aggrFunc.Args, nil)
+		for _, byItem := range aggrFunc.OrderByItems {
+			selfUsedCols = append(selfUsedCols, expression.ExtractColumns(byItem.Expr)...)
+		}
 	}
 	if len(la.AggFuncs) == 0 {
 		// If all the aggregate functions are pruned, we should add an aggregate function to keep the correctness.
-		one, err := aggregation.NewAggFuncDesc(la.ctx, ast.AggFuncFirstRow, []expression.Expression{expression.One})
+		one, err := aggregation.NewAggFuncDesc(la.ctx, ast.AggFuncFirstRow, []expression.Expression{expression.One}, false)
 		if err != nil {
 			return err
 		}
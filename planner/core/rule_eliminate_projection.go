@@ -184,11 +184,22 @@ func (p *LogicalProjection) replaceExprColumns(replace map[string]*expression.Co
 	}
 }
 
+func (p *LogicalExpand) replaceExprColumns(replace map[string]*expression.Column) {
+	for _, exprs := range p.LevelExprs {
+		for _, expr := range exprs {
+			ResolveExprAndReplace(expr, replace)
+		}
+	}
+}
+
 func (la *LogicalAggregation) replaceExprColumns(replace map[string]*expression.Column) {
 	for _, agg := range la.AggFuncs {
 		for _, aggExpr := range agg.Args {
 			ResolveExprAndReplace(aggExpr, replace)
 		}
+		for _, byItem := range agg.OrderByItems {
+			ResolveExprAndReplace(byItem.Expr, replace)
+		}
 	}
 	for _, gbyItem := range la.GroupByItems {
 		ResolveExprAndReplace(gbyItem, replace)
@@ -153,6 +153,7 @@ func (o *outerJoinEliminator) isInnerJoinKeysContainIndex(innerPlan LogicalPlan,
 //   1. MAX(arg)
 //   2. MIN(arg)
 //   3. FIRST_ROW(arg)
+//   4. Other agg functions with DISTINCT flag, like SUM(DISTINCT arg)
 func (o *outerJoinEliminator) getDupAgnosticAggCols(
 	p LogicalPlan,
 	oldAggCols []*expression.Column, // Reuse the original buffer.
@@ -163,7 +164,8 @@ func (o *outerJoinEliminator) getDupAgnosticAggCols(
 	}
 	newAggCols = oldAggCols[:0]
 	for _, aggDesc := range agg.AggFuncs {
-		if aggDesc.Name != ast.AggFuncFirstRow &&
+		if !aggDesc.HasDistinct &&
+			aggDesc.Name != ast.AggFuncFirstRow &&
 			aggDesc.Name != ast.AggFuncMax &&
 			aggDesc.Name != ast.AggFuncMin {
 			// If not all aggregate functions are duplicate agnostic,
@@ -173,6 +175,9 @@ func (o *outerJoinEliminator) getDupAgnosticAggCols(
 		for _, expr := range aggDesc.Args {
 			newAggCols = append(newAggCols, expression.ExtractColumns(expr)...)
 		}
+		for _, byItem := range aggDesc.OrderByItems {
+			newAggCols = append(newAggCols, expression.ExtractColumns(byItem.Expr)...)
+		}
 	}
 	return true, newAggCols
 }
@@ -205,6 +210,9 @@ func (o *outerJoinEliminator) doOptimize(p LogicalPlan, aggCols []*expression.Co
 			for _, expr := range aggDesc.Args {
 				parentCols = append(parentCols, expression.ExtractColumns(expr)...)
 			}
+			for _, byItem := range aggDesc.OrderByItems {
+				parentCols = append(parentCols, expression.ExtractColumns(byItem.Expr)...)
+			}
 		}
 	default:
 		parentCols = append(parentCols[:0], p.Schema().Columns...)
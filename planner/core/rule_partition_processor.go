@@ -0,0 +1,313 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+// partitionProcessor rewrites the ast for table partition.
+//
+// create table t (id int) partition by range (id)
+//   (partition p1 values less than (10),
+//    partition p2 values less than (20),
+//    partition p3 values less than (30))
+//
+// select * from t is equal to
+// select * from (union all
+//      select * from p1 where id < 10
+//      select * from p2 where id < 20
+//      select * from p3 where id < 30)
+//
+// partitionProcessor is here because it's easier to prune partition after predicate push down.
+type partitionProcessor struct{}
+
+func (s *partitionProcessor) optimize(ctx context.Context, lp LogicalPlan) (LogicalPlan, error) {
+	return s.rewriteDataSource(lp)
+}
+
+func (s *partitionProcessor) rewriteDataSource(lp LogicalPlan) (LogicalPlan, error) {
+	// Assert there will not be sel -> sel in the ast.
+	switch p := lp.(type) {
+	case *DataSource:
+		return s.prune(p)
+	case *LogicalUnionScan:
+		ds := p.Children()[0]
+		ds, err := s.rewriteDataSource(ds)
+		if err != nil {
+			return nil, err
+		}
+		if ua, ok := ds.(*LogicalUnionAll); ok {
+			// Adjust the UnionScan->Union->DataSource1, DataSource2 ... to
+			// Union->(UnionScan->DataSource1), (UnionScan->DataSource2)
+			children := make([]LogicalPlan, 0, len(ua.Children()))
+			for _, child := range ua.Children() {
+				us := LogicalUnionScan{
+					conditions: p.conditions,
+					handleCol:  p.handleCol,
+				}.Init(ua.ctx)
+				us.SetChildren(child)
+				children = append(children, us)
+			}
+			ua.SetChildren(children...)
+			return ua, nil
+		}
+		// Only one partition, no union all.
+		p.SetChildren(ds)
+		return p, nil
+	default:
+		children := lp.Children()
+		for i, child := range children {
+			newChild, err := s.rewriteDataSource(child)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = newChild
+		}
+	}
+	return lp, nil
+}
+
+// prune splits the DataSource of a partitioned table into the DataSources of
+// the partitions which may contain rows satisfying the filters.
+func (s *partitionProcessor) prune(ds *DataSource) (LogicalPlan, error) {
+	pi := ds.tableInfo.GetPartitionInfo()
+	if pi == nil {
+		return ds, nil
+	}
+	defs := s.selectPartitions(pi, ds.partitionNames)
+	if len(ds.allConds) == 0 {
+		return s.makeUnionAllChildren(ds, pi, defs)
+	}
+	partCol, err := s.findPartitionColumn(ds, pi)
+	if err != nil {
+		return nil, err
+	}
+	// The partitions can only be pruned when the partition expression is a
+	// column, because we calculate the column ranges to prune them.
+	if partCol != nil {
+		switch pi.Type {
+		case model.PartitionTypeRange, model.PartitionTypeList:
+			defs, err = s.pruneByPartitionExprs(ds, pi, partCol, defs)
+		case model.PartitionTypeHash:
+			defs, err = s.pruneHashPartition(ds, pi, partCol, defs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.makeUnionAllChildren(ds, pi, defs)
+}
+
+// selectPartitions returns the partitions selected by `PARTITION (p0, p1)`,
+// all the partitions are returned if no partition is selected explicitly.
+func (s *partitionProcessor) selectPartitions(pi *model.PartitionInfo, names []model.CIStr) []model.PartitionDefinition {
+	if len(names) == 0 {
+		return pi.Definitions
+	}
+	defs := make([]model.PartitionDefinition, 0, len(names))
+	for _, def := range pi.Definitions {
+		for _, name := range names {
+			if def.Name.L == name.L {
+				defs = append(defs, def)
+				break
+			}
+		}
+	}
+	return defs
+}
+
+// parsePartitionExpr parses an expression on the table columns of the DataSource.
+func (s *partitionProcessor) parsePartitionExpr(ds *DataSource, exprStr string) (expression.Expression, error) {
+	columns := ds.table.Cols()
+	names := make(types.NameSlice, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, &types.FieldName{
+			DBName:      ds.DBName,
+			TblName:     ds.tableInfo.Name,
+			ColName:     col.Name,
+			OrigTblName: ds.tableInfo.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return expression.ParseSimpleExprWithSchema(ds.ctx, exprStr, expression.NewSchema(ds.TblCols...), names)
+}
+
+// findPartitionColumn returns the column of the DataSource if the partition
+// expression is a single column, otherwise it returns nil.
+func (s *partitionProcessor) findPartitionColumn(ds *DataSource, pi *model.PartitionInfo) (*expression.Column, error) {
+	expr, err := s.parsePartitionExpr(ds, tables.PartitionExprString(pi))
+	if err != nil {
+		return nil, err
+	}
+	col, _ := expr.(*expression.Column)
+	return col, nil
+}
+
+// pruneByPartitionExprs prunes the range and list partitions. For each partition,
+// the filters together with the partition expression, e.g. `a >= 10 and a < 20`
+// for range partition or `a in (1, 3)` for list partition, are used to calculate
+// the ranges of the partition column, the partition can be pruned if there is
+// no range left.
+func (s *partitionProcessor) pruneByPartitionExprs(ds *DataSource, pi *model.PartitionInfo, partCol *expression.Column, defs []model.PartitionDefinition) ([]model.PartitionDefinition, error) {
+	exprStr := tables.PartitionExprString(pi)
+	ret := make([]model.PartitionDefinition, 0, len(defs))
+	for _, def := range defs {
+		partExprStr := s.buildPartitionExprString(pi, def, exprStr)
+		partExpr, err := s.parsePartitionExpr(ds, partExprStr)
+		if err != nil {
+			return nil, err
+		}
+		conds := make([]expression.Expression, 0, len(ds.allConds)+2)
+		conds = append(conds, ds.allConds...)
+		conds = append(conds, expression.SplitCNFItems(partExpr)...)
+		ranges, err := s.buildColumnRanges(ds, partCol, conds)
+		if err != nil {
+			return nil, err
+		}
+		if len(ranges) > 0 {
+			ret = append(ret, def)
+		}
+	}
+	return ret, nil
+}
+
+// buildPartitionExprString builds the condition of the values held by the
+// partition. The NULL value is regarded as less than any other value, so it
+// belongs to the first range partition.
+func (s *partitionProcessor) buildPartitionExprString(pi *model.PartitionInfo, def model.PartitionDefinition, exprStr string) string {
+	if pi.Type == model.PartitionTypeList {
+		values := make([]string, 0, len(def.InValues))
+		conds := make([]string, 0, 2)
+		for _, v := range def.InValues {
+			if strings.EqualFold(v, "NULL") {
+				conds = append(conds, fmt.Sprintf("((%s) IS NULL)", exprStr))
+				continue
+			}
+			values = append(values, v)
+		}
+		if len(values) > 0 {
+			conds = append(conds, fmt.Sprintf("((%s) IN (%s))", exprStr, strings.Join(values, ",")))
+		}
+		return strings.Join(conds, " OR ")
+	}
+
+	idx := pi.FindPartitionDefinitionByName(def.Name.L)
+	conds := make([]string, 0, 2)
+	if idx > 0 {
+		conds = append(conds, fmt.Sprintf("((%s) >= (%s))", exprStr, pi.Definitions[idx-1].LessThan[0]))
+	}
+	if !strings.EqualFold(def.LessThan[0], "MAXVALUE") {
+		upper := fmt.Sprintf("((%s) < (%s))", exprStr, def.LessThan[0])
+		if idx == 0 {
+			upper = fmt.Sprintf("(%s OR ((%s) IS NULL))", upper, exprStr)
+		}
+		conds = append(conds, upper)
+	}
+	if len(conds) == 0 {
+		return "true"
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// pruneHashPartition prunes the hash partitions when the filters limit the
+// partition column to some point values.
+func (s *partitionProcessor) pruneHashPartition(ds *DataSource, pi *model.PartitionInfo, partCol *expression.Column, defs []model.PartitionDefinition) ([]model.PartitionDefinition, error) {
+	ranges, err := s.buildColumnRanges(ds, partCol, ds.allConds)
+	if err != nil {
+		return nil, err
+	}
+	sc := ds.ctx.GetSessionVars().StmtCtx
+	used := make(map[int64]struct{}, len(ranges))
+	for _, ran := range ranges {
+		low, high := ran.LowVal[0], ran.HighVal[0]
+		var idx int64
+		switch {
+		case low.IsNull() && high.IsNull():
+			// The NULL value is located in the first partition.
+			idx = 0
+		case ran.IsPoint(sc) && low.Kind() == types.KindInt64:
+			idx = low.GetInt64() % int64(len(pi.Definitions))
+		case ran.IsPoint(sc) && low.Kind() == types.KindUint64:
+			idx = int64(low.GetUint64()) % int64(len(pi.Definitions))
+		default:
+			return defs, nil
+		}
+		if idx < 0 {
+			idx = -idx
+		}
+		used[pi.Definitions[idx].ID] = struct{}{}
+	}
+	ret := make([]model.PartitionDefinition, 0, len(used))
+	for _, def := range defs {
+		if _, ok := used[def.ID]; ok {
+			ret = append(ret, def)
+		}
+	}
+	return ret, nil
+}
+
+// buildColumnRanges calculates the ranges of the partition column from the conditions.
+func (s *partitionProcessor) buildColumnRanges(ds *DataSource, partCol *expression.Column, conds []expression.Expression) ([]*ranger.Range, error) {
+	accessConds := ranger.ExtractAccessConditionsForColumn(conds, partCol.UniqueID)
+	return ranger.BuildColumnRange(accessConds, ds.ctx.GetSessionVars().StmtCtx, partCol.RetType, types.UnspecifiedLength)
+}
+
+func (s *partitionProcessor) makeUnionAllChildren(ds *DataSource, pi *model.PartitionInfo, defs []model.PartitionDefinition) (LogicalPlan, error) {
+	children := make([]LogicalPlan, 0, len(defs))
+	for _, def := range defs {
+		// Not a deep copy.
+		newDataSource := *ds
+		newDataSource.baseLogicalPlan = newBaseLogicalPlan(ds.ctx, TypeTableScan, &newDataSource)
+		newDataSource.isPartition = true
+		newDataSource.physicalTableID = def.ID
+		newDataSource.statisticTable = getStatsTable(ds.ctx, ds.tableInfo, def.ID)
+		// The access paths are modified when deriving stats, so each partition needs its own copy.
+		newDataSource.possibleAccessPaths = make([]*util.AccessPath, len(ds.possibleAccessPaths))
+		for i := range ds.possibleAccessPaths {
+			newPath := *ds.possibleAccessPaths[i]
+			newDataSource.possibleAccessPaths[i] = &newPath
+		}
+		children = append(children, &newDataSource)
+	}
+	if len(children) == 0 {
+		// No result after table pruning.
+		tableDual := LogicalTableDual{RowCount: 0}.Init(ds.ctx)
+		tableDual.schema = ds.Schema()
+		tableDual.names = ds.names
+		return tableDual, nil
+	}
+	if len(children) == 1 {
+		// No need for the union all.
+		return children[0], nil
+	}
+	unionAll := LogicalUnionAll{}.Init(ds.ctx)
+	unionAll.SetChildren(children...)
+	unionAll.SetSchema(ds.schema)
+	unionAll.names = ds.names
+	return unionAll, nil
+}
+
+func (*partitionProcessor) name() string {
+	return "partition_processor"
+}
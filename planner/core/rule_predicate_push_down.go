@@ -366,6 +366,14 @@ func (p *LogicalLimit) PredicatePushDown(predicates []expression.Expression) ([]
 	return predicates, p
 }
 
+// PredicatePushDown implements LogicalPlan PredicatePushDown interface.
+func (p *LogicalExpand) PredicatePushDown(predicates []expression.Expression) ([]expression.Expression, LogicalPlan) {
+	// The group-by columns are replaced by NULL in some levels, so no condition
+	// can be pushed through the Expand.
+	p.baseLogicalPlan.PredicatePushDown(nil)
+	return predicates, p
+}
+
 // deriveOtherConditions given a LogicalJoin, check the OtherConditions to see if we can derive more
 // conditions for left/right child pushdown.
 func deriveOtherConditions(p *LogicalJoin, deriveLeft bool, deriveRight bool) (leftCond []expression.Expression,
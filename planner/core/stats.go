@@ -44,6 +44,20 @@ func (p *LogicalTableDual) DeriveStats(childStats []*property.StatsInfo, selfSch
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalUnionAll) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema) (*property.StatsInfo, error) {
+	p.stats = &property.StatsInfo{
+		Cardinality: make([]float64, selfSchema.Len()),
+	}
+	for _, childProfile := range childStats {
+		p.stats.RowCount += childProfile.RowCount
+		for i := range p.stats.Cardinality {
+			p.stats.Cardinality[i] += childProfile.Cardinality[i]
+		}
+	}
+	return p.stats, nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalMemTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema) (*property.StatsInfo, error) {
 	statsTable := statistics.PseudoTable(p.tableInfo)
@@ -317,6 +331,35 @@ func (p *LogicalProjection) DeriveStats(childStats []*property.StatsInfo, selfSc
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalExpand) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema) (*property.StatsInfo, error) {
+	childProfile := childStats[0]
+	levels := float64(len(p.LevelExprs))
+	p.stats = &property.StatsInfo{
+		RowCount:    childProfile.RowCount * levels,
+		Cardinality: make([]float64, selfSchema.Len()),
+	}
+	for i := range selfSchema.Columns {
+		cols := make([]*expression.Column, 0, len(p.LevelExprs))
+		for _, exprs := range p.LevelExprs {
+			if col, ok := exprs[i].(*expression.Column); ok {
+				cols = append(cols, col)
+			}
+		}
+		switch len(cols) {
+		case 0:
+			// The grouping ID is different in every level.
+			p.stats.Cardinality[i] = levels
+		case len(p.LevelExprs):
+			p.stats.Cardinality[i] = getCardinality(cols, childSchema[0], childProfile)
+		default:
+			// The group-by column may be rolled up to NULL.
+			p.stats.Cardinality[i] = getCardinality(cols, childSchema[0], childProfile) + 1
+		}
+	}
+	return p.stats, nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (la *LogicalAggregation) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema) (*property.StatsInfo, error) {
 	childProfile := childStats[0]
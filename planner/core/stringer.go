@@ -87,6 +87,13 @@ func toString(in Plan, strs []string, idxs []int) ([]string, []int) {
 			r := x.RightJoinKeys[i].String()
 			str += fmt.Sprintf("(%s,%s)", l, r)
 		}
+	case *LogicalUnionAll, *PhysicalUnionAll:
+		last := len(idxs) - 1
+		idx := idxs[last]
+		children := strs[idx:]
+		strs = strs[:idx]
+		idxs = idxs[:last]
+		str = "UnionAll{" + strings.Join(children, "->") + "}"
 	case *LogicalLimit, *PhysicalLimit:
 		str = "Limit"
 	case *ShowDDL:
@@ -121,6 +128,8 @@ func toString(in Plan, strs []string, idxs []int) ([]string, []int) {
 		str = fmt.Sprintf("Sel(%s)", x.Conditions)
 	case *LogicalProjection, *PhysicalProjection:
 		str = "Projection"
+	case *LogicalExpand, *PhysicalExpand:
+		str = "Expand"
 	case *LogicalTopN:
 		str = fmt.Sprintf("TopN(%v,%d,%d)", x.ByItems, x.Offset, x.Count)
 	case *PhysicalTopN:
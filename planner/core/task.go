@@ -142,6 +142,19 @@ func (p *basePhysicalPlan) attach2Task(tasks ...task) task {
 	return attachPlan2Task(p.self, t)
 }
 
+func (p *PhysicalUnionAll) attach2Task(tasks ...task) task {
+	t := &rootTask{p: p}
+	childPlans := make([]PhysicalPlan, 0, len(tasks))
+	for _, task := range tasks {
+		task = finishCopTask(p.ctx, task)
+		// The children of UnionExec are executed one by one.
+		t.cst += task.cost()
+		childPlans = append(childPlans, task.plan())
+	}
+	p.SetChildren(childPlans...)
+	return t
+}
+
 // GetCost computes cost of hash join operator itself.
 func (p *PhysicalHashJoin) GetCost(lCnt, rCnt float64) float64 {
 	buildCnt, probeCnt := lCnt, rCnt
@@ -535,6 +548,13 @@ func (p *PhysicalProjection) attach2Task(tasks ...task) task {
 	return t
 }
 
+func (p *PhysicalExpand) attach2Task(tasks ...task) task {
+	t := finishCopTask(p.ctx, tasks[0].copy())
+	t = attachPlan2Task(p, t)
+	t.addCost(t.count() * p.ctx.GetSessionVars().CPUFactor)
+	return t
+}
+
 func (sel *PhysicalSelection) attach2Task(tasks ...task) task {
 	sessVars := sel.ctx.GetSessionVars()
 	t := finishCopTask(sel.ctx, tasks[0].copy())
@@ -549,6 +569,13 @@ func CheckAggCanPushCop(sctx sessionctx.Context, aggFuncs []*aggregation.AggFunc
 	sc := sctx.GetSessionVars().StmtCtx
 	client := sctx.GetClient()
 	for _, aggFunc := range aggFuncs {
+		// The distinct values of different regions can't be merged from the
+		// partial results, so the distinct aggregate functions are not pushed
+		// down, see aggregationPushDownSolver.splitDistinctAgg for the way to
+		// push down the distinct part.
+		if aggFunc.HasDistinct {
+			return false
+		}
 		pb := aggregation.AggFuncToPBExpr(sc, client, aggFunc)
 		if pb == nil {
 			return false
@@ -589,11 +616,24 @@ func BuildFinalModeAggregation(
 		if aggregation.NeedValue(finalAggFunc.Name) {
 			partialSchema.Append(&expression.Column{
 				UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
-				RetType:  finalSchema.Columns[i].GetType(),
+				RetType:  aggregation.PartialValueType(finalAggFunc.Name, finalSchema.Columns[i].GetType()),
 			})
 			args = append(args, partialSchema.Columns[partialCursor])
 			partialCursor++
 		}
+		if aggregation.NeedVariance(finalAggFunc.Name) {
+			partialSchema.Append(&expression.Column{
+				UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
+				RetType:  aggregation.NewDoubleFieldType(),
+			})
+			args = append(args, partialSchema.Columns[partialCursor])
+			partialCursor++
+		}
+		if finalAggFunc.Name == ast.AggFuncGroupConcat {
+			// The separator is kept to concatenate the partial results.
+			args = append(args, aggFunc.Args[len(aggFunc.Args)-1])
+			finalAggFunc.OrderByItems = aggFunc.OrderByItems
+		}
 		finalAggFunc.Args = args
 		finalAggFunc.Mode = aggregation.FinalMode
 		finalAggFunc.RetTp = aggFunc.RetTp
@@ -674,6 +714,9 @@ func RemoveUnnecessaryFirstRow(
 		if aggregation.NeedValue(aggFunc.Name) {
 			partialCursor++
 		}
+		if aggregation.NeedVariance(aggFunc.Name) {
+			partialCursor++
+		}
 		newAggFuncs = append(newAggFuncs, aggFunc)
 	}
 	return newAggFuncs
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/planner/cascades"
 	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 )
@@ -38,6 +39,14 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		return nil, nil, err
 	}
 
+	// Check privilege. The table information needed by the privilege check
+	// is collected into the visitInfo by the plan builder.
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		if err := plannercore.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, builder.GetVisitInfo()); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	names := p.OutputNames()
 
 	// Handle the non-logical plan statement.
@@ -0,0 +1,82 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privilege
+
+import (
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+type keyType int
+
+func (k keyType) String() string {
+	return "privilege-key"
+}
+
+// Manager is the interface for providing privilege related operations.
+type Manager interface {
+	// ShowGrants shows granted privileges for user, merged with the privileges of the roles.
+	ShowGrants(ctx sessionctx.Context, user *auth.UserIdentity, roles []*auth.RoleIdentity) ([]string, error)
+
+	// RequestVerification verifies user privilege for the request.
+	// If table is "", only check global/db scope privileges.
+	// If table is not "", check global/db/table scope privileges.
+	// The privileges of the active roles are checked after the privileges of the user.
+	RequestVerification(activeRoles []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool
+
+	// AuthPlugin returns the authentication plugin of the account which the
+	// user connecting from the host will be authenticated as.
+	AuthPlugin(user, host string) (string, bool)
+
+	// ConnectionVerification verifies user privilege for connection.
+	// For caching_sha2_password accounts the authentication is the cleartext
+	// password, otherwise it is the scramble of mysql_native_password.
+	// It returns the user and host of the matched account on success.
+	ConnectionVerification(user, host string, authentication, salt []byte) (string, string, bool)
+
+	// DBIsVisible returns true is the database is visible to current user.
+	DBIsVisible(activeRoles []*auth.RoleIdentity, db string) bool
+
+	// UserPrivilegesTable provide data for INFORMATION_SCHEMA.USER_PRIVILEGES table.
+	UserPrivilegesTable() [][]types.Datum
+
+	// ApplicableRolesTable provide data for INFORMATION_SCHEMA.APPLICABLE_ROLES table.
+	ApplicableRolesTable(user, host string) [][]types.Datum
+
+	// FindEdge checks whether the role is granted to the user.
+	FindEdge(ctx sessionctx.Context, role *auth.RoleIdentity, user *auth.UserIdentity) bool
+
+	// GetDefaultRoles returns the roles activated when the user logs in.
+	GetDefaultRoles(user, host string) []*auth.RoleIdentity
+
+	// GetAllRoles returns the roles granted to the user.
+	GetAllRoles(user, host string) []*auth.RoleIdentity
+}
+
+const key keyType = 0
+
+// BindPrivilegeManager binds Manager to context.
+func BindPrivilegeManager(ctx sessionctx.Context, pc Manager) {
+	ctx.SetValue(key, pc)
+}
+
+// GetPrivilegeManager gets Checker from context.
+func GetPrivilegeManager(ctx sessionctx.Context) Manager {
+	if v, ok := ctx.Value(key).(Manager); ok {
+		return v
+	}
+	return nil
+}
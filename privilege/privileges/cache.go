@@ -0,0 +1,753 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
+	"go.uber.org/zap"
+)
+
+var userTablePrivilegeMask = computePrivMask(mysql.AllGlobalPrivs)
+
+func computePrivMask(privs []mysql.PrivilegeType) mysql.PrivilegeType {
+	var mask mysql.PrivilegeType
+	for _, p := range privs {
+		mask |= p
+	}
+	return mask
+}
+
+// baseRecord is used to represent a base record in privilege cache,
+// it only store Host and User field, and it should be nested in other record type.
+type baseRecord struct {
+	Host string // max length 60, primary key
+	User string // max length 32, primary key
+
+	// patChars is compiled from Host, cached for pattern match performance.
+	patChars []byte
+	patTypes []byte
+}
+
+// UserRecord is used to represent a user record in privilege cache.
+type UserRecord struct {
+	baseRecord
+
+	AuthenticationString string
+	AuthPlugin           string
+	Privileges           mysql.PrivilegeType
+	// AccountLocked is true for the roles, they can't log in.
+	AccountLocked bool
+}
+
+type dbRecord struct {
+	baseRecord
+
+	DB         string
+	Privileges mysql.PrivilegeType
+}
+
+type tablesPrivRecord struct {
+	baseRecord
+
+	DB         string
+	TableName  string
+	Grantor    string
+	TablePriv  mysql.PrivilegeType
+	ColumnPriv mysql.PrivilegeType
+}
+
+type defaultRoleRecord struct {
+	baseRecord
+
+	DefaultRoleUser string
+	DefaultRoleHost string
+}
+
+// roleEdge is a role granted to a user or another role.
+type roleEdge struct {
+	role            *auth.RoleIdentity
+	withAdminOption bool
+}
+
+// roleGraphEdgesTable is the roles granted to a user, keyed by 'role'@'host'.
+type roleGraphEdgesTable struct {
+	roleList map[string]*roleEdge
+}
+
+// MySQLPrivilege is the in-memory cache of mysql privilege tables.
+type MySQLPrivilege struct {
+	User         []UserRecord
+	DB           []dbRecord
+	TablesPriv   []tablesPrivRecord
+	DefaultRoles []defaultRoleRecord
+	// RoleGraph is the roles granted to the users, keyed by 'user'@'host'.
+	RoleGraph map[string]roleGraphEdgesTable
+}
+
+// LoadAll loads the tables from database to memory.
+func (p *MySQLPrivilege) LoadAll(ctx sessionctx.Context) error {
+	err := p.LoadUserTable(ctx)
+	if err != nil {
+		logutil.BgLogger().Warn("load mysql.user fail", zap.Error(err))
+		return errors.Trace(err)
+	}
+
+	err = p.LoadDBTable(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.db fail", zap.Error(err))
+			return errors.Trace(err)
+		}
+		logutil.BgLogger().Warn("mysql.db maybe missing")
+	}
+
+	err = p.LoadTablesPrivTable(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.tables_priv fail", zap.Error(err))
+			return errors.Trace(err)
+		}
+		logutil.BgLogger().Warn("mysql.tables_priv missing")
+	}
+
+	err = p.LoadDefaultRoles(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.default_roles fail", zap.Error(err))
+			return errors.Trace(err)
+		}
+		logutil.BgLogger().Warn("mysql.default_roles missing")
+	}
+
+	err = p.LoadRoleGraph(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.role_edges fail", zap.Error(err))
+			return errors.Trace(err)
+		}
+		logutil.BgLogger().Warn("mysql.role_edges missing")
+	}
+	return nil
+}
+
+func noSuchTable(err error) bool {
+	return infoschema.ErrTableNotExists.Equal(errors.Cause(err))
+}
+
+// LoadUserTable loads the mysql.user table from database.
+func (p *MySQLPrivilege) LoadUserTable(ctx sessionctx.Context) error {
+	err := p.loadTable(ctx, fmt.Sprintf("select * from %s.%s", mysql.SystemDB, mysql.UserTable), p.decodeUserTableRow)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// See https://dev.mysql.com/doc/refman/8.0/en/connection-access.html
+	// When multiple matches are possible, the server must determine which of them to use. It resolves this issue as follows:
+	// 1. Whenever the server reads the user table into memory, it sorts the rows.
+	// 2. When a client attempts to connect, the server looks through the rows in sorted order.
+	// 3. The server uses the first row that matches the client host name and user name.
+	// The server uses sorting rules that order rows with the most-specific Host values first.
+	sort.SliceStable(p.User, func(i, j int) bool {
+		return compareHost(p.User[i].Host, p.User[j].Host) < 0
+	})
+	return nil
+}
+
+// compareHost orders the literal host names before the patterns,
+// and the patterns with longer literal prefix first.
+func compareHost(x, y string) int {
+	px, py := literalPrefixLen(x), literalPrefixLen(y)
+	if px == len(x) && py != len(y) {
+		return -1
+	}
+	if px != len(x) && py == len(y) {
+		return 1
+	}
+	return py - px
+}
+
+func literalPrefixLen(host string) int {
+	if idx := strings.IndexAny(host, "%_"); idx >= 0 {
+		return idx
+	}
+	return len(host)
+}
+
+// LoadDBTable loads the mysql.db table from database.
+func (p *MySQLPrivilege) LoadDBTable(ctx sessionctx.Context) error {
+	return p.loadTable(ctx, fmt.Sprintf("select * from %s.%s", mysql.SystemDB, mysql.DBTable), p.decodeDBTableRow)
+}
+
+// LoadTablesPrivTable loads the mysql.tables_priv table from database.
+func (p *MySQLPrivilege) LoadTablesPrivTable(ctx sessionctx.Context) error {
+	return p.loadTable(ctx, fmt.Sprintf("select * from %s.%s", mysql.SystemDB, mysql.TablePrivTable), p.decodeTablesPrivTableRow)
+}
+
+// LoadDefaultRoles loads the mysql.default_roles table from database.
+func (p *MySQLPrivilege) LoadDefaultRoles(ctx sessionctx.Context) error {
+	return p.loadTable(ctx, fmt.Sprintf("select * from %s.%s", mysql.SystemDB, mysql.DefaultRoleTable), p.decodeDefaultRoleTableRow)
+}
+
+// LoadRoleGraph loads the mysql.role_edges table from database.
+func (p *MySQLPrivilege) LoadRoleGraph(ctx sessionctx.Context) error {
+	p.RoleGraph = make(map[string]roleGraphEdgesTable)
+	return p.loadTable(ctx, fmt.Sprintf("select * from %s.%s", mysql.SystemDB, mysql.RoleEdgeTable), p.decodeRoleEdgesTable)
+}
+
+func (p *MySQLPrivilege) loadTable(sctx sessionctx.Context, sql string,
+	decodeTableRow func(chunk.Row, []*ast.ResultField) error) error {
+	rows, fields, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range rows {
+		err = decodeTableRow(row, fields)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (record *baseRecord) assignUserOrHost(row chunk.Row, i int, f *ast.ResultField) {
+	switch f.ColumnAsName.L {
+	case "user":
+		record.User = row.GetString(i)
+	case "host":
+		record.Host = row.GetString(i)
+		record.patChars, record.patTypes = stringutil.CompilePattern(record.Host, '\\')
+	}
+}
+
+func (p *MySQLPrivilege) decodeUserTableRow(row chunk.Row, fields []*ast.ResultField) error {
+	var value UserRecord
+	for i, f := range fields {
+		switch {
+		case f.ColumnAsName.L == "authentication_string":
+			value.AuthenticationString = row.GetString(i)
+		case f.ColumnAsName.L == "account_locked":
+			value.AccountLocked = row.GetString(i) == "Y"
+		case f.ColumnAsName.L == "plugin":
+			if row.GetString(i) != "" {
+				value.AuthPlugin = row.GetString(i)
+			} else {
+				value.AuthPlugin = mysql.AuthNativePassword
+			}
+		case f.Column.Tp == mysql.TypeString && strings.HasSuffix(f.ColumnAsName.L, "_priv"):
+			if row.GetString(i) == "Y" {
+				priv, ok := mysql.Col2PrivType[f.ColumnAsName.O]
+				if !ok {
+					return errInvalidPrivilegeType.GenWithStack("Unknown Privilege Type!")
+				}
+				value.Privileges |= priv
+			}
+		default:
+			value.assignUserOrHost(row, i, f)
+		}
+	}
+	p.User = append(p.User, value)
+	return nil
+}
+
+func (p *MySQLPrivilege) decodeDBTableRow(row chunk.Row, fields []*ast.ResultField) error {
+	var value dbRecord
+	for i, f := range fields {
+		switch {
+		case f.ColumnAsName.L == "db":
+			value.DB = row.GetString(i)
+		case f.Column.Tp == mysql.TypeString && strings.HasSuffix(f.ColumnAsName.L, "_priv"):
+			if row.GetString(i) == "Y" {
+				priv, ok := mysql.Col2PrivType[f.ColumnAsName.O]
+				if !ok {
+					return errInvalidPrivilegeType.GenWithStack("Unknown Privilege Type!")
+				}
+				value.Privileges |= priv
+			}
+		default:
+			value.assignUserOrHost(row, i, f)
+		}
+	}
+	p.DB = append(p.DB, value)
+	return nil
+}
+
+func (p *MySQLPrivilege) decodeTablesPrivTableRow(row chunk.Row, fields []*ast.ResultField) error {
+	var value tablesPrivRecord
+	for i, f := range fields {
+		switch f.ColumnAsName.L {
+		case "db":
+			value.DB = row.GetString(i)
+		case "table_name":
+			value.TableName = row.GetString(i)
+		case "grantor":
+			value.Grantor = row.GetString(i)
+		case "table_priv":
+			value.TablePriv = DecodeSetToPrivilege(row.GetString(i))
+		case "column_priv":
+			value.ColumnPriv = DecodeSetToPrivilege(row.GetString(i))
+		default:
+			value.assignUserOrHost(row, i, f)
+		}
+	}
+	p.TablesPriv = append(p.TablesPriv, value)
+	return nil
+}
+
+func (p *MySQLPrivilege) decodeDefaultRoleTableRow(row chunk.Row, fields []*ast.ResultField) error {
+	var value defaultRoleRecord
+	for i, f := range fields {
+		switch f.ColumnAsName.L {
+		case "default_role_host":
+			value.DefaultRoleHost = row.GetString(i)
+		case "default_role_user":
+			value.DefaultRoleUser = row.GetString(i)
+		default:
+			value.assignUserOrHost(row, i, f)
+		}
+	}
+	p.DefaultRoles = append(p.DefaultRoles, value)
+	return nil
+}
+
+func (p *MySQLPrivilege) decodeRoleEdgesTable(row chunk.Row, fields []*ast.ResultField) error {
+	var fromUser, fromHost, toHost, toUser string
+	var withAdminOption bool
+	for i, f := range fields {
+		switch f.ColumnAsName.L {
+		case "from_host":
+			fromHost = row.GetString(i)
+		case "from_user":
+			fromUser = row.GetString(i)
+		case "to_host":
+			toHost = row.GetString(i)
+		case "to_user":
+			toUser = row.GetString(i)
+		case "with_admin_option":
+			withAdminOption = row.GetString(i) == "Y"
+		}
+	}
+	role := &auth.RoleIdentity{Username: fromUser, Hostname: fromHost}
+	key := userKey(toUser, toHost)
+	edges, ok := p.RoleGraph[key]
+	if !ok {
+		edges = roleGraphEdgesTable{roleList: make(map[string]*roleEdge)}
+		p.RoleGraph[key] = edges
+	}
+	edges.roleList[role.String()] = &roleEdge{role: role, withAdminOption: withAdminOption}
+	return nil
+}
+
+func userKey(user, host string) string {
+	return fmt.Sprintf("'%s'@'%s'", user, host)
+}
+
+// DecodeSetToPrivilege decodes the comma separated privilege names stored in
+// mysql.tables_priv into the privilege type.
+func DecodeSetToPrivilege(s string) mysql.PrivilegeType {
+	var ret mysql.PrivilegeType
+	if s == "" {
+		return ret
+	}
+	for _, str := range strings.Split(s, ",") {
+		priv, ok := mysql.SetStr2Priv[str]
+		if !ok {
+			logutil.BgLogger().Warn("unsupported privilege", zap.String("type", str))
+			continue
+		}
+		ret |= priv
+	}
+	return ret
+}
+
+// EncodePrivilegeToSet encodes the privilege type into the comma separated
+// privilege names stored in mysql.tables_priv.
+func EncodePrivilegeToSet(priv mysql.PrivilegeType) string {
+	var strs []string
+	for _, p := range mysql.AllTablePrivs {
+		if priv&p != 0 {
+			strs = append(strs, mysql.Priv2SetStr[p])
+		}
+	}
+	if priv&mysql.GrantPriv != 0 {
+		strs = append(strs, mysql.Priv2SetStr[mysql.GrantPriv])
+	}
+	return strings.Join(strs, ",")
+}
+
+func (record *baseRecord) match(user, host string) bool {
+	return record.User == user && stringutil.DoMatch(host, record.patChars, record.patTypes)
+}
+
+// connectionVerification finds the account which the user connecting from
+// the host will be authenticated as.
+func (p *MySQLPrivilege) connectionVerification(user, host string) *UserRecord {
+	for i := 0; i < len(p.User); i++ {
+		record := &p.User[i]
+		if record.match(user, host) {
+			return record
+		}
+	}
+	return nil
+}
+
+func (p *MySQLPrivilege) userExists(user, host string) bool {
+	for _, record := range p.User {
+		if record.User == user && record.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *MySQLPrivilege) matchUser(user, host string) *UserRecord {
+	return p.connectionVerification(user, host)
+}
+
+func (p *MySQLPrivilege) matchDB(user, host, db string) *dbRecord {
+	for i := 0; i < len(p.DB); i++ {
+		record := &p.DB[i]
+		if record.match(user, host) && strings.EqualFold(record.DB, db) {
+			return record
+		}
+	}
+	return nil
+}
+
+func (p *MySQLPrivilege) matchTables(user, host, db, table string) *tablesPrivRecord {
+	for i := 0; i < len(p.TablesPriv); i++ {
+		record := &p.TablesPriv[i]
+		if record.match(user, host) && strings.EqualFold(record.DB, db) && strings.EqualFold(record.TableName, table) {
+			return record
+		}
+	}
+	return nil
+}
+
+// FindAllRole returns the active roles and all the roles granted to them recursively.
+func (p *MySQLPrivilege) FindAllRole(activeRoles []*auth.RoleIdentity) []*auth.RoleIdentity {
+	var ret []*auth.RoleIdentity
+	visited := make(map[string]bool)
+	queue := append([]*auth.RoleIdentity(nil), activeRoles...)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		key := role.String()
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		ret = append(ret, role)
+		for _, edge := range p.RoleGraph[key].roleList {
+			queue = append(queue, edge.role)
+		}
+	}
+	return ret
+}
+
+// FindRole checks whether the role is granted to the user directly.
+func (p *MySQLPrivilege) FindRole(user, host string, role *auth.RoleIdentity) bool {
+	_, ok := p.RoleGraph[userKey(user, host)].roleList[role.String()]
+	return ok
+}
+
+// getAllRoles returns the roles granted to the user directly.
+func (p *MySQLPrivilege) getAllRoles(user, host string) []*auth.RoleIdentity {
+	edges := p.RoleGraph[userKey(user, host)].roleList
+	ret := make([]*auth.RoleIdentity, 0, len(edges))
+	for _, edge := range edges {
+		ret = append(ret, edge.role)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].String() < ret[j].String()
+	})
+	return ret
+}
+
+// getDefaultRoles returns the roles activated when the user logs in.
+func (p *MySQLPrivilege) getDefaultRoles(user, host string) []*auth.RoleIdentity {
+	var ret []*auth.RoleIdentity
+	for _, record := range p.DefaultRoles {
+		if record.User == user && record.Host == host {
+			ret = append(ret, &auth.RoleIdentity{Username: record.DefaultRoleUser, Hostname: record.DefaultRoleHost})
+		}
+	}
+	return ret
+}
+
+// RequestVerification checks whether the user or the active roles have
+// sufficient privileges to do the operation.
+func (p *MySQLPrivilege) RequestVerification(activeRoles []*auth.RoleIdentity, user, host, db, table, column string, priv mysql.PrivilegeType) bool {
+	if p.requestVerification(user, host, db, table, column, priv) {
+		return true
+	}
+	for _, role := range p.FindAllRole(activeRoles) {
+		if p.requestVerification(role.Username, role.Hostname, db, table, column, priv) {
+			return true
+		}
+	}
+	return priv == 0
+}
+
+func (p *MySQLPrivilege) requestVerification(user, host, db, table, column string, priv mysql.PrivilegeType) bool {
+	record1 := p.matchUser(user, host)
+	if record1 != nil && record1.Privileges&priv > 0 {
+		return true
+	}
+
+	record2 := p.matchDB(user, host, db)
+	if record2 != nil && record2.Privileges&priv > 0 {
+		return true
+	}
+
+	record3 := p.matchTables(user, host, db, table)
+	if record3 != nil {
+		if record3.TablePriv&priv > 0 {
+			return true
+		}
+		if column != "" && record3.ColumnPriv&priv > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DBIsVisible checks whether the user or the active roles can see the db.
+func (p *MySQLPrivilege) DBIsVisible(activeRoles []*auth.RoleIdentity, user, host, db string) bool {
+	// INFORMATION_SCHEMA is visible to all users.
+	if strings.EqualFold(db, "INFORMATION_SCHEMA") {
+		return true
+	}
+	if p.dbIsVisible(user, host, db) {
+		return true
+	}
+	for _, role := range p.FindAllRole(activeRoles) {
+		if p.dbIsVisible(role.Username, role.Hostname, db) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *MySQLPrivilege) dbIsVisible(user, host, db string) bool {
+	if record := p.matchUser(user, host); record != nil {
+		if record.Privileges != 0 {
+			return true
+		}
+	}
+
+	if record := p.matchDB(user, host, db); record != nil {
+		if record.Privileges > 0 {
+			return true
+		}
+	}
+
+	for _, record := range p.TablesPriv {
+		if record.match(user, host) && strings.EqualFold(record.DB, db) {
+			if record.TablePriv != 0 || record.ColumnPriv != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// showGrants returns the grants of the user, merged with the privileges of
+// the roles and the roles granted to them.
+func (p *MySQLPrivilege) showGrants(user, host string, roles []*auth.RoleIdentity) []string {
+	var gs []string
+	userName := userKey(user, host)
+	grantees := make(map[string]bool)
+	grantees[userName] = true
+	for _, role := range p.FindAllRole(roles) {
+		grantees[role.String()] = true
+	}
+
+	// Show global grants.
+	var globalPriv mysql.PrivilegeType
+	for _, record := range p.User {
+		if grantees[userKey(record.User, record.Host)] {
+			globalPriv |= record.Privileges
+		}
+	}
+	gs = append(gs, formatGrant("*.*", globalPriv, mysql.AllGlobalPrivs, userName))
+
+	// Show db scope grants.
+	dbPrivs := make(map[string]mysql.PrivilegeType)
+	for _, record := range p.DB {
+		if grantees[userKey(record.User, record.Host)] && record.Privileges != 0 {
+			dbPrivs[fmt.Sprintf("`%s`.*", record.DB)] |= record.Privileges
+		}
+	}
+	for _, level := range sortedLevels(dbPrivs) {
+		gs = append(gs, formatGrant(level, dbPrivs[level], mysql.AllDBPrivs, userName))
+	}
+
+	// Show table scope grants.
+	tablePrivs := make(map[string]mysql.PrivilegeType)
+	for _, record := range p.TablesPriv {
+		if grantees[userKey(record.User, record.Host)] && record.TablePriv != 0 {
+			tablePrivs[fmt.Sprintf("`%s`.`%s`", record.DB, record.TableName)] |= record.TablePriv
+		}
+	}
+	for _, level := range sortedLevels(tablePrivs) {
+		gs = append(gs, formatGrant(level, tablePrivs[level], mysql.AllTablePrivs, userName))
+	}
+
+	// Show role grants.
+	granted := p.getAllRoles(user, host)
+	if len(granted) > 0 {
+		names := make([]string, 0, len(granted))
+		for _, role := range granted {
+			names = append(names, role.String())
+		}
+		gs = append(gs, fmt.Sprintf("GRANT %s TO %s", strings.Join(names, ","), userName))
+	}
+	return gs
+}
+
+func sortedLevels(privs map[string]mysql.PrivilegeType) []string {
+	levels := make([]string, 0, len(privs))
+	for level := range privs {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	return levels
+}
+
+// formatGrant formats a GRANT statement of the privileges in the scope,
+// all privileges of the scope are shown as ALL PRIVILEGES.
+func formatGrant(level string, privs mysql.PrivilegeType, all []mysql.PrivilegeType, userName string) string {
+	var names []string
+	allGranted := true
+	for _, priv := range all {
+		if privs&priv != 0 {
+			names = append(names, mysql.Priv2Str[priv])
+		} else {
+			allGranted = false
+		}
+	}
+	var s string
+	switch {
+	case allGranted:
+		s = fmt.Sprintf("GRANT ALL PRIVILEGES ON %s TO %s", level, userName)
+	case len(names) == 0:
+		s = fmt.Sprintf("GRANT USAGE ON %s TO %s", level, userName)
+	default:
+		s = fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(names, ","), level, userName)
+	}
+	if privs&mysql.GrantPriv != 0 {
+		s += " WITH GRANT OPTION"
+	}
+	return s
+}
+
+// UserPrivilegesTable provide data for INFORMATION_SCHEMA.USER_PRIVILEGES table.
+func (p *MySQLPrivilege) UserPrivilegesTable() [][]types.Datum {
+	var rows [][]types.Datum
+	for _, user := range p.User {
+		rec := user.Privileges & userTablePrivilegeMask
+		isGrantable := "NO"
+		if user.Privileges&mysql.GrantPriv > 0 {
+			isGrantable = "YES"
+		}
+		grantee := fmt.Sprintf("'%s'@'%s'", user.User, user.Host)
+		if rec == 0 {
+			rows = append(rows, types.MakeDatums(grantee, "def", "USAGE", "NO"))
+			continue
+		}
+		for _, priv := range mysql.AllGlobalPrivs {
+			if rec&priv > 0 {
+				rows = append(rows, types.MakeDatums(grantee, "def", mysql.Priv2Str[priv], isGrantable))
+			}
+		}
+	}
+	return rows
+}
+
+// ApplicableRolesTable provide data for INFORMATION_SCHEMA.APPLICABLE_ROLES table,
+// which are the roles granted to the user recursively.
+func (p *MySQLPrivilege) ApplicableRolesTable(user, host string) [][]types.Datum {
+	var rows [][]types.Datum
+	visited := make(map[string]bool)
+	queue := []*auth.RoleIdentity{{Username: user, Hostname: host}}
+	for len(queue) > 0 {
+		grantee := queue[0]
+		queue = queue[1:]
+		if visited[grantee.String()] {
+			continue
+		}
+		visited[grantee.String()] = true
+		defaults := make(map[string]bool)
+		for _, role := range p.getDefaultRoles(grantee.Username, grantee.Hostname) {
+			defaults[role.String()] = true
+		}
+		for _, role := range p.getAllRoles(grantee.Username, grantee.Hostname) {
+			edge := p.RoleGraph[grantee.String()].roleList[role.String()]
+			rows = append(rows, types.MakeDatums(user, host, grantee.Username, grantee.Hostname,
+				role.Username, role.Hostname, yesOrNo(edge.withAdminOption), yesOrNo(defaults[role.String()]), "NO"))
+			queue = append(queue, role)
+		}
+	}
+	return rows
+}
+
+func yesOrNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+// Handle wraps MySQLPrivilege providing thread safe access.
+type Handle struct {
+	priv atomic.Value
+}
+
+// NewHandle returns a Handle.
+func NewHandle() *Handle {
+	h := &Handle{}
+	h.priv.Store(&MySQLPrivilege{})
+	return h
+}
+
+// Get the MySQLPrivilege for read.
+func (h *Handle) Get() *MySQLPrivilege {
+	return h.priv.Load().(*MySQLPrivilege)
+}
+
+// Update loads all the privilege info from kv storage.
+func (h *Handle) Update(ctx sessionctx.Context) error {
+	var priv MySQLPrivilege
+	err := priv.LoadAll(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	h.priv.Store(&priv)
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+var (
+	errInvalidPrivilegeType = terror.ClassPrivilege.New(mysql.ErrInvalidPrivilegeType, mysql.MySQLErrName[mysql.ErrInvalidPrivilegeType])
+	errNonexistingGrant     = terror.ClassPrivilege.New(mysql.ErrNonexistingGrant, mysql.MySQLErrName[mysql.ErrNonexistingGrant])
+	errRoleNotGranted       = terror.ClassPrivilege.New(mysql.ErrRoleNotGranted, mysql.MySQLErrName[mysql.ErrRoleNotGranted])
+)
+
+// UserPrivileges implements privilege.Manager interface.
+// This is used to check privilege for the current user.
+type UserPrivileges struct {
+	user string
+	host string
+	*Handle
+}
+
+var _ privilege.Manager = (*UserPrivileges)(nil)
+
+// RequestVerification implements the Manager interface.
+func (p *UserPrivileges) RequestVerification(activeRoles []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool {
+	if p.user == "" && p.host == "" {
+		return true
+	}
+
+	// Skip check for INFORMATION_SCHEMA database.
+	// See https://dev.mysql.com/doc/refman/5.7/en/information-schema.html
+	if strings.EqualFold(db, "INFORMATION_SCHEMA") {
+		return true
+	}
+
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.RequestVerification(activeRoles, p.user, p.host, db, table, column, priv)
+}
+
+// AuthPlugin implements the Manager interface.
+func (p *UserPrivileges) AuthPlugin(user, host string) (string, bool) {
+	record := p.Handle.Get().connectionVerification(user, host)
+	if record == nil {
+		return "", false
+	}
+	return record.AuthPlugin, true
+}
+
+// ConnectionVerification implements the Manager interface.
+func (p *UserPrivileges) ConnectionVerification(user, host string, authentication, salt []byte) (u string, h string, success bool) {
+	mysqlPriv := p.Handle.Get()
+	record := mysqlPriv.connectionVerification(user, host)
+	if record == nil {
+		logutil.BgLogger().Error("get user privilege record fail",
+			zap.String("user", user), zap.String("host", host))
+		return
+	}
+
+	u = record.User
+	h = record.Host
+
+	// Roles are locked accounts which can't log in.
+	if record.AccountLocked {
+		logutil.BgLogger().Error("the account is locked", zap.String("user", user), zap.String("host", host))
+		return
+	}
+
+	switch record.AuthPlugin {
+	case mysql.AuthCachingSha2Password:
+		// The client sends the cleartext password after the full authentication.
+		if !auth.CheckShaPassword([]byte(record.AuthenticationString), string(authentication)) {
+			logutil.BgLogger().Error("check caching_sha2_password failed", zap.String("user", user), zap.String("host", host))
+			return
+		}
+	default:
+		pwd := record.AuthenticationString
+		// Login a user without password.
+		if len(pwd) == 0 {
+			if len(authentication) != 0 {
+				return
+			}
+			break
+		}
+		if len(pwd) != mysql.PWDHashLen+1 {
+			logutil.BgLogger().Error("user password from system DB not like sha1sum", zap.String("user", user))
+			return
+		}
+		hpwd, err := auth.DecodePassword(pwd)
+		if err != nil {
+			logutil.BgLogger().Error("decode password string failed", zap.Error(err))
+			return
+		}
+		if !auth.CheckScrambledPassword(salt, hpwd, authentication) {
+			return
+		}
+	}
+
+	p.user = user
+	p.host = host
+	success = true
+	return
+}
+
+// DBIsVisible implements the Manager interface.
+func (p *UserPrivileges) DBIsVisible(activeRoles []*auth.RoleIdentity, db string) bool {
+	if p.user == "" && p.host == "" {
+		return true
+	}
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.DBIsVisible(activeRoles, p.user, p.host, db)
+}
+
+// UserPrivilegesTable implements the Manager interface.
+func (p *UserPrivileges) UserPrivilegesTable() [][]types.Datum {
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.UserPrivilegesTable()
+}
+
+// ApplicableRolesTable implements the Manager interface.
+func (p *UserPrivileges) ApplicableRolesTable(user, host string) [][]types.Datum {
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.ApplicableRolesTable(user, host)
+}
+
+// FindEdge implements the Manager interface.
+func (p *UserPrivileges) FindEdge(ctx sessionctx.Context, role *auth.RoleIdentity, user *auth.UserIdentity) bool {
+	mysqlPriv := p.Handle.Get()
+	if !mysqlPriv.userExists(role.Username, role.Hostname) {
+		return false
+	}
+	return mysqlPriv.FindRole(user.Username, user.Hostname, role)
+}
+
+// GetDefaultRoles implements the Manager interface.
+func (p *UserPrivileges) GetDefaultRoles(user, host string) []*auth.RoleIdentity {
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.getDefaultRoles(user, host)
+}
+
+// GetAllRoles implements the Manager interface.
+func (p *UserPrivileges) GetAllRoles(user, host string) []*auth.RoleIdentity {
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.getAllRoles(user, host)
+}
+
+// ShowGrants implements privilege.Manager ShowGrants interface.
+func (p *UserPrivileges) ShowGrants(ctx sessionctx.Context, user *auth.UserIdentity, roles []*auth.RoleIdentity) (grants []string, err error) {
+	mysqlPriv := p.Handle.Get()
+	u := user.Username
+	h := user.Hostname
+	if len(user.AuthUsername) > 0 && len(user.AuthHostname) > 0 {
+		u = user.AuthUsername
+		h = user.AuthHostname
+	}
+	if !mysqlPriv.userExists(u, h) {
+		return nil, errNonexistingGrant.GenWithStackByArgs(u, h)
+	}
+	for _, role := range roles {
+		if !mysqlPriv.FindRole(u, h, role) {
+			return nil, errRoleNotGranted.GenWithStackByArgs(role.String(), userKey(u, h))
+		}
+	}
+	grants = mysqlPriv.showGrants(u, h, roles)
+	return
+}
+
+func init() {
+	privilegeMySQLErrCodes := map[terror.ErrCode]uint16{
+		mysql.ErrInvalidPrivilegeType: mysql.ErrInvalidPrivilegeType,
+		mysql.ErrNonexistingGrant:     mysql.ErrNonexistingGrant,
+		mysql.ErrRoleNotGranted:       mysql.ErrRoleNotGranted,
+	}
+	terror.ErrClassToMySQLCodes[terror.ClassPrivilege] = privilegeMySQLErrCodes
+}
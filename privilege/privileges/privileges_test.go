@@ -0,0 +1,294 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges_test
+
+import (
+	"crypto/sha1"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/util/testkit"
+	"github.com/pingcap/tidb/util/testleak"
+)
+
+func TestT(t *testing.T) {
+	CustomVerboseFlag = true
+	TestingT(t)
+}
+
+var _ = Suite(&testPrivilegeSuite{})
+
+type testPrivilegeSuite struct {
+	store kv.Storage
+	dom   *domain.Domain
+}
+
+func (s *testPrivilegeSuite) SetUpSuite(c *C) {
+	testleak.BeforeTest()
+	var err error
+	s.store, err = mockstore.NewMockTikvStore()
+	c.Assert(err, IsNil)
+	session.SetSchemaLease(0)
+	session.DisableStats4Test()
+	s.dom, err = session.BootstrapSession(s.store)
+	c.Assert(err, IsNil)
+}
+
+func (s *testPrivilegeSuite) TearDownSuite(c *C) {
+	s.dom.Close()
+	s.store.Close()
+	testleak.AfterTest(c)()
+}
+
+// scramble computes the mysql_native_password response of the client.
+func scramble(pwd string, salt []byte) []byte {
+	if pwd == "" {
+		return nil
+	}
+	stage1 := sha1.Sum([]byte(pwd))
+	stage2 := sha1.Sum(stage1[:])
+	h := sha1.New()
+	h.Write(salt)
+	h.Write(stage2[:])
+	res := h.Sum(nil)
+	for i := range res {
+		res[i] ^= stage1[i]
+	}
+	return res
+}
+
+func (s *testPrivilegeSuite) login(c *C, user, pwd string) *testkit.TestKit {
+	tk := testkit.NewTestKit(c, s.store)
+	var err error
+	tk.Se, err = session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	salt := []byte("0123456789abcdefghij")
+	c.Assert(tk.Se.Auth(&auth.UserIdentity{Username: user, Hostname: "localhost"}, scramble(pwd, salt), salt), IsTrue)
+	return tk
+}
+
+func (s *testPrivilegeSuite) TestAuthentication(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("CREATE USER 'auth_native'@'%' IDENTIFIED BY 'pwd'")
+	tk.MustExec("CREATE USER 'auth_sha2'@'%' IDENTIFIED WITH 'caching_sha2_password' BY 'pwd'")
+	tk.MustExec("CREATE USER 'auth_empty'@'%'")
+	tk.MustGetErrCode("CREATE USER 'auth_empty'@'%'", mysql.ErrCannotUser)
+	tk.MustExec("CREATE USER IF NOT EXISTS 'auth_empty'@'%'")
+	tk.MustGetErrCode("CREATE USER 'auth_bad'@'%' IDENTIFIED WITH 'unknown_plugin' BY 'pwd'", mysql.ErrPluginIsNotLoaded)
+	tk.MustGetErrCode("CREATE USER 'auth_bad'@'%' IDENTIFIED BY PASSWORD 'abc'", mysql.ErrPasswordFormat)
+
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	salt := []byte("0123456789abcdefghij")
+	user := &auth.UserIdentity{Username: "auth_native", Hostname: "127.0.0.1"}
+	plugin, ok := se.AuthPlugin(user)
+	c.Assert(ok, IsTrue)
+	c.Assert(plugin, Equals, mysql.AuthNativePassword)
+	c.Assert(se.Auth(user, scramble("pwd", salt), salt), IsTrue)
+	c.Assert(se.Auth(user, scramble("wrong", salt), salt), IsFalse)
+	c.Assert(se.GetSessionVars().User.AuthUsername, Equals, "auth_native")
+	c.Assert(se.GetSessionVars().User.AuthHostname, Equals, "%")
+
+	// caching_sha2_password accounts are verified with the cleartext password.
+	user = &auth.UserIdentity{Username: "auth_sha2", Hostname: "127.0.0.1"}
+	plugin, ok = se.AuthPlugin(user)
+	c.Assert(ok, IsTrue)
+	c.Assert(plugin, Equals, mysql.AuthCachingSha2Password)
+	c.Assert(se.Auth(user, []byte("pwd"), salt), IsTrue)
+	c.Assert(se.Auth(user, []byte("wrong"), salt), IsFalse)
+
+	user = &auth.UserIdentity{Username: "auth_empty", Hostname: "127.0.0.1"}
+	c.Assert(se.Auth(user, nil, salt), IsTrue)
+	c.Assert(se.Auth(user, scramble("pwd", salt), salt), IsFalse)
+
+	_, ok = se.AuthPlugin(&auth.UserIdentity{Username: "auth_none", Hostname: "127.0.0.1"})
+	c.Assert(ok, IsFalse)
+
+	// Change the password and keep the plugin of the account.
+	tk.MustExec("ALTER USER 'auth_sha2'@'%' IDENTIFIED BY 'new'")
+	user = &auth.UserIdentity{Username: "auth_sha2", Hostname: "127.0.0.1"}
+	c.Assert(se.Auth(user, []byte("pwd"), salt), IsFalse)
+	c.Assert(se.Auth(user, []byte("new"), salt), IsTrue)
+	tk.MustGetErrCode("ALTER USER 'auth_none'@'%' IDENTIFIED BY 'pwd'", mysql.ErrCannotUser)
+	tk.MustExec("ALTER USER IF EXISTS 'auth_none'@'%' IDENTIFIED BY 'pwd'")
+
+	// A user changes the own password.
+	utk := s.login(c, "auth_native", "pwd")
+	utk.MustExec("ALTER USER USER() IDENTIFIED BY 'changed'")
+	user = &auth.UserIdentity{Username: "auth_native", Hostname: "127.0.0.1"}
+	c.Assert(se.Auth(user, scramble("changed", salt), salt), IsTrue)
+	utk.MustGetErrCode("ALTER USER 'auth_sha2'@'%' IDENTIFIED BY 'pwd'", mysql.ErrSpecificAccessDenied)
+
+	// A statement which fails for one of the users changes none of them.
+	tk.MustGetErrCode("DROP USER 'auth_sha2'@'%', 'auth_none'@'%'", mysql.ErrCannotUser)
+	tk.MustQuery("SELECT User FROM mysql.user WHERE User = 'auth_sha2'").Check(testkit.Rows("auth_sha2"))
+
+	tk.MustExec("DROP USER 'auth_native'@'%', 'auth_sha2'@'%'")
+	c.Assert(se.Auth(user, scramble("changed", salt), salt), IsFalse)
+	tk.MustGetErrCode("DROP USER 'auth_native'@'%'", mysql.ErrCannotUser)
+	tk.MustExec("DROP USER IF EXISTS 'auth_native'@'%', 'auth_empty'@'%'")
+}
+
+func (s *testPrivilegeSuite) TestGrantRevoke(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("CREATE DATABASE priv_db")
+	tk.MustExec("CREATE TABLE priv_db.t (a int primary key, b int)")
+	tk.MustExec("CREATE TABLE priv_db.t2 (a int)")
+	tk.MustExec("CREATE USER 'grant_user'@'%' IDENTIFIED BY 'pwd'")
+
+	utk := s.login(c, "grant_user", "pwd")
+	utk.MustQuery("SHOW GRANTS").Check(testkit.Rows("GRANT USAGE ON *.* TO 'grant_user'@'%'"))
+	utk.MustGetErrCode("USE priv_db", mysql.ErrDBaccessDenied)
+	utk.MustGetErrCode("SELECT * FROM priv_db.t", mysql.ErrTableaccessDenied)
+	utk.MustGetErrCode("CREATE USER 'other'@'%'", mysql.ErrSpecificAccessDenied)
+	utk.MustGetErrCode("SHOW GRANTS FOR 'root'@'%'", mysql.ErrDBaccessDenied)
+
+	// Table level.
+	tk.MustExec("GRANT SELECT, INSERT ON priv_db.t TO 'grant_user'@'%'")
+	utk.MustQuery("SHOW GRANTS").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'grant_user'@'%'",
+		"GRANT Select,Insert ON `priv_db`.`t` TO 'grant_user'@'%'"))
+	utk.MustExec("USE priv_db")
+	utk.MustExec("INSERT INTO t VALUES (1, 1)")
+	utk.MustQuery("SELECT a FROM t").Check(testkit.Rows("1"))
+	utk.MustGetErrCode("SELECT * FROM t2", mysql.ErrTableaccessDenied)
+	utk.MustGetErrCode("DELETE FROM t", mysql.ErrTableaccessDenied)
+	utk.MustGetErrCode("DROP TABLE t", mysql.ErrTableaccessDenied)
+	// Only the target table needs the DELETE privilege.
+	tk.MustExec("GRANT DELETE ON priv_db.t2 TO 'grant_user'@'%'")
+	utk.MustGetErrCode("DELETE FROM t WHERE a = 2", mysql.ErrTableaccessDenied)
+	tk.MustExec("REVOKE DELETE ON priv_db.t2 FROM 'grant_user'@'%'")
+	tk.MustExec("GRANT DELETE ON priv_db.t TO 'grant_user'@'%'")
+	utk.MustExec("DELETE FROM t WHERE a = 2")
+	utk.MustQuery("SELECT a FROM t").Check(testkit.Rows("1"))
+	tk.MustExec("REVOKE DELETE ON priv_db.t FROM 'grant_user'@'%'")
+	tk.MustGetErrCode("GRANT SUPER ON priv_db.t TO 'grant_user'@'%'", mysql.ErrIllegalGrantForTable)
+	tk.MustGetErrCode("GRANT SELECT ON priv_db.t3 TO 'grant_user'@'%'", mysql.ErrNoSuchTable)
+	tk.MustExec("REVOKE INSERT ON priv_db.t FROM 'grant_user'@'%'")
+	utk.MustGetErrCode("INSERT INTO t VALUES (2, 2)", mysql.ErrTableaccessDenied)
+	tk.MustExec("REVOKE SELECT ON priv_db.t FROM 'grant_user'@'%'")
+	utk.MustGetErrCode("SELECT * FROM t", mysql.ErrTableaccessDenied)
+
+	// Database level.
+	tk.MustExec("GRANT ALL ON priv_db.* TO 'grant_user'@'%'")
+	utk.MustQuery("SELECT a FROM t2").Check(testkit.Rows())
+	utk.MustExec("DELETE FROM t")
+	utk.MustExec("CREATE TABLE t3 (a int)")
+	utk.MustExec("DROP TABLE t3")
+	utk.MustQuery("SHOW DATABASES").Check(testkit.Rows("INFORMATION_SCHEMA", "priv_db"))
+	tk.MustExec("REVOKE DROP ON priv_db.* FROM 'grant_user'@'%'")
+	utk.MustGetErrCode("DROP TABLE t2", mysql.ErrTableaccessDenied)
+	tk.MustGetErrCode("REVOKE SELECT ON test.* FROM 'grant_user'@'%'", mysql.ErrNonexistingGrant)
+
+	// Global level.
+	utk.MustGetErrCode("SELECT * FROM mysql.user", mysql.ErrTableaccessDenied)
+	tk.MustExec("GRANT SELECT ON *.* TO 'grant_user'@'%' WITH GRANT OPTION")
+	utk.MustQuery("SELECT User FROM mysql.user WHERE User = 'grant_user'").Check(testkit.Rows("grant_user"))
+	utk.MustQuery("SHOW GRANTS FOR 'root'@'%'").Check(testkit.Rows("GRANT ALL PRIVILEGES ON *.* TO 'root'@'%' WITH GRANT OPTION"))
+	utk.MustExec("GRANT SELECT ON *.* TO 'grant_user'@'%'")
+	utk.MustGetErrCode("GRANT INSERT ON *.* TO 'grant_user'@'%'", mysql.ErrSpecificAccessDenied)
+	tk.MustExec("REVOKE SELECT, GRANT OPTION ON *.* FROM 'grant_user'@'%'")
+	utk.MustGetErrCode("SELECT * FROM mysql.user", mysql.ErrTableaccessDenied)
+
+	// GRANT creates the user only when the authentication is given.
+	tk.MustGetErrCode("GRANT SELECT ON *.* TO 'grant_new'@'%'", mysql.ErrCantCreateUserWithGrant)
+	tk.MustExec("GRANT SELECT ON *.* TO 'grant_new'@'%' IDENTIFIED BY 'pwd'")
+	s.login(c, "grant_new", "pwd").MustQuery("SHOW GRANTS").Check(testkit.Rows("GRANT Select ON *.* TO 'grant_new'@'%'"))
+
+	tk.MustExec("DROP USER 'grant_user'@'%', 'grant_new'@'%'")
+	err := tk.QueryToErr("SHOW GRANTS FOR 'grant_user'@'%'")
+	c.Assert(err, ErrorMatches, ".*There is no such grant defined for user 'grant_user' on host '%'")
+	tk.MustQuery("SELECT * FROM mysql.db WHERE User = 'grant_user'").Check(testkit.Rows())
+	tk.MustQuery("SELECT * FROM mysql.tables_priv WHERE User = 'grant_user'").Check(testkit.Rows())
+	tk.MustExec("DROP DATABASE priv_db")
+}
+
+func (s *testPrivilegeSuite) TestRole(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("CREATE DATABASE role_db")
+	tk.MustExec("CREATE TABLE role_db.t (a int)")
+	tk.MustExec("CREATE ROLE 'r_read', 'r_write'@'%'")
+	tk.MustGetErrCode("CREATE ROLE 'r_read'", mysql.ErrCannotUser)
+	tk.MustExec("GRANT SELECT ON role_db.* TO 'r_read'")
+	tk.MustExec("GRANT INSERT ON role_db.t TO 'r_write'")
+	tk.MustExec("GRANT 'r_read' TO 'r_write'")
+	tk.MustExec("CREATE USER 'role_user'@'%' IDENTIFIED BY 'pwd'")
+	tk.MustGetErrCode("GRANT 'r_none' TO 'role_user'", mysql.ErrCannotUser)
+	tk.MustGetErrCode("GRANT 'r_read' TO 'user_none'", mysql.ErrCannotUser)
+	tk.MustExec("GRANT 'r_write' TO 'role_user'")
+
+	// A role is a locked account.
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "r_read", Hostname: "localhost"}, nil, nil), IsFalse)
+
+	// The privileges of the roles take effect once they are active.
+	utk := s.login(c, "role_user", "pwd")
+	utk.MustGetErrCode("SELECT * FROM role_db.t", mysql.ErrTableaccessDenied)
+	utk.MustGetErrCode("SET ROLE 'r_read'", mysql.ErrRoleNotGranted)
+	utk.MustExec("SET ROLE 'r_write'")
+	utk.MustExec("INSERT INTO role_db.t VALUES (1)")
+	utk.MustQuery("SELECT a FROM role_db.t").Check(testkit.Rows("1"))
+	utk.MustExec("SET ROLE NONE")
+	utk.MustGetErrCode("SELECT * FROM role_db.t", mysql.ErrTableaccessDenied)
+	utk.MustExec("SET ROLE ALL")
+	utk.MustQuery("SELECT a FROM role_db.t").Check(testkit.Rows("1"))
+	utk.MustExec("SET ROLE ALL EXCEPT 'r_write'")
+	utk.MustGetErrCode("SELECT * FROM role_db.t", mysql.ErrTableaccessDenied)
+
+	utk.MustQuery("SHOW GRANTS").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'role_user'@'%'",
+		"GRANT 'r_write'@'%' TO 'role_user'@'%'"))
+	utk.MustQuery("SHOW GRANTS FOR CURRENT_USER() USING 'r_write'").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'role_user'@'%'",
+		"GRANT Select ON `role_db`.* TO 'role_user'@'%'",
+		"GRANT Insert ON `role_db`.`t` TO 'role_user'@'%'",
+		"GRANT 'r_write'@'%' TO 'role_user'@'%'"))
+	err = utk.QueryToErr("SHOW GRANTS FOR CURRENT_USER() USING 'r_read'")
+	c.Assert(err, ErrorMatches, ".*'r_read'@'%' .*not granted to 'role_user'@'%'")
+	utk.MustQuery("SELECT GRANTEE, ROLE_NAME, IS_DEFAULT FROM information_schema.APPLICABLE_ROLES").Check(testkit.Rows(
+		"role_user r_write NO",
+		"r_write r_read NO"))
+
+	// The default roles are activated at login.
+	utk.MustGetErrCode("SET DEFAULT ROLE 'r_read' TO 'role_user'", mysql.ErrRoleNotGranted)
+	utk.MustExec("SET DEFAULT ROLE ALL TO CURRENT_USER()")
+	utk = s.login(c, "role_user", "pwd")
+	utk.MustQuery("SELECT a FROM role_db.t").Check(testkit.Rows("1"))
+	utk.MustQuery("SELECT GRANTEE, ROLE_NAME, IS_DEFAULT FROM information_schema.APPLICABLE_ROLES").Check(testkit.Rows(
+		"role_user r_write YES",
+		"r_write r_read NO"))
+	tk.MustExec("SET DEFAULT ROLE NONE TO 'role_user'")
+	utk = s.login(c, "role_user", "pwd")
+	utk.MustGetErrCode("SELECT * FROM role_db.t", mysql.ErrTableaccessDenied)
+	utk.MustExec("SET ROLE DEFAULT")
+	utk.MustGetErrCode("SELECT * FROM role_db.t", mysql.ErrTableaccessDenied)
+
+	tk.MustExec("REVOKE 'r_write' FROM 'role_user'")
+	utk.MustGetErrCode("SET ROLE 'r_write'", mysql.ErrRoleNotGranted)
+	tk.MustExec("GRANT 'r_read' TO 'role_user'")
+	tk.MustExec("SET DEFAULT ROLE 'r_read' TO 'role_user'")
+	tk.MustExec("DROP ROLE 'r_read', 'r_write'")
+	tk.MustQuery("SELECT * FROM mysql.role_edges").Check(testkit.Rows())
+	tk.MustQuery("SELECT * FROM mysql.default_roles").Check(testkit.Rows())
+	tk.MustGetErrCode("DROP ROLE 'r_read'", mysql.ErrCannotUser)
+	tk.MustExec("DROP USER 'role_user'")
+	tk.MustExec("DROP DATABASE role_db")
+}
@@ -37,6 +37,9 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
 	"crypto/tls"
 	"encoding/binary"
 	"fmt"
@@ -52,6 +55,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -244,6 +248,7 @@ type handshakeResponse41 struct {
 	User       string
 	DBName     string
 	Auth       []byte
+	AuthPlugin string
 	Attrs      map[string]string
 }
 
@@ -370,9 +375,14 @@ func parseHandshakeResponseBody(ctx context.Context, packet *handshakeResponse41
 	}
 
 	if packet.Capability&mysql.ClientPluginAuth > 0 {
-		// TODO: Support mysql.ClientPluginAuth, skip it now
 		idx := bytes.IndexByte(data[offset:], 0)
-		offset = offset + idx + 1
+		if idx >= 0 {
+			packet.AuthPlugin = string(data[offset : offset+idx])
+			offset = offset + idx + 1
+		} else {
+			packet.AuthPlugin = string(data[offset:])
+			offset = len(data)
+		}
 	}
 
 	if packet.Capability&mysql.ClientConnectAtts > 0 {
@@ -444,9 +454,9 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 		return err
 	}
 
-	if (resp.Capability&mysql.ClientSSL > 0) && cc.server.tlsConfig != nil {
+	if tlsConfig := cc.server.getTLSConfig(); (resp.Capability&mysql.ClientSSL > 0) && tlsConfig != nil {
 		// The packet is a SSLRequest, let's switch to TLS.
-		if err = cc.upgradeToTLS(cc.server.tlsConfig); err != nil {
+		if err = cc.upgradeToTLS(tlsConfig); err != nil {
 			return err
 		}
 		// Read the following HandshakeResponse packet.
@@ -462,6 +472,8 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 		if err != nil {
 			return err
 		}
+	} else if cc.server.cfg.Security.RequireSecureTransport {
+		return errSecureTransportRequired
 	}
 
 	// Read the remaining part of the packet.
@@ -480,7 +492,7 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 	cc.collation = resp.Collation
 	cc.attrs = resp.Attrs
 
-	err = cc.openSessionAndDoAuth()
+	err = cc.openSessionAndDoAuth(ctx, resp.Auth, resp.AuthPlugin)
 	return err
 }
 
@@ -498,7 +510,7 @@ func (cc *clientConn) SessionStatusToString() string {
 	)
 }
 
-func (cc *clientConn) openSessionAndDoAuth() error {
+func (cc *clientConn) openSessionAndDoAuth(ctx context.Context, authData []byte, authPlugin string) error {
 	var tlsStatePtr *tls.ConnectionState
 	if cc.tlsConn != nil {
 		tlsState := cc.tlsConn.ConnectionState()
@@ -509,6 +521,36 @@ func (cc *clientConn) openSessionAndDoAuth() error {
 	if err != nil {
 		return err
 	}
+	hasPassword := "YES"
+	if len(authData) == 0 {
+		hasPassword = "NO"
+	}
+	host, err := cc.PeerHost(hasPassword)
+	if err != nil {
+		return err
+	}
+	userIdentity := &auth.UserIdentity{Username: cc.user, Hostname: host}
+	plugin, ok := cc.ctx.AuthPlugin(userIdentity)
+	if !ok {
+		return errAccessDenied.GenWithStackByArgs(cc.user, host, hasPassword)
+	}
+	switch plugin {
+	case mysql.AuthCachingSha2Password:
+		authData, err = cc.authCachingSha2Password(ctx, authData, authPlugin)
+	default:
+		// The client computed the authentication with another plugin, ask
+		// it to switch to mysql_native_password.
+		if authPlugin != "" && authPlugin != mysql.AuthNativePassword {
+			authData, err = cc.writeAuthSwitchRequest(mysql.AuthNativePassword)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if !cc.ctx.Auth(userIdentity, authData, cc.salt) {
+		return errAccessDenied.GenWithStackByArgs(cc.user, host, hasPassword)
+	}
+	cc.ctx.SetSessionManager(cc.server)
 	if cc.dbname != "" {
 		err = cc.useDB(context.Background(), cc.dbname)
 		if err != nil {
@@ -518,6 +560,93 @@ func (cc *clientConn) openSessionAndDoAuth() error {
 	return nil
 }
 
+// writeAuthSwitchRequest asks the client to authenticate with the plugin,
+// and returns the authentication data the client responses.
+// See https://dev.mysql.com/doc/internals/en/connection-phase-packets.html#packet-Protocol::AuthSwitchRequest
+func (cc *clientConn) writeAuthSwitchRequest(plugin string) ([]byte, error) {
+	data := make([]byte, 4, 4+1+len(plugin)+1+len(cc.salt)+1)
+	data = append(data, mysql.AuthSwitchRequest)
+	data = append(data, []byte(plugin)...)
+	data = append(data, 0)
+	data = append(data, cc.salt...)
+	data = append(data, 0)
+	err := cc.writePacket(data)
+	if err != nil {
+		return nil, err
+	}
+	err = cc.flush()
+	if err != nil {
+		return nil, err
+	}
+	return cc.readPacket()
+}
+
+// authCachingSha2Password does the exchanges of caching_sha2_password and
+// returns the cleartext password sent by the client. There is no cache of the
+// password hashes, so the fast authentication always fails and the client is
+// asked to do the full authentication: the password is sent in cleartext over
+// TLS, or encrypted with the RSA public key of the server otherwise.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/page_caching_sha2_authentication_exchanges.html
+func (cc *clientConn) authCachingSha2Password(ctx context.Context, authData []byte, authPlugin string) ([]byte, error) {
+	var err error
+	if authPlugin != mysql.AuthCachingSha2Password {
+		authData, err = cc.writeAuthSwitchRequest(mysql.AuthCachingSha2Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The client sends an empty scramble for an empty password.
+	if len(authData) == 0 {
+		return authData, nil
+	}
+	data := cc.alloc.AllocWithLen(4, 2)
+	data = append(data, mysql.AuthMoreData, mysql.CachingSha2PerformFullAuthentication)
+	if err = cc.writePacket(data); err != nil {
+		return nil, err
+	}
+	if err = cc.flush(); err != nil {
+		return nil, err
+	}
+	data, err = cc.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	if cc.tlsConn != nil {
+		return bytes.TrimRight(data, "\x00"), nil
+	}
+	if len(data) != 1 || data[0] != mysql.CachingSha2RequestPublicKey {
+		logutil.Logger(ctx).Debug("the client does not request the public key over the insecure connection")
+		return nil, errAccessDenied.GenWithStackByArgs(cc.user, cc.peerHost, "YES")
+	}
+	key, pubKey, err := cc.server.rsaKey()
+	if err != nil {
+		return nil, err
+	}
+	data = cc.alloc.AllocWithLen(4, 1+len(pubKey))
+	data = append(data, mysql.AuthMoreData)
+	data = append(data, pubKey...)
+	if err = cc.writePacket(data); err != nil {
+		return nil, err
+	}
+	if err = cc.flush(); err != nil {
+		return nil, err
+	}
+	data, err = cc.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	plain, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, key, data, nil)
+	if err != nil {
+		logutil.Logger(ctx).Debug("decrypt the password failed", zap.Error(err))
+		return nil, errAccessDenied.GenWithStackByArgs(cc.user, cc.peerHost, "YES")
+	}
+	// The client xors the null terminated password with the salt.
+	for i := range plain {
+		plain[i] ^= cc.salt[i%len(cc.salt)]
+	}
+	return bytes.TrimRight(plain, "\x00"), nil
+}
+
 func (cc *clientConn) PeerHost(hasPassword string) (host string, err error) {
 	if len(cc.peerHost) > 0 {
 		return cc.peerHost, nil
@@ -598,7 +727,9 @@ func (cc *clientConn) Run(ctx context.Context) {
 			return
 		}
 
-		if err = cc.dispatch(ctx, data); err != nil {
+		err = cc.dispatch(ctx, data)
+		cc.ctx.SetProcessInfo("", time.Now(), mysql.ComSleep)
+		if err != nil {
 			if terror.ErrorEqual(err, io.EOF) {
 
 				return
@@ -673,6 +804,7 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 	if cmd < mysql.ComEnd {
 		cc.ctx.SetCommandValue(cmd)
 	}
+	cc.ctx.SetProcessInfo("", time.Now(), cmd)
 
 	dataStr := string(hack.String(data))
 
@@ -17,8 +17,12 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 )
 
@@ -73,6 +77,21 @@ type QueryCtx interface {
 	GetSessionVars() *variable.SessionVars
 
 	SetCommandValue(command byte)
+
+	// SetProcessInfo sets the information the processlist shows for this connection.
+	SetProcessInfo(sql string, t time.Time, command byte)
+
+	// ShowProcess returns the information the processlist shows for this connection.
+	ShowProcess() *util.ProcessInfo
+
+	// SetSessionManager sets the session manager the session belongs to.
+	SetSessionManager(util.SessionManager)
+
+	// AuthPlugin returns the authentication plugin of the account the user will be authenticated as.
+	AuthPlugin(user *auth.UserIdentity) (string, bool)
+
+	// Auth verifies user's authentication.
+	Auth(user *auth.UserIdentity, authentication []byte, salt []byte) bool
 }
 
 // PreparedStatement is the interface to use a prepared statement.
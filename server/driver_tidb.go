@@ -18,14 +18,17 @@ import (
 	"crypto/tls"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/sqlexec"
 )
@@ -161,6 +164,31 @@ func (tc *TiDBContext) SetCommandValue(command byte) {
 	tc.session.SetCommandValue(command)
 }
 
+// SetProcessInfo implements QueryCtx SetProcessInfo method.
+func (tc *TiDBContext) SetProcessInfo(sql string, t time.Time, command byte) {
+	tc.session.SetProcessInfo(sql, t, command)
+}
+
+// ShowProcess implements QueryCtx ShowProcess method.
+func (tc *TiDBContext) ShowProcess() *util.ProcessInfo {
+	return tc.session.ShowProcess()
+}
+
+// SetSessionManager implements QueryCtx SetSessionManager method.
+func (tc *TiDBContext) SetSessionManager(sm util.SessionManager) {
+	tc.session.SetSessionManager(sm)
+}
+
+// AuthPlugin implements QueryCtx AuthPlugin method.
+func (tc *TiDBContext) AuthPlugin(user *auth.UserIdentity) (string, bool) {
+	return tc.session.AuthPlugin(user)
+}
+
+// Auth implements QueryCtx Auth method.
+func (tc *TiDBContext) Auth(user *auth.UserIdentity, authentication []byte, salt []byte) bool {
+	return tc.session.Auth(user, authentication, salt)
+}
+
 // GetSessionVars return SessionVars.
 func (tc *TiDBContext) GetSessionVars() *variable.SessionVars {
 	return tc.session.GetSessionVars()
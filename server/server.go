@@ -30,7 +30,10 @@ package server
 
 import (
 	"context"
-	"crypto/tls"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"math/rand"
 	"net"
@@ -38,6 +41,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unsafe"
 
 	// For pprof
 	_ "net/http/pprof"
@@ -58,9 +62,10 @@ var (
 )
 
 var (
-	errInvalidSequence = terror.ClassServer.New(mysql.ErrInvalidSequence, mysql.MySQLErrName[mysql.ErrInvalidSequence])
-	errInvalidType     = terror.ClassServer.New(mysql.ErrInvalidType, mysql.MySQLErrName[mysql.ErrInvalidType])
-	errAccessDenied    = terror.ClassServer.New(mysql.ErrAccessDenied, mysql.MySQLErrName[mysql.ErrAccessDenied])
+	errInvalidSequence         = terror.ClassServer.New(mysql.ErrInvalidSequence, mysql.MySQLErrName[mysql.ErrInvalidSequence])
+	errInvalidType             = terror.ClassServer.New(mysql.ErrInvalidType, mysql.MySQLErrName[mysql.ErrInvalidType])
+	errAccessDenied            = terror.ClassServer.New(mysql.ErrAccessDenied, mysql.MySQLErrName[mysql.ErrAccessDenied])
+	errSecureTransportRequired = terror.ClassServer.New(mysql.ErrSecureTransportRequired, mysql.MySQLErrName[mysql.ErrSecureTransportRequired])
 )
 
 // DefaultCapability is the capability of the server when it is created using the default configuration.
@@ -74,7 +79,7 @@ const defaultCapability = mysql.ClientLongPassword | mysql.ClientLongFlag |
 // Server is the MySQL protocol server
 type Server struct {
 	cfg        *config.Config
-	tlsConfig  *tls.Config
+	tlsState   unsafe.Pointer // *tlsState
 	driver     IDriver
 	listener   net.Listener
 	socket     net.Listener
@@ -88,6 +93,32 @@ type Server struct {
 	// So we just stop the listener and store to force clients to chose other TiDB servers.
 	stopListenerCh chan struct{}
 	statusServer   *http.Server
+
+	// rsa is the key pair used to exchange the password of caching_sha2_password
+	// over insecure connections, it is generated when first needed.
+	rsa struct {
+		sync.Once
+		key    *rsa.PrivateKey
+		pubKey []byte
+		err    error
+	}
+}
+
+// rsaKey returns the RSA private key and the PEM encoded public key.
+func (s *Server) rsaKey() (*rsa.PrivateKey, []byte, error) {
+	s.rsa.Do(func() {
+		s.rsa.key, s.rsa.err = rsa.GenerateKey(cryptorand.Reader, 2048)
+		if s.rsa.err != nil {
+			return
+		}
+		var der []byte
+		der, s.rsa.err = x509.MarshalPKIXPublicKey(&s.rsa.key.PublicKey)
+		if s.rsa.err != nil {
+			return
+		}
+		s.rsa.pubKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	})
+	return s.rsa.key, s.rsa.pubKey, errors.Trace(s.rsa.err)
 }
 
 // ConnectionCount gets current connection count.
@@ -126,13 +157,20 @@ func NewServer(cfg *config.Config, driver IDriver) (*Server, error) {
 		stopListenerCh: make(chan struct{}, 1),
 	}
 
+	state, err := loadTLSState(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if state == nil && cfg.Security.RequireSecureTransport {
+		return nil, errors.New("require-secure-transport is set but TLS is not configured")
+	}
+
 	s.capability = defaultCapability
-	if s.tlsConfig != nil {
+	if state != nil {
+		s.setTLSState(state)
 		s.capability |= mysql.ClientSSL
 	}
 
-	var err error
-
 	if s.cfg.Host != "" && s.cfg.Port != 0 {
 		addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
 		if s.listener, err = net.Listen("tcp", addr); err == nil {
@@ -324,6 +362,58 @@ func (s *Server) kickIdleConnection() {
 	}
 }
 
+// ShowProcessList implements the SessionManager interface.
+func (s *Server) ShowProcessList() map[uint64]*util.ProcessInfo {
+	s.rwlock.RLock()
+	defer s.rwlock.RUnlock()
+	rs := make(map[uint64]*util.ProcessInfo, len(s.clients))
+	for _, client := range s.clients {
+		if atomic.LoadInt32(&client.status) == connStatusWaitShutdown {
+			continue
+		}
+		if pi := client.ctx.ShowProcess(); pi != nil {
+			rs[pi.ID] = pi
+		}
+	}
+	return rs
+}
+
+// GetProcessInfo implements the SessionManager interface.
+func (s *Server) GetProcessInfo(id uint64) (*util.ProcessInfo, bool) {
+	s.rwlock.RLock()
+	conn, ok := s.clients[uint32(id)]
+	s.rwlock.RUnlock()
+	if !ok || atomic.LoadInt32(&conn.status) == connStatusWaitShutdown {
+		return nil, false
+	}
+	return conn.ctx.ShowProcess(), true
+}
+
+// Kill implements the SessionManager interface.
+func (s *Server) Kill(connectionID uint64, query bool) {
+	logutil.BgLogger().Info("kill", zap.Uint64("connID", connectionID), zap.Bool("query", query))
+
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	conn, ok := s.clients[uint32(connectionID)]
+	if !ok {
+		return
+	}
+
+	if !query {
+		// The goroutine of an idle connection is blocked on reading the next
+		// packet, so close the connection here and let it exit on the read error.
+		if atomic.CompareAndSwapInt32(&conn.status, connStatusReading, connStatusShutdown) {
+			terror.Log(conn.closeWithoutLock())
+			return
+		}
+		// Mark the client connection status as WaitShutdown, when the goroutine detect
+		// this, it will end the dispatch loop and exit.
+		atomic.StoreInt32(&conn.status, connStatusWaitShutdown)
+	}
+	killConn(conn)
+}
+
 func init() {
 	serverMySQLErrCodes := map[terror.ErrCode]uint16{
 		mysql.ErrNotAllowedCommand: mysql.ErrNotAllowedCommand,
@@ -331,6 +421,8 @@ func init() {
 		mysql.ErrUnknownFieldType:  mysql.ErrUnknownFieldType,
 		mysql.ErrInvalidSequence:   mysql.ErrInvalidSequence,
 		mysql.ErrInvalidType:       mysql.ErrInvalidType,
+
+		mysql.ErrSecureTransportRequired: mysql.ErrSecureTransportRequired,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassServer] = serverMySQLErrCodes
 }
@@ -14,6 +14,7 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"io/ioutil"
@@ -113,6 +114,12 @@ func (dbt *DBTest) mustExec(query string) (res sql.Result) {
 	return res
 }
 
+func (dbt *DBTest) mustQuery(query string, args ...interface{}) (rows *sql.Rows) {
+	rows, err := dbt.db.Query(query, args...)
+	dbt.Assert(err, IsNil, Commentf("Query %s", query))
+	return rows
+}
+
 func runTestIssue3662(c *C) {
 	db, err := sql.Open("mysql", getDSN(func(config *mysql.Config) {
 		config.DBName = "non_existing_schema"
@@ -148,6 +155,107 @@ func runTestResultFieldTableIsNull(c *C) {
 	})
 }
 
+func runTestProcesslist(c *C) {
+	runTests(c, nil, func(dbt *DBTest) {
+		query := "select id, user, db, command, info from information_schema.processlist"
+		rows := dbt.mustQuery(query)
+		var found bool
+		for rows.Next() {
+			var (
+				id            uint64
+				user, command string
+				db, info      sql.NullString
+			)
+			err := rows.Scan(&id, &user, &db, &command, &info)
+			dbt.Check(err, IsNil)
+			if info.String == query {
+				found = true
+				dbt.Check(id > 0, IsTrue)
+				dbt.Check(user, Equals, "root")
+				dbt.Check(db.String, Equals, "test")
+				dbt.Check(command, Equals, "Query")
+			}
+		}
+		dbt.Check(rows.Close(), IsNil)
+		dbt.Check(found, IsTrue)
+	})
+}
+
+func runTestKill(c *C) {
+	runTests(c, nil, func(dbt *DBTest) {
+		ctx := context.Background()
+		conn1, err := dbt.db.Conn(ctx)
+		dbt.Check(err, IsNil)
+		defer conn1.Close()
+		conn2, err := dbt.db.Conn(ctx)
+		dbt.Check(err, IsNil)
+		defer conn2.Close()
+
+		var connID uint64
+		err = conn1.QueryRowContext(ctx, "select connection_id()").Scan(&connID)
+		dbt.Check(err, IsNil)
+
+		// KILL QUERY on an idle connection keeps the connection usable.
+		_, err = conn2.ExecContext(ctx, fmt.Sprintf("kill query %d", connID))
+		dbt.Check(err, IsNil)
+		var id uint64
+		err = conn1.QueryRowContext(ctx, "select connection_id()").Scan(&id)
+		dbt.Check(err, IsNil)
+		dbt.Check(id, Equals, connID)
+
+		_, err = conn2.ExecContext(ctx, fmt.Sprintf("kill %d", connID))
+		dbt.Check(err, IsNil)
+		err = conn1.QueryRowContext(ctx, "select connection_id()").Scan(&id)
+		dbt.Check(err, NotNil)
+
+		_, err = conn2.ExecContext(ctx, "kill 999999")
+		dbt.Check(err, NotNil)
+		dbt.Check(err.Error(), Equals, "Error 1094: Unknown thread id: 999999")
+	})
+}
+
+func runTestAuth(c *C) {
+	runTests(c, nil, func(dbt *DBTest) {
+		dbt.mustExec("CREATE USER 'authtest'@'%' IDENTIFIED BY 'pwd'")
+		dbt.mustExec("GRANT ALL ON test.* TO 'authtest'@'%'")
+	})
+	runTests(c, func(config *mysql.Config) {
+		config.User = "authtest"
+		config.Passwd = "pwd"
+	}, func(dbt *DBTest) {
+		rows := dbt.mustQuery("SELECT CURRENT_USER()")
+		dbt.Check(rows.Next(), IsTrue)
+		var user string
+		dbt.Check(rows.Scan(&user), IsNil)
+		dbt.Check(user, Equals, "authtest@%")
+		dbt.Check(rows.Close(), IsNil)
+	})
+
+	for _, overrider := range []configOverrider{
+		func(config *mysql.Config) {
+			config.User = "authtest"
+			config.Passwd = "wrong"
+		},
+		func(config *mysql.Config) {
+			config.User = "authtest"
+		},
+		func(config *mysql.Config) {
+			config.User = "nosuchuser"
+		},
+	} {
+		db, err := sql.Open("mysql", getDSN(overrider))
+		c.Assert(err, IsNil)
+		err = db.Ping()
+		c.Assert(err, NotNil)
+		c.Assert(err.Error(), Matches, "Error 1045: Access denied for user .*")
+		db.Close()
+	}
+
+	runTests(c, nil, func(dbt *DBTest) {
+		dbt.mustExec("DROP USER 'authtest'@'%'")
+	})
+}
+
 const retryTime = 100
 
 func waitUntilServerOnline(statusPort uint) {
@@ -16,7 +16,23 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/kv"
@@ -82,6 +98,21 @@ func (ts *TidbTestSuite) TestResultFieldTableIsNull(c *C) {
 	runTestResultFieldTableIsNull(c)
 }
 
+func (ts *TidbTestSuite) TestProcesslist(c *C) {
+	c.Parallel()
+	runTestProcesslist(c)
+}
+
+func (ts *TidbTestSuite) TestKill(c *C) {
+	c.Parallel()
+	runTestKill(c)
+}
+
+func (ts *TidbTestSuite) TestAuth(c *C) {
+	c.Parallel()
+	runTestAuth(c)
+}
+
 func (ts *TidbTestSuite) TestShowTablesFlen(c *C) {
 	qctx, err := ts.tidbdrv.OpenCtx(uint64(0), 0, uint8(tmysql.DefaultCollationID), "test", nil)
 	c.Assert(err, IsNil)
@@ -116,3 +147,180 @@ func (ts *TidbTestSuite) TestNullFlag(c *C) {
 	expectFlag := uint16(tmysql.NotNullFlag | tmysql.BinaryFlag)
 	c.Assert(dumpFlag(cols[0].Type, cols[0].Flag), Equals, expectFlag)
 }
+
+// generateCert generates a certificate signed by the parent, or a self-signed
+// one when the parent is nil, and writes the key and the certificate as PEM files.
+func generateCert(sn int, commonName string, parentCert *x509.Certificate, parentKey *rsa.PrivateKey,
+	outKeyFile string, outCertFile string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(int64(sn)),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parentCert == nil,
+	}
+	if parentCert == nil {
+		parentCert, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err = ioutil.WriteFile(outKeyFile, keyPEM, 0600); err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err = ioutil.WriteFile(outCertFile, certPEM, 0644); err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+// startTLSServer starts a server with the security config on the port, the
+// listener is open once the server is created.
+func (ts *TidbTestSuite) startTLSServer(c *C, port uint, security config.Security) *Server {
+	cfg := config.NewConfig()
+	cfg.Port = port
+	cfg.Status.ReportStatus = false
+	cfg.Security = security
+	server, err := NewServer(cfg, ts.tidbdrv)
+	c.Assert(err, IsNil)
+	go server.Run()
+	return server
+}
+
+func openTLSTestDB(c *C, port uint, tlsConfig string) *sql.DB {
+	db, err := sql.Open("mysql", getDSN(func(config *mysql.Config) {
+		config.Addr = fmt.Sprintf("127.0.0.1:%d", port)
+		config.TLSConfig = tlsConfig
+	}))
+	c.Assert(err, IsNil)
+	return db
+}
+
+func queryStatus(c *C, db *sql.DB, name string) string {
+	rows, err := db.Query("SHOW STATUS")
+	c.Assert(err, IsNil)
+	defer rows.Close()
+	for rows.Next() {
+		var key, val string
+		c.Assert(rows.Scan(&key, &val), IsNil)
+		if key == name {
+			return val
+		}
+	}
+	c.Fatalf("status %s not found", name)
+	return ""
+}
+
+func (ts *TidbTestSuite) TestTLS(c *C) {
+	dir := c.MkDir()
+	caCert, caKey, err := generateCert(0, "TiDB CA", nil, nil,
+		filepath.Join(dir, "ca-key.pem"), filepath.Join(dir, "ca-cert.pem"))
+	c.Assert(err, IsNil)
+	_, _, err = generateCert(1, "tidb-server", caCert, caKey,
+		filepath.Join(dir, "server-key.pem"), filepath.Join(dir, "server-cert.pem"))
+	c.Assert(err, IsNil)
+	security := config.Security{
+		SSLCA:   filepath.Join(dir, "ca-cert.pem"),
+		SSLCert: filepath.Join(dir, "server-cert.pem"),
+		SSLKey:  filepath.Join(dir, "server-key.pem"),
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	err = mysql.RegisterTLSConfig("tidb-test", &tls.Config{RootCAs: pool, ServerName: "tidb-server"})
+	c.Assert(err, IsNil)
+
+	server := ts.startTLSServer(c, 4002, security)
+	caFile, certFile, keyFile := server.TLSFiles()
+	c.Assert([]string{caFile, certFile, keyFile}, DeepEquals, []string{security.SSLCA, security.SSLCert, security.SSLKey})
+	connect := func(tlsConfig string) *sql.DB {
+		return openTLSTestDB(c, 4002, tlsConfig)
+	}
+	db := connect("tidb-test")
+	c.Assert(db.Ping(), IsNil)
+	c.Assert(queryStatus(c, db, "Ssl_cipher"), Not(Equals), "")
+	c.Assert(queryStatus(c, db, "Ssl_version"), Matches, "TLSv1.*")
+	db.Close()
+	db = connect("")
+	c.Assert(db.Ping(), IsNil)
+	c.Assert(queryStatus(c, db, "Ssl_cipher"), Equals, "")
+	db.Close()
+	server.Close()
+
+	// Reject the connections without TLS.
+	security.RequireSecureTransport = true
+	server = ts.startTLSServer(c, 4002, security)
+	db = connect("")
+	err = db.Ping()
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, "Error 3159: Connections using insecure transport are prohibited.*")
+	db.Close()
+	db = connect("tidb-test")
+	c.Assert(db.Ping(), IsNil)
+	db.Close()
+	server.Close()
+
+	// TLS is required but not configured.
+	cfg := config.NewConfig()
+	cfg.Port = 4002
+	cfg.Security.RequireSecureTransport = true
+	_, err = NewServer(cfg, ts.tidbdrv)
+	c.Assert(err, NotNil)
+	cfg.Security = security
+	cfg.Security.SSLKey = filepath.Join(dir, "missing-key.pem")
+	_, err = NewServer(cfg, ts.tidbdrv)
+	c.Assert(os.IsNotExist(errors.Cause(err)), IsTrue)
+}
+
+func (ts *TidbTestSuite) TestAutoTLS(c *C) {
+	dir := c.MkDir()
+	server := ts.startTLSServer(c, 4003, config.Security{AutoTLS: true, AutoTLSDir: dir})
+	defer server.Close()
+	certFile, keyFile := filepath.Join(dir, autoCertFile), filepath.Join(dir, autoKeyFile)
+	_, err := os.Stat(certFile)
+	c.Assert(err, IsNil)
+	_, err = os.Stat(keyFile)
+	c.Assert(err, IsNil)
+
+	db := openTLSTestDB(c, 4003, "skip-verify")
+	c.Assert(queryStatus(c, db, "Ssl_cipher"), Not(Equals), "")
+	db.Close()
+
+	// The certificate is generated again if it is removed before reloading.
+	oldCert := server.getTLSConfig().Certificates[0].Certificate[0]
+	c.Assert(server.ReloadTLSConfig(), IsNil)
+	c.Assert(server.getTLSConfig().Certificates[0].Certificate[0], DeepEquals, oldCert)
+	c.Assert(os.Remove(certFile), IsNil)
+	c.Assert(server.ReloadTLSConfig(), IsNil)
+	newCert := server.getTLSConfig().Certificates[0].Certificate[0]
+	c.Assert(newCert, Not(DeepEquals), oldCert)
+	db = openTLSTestDB(c, 4003, "skip-verify")
+	c.Assert(queryStatus(c, db, "Ssl_cipher"), Not(Equals), "")
+	db.Close()
+
+	// A broken certificate keeps the current one.
+	c.Assert(ioutil.WriteFile(certFile, []byte("broken"), 0644), IsNil)
+	c.Assert(server.ReloadTLSConfig(), NotNil)
+	c.Assert(server.getTLSConfig().Certificates[0].Certificate[0], DeepEquals, newCert)
+
+	// The certificate can't be generated if the directory isn't writable.
+	cfg := config.NewConfig()
+	cfg.Port = 4003
+	cfg.Security = config.Security{AutoTLS: true, AutoTLSDir: certFile}
+	_, err = NewServer(cfg, ts.tidbdrv)
+	c.Assert(err, ErrorMatches, ".*auto-tls-dir.*")
+}
@@ -0,0 +1,203 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// autoCertFile and autoKeyFile are the files under auto-tls-dir which
+	// hold the self-signed certificate generated when auto-tls is set.
+	autoCertFile = "tidb-server-cert.pem"
+	autoKeyFile  = "tidb-server-key.pem"
+
+	autoCertCommonName = "TiDB_Server_Auto_Generated_Server_Certificate"
+	autoCertValidity   = 365 * 24 * time.Hour
+)
+
+// tlsCertFiles returns the certificate and the key of the MySQL protocol
+// listener. When auto-tls is set and no certificate is configured, a
+// self-signed certificate is generated under auto-tls-dir if it doesn't
+// exist yet. Empty paths mean that TLS is not configured.
+func tlsCertFiles(cfg *config.Config) (string, string, error) {
+	security := &cfg.Security
+	if security.SSLCert != "" || security.SSLKey != "" || !security.AutoTLS {
+		return security.SSLCert, security.SSLKey, nil
+	}
+	dir := security.AutoTLSDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	certFile := filepath.Join(dir, autoCertFile)
+	keyFile := filepath.Join(dir, autoKeyFile)
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if certErr == nil && keyErr == nil {
+		return certFile, keyFile, nil
+	}
+	err := os.MkdirAll(dir, 0700)
+	if err == nil {
+		err = generateSelfSignedCert(certFile, keyFile)
+	}
+	if err != nil {
+		return "", "", errors.Annotatef(err, "failed to generate the self-signed certificate, auto-tls-dir %s must be a writable directory", dir)
+	}
+	logutil.BgLogger().Info("generated the self-signed TLS certificate",
+		zap.String("cert", certFile), zap.String("key", keyFile))
+	return certFile, keyFile, nil
+}
+
+// generateSelfSignedCert writes a new RSA key and a self-signed certificate
+// of it as PEM files.
+func generateSelfSignedCert(certFile, keyFile string) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          sn,
+		Subject:               pkix.Name{CommonName: autoCertCommonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(autoCertValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err = ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return errors.Trace(ioutil.WriteFile(certFile, certPEM, 0644))
+}
+
+// tlsState is the TLS config of the MySQL protocol listener and the files it
+// is loaded from, it is swapped as a whole when the certificates are reloaded.
+type tlsState struct {
+	config   *tls.Config
+	caFile   string
+	certFile string
+	keyFile  string
+}
+
+// loadTLSState loads the certificates of the MySQL protocol listener, it
+// returns nil if TLS is not configured. The client certificates are verified
+// against the CA when it is given and the client sends one.
+func loadTLSState(cfg *config.Config) (*tlsState, error) {
+	certFile, keyFile, err := tlsCertFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	caFile := cfg.Security.SSLCA
+	if caFile != "" {
+		caCert, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("failed to load the CA certificates from %s", caFile)
+		}
+		tlsConfig.ClientCAs = certPool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	logutil.BgLogger().Info("loaded the TLS certificates for the MySQL protocol",
+		zap.String("cert", certFile), zap.String("key", keyFile), zap.String("ca", caFile))
+	return &tlsState{config: tlsConfig, caFile: caFile, certFile: certFile, keyFile: keyFile}, nil
+}
+
+func (s *Server) getTLSState() *tlsState {
+	return (*tlsState)(atomic.LoadPointer(&s.tlsState))
+}
+
+func (s *Server) setTLSState(state *tlsState) {
+	atomic.StorePointer(&s.tlsState, unsafe.Pointer(state))
+}
+
+func (s *Server) getTLSConfig() *tls.Config {
+	if state := s.getTLSState(); state != nil {
+		return state.config
+	}
+	return nil
+}
+
+// TLSEnabled returns whether the MySQL protocol listener accepts TLS connections.
+func (s *Server) TLSEnabled() bool {
+	return s.getTLSState() != nil
+}
+
+// TLSFiles returns the CA, the certificate and the key files in use, they
+// are empty if TLS is not enabled.
+func (s *Server) TLSFiles() (caFile, certFile, keyFile string) {
+	if state := s.getTLSState(); state != nil {
+		return state.caFile, state.certFile, state.keyFile
+	}
+	return "", "", ""
+}
+
+// ReloadTLSConfig reloads the certificates from the configured files, the new
+// connections use them while the established ones are not affected. The
+// current certificates are kept if the new ones fail to load, and nothing is
+// done if TLS is not enabled when the server starts.
+func (s *Server) ReloadTLSConfig() error {
+	if s.getTLSState() == nil {
+		return nil
+	}
+	state, err := loadTLSState(s.cfg)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return errors.New("TLS is not configured")
+	}
+	s.setTLSState(state)
+	return nil
+}
@@ -38,10 +38,13 @@ import (
 	"github.com/pingcap/tidb/owner"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/privileges"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -68,11 +71,18 @@ type Session interface {
 	SetCommandValue(byte)
 	SetTLSState(*tls.ConnectionState)
 	SetCollation(coID int) error
+	// AuthPlugin returns the authentication plugin of the account the user will be authenticated as.
+	AuthPlugin(user *auth.UserIdentity) (string, bool)
+	// Auth verifies the authentication of the user, and sets the user of the session on success.
+	Auth(user *auth.UserIdentity, authentication []byte, salt []byte) bool
 	Close()
 	// PrePareTxnCtx is exported for test.
 	PrepareTxnCtx(context.Context)
 	// FieldList returns fields list of a table.
 	FieldList(tableName string) (fields []*ast.ResultField, err error)
+	SetProcessInfo(sql string, t time.Time, command byte)
+	ShowProcess() *util.ProcessInfo
+	SetSessionManager(util.SessionManager)
 }
 
 var (
@@ -122,6 +132,10 @@ type session struct {
 
 	// shared coprocessor client per session
 	client kv.Client
+
+	processInfo atomic.Value
+	// sessionManager is set by the server the session belongs to.
+	sessionManager util.SessionManager
 }
 
 // DDLOwnerChecker returns s.ddlOwnerChecker.
@@ -167,6 +181,44 @@ func (s *session) SetCommandValue(command byte) {
 	atomic.StoreUint32(&s.sessionVars.CommandValue, uint32(command))
 }
 
+// SetProcessInfo records the statement the session is currently handling.
+func (s *session) SetProcessInfo(sql string, t time.Time, command byte) {
+	pi := util.ProcessInfo{
+		ID:            s.sessionVars.ConnectionID,
+		DB:            s.sessionVars.CurrentDB,
+		Command:       command,
+		Time:          t,
+		State:         s.Status(),
+		Info:          sql,
+		CurTxnStartTS: s.sessionVars.TxnCtx.StartTS,
+	}
+	if s.sessionVars.User != nil {
+		pi.User = s.sessionVars.User.Username
+		pi.Host = s.sessionVars.User.Hostname
+	}
+	s.processInfo.Store(&pi)
+}
+
+// ShowProcess returns the ProcessInfo last recorded by SetProcessInfo.
+func (s *session) ShowProcess() *util.ProcessInfo {
+	var pi *util.ProcessInfo
+	tmp := s.processInfo.Load()
+	if tmp != nil {
+		pi = tmp.(*util.ProcessInfo)
+	}
+	return pi
+}
+
+// SetSessionManager sets the session manager which owns the session.
+func (s *session) SetSessionManager(sm util.SessionManager) {
+	s.sessionManager = sm
+}
+
+// GetSessionManager implements the sessionctx.Context interface.
+func (s *session) GetSessionManager() util.SessionManager {
+	return s.sessionManager
+}
+
 func (s *session) SetCollation(coID int) error {
 	cs, co, err := charset.GetCharsetInfoByID(coID)
 	if err != nil {
@@ -594,6 +646,9 @@ func (s *session) execute(ctx context.Context, sql string) (recordSets []sqlexec
 	for _, stmtNode := range stmtNodes {
 		s.sessionVars.StartTime = time.Now()
 		s.PrepareTxnCtx(ctx)
+		if s.sessionManager != nil {
+			s.SetProcessInfo(stmtNode.Text(), s.sessionVars.StartTime, byte(atomic.LoadUint32(&s.sessionVars.CommandValue)))
+		}
 
 		// Step2: Transform abstract syntax tree to a physical plan(stored in executor.ExecStmt).
 		// Some executions are done in compile stage, so we reset them before compile.
@@ -720,6 +775,29 @@ func (s *session) Close() {
 	s.RollbackTxn(ctx)
 }
 
+// AuthPlugin implements Session AuthPlugin interface.
+func (s *session) AuthPlugin(user *auth.UserIdentity) (string, bool) {
+	pm := privilege.GetPrivilegeManager(s)
+	return pm.AuthPlugin(user.Username, user.Hostname)
+}
+
+// Auth implements Session Auth interface.
+func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []byte) bool {
+	pm := privilege.GetPrivilegeManager(s)
+	authUser, authHost, success := pm.ConnectionVerification(user.Username, user.Hostname, authentication, salt)
+	if !success {
+		return false
+	}
+	s.sessionVars.User = &auth.UserIdentity{
+		Username:     user.Username,
+		Hostname:     user.Hostname,
+		AuthUsername: authUser,
+		AuthHostname: authHost,
+	}
+	s.sessionVars.ActiveRoles = pm.GetDefaultRoles(authUser, authHost)
+	return true
+}
+
 // GetSessionVars implements the context.Context interface.
 func (s *session) GetSessionVars() *variable.SessionVars {
 	return s.sessionVars
@@ -769,6 +847,15 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 		return nil, err
 	}
 
+	se2, err := createSession(store)
+	if err != nil {
+		return nil, err
+	}
+	err = dom.LoadPrivilegeLoop(se2)
+	if err != nil {
+		return nil, err
+	}
+
 	return dom, err
 }
 
@@ -812,6 +899,7 @@ func createSession(store kv.Storage) (*session, error) {
 	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
 	domain.BindDomain(s, dom)
+	privilege.BindPrivilegeManager(s, &privileges.UserPrivileges{Handle: dom.PrivilegeHandle()})
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
 	s.txn.init()
@@ -831,6 +919,7 @@ func CreateSessionWithDomain(store kv.Storage, dom *domain.Domain) (*session, er
 	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
 	domain.BindDomain(s, dom)
+	privilege.BindPrivilegeManager(s, &privileges.UserPrivileges{Handle: dom.PrivilegeHandle()})
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
 	s.txn.init()
@@ -912,6 +1001,7 @@ var builtinGlobalVariable = []string{
 	variable.NetWriteTimeout,
 	variable.MaxExecutionTime,
 	variable.InnodbLockWaitTimeout,
+	variable.ForeignKeyChecks,
 
 	/* TiDB specific global variables: */
 	variable.TiDBSkipUTF8Check,
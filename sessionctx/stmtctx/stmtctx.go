@@ -116,6 +116,9 @@ type StatementContext struct {
 	nowTs          time.Time // use this variable for now/current_timestamp calculation/cache for one stmt
 	stmtTimeCached bool
 	StmtType       string
+	// Deadline is the time by which the statement must finish, it is zero if
+	// the statement has no execution time limit.
+	Deadline time.Time
 }
 
 // StmtHints are SessionVars related sql hints.
@@ -124,11 +127,13 @@ type StmtHints struct {
 	HasAllowInSubqToJoinAndAggHint bool
 	HasMemQuotaHint                bool
 	HasReplicaReadHint             bool
+	HasMaxExecutionTime            bool
 
 	// Hint Information
 	AllowInSubqToJoinAndAgg bool
 	MemQuotaQuery           int64
 	ReplicaRead             byte
+	MaxExecutionTime        uint64
 }
 
 // GetNowTsCached getter for nowTs, if not set get now time and cache it
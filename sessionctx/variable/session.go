@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
@@ -163,6 +164,12 @@ type SessionVars struct {
 	// TLSConnectionState is the TLS connection state (nil if not using TLS).
 	TLSConnectionState *tls.ConnectionState
 
+	// User is the user identity with which the session login.
+	User *auth.UserIdentity
+
+	// ActiveRoles is the roles activated in the session.
+	ActiveRoles []*auth.RoleIdentity
+
 	// ConnectionID is the connection id of the current session.
 	ConnectionID uint64
 
@@ -283,6 +290,10 @@ type SessionVars struct {
 	// See https://dev.mysql.com/doc/refman/5.7/en/server-system-variables.html#sysvar_max_execution_time
 	MaxExecutionTime uint64
 
+	// ForeignKeyChecks indicates whether foreign key constraints are checked and enforced.
+	// See https://dev.mysql.com/doc/refman/5.7/en/server-system-variables.html#sysvar_foreign_key_checks
+	ForeignKeyChecks bool
+
 	// Killed is a flag to indicate that this query is killed.
 	Killed uint32
 
@@ -345,7 +356,6 @@ func NewSessionVars() *SessionVars {
 		Users:                       make(map[string]string),
 		systems:                     make(map[string]string),
 		TxnCtx:                      &TransactionContext{},
-		KVVars:                      kv.NewVariables(),
 		StrictSQLMode:               true,
 		Status:                      mysql.ServerStatusAutocommit,
 		StmtCtx:                     new(stmtctx.StatementContext),
@@ -372,7 +382,9 @@ func NewSessionVars() *SessionVars {
 		EnableNoopFuncs:             DefTiDBEnableNoopFuncs,
 		replicaRead:                 kv.ReplicaReadLeader,
 		AllowRemoveAutoInc:          DefTiDBAllowRemoveAutoInc,
+		ForeignKeyChecks:            true,
 	}
+	vars.KVVars = kv.NewVariables(&vars.Killed)
 	vars.Concurrency = Concurrency{
 		IndexLookupConcurrency:     DefIndexLookupConcurrency,
 		IndexSerialScanConcurrency: DefIndexSerialScanConcurrency,
@@ -412,6 +424,15 @@ func (s *SessionVars) GetReplicaRead() kv.ReplicaReadType {
 	return s.replicaRead
 }
 
+// GetMaxExecutionTime gets the max execution time of the current statement,
+// the MAX_EXECUTION_TIME hint takes precedence over the session variable.
+func (s *SessionVars) GetMaxExecutionTime() uint64 {
+	if s.StmtCtx.HasMaxExecutionTime {
+		return s.StmtCtx.MaxExecutionTime
+	}
+	return s.MaxExecutionTime
+}
+
 // SetReplicaRead set SessionVars.replicaRead.
 func (s *SessionVars) SetReplicaRead(val kv.ReplicaReadType) {
 	s.replicaRead = val
@@ -562,6 +583,8 @@ func (s *SessionVars) SetSystemVar(name string, val string) error {
 	case MaxExecutionTime:
 		timeoutMS := tidbOptPositiveInt32(val, 0)
 		s.MaxExecutionTime = uint64(timeoutMS)
+	case ForeignKeyChecks:
+		s.ForeignKeyChecks = TiDBOptOn(val)
 	case TiDBSkipUTF8Check:
 		s.SkipUTF8Check = TiDBOptOn(val)
 	case TiDBOptAggPushDown:
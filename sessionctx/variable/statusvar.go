@@ -46,6 +46,18 @@ func RegisterStatistics(s Statistics) {
 	statisticsListLock.Unlock()
 }
 
+// UnregisterStatistics unregisters statistics.
+func UnregisterStatistics(s Statistics) {
+	statisticsListLock.Lock()
+	defer statisticsListLock.Unlock()
+	for i, stat := range statisticsList {
+		if stat == s {
+			statisticsList = append(statisticsList[:i], statisticsList[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetStatusVars gets registered statistics status variables.
 // TODO: Refactor this function to avoid repeated memory allocation / dealloc
 func GetStatusVars(vars *SessionVars) (map[string]*StatusVal, error) {
@@ -92,6 +104,9 @@ var tlsCiphers = []uint16{
 	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
 	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
 	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_AES_128_GCM_SHA256,
+	tls.TLS_AES_256_GCM_SHA384,
+	tls.TLS_CHACHA20_POLY1305_SHA256,
 }
 
 var tlsSupportedCiphers string
@@ -102,6 +117,7 @@ var tlsVersionString = map[uint16]string{
 	tls.VersionTLS10: "TLSv1",
 	tls.VersionTLS11: "TLSv1.1",
 	tls.VersionTLS12: "TLSv1.2",
+	tls.VersionTLS13: "TLSv1.3",
 }
 
 // Taken from https://testssl.sh/openssl-rfc.mapping.html .
@@ -128,6 +144,9 @@ var tlsCipherString = map[uint16]string{
 	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: "ECDHE-ECDSA-AES256-GCM-SHA384",
 	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305:    "ECDHE-RSA-CHACHA20-POLY1305",
 	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305:  "ECDHE-ECDSA-CHACHA20-POLY1305",
+	tls.TLS_AES_128_GCM_SHA256:                  "TLS_AES_128_GCM_SHA256",
+	tls.TLS_AES_256_GCM_SHA384:                  "TLS_AES_256_GCM_SHA384",
+	tls.TLS_CHACHA20_POLY1305_SHA256:            "TLS_CHACHA20_POLY1305_SHA256",
 }
 
 var defaultStatus = map[string]*StatusVal{
@@ -220,13 +220,14 @@ var defaultSysVars = []*SysVar{
 	{ScopeNone, "ssl_cert", ""},
 	{ScopeNone, "ssl_key", ""},
 	{ScopeNone, "ssl_cipher", ""},
-	{ScopeNone, "tls_version", "TLSv1,TLSv1.1,TLSv1.2"},
+	{ScopeNone, "tls_version", "TLSv1,TLSv1.1,TLSv1.2,TLSv1.3"},
+	{ScopeNone, RequireSecureTransport, "OFF"},
 	{ScopeNone, "system_time_zone", "CST"},
 	{ScopeGlobal, InnodbPrintAllDeadlocks, "0"},
 	{ScopeNone, "innodb_autoinc_lock_mode", "1"},
 	{ScopeGlobal, "slave_net_timeout", "3600"},
 	{ScopeGlobal, "key_buffer_size", "8388608"},
-	{ScopeGlobal | ScopeSession, ForeignKeyChecks, "OFF"},
+	{ScopeGlobal | ScopeSession, ForeignKeyChecks, "1"},
 	{ScopeGlobal, "host_cache_size", "279"},
 	{ScopeGlobal, DelayKeyWrite, "ON"},
 	{ScopeNone, "metadata_locks_cache_size", "1024"},
@@ -795,6 +796,8 @@ const (
 	Port = "port"
 	// DataDir is the name for 'datadir' system variable.
 	DataDir = "datadir"
+	// RequireSecureTransport is the name for 'require_secure_transport' system variable.
+	RequireSecureTransport = "require_secure_transport"
 	// Profiling is the name for 'Profiling' system variable.
 	Profiling = "profiling"
 	// Socket is the name for 'socket' system variable.
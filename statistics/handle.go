@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/store/tikv/oracle"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
@@ -133,7 +134,7 @@ func (h *Handle) Update(is infoschema.InfoSchema) error {
 		modifyCount := row.GetInt64(2)
 		count := row.GetInt64(3)
 		lastVersion = version
-		table, ok := is.TableByID(physicalID)
+		table, ok := h.getTableByPhysicalID(is, physicalID)
 		if !ok {
 			logutil.BgLogger().Debug("unknown physical ID in stats meta table, maybe it has been dropped", zap.Int64("ID", physicalID))
 			deletedTableIDs = append(deletedTableIDs, physicalID)
@@ -160,6 +161,35 @@ func (h *Handle) Update(is infoschema.InfoSchema) error {
 	return nil
 }
 
+func (h *Handle) getTableByPhysicalID(is infoschema.InfoSchema, physicalID int64) (table.Table, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if is.SchemaMetaVersion() != h.mu.schemaVersion || h.mu.pid2tid == nil {
+		h.mu.schemaVersion = is.SchemaMetaVersion()
+		h.mu.pid2tid = buildPartitionID2TableID(is)
+	}
+	if id, ok := h.mu.pid2tid[physicalID]; ok {
+		return is.TableByID(id)
+	}
+	return is.TableByID(physicalID)
+}
+
+func buildPartitionID2TableID(is infoschema.InfoSchema) map[int64]int64 {
+	mapper := make(map[int64]int64)
+	for _, db := range is.AllSchemas() {
+		for _, tbl := range db.Tables {
+			pi := tbl.GetPartitionInfo()
+			if pi == nil {
+				continue
+			}
+			for _, def := range pi.Definitions {
+				mapper[def.ID] = tbl.ID
+			}
+		}
+	}
+	return mapper
+}
+
 func getFullTableName(is infoschema.InfoSchema, tblInfo *model.TableInfo) string {
 	for _, schema := range is.AllSchemas() {
 		if t, err := is.TableByName(schema.Name, tblInfo.Name); err == nil {
@@ -189,6 +219,57 @@ func (h *Handle) GetPartitionStats(tblInfo *model.TableInfo, pid int64) *Table {
 	return tbl
 }
 
+// InvalidateTableStats removes the statistics of the physical table from the
+// cache, it is used when the data of the table is dropped.
+func (h *Handle) InvalidateTableStats(physicalID int64) {
+	h.statsCache.Lock()
+	oldCache := h.statsCache.Load().(statsCache)
+	h.statsCache.Store(oldCache.update(nil, []int64{physicalID}, oldCache.version))
+	h.statsCache.Unlock()
+}
+
+// UpdateTableInfo points the cached statistics of the table at tblInfo, so they still
+// match the columns and indices of the table after a metadata-only DDL renames them.
+// The statistics of all the partitions are updated for a partitioned table.
+func (h *Handle) UpdateTableInfo(tblInfo *model.TableInfo) {
+	h.statsCache.Lock()
+	defer h.statsCache.Unlock()
+	oldCache := h.statsCache.Load().(statsCache)
+	physicalIDs := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			physicalIDs = append(physicalIDs, def.ID)
+		}
+	}
+	newTbls := make([]*Table, 0, len(physicalIDs))
+	for _, id := range physicalIDs {
+		oldTbl, ok := oldCache.tables[id]
+		if !ok {
+			continue
+		}
+		newTbl := oldTbl.Copy()
+		for _, colInfo := range tblInfo.Columns {
+			if col, ok := newTbl.Columns[colInfo.ID]; ok {
+				newCol := *col
+				newCol.Info = colInfo
+				newTbl.Columns[colInfo.ID] = &newCol
+			}
+		}
+		for _, idxInfo := range tblInfo.Indices {
+			if idx, ok := newTbl.Indices[idxInfo.ID]; ok {
+				newIdx := *idx
+				newIdx.Info = idxInfo
+				newTbl.Indices[idxInfo.ID] = &newIdx
+			}
+		}
+		newTbls = append(newTbls, newTbl)
+	}
+	if len(newTbls) == 0 {
+		return
+	}
+	h.statsCache.Store(oldCache.update(newTbls, nil, oldCache.version))
+}
+
 func (h *Handle) updateStatsCache(newCache statsCache) {
 	h.statsCache.Lock()
 	oldCache := h.statsCache.Load().(statsCache)
@@ -151,6 +151,18 @@ func (t *Table) IsOutdated() bool {
 	return false
 }
 
+// HealthyPercentage returns the health score of the table statistics in
+// [0, 100], which goes down as the rows modified since the last analyze grow
+// towards the row count.
+func (t *Table) HealthyPercentage() int64 {
+	if t.ModifyCount < t.Count {
+		return int64((1 - float64(t.ModifyCount)/float64(t.Count)) * 100)
+	} else if t.ModifyCount == 0 {
+		return 100
+	}
+	return 0
+}
+
 // ColumnGreaterRowCount estimates the row count where the column greater than value.
 func (t *Table) ColumnGreaterRowCount(sc *stmtctx.StatementContext, value types.Datum, colID int64) float64 {
 	c, ok := t.Columns[colID]
@@ -19,6 +19,7 @@ import (
 	"math"
 	"math/rand"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -208,6 +209,7 @@ type Backoffer struct {
 	types      []fmt.Stringer
 	vars       *kv.Variables
 	noop       bool
+	deadline   time.Time
 
 	backoffSleepMS map[backoffType]int
 	backoffTimes   map[backoffType]int
@@ -245,6 +247,12 @@ func (b *Backoffer) WithVars(vars *kv.Variables) *Backoffer {
 	return b
 }
 
+// WithDeadline sets the deadline of the statement to the Backoffer and return it.
+func (b *Backoffer) WithDeadline(deadline time.Time) *Backoffer {
+	b.deadline = deadline
+	return b
+}
+
 // Backoff sleeps a while base on the backoffType and records the error message.
 // It returns a retryable error if total sleep time exceeds maxSleep.
 func (b *Backoffer) Backoff(typ backoffType, err error) error {
@@ -262,6 +270,12 @@ func (b *Backoffer) BackoffWithMaxSleep(typ backoffType, maxSleepMs int, err err
 		return errors.Trace(err)
 	default:
 	}
+	if b.vars.Killed != nil && atomic.LoadUint32(b.vars.Killed) == 1 {
+		return ErrQueryInterrupted
+	}
+	if !b.deadline.IsZero() && time.Now().After(b.deadline) {
+		return ErrMaxExecTimeExceeded
+	}
 
 	b.errors = append(b.errors, errors.Errorf("%s at %s", err.Error(), time.Now().Format(time.RFC3339Nano)))
 	b.types = append(b.types, typ)
@@ -328,6 +342,7 @@ func (b *Backoffer) Clone() *Backoffer {
 		totalSleep: b.totalSleep,
 		errors:     b.errors,
 		vars:       b.vars,
+		deadline:   b.deadline,
 	}
 }
 
@@ -341,5 +356,6 @@ func (b *Backoffer) Fork() (*Backoffer, context.CancelFunc) {
 		totalSleep: b.totalSleep,
 		errors:     b.errors,
 		vars:       b.vars,
+		deadline:   b.deadline,
 	}, cancel
 }
@@ -16,7 +16,10 @@ package tikv
 import (
 	"context"
 	"errors"
+	"time"
+
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/kv"
 )
 
 type testBackoffSuite struct {
@@ -40,3 +43,18 @@ func (s *testBackoffSuite) TestBackoffWithMax(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(b.totalSleep, Equals, 30)
 }
+
+func (s *testBackoffSuite) TestBackoffKilled(c *C) {
+	killed := uint32(1)
+	b := NewBackoffer(context.TODO(), 2000).WithVars(kv.NewVariables(&killed))
+	err := b.Backoff(boTxnLockFast, errors.New("test"))
+	c.Assert(err, Equals, ErrQueryInterrupted)
+	c.Assert(b.totalSleep, Equals, 0)
+}
+
+func (s *testBackoffSuite) TestBackoffDeadline(c *C) {
+	b := NewBackoffer(context.TODO(), 2000).WithDeadline(time.Now().Add(-time.Second))
+	err := b.Backoff(boTxnLockFast, errors.New("test"))
+	c.Assert(err, Equals, ErrMaxExecTimeExceeded)
+	c.Assert(b.Clone().deadline, Equals, b.deadline)
+}
@@ -409,7 +409,7 @@ func (worker *copIteratorWorker) run(ctx context.Context) {
 			respCh = task.respChan
 		}
 
-		bo := NewBackoffer(ctx, copNextMaxBackoff).WithVars(worker.vars)
+		bo := NewBackoffer(ctx, copNextMaxBackoff).WithVars(worker.vars).WithDeadline(worker.req.Deadline)
 		worker.handleTask(bo, task, respCh)
 		close(task.respChan)
 		select {
@@ -580,6 +580,14 @@ func (worker *copIteratorWorker) handleTask(bo *Backoffer, task *copTask, respCh
 	}()
 	remainTasks := []*copTask{task}
 	for len(remainTasks) > 0 {
+		if worker.vars != nil && worker.vars.Killed != nil && atomic.LoadUint32(worker.vars.Killed) == 1 {
+			worker.sendToRespCh(&copResponse{err: ErrQueryInterrupted}, respCh, true)
+			return
+		}
+		if !worker.req.Deadline.IsZero() && time.Now().After(worker.req.Deadline) {
+			worker.sendToRespCh(&copResponse{err: ErrMaxExecTimeExceeded}, respCh, true)
+			return
+		}
 		tasks, err := worker.handleTaskOnce(bo, remainTasks[0], respCh)
 		if err != nil {
 			resp := &copResponse{err: errors.Trace(err)}
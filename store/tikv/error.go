@@ -38,6 +38,7 @@ var (
 	ErrTiKVServerBusy              = terror.ClassTiKV.New(mysql.ErrTiKVServerBusy, mysql.MySQLErrName[mysql.ErrTiKVServerBusy])
 	ErrGCTooEarly                  = terror.ClassTiKV.New(mysql.ErrGCTooEarly, mysql.MySQLErrName[mysql.ErrGCTooEarly])
 	ErrQueryInterrupted            = terror.ClassTiKV.New(mysql.ErrQueryInterrupted, mysql.MySQLErrName[mysql.ErrQueryInterrupted])
+	ErrMaxExecTimeExceeded         = terror.ClassTiKV.New(mysql.ErrMaxExecTimeExceeded, mysql.MySQLErrName[mysql.ErrMaxExecTimeExceeded])
 	ErrLockAcquireFailAndNoWaitSet = terror.ClassTiKV.New(mysql.ErrLockAcquireFailAndNoWaitSet, mysql.MySQLErrName[mysql.ErrLockAcquireFailAndNoWaitSet])
 	ErrLockWaitTimeout             = terror.ClassTiKV.New(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrLockWaitTimeout])
 )
@@ -52,6 +53,7 @@ func init() {
 		mysql.ErrGCTooEarly:                  mysql.ErrGCTooEarly,
 		mysql.ErrTruncatedWrongValue:         mysql.ErrTruncatedWrongValue,
 		mysql.ErrQueryInterrupted:            mysql.ErrQueryInterrupted,
+		mysql.ErrMaxExecTimeExceeded:         mysql.ErrMaxExecTimeExceeded,
 		mysql.ErrLockAcquireFailAndNoWaitSet: mysql.ErrLockAcquireFailAndNoWaitSet,
 		mysql.ErrDataOutOfRange:              mysql.ErrDataOutOfRange,
 		mysql.ErrLockWaitTimeout:             mysql.ErrLockWaitTimeout,
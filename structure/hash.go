@@ -217,21 +217,36 @@ func (t *TxStructure) HGetAll(key []byte) ([]HashPair, error) {
 	return res, errors.Trace(err)
 }
 
-// HGetLastN gets latest N fields and values in hash.
+// HGetLastN gets latest N fields and values in hash, the latest first. The
+// store doesn't support the reverse scan, so the hash is scanned forward and
+// only the last N pairs are kept.
 func (t *TxStructure) HGetLastN(key []byte, num int) ([]HashPair, error) {
-	res := make([]HashPair, 0, num)
-	err := t.iterReverseHash(key, func(field []byte, value []byte) (bool, error) {
+	if num <= 0 {
+		return nil, nil
+	}
+	window := make([]HashPair, 0, num)
+	next := 0
+	err := t.iterateHash(key, func(field []byte, value []byte) error {
 		pair := HashPair{
 			Field: append([]byte{}, field...),
 			Value: append([]byte{}, value...),
 		}
-		res = append(res, pair)
-		if len(res) >= num {
-			return false, nil
+		if len(window) < num {
+			window = append(window, pair)
+		} else {
+			window[next] = pair
 		}
-		return true, nil
+		next = (next + 1) % num
+		return nil
 	})
-	return res, errors.Trace(err)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := make([]HashPair, 0, len(window))
+	for i := 1; i <= len(window); i++ {
+		res = append(res, window[(next-i+len(window))%len(window)])
+	}
+	return res, nil
 }
 
 // HClear removes the hash value of the key.
@@ -286,37 +301,6 @@ func (t *TxStructure) iterateHash(key []byte, fn func(k []byte, v []byte) error)
 	return nil
 }
 
-func (t *TxStructure) iterReverseHash(key []byte, fn func(k []byte, v []byte) (bool, error)) error {
-	dataPrefix := t.hashDataKeyPrefix(key)
-	it, err := t.reader.IterReverse(dataPrefix.PrefixNext())
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	var field []byte
-	for it.Valid() {
-		if !it.Key().HasPrefix(dataPrefix) {
-			break
-		}
-
-		_, field, err = t.decodeHashDataKey(it.Key())
-		if err != nil {
-			return errors.Trace(err)
-		}
-
-		more, err := fn(field, it.Value())
-		if !more || err != nil {
-			return errors.Trace(err)
-		}
-
-		err = it.Next()
-		if err != nil {
-			return errors.Trace(err)
-		}
-	}
-	return nil
-}
-
 func (t *TxStructure) loadHashMeta(metaKey []byte) (hashMeta, error) {
 	v, err := t.reader.Get(context.TODO(), metaKey)
 	if kv.ErrNotExist.Equal(err) {
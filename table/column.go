@@ -246,6 +246,9 @@ func NewColDesc(col *Column) *ColDesc {
 		Privileges: defaultPrivileges,
 		Comment:    col.Comment,
 	}
+	if !mysql.HasNoDefaultValueFlag(col.Flag) {
+		desc.DefaultValue = col.GetDefaultValue()
+	}
 	if !field_types.HasCharset(&col.ColumnInfo.FieldType) {
 		desc.Charset = nil
 		desc.Collation = nil
@@ -0,0 +1,122 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+)
+
+// FKLookupColumns returns the columns of the foreign key in the table and the index used to look up rows
+// by them. The index is nil if the columns are the handle or no index can be used.
+func FKLookupColumns(tbl table.Table, colNames []model.CIStr) ([]*table.Column, *model.IndexInfo, bool) {
+	cols := make([]*table.Column, 0, len(colNames))
+	for _, colName := range colNames {
+		col := table.FindCol(tbl.Cols(), colName.L)
+		if col == nil {
+			return nil, nil, false
+		}
+		cols = append(cols, col)
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.IsHandleColumns(colNames) {
+		return cols, nil, true
+	}
+	return cols, tblInfo.FindIndexByColumns(colNames), true
+}
+
+// LookupFKRows returns the handles of the rows whose cols equal vals in the current transaction. It reads the
+// row by handle if cols are the handle, or seeks idx if it's not nil, otherwise it scans the table. At most
+// limit handles are returned if limit is positive.
+func LookupFKRows(ctx context.Context, sctx sessionctx.Context, tbl table.Table, cols []*table.Column, idx *model.IndexInfo,
+	vals []types.Datum, limit int) ([]int64, error) {
+	sc := sctx.GetSessionVars().StmtCtx
+	converted := make([]types.Datum, 0, len(vals))
+	for i, val := range vals {
+		d, err := val.ConvertTo(sc, &cols[i].FieldType)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, d)
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var handles []int64
+	if len(cols) == 1 && cols[0].IsPKHandleColumn(tbl.Meta()) {
+		h := converted[0].GetInt64()
+		_, err = txn.Get(ctx, tbl.RecordKey(h))
+		if kv.IsErrNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(handles, h), nil
+	}
+
+	if idx == nil {
+		err = tbl.IterRecords(sctx, tbl.FirstKey(), tbl.Cols(), func(h int64, rec []types.Datum, _ []*table.Column) (bool, error) {
+			for i, col := range cols {
+				cmp, err := rec[col.Offset].CompareDatum(sc, &converted[i])
+				if err != nil || cmp != 0 {
+					return true, err
+				}
+			}
+			handles = append(handles, h)
+			return limit <= 0 || len(handles) < limit, nil
+		})
+		return handles, err
+	}
+
+	prefix := tablecodec.EncodeTableIndexPrefix(tbl.Meta().ID, idx.ID)
+	seekKey, err := codec.EncodeKey(sc, append([]byte{}, prefix...), converted...)
+	if err != nil {
+		return nil, err
+	}
+	it, err := txn.Iter(seekKey, kv.Key(seekKey).PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for it.Valid() && it.Key().HasPrefix(seekKey) {
+		vv, err := codec.Decode(it.Key()[len(prefix):], len(idx.Columns))
+		if err != nil {
+			return nil, err
+		}
+		var h int64
+		if len(vv) > len(idx.Columns) {
+			h = vv[len(vv)-1].GetInt64()
+		} else if h, err = DecodeHandle(it.Value()); err != nil {
+			return nil, err
+		}
+		handles = append(handles, h)
+		if limit > 0 && len(handles) >= limit {
+			break
+		}
+		if err = it.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return handles, nil
+}
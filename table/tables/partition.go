@@ -0,0 +1,341 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+// Both partition and partitionedTable implement the table.Table interface.
+var _ table.PhysicalTable = &partition{}
+var _ table.PartitionedTable = &partitionedTable{}
+
+// partition is a feature from MySQL:
+// See https://dev.mysql.com/doc/refman/8.0/en/partitioning.html
+// A partition table may contain many partitions, each partition has a unique partition
+// id. The underlying representation of a partition and a normal table (a table with no
+// partitions) is basically the same.
+// partition also implements the table.Table interface.
+type partition struct {
+	TableCommon
+}
+
+// GetPhysicalID implements table.Table GetPhysicalID interface.
+func (p *partition) GetPhysicalID() int64 {
+	return p.physicalTableID
+}
+
+// partitionedTable implements the table.PartitionedTable interface.
+// partitionedTable is a table, it contains many Partitions.
+type partitionedTable struct {
+	TableCommon
+	partitionExpr *PartitionExpr
+	partitions    map[int64]*partition
+}
+
+func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
+	ret := &partitionedTable{TableCommon: *tbl}
+	partitionExpr, err := generatePartitionExpr(tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret.partitionExpr = partitionExpr
+
+	pi := tblInfo.GetPartitionInfo()
+	partitions := make(map[int64]*partition, len(pi.Definitions))
+	for _, p := range pi.Definitions {
+		var t partition
+		initTableCommon(&t.TableCommon, tblInfo, p.ID, tbl.Columns, tbl.alloc)
+		if err := initTableIndices(&t.TableCommon); err != nil {
+			return nil, errors.Trace(err)
+		}
+		partitions[p.ID] = &t
+	}
+	ret.partitions = partitions
+	return ret, nil
+}
+
+// PartitionExpr is the partition definition expressions.
+type PartitionExpr struct {
+	// Expr is the partition expression, it is the only column for COLUMNS partitioning.
+	Expr expression.Expression
+	// UpperBounds is used by range partition, UpperBounds[i] is `expr < less_than_i`.
+	UpperBounds []expression.Expression
+	// InValues is used by list partition, InValues[i] is `expr in (values_i)`.
+	InValues []expression.Expression
+}
+
+// PartitionExprString returns the partition expression in string form,
+// it is the quoted column name for COLUMNS partitioning.
+func PartitionExprString(pi *model.PartitionInfo) string {
+	if len(pi.Columns) > 0 {
+		return "`" + pi.Columns[0].L + "`"
+	}
+	return pi.Expr
+}
+
+func generatePartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
+	if expression.RewriteAstExpr == nil {
+		return nil, errors.New("partition expression rewriter is not registered")
+	}
+	// The caller should assure partition info is not nil.
+	pi := tblInfo.GetPartitionInfo()
+	ctx := mock.NewContext()
+	exprStr := PartitionExprString(pi)
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, exprStr, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret := &PartitionExpr{Expr: expr}
+	var buf bytes.Buffer
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		ret.UpperBounds = make([]expression.Expression, 0, len(pi.Definitions))
+		for _, def := range pi.Definitions {
+			if strings.EqualFold(def.LessThan[0], "MAXVALUE") {
+				// Expr less than maxvalue is always true.
+				buf.WriteString("true")
+			} else {
+				fmt.Fprintf(&buf, "((%s) < (%s))", exprStr, def.LessThan[0])
+			}
+			bound, err := expression.ParseSimpleExprWithTableInfo(ctx, buf.String(), tblInfo)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ret.UpperBounds = append(ret.UpperBounds, bound)
+			buf.Reset()
+		}
+	case model.PartitionTypeList:
+		ret.InValues = make([]expression.Expression, 0, len(pi.Definitions))
+		for _, def := range pi.Definitions {
+			values := make([]string, 0, len(def.InValues))
+			hasNull := false
+			for _, v := range def.InValues {
+				if strings.EqualFold(v, "NULL") {
+					hasNull = true
+					continue
+				}
+				values = append(values, v)
+			}
+			conds := make([]string, 0, 2)
+			if len(values) > 0 {
+				conds = append(conds, fmt.Sprintf("((%s) IN (%s))", exprStr, strings.Join(values, ",")))
+			}
+			if hasNull {
+				conds = append(conds, fmt.Sprintf("((%s) IS NULL)", exprStr))
+			}
+			in, err := expression.ParseSimpleExprWithTableInfo(ctx, strings.Join(conds, " OR "), tblInfo)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ret.InValues = append(ret.InValues, in)
+		}
+	}
+	return ret, nil
+}
+
+// PartitionExpr returns the partition expression.
+func (t *partitionedTable) PartitionExpr() *PartitionExpr {
+	return t.partitionExpr
+}
+
+// locatePartition returns the partition ID of the input record.
+func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int64, error) {
+	var err error
+	var idx int
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		idx, err = t.locateRangePartition(ctx, pi, r)
+	case model.PartitionTypeList:
+		idx, err = t.locateListPartition(ctx, pi, r)
+	case model.PartitionTypeHash:
+		idx, err = t.locateHashPartition(ctx, pi, r)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return pi.Definitions[idx].ID, nil
+}
+
+func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+	row := chunk.MutRowFromDatums(r).ToRow()
+	v, err := t.partitionExpr.Expr.Eval(row)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if v.IsNull() {
+		// The NULL value is regarded as less than any other value.
+		return 0, nil
+	}
+	var evalErr error
+	idx := sort.Search(len(t.partitionExpr.UpperBounds), func(i int) bool {
+		ret, isNull, err := t.partitionExpr.UpperBounds[i].EvalInt(ctx, row)
+		if err != nil {
+			evalErr = err
+			return true
+		}
+		return !isNull && ret > 0
+	})
+	if evalErr != nil {
+		return 0, errors.Trace(evalErr)
+	}
+	if idx >= len(t.partitionExpr.UpperBounds) {
+		return 0, table.ErrNoPartitionForGivenValue.GenWithStackByArgs(t.partitionValueString(row))
+	}
+	return idx, nil
+}
+
+func (t *partitionedTable) locateListPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+	row := chunk.MutRowFromDatums(r).ToRow()
+	for i, in := range t.partitionExpr.InValues {
+		ret, isNull, err := in.EvalInt(ctx, row)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if !isNull && ret > 0 {
+			return i, nil
+		}
+	}
+	return 0, table.ErrNoPartitionForGivenValue.GenWithStackByArgs(t.partitionValueString(row))
+}
+
+func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+	ret, isNull, err := t.partitionExpr.Expr.EvalInt(ctx, chunk.MutRowFromDatums(r).ToRow())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if isNull {
+		return 0, nil
+	}
+	idx := ret % int64(len(pi.Definitions))
+	if idx < 0 {
+		idx = -idx
+	}
+	return int(idx), nil
+}
+
+func (t *partitionedTable) partitionValueString(row chunk.Row) string {
+	v, err := t.partitionExpr.Expr.Eval(row)
+	if err != nil {
+		return "?"
+	}
+	if v.IsNull() {
+		return "NULL"
+	}
+	s, err := v.ToString()
+	if err != nil {
+		return "?"
+	}
+	return s
+}
+
+// GetPartition returns a Table, which is actually a partition.
+func (t *partitionedTable) GetPartition(pid int64) table.PhysicalTable {
+	// Attention, can't simply use `return t.partitions[pid]` here.
+	// Because A nil of type *partition is a kind of `table.PhysicalTable`
+	p, ok := t.partitions[pid]
+	if !ok {
+		return nil
+	}
+	return p
+}
+
+// GetPartitionByRow returns a Table, which is actually a Partition.
+func (t *partitionedTable) GetPartitionByRow(ctx sessionctx.Context, r []types.Datum) (table.PhysicalTable, error) {
+	pid, err := t.locatePartition(ctx, t.Meta().GetPartitionInfo(), r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return t.partitions[pid], nil
+}
+
+// AddRecord implements the AddRecord method for the table.Table interface.
+func (t *partitionedTable) AddRecord(ctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (recordID int64, err error) {
+	partitionInfo := t.meta.GetPartitionInfo()
+	pid, err := t.locatePartition(ctx, partitionInfo, r)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	tbl := t.GetPartition(pid)
+	return tbl.AddRecord(ctx, r, opts...)
+}
+
+// RemoveRecord implements table.Table RemoveRecord interface.
+func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h int64, r []types.Datum) error {
+	partitionInfo := t.meta.GetPartitionInfo()
+	pid, err := t.locatePartition(ctx, partitionInfo, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tbl := t.GetPartition(pid)
+	return tbl.RemoveRecord(ctx, h, r)
+}
+
+// UpdateRecord implements table.Table UpdateRecord interface.
+// `touched` means which columns are really modified, used for secondary indices.
+// Length of `oldData` and `newData` equals to length of `t.WritableCols()`.
+func (t *partitionedTable) UpdateRecord(ctx sessionctx.Context, h int64, currData, newData []types.Datum, touched []bool) error {
+	partitionInfo := t.meta.GetPartitionInfo()
+	from, err := t.locatePartition(ctx, partitionInfo, currData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	to, err := t.locatePartition(ctx, partitionInfo, newData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The old and new data locate in different partitions.
+	// Remove record from old partition and add record to new partition.
+	if from != to {
+		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// UpdateRecord should be side effect free, but there're two steps here.
+		// What would happen if step1 succeed but step2 meets error? It's hard
+		// to rollback.
+		// So this special order is chosen: add record first, errors such as
+		// 'Key Already Exists' will generally happen during step1, errors are
+		// unlikely to happen in step2.
+		return t.GetPartition(from).RemoveRecord(ctx, h, currData)
+	}
+	return t.GetPartition(to).UpdateRecord(ctx, h, currData, newData, touched)
+}
+
+// FindPartitionByName finds partition in table meta by name.
+func FindPartitionByName(meta *model.TableInfo, parName string) (int64, error) {
+	if meta.Partition == nil {
+		return 0, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(parName, meta.Name.O))
+	}
+	idx := meta.Partition.FindPartitionDefinitionByName(parName)
+	if idx < 0 {
+		return 0, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(parName, meta.Name.O))
+	}
+	return meta.Partition.Definitions[idx].ID, nil
+}
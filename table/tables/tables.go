@@ -103,7 +103,11 @@ func TableFromMeta(alloc autoid.Allocator, tblInfo *model.TableInfo) (table.Tabl
 	if err := initTableIndices(&t); err != nil {
 		return nil, err
 	}
-	return &t, nil
+	if tblInfo.GetPartitionInfo() == nil {
+		return &t, nil
+	}
+
+	return newPartitionedTable(&t, tblInfo)
 }
 
 // initTableCommon initializes a TableCommon struct.
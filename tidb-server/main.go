@@ -104,7 +104,9 @@ func main() {
 	}
 	createStoreAndDomain()
 	createServer()
+	setTLSGlobalVars()
 	signal.SetupSignalHandler(serverShutdown)
+	signal.SetupReloadHandler(serverReload)
 	runServer()
 	cleanup()
 	syncLog()
@@ -239,6 +241,23 @@ func setGlobalVars() {
 
 	variable.SysVars[variable.Port].Value = fmt.Sprintf("%d", cfg.Port)
 	variable.SysVars[variable.DataDir].Value = cfg.Path
+	if cfg.Security.RequireSecureTransport {
+		variable.SysVars[variable.RequireSecureTransport].Value = "ON"
+	}
+}
+
+// setTLSGlobalVars sets the TLS system variables once the server has loaded the certificates.
+func setTLSGlobalVars() {
+	if !svr.TLSEnabled() {
+		return
+	}
+	variable.SysVars["have_ssl"].Value = "YES"
+	variable.SysVars["have_openssl"].Value = "YES"
+	// The files are the same when the certificates are reloaded, only their contents change.
+	caFile, certFile, keyFile := svr.TLSFiles()
+	variable.SysVars["ssl_ca"].Value = caFile
+	variable.SysVars["ssl_cert"].Value = certFile
+	variable.SysVars["ssl_key"].Value = keyFile
 }
 
 func setupLog() {
@@ -269,6 +288,12 @@ func serverShutdown(isgraceful bool) {
 	svr.Close()
 }
 
+func serverReload() {
+	if err := svr.ReloadTLSConfig(); err != nil {
+		log.Warn("reload TLS certificates failed", zap.Error(err))
+	}
+}
+
 func runServer() {
 	err := svr.Run()
 	terror.MustNil(err)
@@ -19,6 +19,7 @@ package types
 
 import (
 	"io"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/charset"
@@ -77,6 +78,12 @@ func IsNonBinaryStr(ft *FieldType) bool {
 	return false
 }
 
+// IsCaseInsensitiveStr returns a boolean indicating
+// whether the field type is a string type with a case-insensitive collation.
+func IsCaseInsensitiveStr(ft *FieldType) bool {
+	return IsNonBinaryStr(ft) && strings.HasSuffix(ft.Collate, "_ci")
+}
+
 // IsString returns a boolean indicating
 // whether the field type is a string type.
 func IsString(tp byte) bool {
@@ -244,6 +244,26 @@ func (s *testTypeEtcSuite) TestIsNonBinaryStr(c *C) {
 	c.Assert(res, Equals, false)
 }
 
+func (s *testTypeEtcSuite) TestIsCaseInsensitiveStr(c *C) {
+	defer testleak.AfterTest(c)()
+	in := FieldType{
+		Tp:      mysql.TypeVarchar,
+		Charset: charset.CharsetUTF8MB4,
+		Collate: charset.CollationUTF8MB4,
+	}
+	c.Assert(IsCaseInsensitiveStr(&in), Equals, false)
+
+	in.Collate = "utf8mb4_general_ci"
+	c.Assert(IsCaseInsensitiveStr(&in), Equals, true)
+
+	in.Tp = mysql.TypeLonglong
+	c.Assert(IsCaseInsensitiveStr(&in), Equals, false)
+
+	in.Tp = mysql.TypeBlob
+	in.Collate = charset.CollationBin
+	c.Assert(IsCaseInsensitiveStr(&in), Equals, false)
+}
+
 func (s *testTypeEtcSuite) TestIsTypePrefixable(c *C) {
 	defer testleak.AfterTest(c)()
 
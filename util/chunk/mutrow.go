@@ -61,7 +61,16 @@ func MutRowFromDatums(datums []types.Datum) MutRow {
 func MutRowFromTypes(types []*types.FieldType) MutRow {
 	c := &Chunk{columns: make([]*Column, 0, len(types))}
 	for _, tp := range types {
-		col := makeMutRowColumn(zeroValForType(tp))
+		val := zeroValForType(tp)
+		var col *Column
+		if val == nil && getFixedLen(tp) == varElemLen {
+			// Keep the layout consistent with the Chunk Column of the type,
+			// otherwise appending the NULL to a Chunk corrupts the Column.
+			col = makeMutRowBytesColumn(nil)
+			col.nullBitmap[0] = 0
+		} else {
+			col = makeMutRowColumn(val)
+		}
 		c.columns = append(c.columns, col)
 	}
 	return MutRow{c: c, idx: 0}
@@ -174,6 +174,22 @@ func (s *testChunkSuite) TestMutRowShallowCopyPartialRow(c *check.C) {
 	c.Assert(row.GetInt64(1), check.Equals, mutRow.ToRow().GetInt64(1))
 }
 
+func (s *testChunkSuite) TestMutRowAppendNullToChunk(c *check.C) {
+	colTypes := []*types.FieldType{
+		types.NewFieldType(mysql.TypeDatetime),
+		types.NewFieldType(mysql.TypeLonglong),
+	}
+	mutRow := MutRowFromTypes(colTypes)
+	mutRow.SetDatums(types.NewDatum(nil), types.NewIntDatum(1))
+	chk := NewChunkWithCapacity(colTypes, 2)
+	chk.AppendRow(mutRow.ToRow())
+	chk.AppendRow(mutRow.ToRow())
+	chk.TruncateTo(1)
+	c.Assert(chk.NumRows(), check.Equals, 1)
+	c.Assert(chk.GetRow(0).IsNull(0), check.IsTrue)
+	c.Assert(chk.GetRow(0).GetInt64(1), check.Equals, int64(1))
+}
+
 var rowsNum = 1024
 
 func BenchmarkMutRowShallowCopyPartialRow(b *testing.B) {
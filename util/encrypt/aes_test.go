@@ -0,0 +1,138 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"encoding/hex"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/util/testleak"
+)
+
+func TestT(t *testing.T) {
+	CustomVerboseFlag = true
+	TestingT(t)
+}
+
+var _ = Suite(&testEncryptSuite{})
+
+type testEncryptSuite struct {
+}
+
+func toHex(buf []byte) string {
+	return hex.EncodeToString(buf)
+}
+
+func (s *testEncryptSuite) TestPad(c *C) {
+	defer testleak.AfterTest(c)()
+	tests := []struct {
+		origin    string
+		blockSize int
+		padded    string
+	}{
+		{"", 8, "0808080808080808"},
+		{"1", 8, "3107070707070707"},
+		{"12345678", 8, "31323334353637380808080808080808"},
+		{"1234567", 8, "3132333435363701"},
+		{"123456789", 4, "313233343536373839030303"},
+	}
+	for _, t := range tests {
+		p, err := PKCS7Pad([]byte(t.origin), t.blockSize)
+		c.Assert(err, IsNil)
+		c.Assert(toHex(p), Equals, t.padded, Commentf("%s", t.origin))
+		unpadded, err := PKCS7Unpad(p, t.blockSize)
+		c.Assert(err, IsNil)
+		c.Assert(string(unpadded), Equals, t.origin)
+	}
+
+	badPadded := []string{"", "31323334353637", "3132333435363700", "3132333435363709", "3132333435360302"}
+	for _, str := range badPadded {
+		data, _ := hex.DecodeString(str)
+		_, err := PKCS7Unpad(data, 8)
+		c.Assert(err, NotNil, Commentf("%s", str))
+	}
+}
+
+func (s *testEncryptSuite) TestAESECB(c *C) {
+	defer testleak.AfterTest(c)()
+	// Test vector from NIST SP 800-38A, F.1.1 ECB-AES128.Encrypt.
+	key, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	plain, _ := hex.DecodeString("6bc1bee22e409f96e93d7e117393172a")
+	crypted, err := AESEncryptWithECB(plain, key)
+	c.Assert(err, IsNil)
+	c.Assert(len(crypted), Equals, 32)
+	c.Assert(toHex(crypted[:16]), Equals, "3ad77bb40d7a3660a89ecaf32466ef97")
+	res, err := AESDecryptWithECB(crypted, key)
+	c.Assert(err, IsNil)
+	c.Assert(toHex(res), Equals, toHex(plain))
+
+	// Corrupted data.
+	_, err = AESDecryptWithECB(crypted[:15], key)
+	c.Assert(err, NotNil)
+	// Invalid key size.
+	_, err = AESEncryptWithECB(plain, key[:3])
+	c.Assert(err, NotNil)
+}
+
+func (s *testEncryptSuite) TestAESWithIV(c *C) {
+	defer testleak.AfterTest(c)()
+	key := []byte("1234567890123456")
+	iv := []byte("abcdefghijklmnop")
+	for _, str := range []string{"", "pingcap", "a long string over one block"} {
+		crypted, err := AESEncryptWithCBC([]byte(str), key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(len(crypted)%16, Equals, 0)
+		res, err := AESDecryptWithCBC(crypted, key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(string(res), Equals, str)
+
+		crypted, err = AESEncryptWithOFB([]byte(str), key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(len(crypted), Equals, len(str))
+		res, err = AESDecryptWithOFB(crypted, key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(string(res), Equals, str)
+
+		crypted, err = AESEncryptWithCFB([]byte(str), key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(len(crypted), Equals, len(str))
+		res, err = AESDecryptWithCFB(crypted, key, iv)
+		c.Assert(err, IsNil)
+		c.Assert(string(res), Equals, str)
+	}
+}
+
+func (s *testEncryptSuite) TestDeriveKeyMySQL(c *C) {
+	defer testleak.AfterTest(c)()
+	p := []byte("MySQL=insecure! MySQL=insecure! ")
+	p = DeriveKeyMySQL(p, 16)
+	c.Assert(toHex(p), Equals, "00000000000000000000000000000000")
+
+	// Short keys are padded with zeros.
+	p = DeriveKeyMySQL([]byte("abc"), 16)
+	c.Assert(toHex(p), Equals, "61626300000000000000000000000000")
+
+	// Long keys are folded by xor.
+	p = DeriveKeyMySQL([]byte("abcdefghijklmnopa"), 16)
+	c.Assert(toHex(p), Equals, "0062636465666768696a6b6c6d6e6f70")
+}
+
+func (s *testEncryptSuite) TestPadNotModifyInput(c *C) {
+	defer testleak.AfterTest(c)()
+	buf := []byte("12345678abcdefgh")
+	_, err := PKCS7Pad(buf[:4], 8)
+	c.Assert(err, IsNil)
+	c.Assert(string(buf), Equals, "12345678abcdefgh")
+}
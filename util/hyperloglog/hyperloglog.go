@@ -0,0 +1,133 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hyperloglog
+
+import (
+	"hash"
+	"math"
+	"math/bits"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/spaolacci/murmur3"
+)
+
+const (
+	// precision is the number of hash bits used to pick a register, the
+	// standard error of the estimation is about 1.04 / sqrt(2^precision).
+	precision    = 12
+	numRegisters = 1 << precision
+)
+
+// Sketch is a HyperLogLog sketch used to estimate the number of distinct
+// elements in a set. Like the FMSketch used by statistics, the values are
+// hashed by murmur3 and two sketches can be merged, so it can be built on
+// several partial groups and combined later.
+// See http://algo.inria.fr/flajolet/Publications/FlFuGaMe07.pdf for details.
+type Sketch struct {
+	registers []uint8
+	hashFunc  hash.Hash64
+}
+
+// NewSketch returns a new empty sketch.
+func NewSketch() *Sketch {
+	return &Sketch{
+		registers: make([]uint8, numRegisters),
+		hashFunc:  murmur3.New64(),
+	}
+}
+
+// Reset clears all the values inserted into the sketch.
+func (s *Sketch) Reset() {
+	for i := range s.registers {
+		s.registers[i] = 0
+	}
+}
+
+// InsertHashValue inserts a hash value into the sketch.
+func (s *Sketch) InsertHashValue(hashVal uint64) {
+	idx := hashVal >> (64 - precision)
+	// The rank is the position of the leftmost 1-bit in the remaining bits,
+	// the sentinel bit limits it to 64-precision+1.
+	rank := uint8(bits.LeadingZeros64(hashVal<<precision|1<<(precision-1))) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// InsertValues inserts a tuple of values into the sketch, the values are
+// encoded together so (1, 2) and (2, 1) are different elements.
+func (s *Sketch) InsertValues(sc *stmtctx.StatementContext, values ...types.Datum) error {
+	bytes, err := codec.EncodeValue(sc, nil, values...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.hashFunc.Reset()
+	_, err = s.hashFunc.Write(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.InsertHashValue(s.hashFunc.Sum64())
+	return nil
+}
+
+// Merge merges the sketch `rs` into `s`.
+func (s *Sketch) Merge(rs *Sketch) {
+	for i, rank := range rs.registers {
+		if rank > s.registers[i] {
+			s.registers[i] = rank
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct elements.
+func (s *Sketch) Estimate() int64 {
+	var sum float64
+	zeros := 0
+	for _, rank := range s.registers {
+		sum += 1 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+	m := float64(numRegisters)
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	// Use linear counting for small cardinalities, where the raw estimation
+	// is known to be biased.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(estimate + 0.5)
+}
+
+// Encode encodes the sketch to bytes, so it can be transferred as a partial
+// result of aggregation.
+func (s *Sketch) Encode() []byte {
+	data := make([]byte, 0, 1+numRegisters)
+	data = append(data, precision)
+	return append(data, s.registers...)
+}
+
+// DecodeSketch decodes a sketch from the bytes returned by Encode.
+func DecodeSketch(data []byte) (*Sketch, error) {
+	if len(data) != 1+numRegisters || data[0] != precision {
+		return nil, errors.Errorf("invalid hyperloglog sketch of %d bytes", len(data))
+	}
+	s := NewSketch()
+	copy(s.registers, data[1:])
+	return s, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hyperloglog
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+)
+
+func TestT(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testHyperLogLogSuite{})
+
+type testHyperLogLogSuite struct{}
+
+func buildSketch(c *C, sc *stmtctx.StatementContext, start, end int) *Sketch {
+	s := NewSketch()
+	for i := start; i < end; i++ {
+		c.Assert(s.InsertValues(sc, types.NewIntDatum(int64(i))), IsNil)
+	}
+	return s
+}
+
+func (s *testHyperLogLogSuite) TestEstimate(c *C) {
+	sc := &stmtctx.StatementContext{TimeZone: time.Local}
+	c.Assert(NewSketch().Estimate(), Equals, int64(0))
+
+	for _, ndv := range []int{1, 100, 1000, 100000} {
+		sketch := buildSketch(c, sc, 0, ndv)
+		// Duplicated values don't change the estimation.
+		for i := 0; i < ndv; i += 2 {
+			c.Assert(sketch.InsertValues(sc, types.NewIntDatum(int64(i))), IsNil)
+		}
+		estimate := sketch.Estimate()
+		c.Assert(math.Abs(float64(estimate-int64(ndv)))/float64(ndv) < 0.05, IsTrue, Commentf("ndv %d, estimate %d", ndv, estimate))
+	}
+
+	// Values are encoded as a tuple.
+	sketch := NewSketch()
+	c.Assert(sketch.InsertValues(sc, types.NewIntDatum(1), types.NewIntDatum(2)), IsNil)
+	c.Assert(sketch.InsertValues(sc, types.NewIntDatum(2), types.NewIntDatum(1)), IsNil)
+	c.Assert(sketch.InsertValues(sc, types.NewIntDatum(1), types.NewIntDatum(2)), IsNil)
+	c.Assert(sketch.Estimate(), Equals, int64(2))
+
+	sketch.Reset()
+	c.Assert(sketch.Estimate(), Equals, int64(0))
+}
+
+func (s *testHyperLogLogSuite) TestMerge(c *C) {
+	sc := &stmtctx.StatementContext{TimeZone: time.Local}
+	lhs := buildSketch(c, sc, 0, 30000)
+	rhs := buildSketch(c, sc, 20000, 50000)
+	whole := buildSketch(c, sc, 0, 50000)
+	lhs.Merge(rhs)
+	c.Assert(lhs.registers, DeepEquals, whole.registers)
+	c.Assert(lhs.Estimate(), Equals, whole.Estimate())
+}
+
+func (s *testHyperLogLogSuite) TestEncode(c *C) {
+	sc := &stmtctx.StatementContext{TimeZone: time.Local}
+	sketch := buildSketch(c, sc, 0, 1000)
+	decoded, err := DecodeSketch(sketch.Encode())
+	c.Assert(err, IsNil)
+	c.Assert(decoded.registers, DeepEquals, sketch.registers)
+
+	_, err = DecodeSketch(nil)
+	c.Assert(err, NotNil)
+	_, err = DecodeSketch(sketch.Encode()[1:])
+	c.Assert(err, NotNil)
+}
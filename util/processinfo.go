@@ -0,0 +1,94 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/store/tikv/oracle"
+)
+
+// ProcessInfo is a struct used for show processlist statement.
+type ProcessInfo struct {
+	ID            uint64
+	User          string
+	Host          string
+	DB            string
+	Command       byte
+	Time          time.Time
+	State         uint16
+	Info          string
+	CurTxnStartTS uint64
+}
+
+// ToRowForShow returns []interface{} for the row data of "SHOW [FULL] PROCESSLIST".
+func (pi *ProcessInfo) ToRowForShow(full bool) []interface{} {
+	var info interface{}
+	if len(pi.Info) > 0 {
+		if full {
+			info = pi.Info
+		} else {
+			info = fmt.Sprintf("%.100v", pi.Info)
+		}
+	}
+	t := uint64(time.Since(pi.Time) / time.Second)
+	var db interface{}
+	if len(pi.DB) > 0 {
+		db = pi.DB
+	}
+	return []interface{}{
+		pi.ID,
+		pi.User,
+		pi.Host,
+		db,
+		mysql.Command2Str[pi.Command],
+		t,
+		serverStatus2Str(pi.State),
+		info,
+	}
+}
+
+func (pi *ProcessInfo) txnStartTs() string {
+	if pi.CurTxnStartTS == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s(%d)", oracle.GetTimeFromTS(pi.CurTxnStartTS).Format("01-02 15:04:05.000"), pi.CurTxnStartTS)
+}
+
+// ToRow returns []interface{} for the row data of
+// "SELECT * FROM INFORMATION_SCHEMA.PROCESSLIST".
+func (pi *ProcessInfo) ToRow() []interface{} {
+	return append(pi.ToRowForShow(true), pi.txnStartTs())
+}
+
+func serverStatus2Str(state uint16) string {
+	if state&mysql.ServerStatusInTrans == mysql.ServerStatusInTrans {
+		return "in transaction"
+	}
+	return "autocommit"
+}
+
+// SessionManager is an interface for session manage. Show processlist and
+// kill statement rely on this interface.
+type SessionManager interface {
+	// ShowProcessList returns map[connectionID]ProcessInfo
+	ShowProcessList() map[uint64]*ProcessInfo
+	// GetProcessInfo returns the ProcessInfo of the given connection.
+	GetProcessInfo(id uint64) (*ProcessInfo, bool)
+	// Kill kills the query running on the connection, or the connection
+	// itself if query is false.
+	Kill(connectionID uint64, query bool)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // conditionChecker checks if this condition can be pushed to index planner.
@@ -56,7 +57,11 @@ func (c *conditionChecker) checkScalarFunction(scalar *expression.ScalarFunction
 	case ast.IsNull:
 		return c.checkColumn(scalar.GetArgs()[0])
 	case ast.UnaryNot:
-		if _, ok := scalar.GetArgs()[0].(*expression.ScalarFunction); ok {
+		if s, ok := scalar.GetArgs()[0].(*expression.ScalarFunction); ok {
+			// "not like" can't lead to a range.
+			if s.FuncName.L == ast.Like {
+				return false
+			}
 			return c.check(scalar.GetArgs()[0])
 		}
 		// "not column" or "not constant" can't lead to a range.
@@ -71,10 +76,50 @@ func (c *conditionChecker) checkScalarFunction(scalar *expression.ScalarFunction
 			}
 		}
 		return true
+	case ast.Like:
+		return c.checkLikeFunc(scalar)
 	}
 	return false
 }
 
+// checkLikeFunc checks if the like function is "column like 'prefix...'", the range
+// of which is decided by the literal prefix of the pattern. The condition should be
+// reserved as a filter if the pattern has wildcards other than a single trailing '%'.
+func (c *conditionChecker) checkLikeFunc(scalar *expression.ScalarFunction) bool {
+	args := scalar.GetArgs()
+	if !c.checkColumn(args[0]) || args[0].GetType().EvalType() != types.ETString {
+		return false
+	}
+	// The index is sorted by bytes, so it can't serve the case-insensitive matching.
+	if expression.IsPatternMatchCaseInsensitive(args) {
+		return false
+	}
+	pattern, ok := args[1].(*expression.Constant)
+	if !ok || pattern.Value.IsNull() {
+		return false
+	}
+	escape, ok := args[2].(*expression.Constant)
+	if !ok || escape.Value.IsNull() {
+		return false
+	}
+	patternStr, err := pattern.Value.ToString()
+	if err != nil {
+		return false
+	}
+	patChars, patTypes := stringutil.CompilePattern(patternStr, byte(escape.Value.GetInt64()))
+	prefix, isExact, oneFollows := stringutil.GetLikePatternPrefix(patChars, patTypes)
+	if isExact {
+		return true
+	}
+	if len(prefix) == 0 {
+		return false
+	}
+	if oneFollows || len(patTypes) != len(prefix)+1 {
+		c.shouldReserve = true
+	}
+	return true
+}
+
 func (c *conditionChecker) checkColumn(expr expression.Expression) bool {
 	col, ok := expr.(*expression.Column)
 	if !ok {
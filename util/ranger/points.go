@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // Error instances.
@@ -407,6 +408,50 @@ func (r *builder) buildFromIn(expr *expression.ScalarFunction) ([]point, bool) {
 	return rangePoints[:curPos], hasNull
 }
 
+// buildFromPatternLike builds the range from the literal prefix of the like pattern,
+// e.g. "a like 'abc%'" is converted to the range ["abc", "abd").
+func (r *builder) buildFromPatternLike(expr *expression.ScalarFunction) []point {
+	pdt, err := expr.GetArgs()[1].(*expression.Constant).Eval(chunk.Row{})
+	if err != nil {
+		r.err = errors.Trace(err)
+		return fullRange
+	}
+	pattern, err := pdt.ToString()
+	if err != nil {
+		r.err = errors.Trace(err)
+		return fullRange
+	}
+	edt, err := expr.GetArgs()[2].(*expression.Constant).Eval(chunk.Row{})
+	if err != nil {
+		r.err = errors.Trace(err)
+		return fullRange
+	}
+	prefix, isExact, oneFollows := stringutil.GetLikePatternPrefix(stringutil.CompilePattern(pattern, byte(edt.GetInt64())))
+	if isExact {
+		val := types.NewStringDatum(string(prefix))
+		return []point{{value: val, start: true}, {value: val}}
+	}
+	if len(prefix) == 0 {
+		return []point{{value: types.MinNotNullDatum(), start: true}, {value: types.MaxValueDatum()}}
+	}
+	// The matched strings are longer than the prefix if it's followed by '_',
+	// so the prefix itself can be excluded.
+	startPoint := point{value: types.NewStringDatum(string(prefix)), start: true, excl: oneFollows}
+	endPoint := point{value: types.MaxValueDatum(), excl: true}
+	// The end point is the smallest string which is larger than all the strings
+	// with the prefix, e.g. "abd" for "abc" and "ac" for "ab\xff".
+	highValue := make([]byte, len(prefix))
+	copy(highValue, prefix)
+	for i := len(highValue) - 1; i >= 0; i-- {
+		highValue[i]++
+		if highValue[i] != 0 {
+			endPoint.value = types.NewStringDatum(string(highValue[:i+1]))
+			break
+		}
+	}
+	return []point{startPoint, endPoint}
+}
+
 func (r *builder) buildFromNot(expr *expression.ScalarFunction) []point {
 	switch n := expr.FuncName.L; n {
 	case ast.In:
@@ -456,7 +501,7 @@ func (r *builder) buildFromScalarFunc(expr *expression.ScalarFunction) []point {
 	case ast.LogicAnd:
 		return r.intersection(r.build(expr.GetArgs()[0]), r.build(expr.GetArgs()[1]))
 	case ast.LogicOr:
-		return r.union(r.build(expr.GetArgs()[0]), r.build(expr.GetArgs()[1]))
+		return r.buildFromDNF(expr)
 	case ast.In:
 		retPoints, _ := r.buildFromIn(expr)
 		return retPoints
@@ -464,6 +509,8 @@ func (r *builder) buildFromScalarFunc(expr *expression.ScalarFunction) []point {
 		startPoint := point{start: true}
 		endPoint := point{}
 		return []point{startPoint, endPoint}
+	case ast.Like:
+		return r.buildFromPatternLike(expr)
 	case ast.UnaryNot:
 		return r.buildFromNot(expr.GetArgs()[0].(*expression.ScalarFunction))
 	}
@@ -471,12 +518,29 @@ func (r *builder) buildFromScalarFunc(expr *expression.ScalarFunction) []point {
 	return nil
 }
 
-func (r *builder) intersection(a, b []point) []point {
-	return r.merge(a, b, false)
+// buildFromDNF builds the points of all the flattened disjuncts at once, so that a long
+// chain like "a = 1 or a = 2 or ..." is sorted only once instead of being merged pairwise.
+func (r *builder) buildFromDNF(expr *expression.ScalarFunction) []point {
+	items := expression.FlattenDNFConditions(expr)
+	rangePoints := make([]point, 0, 2*len(items))
+	for _, item := range items {
+		rangePoints = append(rangePoints, r.build(item)...)
+	}
+	sorter := pointSorter{points: rangePoints, sc: r.sc}
+	sort.Sort(&sorter)
+	if sorter.err != nil {
+		r.err = sorter.err
+		return nil
+	}
+	return pickInRangePoints(rangePoints, 1)
 }
 
-func (r *builder) union(a, b []point) []point {
-	return r.merge(a, b, true)
+func (r *builder) intersection(a, b []point) []point {
+	mergedPoints := r.mergeSorted(a, b)
+	if r.err != nil {
+		return nil
+	}
+	return pickInRangePoints(mergedPoints, 2)
 }
 
 func (r *builder) mergeSorted(a, b []point) []point {
@@ -504,21 +568,10 @@ func (r *builder) mergeSorted(a, b []point) []point {
 	return ret
 }
 
-func (r *builder) merge(a, b []point, union bool) []point {
-	mergedPoints := r.mergeSorted(a, b)
-	if r.err != nil {
-		return nil
-	}
-
-	var (
-		inRangeCount         int
-		requiredInRangeCount int
-	)
-	if union {
-		requiredInRangeCount = 1
-	} else {
-		requiredInRangeCount = 2
-	}
+// pickInRangePoints picks the points where the number of overlapped ranges reaches or leaves
+// requiredInRangeCount from the sorted points, the picked points are stored in place.
+func pickInRangePoints(mergedPoints []point, requiredInRangeCount int) []point {
+	inRangeCount := 0
 	curTail := 0
 	for _, val := range mergedPoints {
 		if val.start {
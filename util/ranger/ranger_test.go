@@ -237,6 +237,24 @@ func (s *testRangerSuite) TestTableRange(c *C) {
 			filterConds: "[]",
 			resultStr:   "[[1,1] [2,2] [3,3] [4,4]]",
 		},
+		{
+			exprStr:     "a = 9 or a = 7 or a in (3, 1) or a = 5 or a = 7 or a = 2 or a in (8, 6, 4)",
+			accessConds: "[or(or(eq(test.t.a, 9), or(eq(test.t.a, 7), in(test.t.a, 3, 1))), or(or(eq(test.t.a, 5), eq(test.t.a, 7)), or(eq(test.t.a, 2), in(test.t.a, 8, 6, 4))))]",
+			filterConds: "[]",
+			resultStr:   "[[1,1] [2,2] [3,3] [4,4] [5,5] [6,6] [7,7] [8,8] [9,9]]",
+		},
+		{
+			exprStr:     "a between 1 and 3 or a in (2, 5) or a > 4 or a = -1",
+			accessConds: "[or(or(and(ge(test.t.a, 1), le(test.t.a, 3)), in(test.t.a, 2, 5)), or(gt(test.t.a, 4), eq(test.t.a, -1)))]",
+			filterConds: "[]",
+			resultStr:   "[[-1,-1] [1,3] (4,+inf]]",
+		},
+		{
+			exprStr:     "a is true",
+			accessConds: "[]",
+			filterConds: "[istrue(test.t.a)]",
+			resultStr:   "[[-inf,+inf]]",
+		},
 		{
 			exprStr:     "a not in (1, 2, 3)",
 			accessConds: "[not(in(test.t.a, 1, 2, 3))]",
@@ -460,6 +478,104 @@ func (s *testRangerSuite) TestIndexRange(c *C) {
 			filterConds: "[or(lt(test.t.d, 你好), gt(test.t.d, 你好))]",
 			resultStr:   "[[-inf,\"你好\") (\"你好\",+inf]]",
 		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc%'`,
+			accessConds: "[like(test.t.a, abc%, 92)]",
+			filterConds: "[]",
+			resultStr:   "[[\"abc\",\"abd\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc_'`,
+			accessConds: "[like(test.t.a, abc_, 92)]",
+			filterConds: "[like(test.t.a, abc_, 92)]",
+			resultStr:   "[(\"abc\",\"abd\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc'`,
+			accessConds: "[eq(test.t.a, abc)]",
+			filterConds: "[]",
+			resultStr:   "[[\"abc\",\"abc\"]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc%d'`,
+			accessConds: "[like(test.t.a, abc%d, 92)]",
+			filterConds: "[like(test.t.a, abc%d, 92)]",
+			resultStr:   "[[\"abc\",\"abd\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like '%abc'`,
+			accessConds: "[]",
+			filterConds: "[like(test.t.a, %abc, 92)]",
+			resultStr:   "[[NULL,+inf]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like '_abc'`,
+			accessConds: "[]",
+			filterConds: "[like(test.t.a, _abc, 92)]",
+			resultStr:   "[[NULL,+inf]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'a\\%c%'`,
+			accessConds: "[like(test.t.a, a\\%c%, 92)]",
+			filterConds: "[]",
+			resultStr:   "[[\"a%c\",\"a%d\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'a|%c%' escape '|'`,
+			accessConds: "[like(test.t.a, a|%c%, 124)]",
+			filterConds: "[]",
+			resultStr:   "[[\"a%c\",\"a%d\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like ''`,
+			accessConds: "[eq(test.t.a, )]",
+			filterConds: "[]",
+			resultStr:   "[[\"\",\"\"]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a not like 'abc%'`,
+			accessConds: "[]",
+			filterConds: "[not(like(test.t.a, abc%, 92))]",
+			resultStr:   "[[NULL,+inf]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc%' or a like 'abd%'`,
+			accessConds: "[or(like(test.t.a, abc%, 92), like(test.t.a, abd%, 92))]",
+			filterConds: "[]",
+			resultStr:   "[[\"abc\",\"abe\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc%' and a > 'abcd'`,
+			accessConds: "[like(test.t.a, abc%, 92) gt(test.t.a, abcd)]",
+			filterConds: "[]",
+			resultStr:   "[(\"abcd\",\"abd\")]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a like 'abc%' and b > 1`,
+			accessConds: "[like(test.t.a, abc%, 92)]",
+			filterConds: "[gt(test.t.b, 1)]",
+			resultStr:   "[[\"abc\",\"abd\")]",
+		},
+		{
+			indexPos:    2,
+			exprStr:     `d like 'abc%'`,
+			accessConds: "[like(test.t.d, abc%, 92)]",
+			filterConds: "[like(test.t.d, abc%, 92)]",
+			resultStr:   "[[\"ab\",\"ab\"]]",
+		},
 	}
 
 	ctx := context.Background()
@@ -43,7 +43,6 @@ func SetupSignalHandler(shudownFunc func(bool)) {
 
 	closeSignalChan := make(chan os.Signal, 1)
 	signal.Notify(closeSignalChan,
-		syscall.SIGHUP,
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
@@ -54,3 +53,16 @@ func SetupSignalHandler(shudownFunc func(bool)) {
 		shudownFunc(sig == syscall.SIGQUIT)
 	}()
 }
+
+// SetupReloadHandler calls reloadFunc every time the process gets SIGHUP.
+func SetupReloadHandler(reloadFunc func()) {
+	reloadSignalChan := make(chan os.Signal, 1)
+	signal.Notify(reloadSignalChan, syscall.SIGHUP)
+
+	go func() {
+		for sig := range reloadSignalChan {
+			logutil.BgLogger().Info("got signal to reload", zap.Stringer("signal", sig))
+			reloadFunc()
+		}
+	}()
+}
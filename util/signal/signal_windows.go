@@ -39,3 +39,7 @@ func SetupSignalHandler(shudownFunc func(bool)) {
 		shudownFunc(sig == syscall.SIGQUIT)
 	}()
 }
+
+// SetupReloadHandler does nothing on windows, which has no SIGHUP.
+func SetupReloadHandler(reloadFunc func()) {
+}
@@ -282,6 +282,18 @@ func IsExactMatch(patTypes []byte) bool {
 	return true
 }
 
+// GetLikePatternPrefix returns the literal prefix before the first wildcard of the
+// compiled like pattern. isExact is true if the pattern contains no wildcard, and
+// oneFollows is true if the prefix is followed by the wildcard '_'.
+func GetLikePatternPrefix(patChars, patTypes []byte) (prefix []byte, isExact, oneFollows bool) {
+	for i, pt := range patTypes {
+		if pt != patMatch {
+			return patChars[:i], false, pt == patOne
+		}
+	}
+	return patChars, true, false
+}
+
 // Copy deep copies a string.
 func Copy(src string) string {
 	return string(hack.Slice(src))
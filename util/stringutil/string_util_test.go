@@ -181,6 +181,34 @@ func (s *testStringUtilSuite) TestIsExactMatch(c *C) {
 	}
 }
 
+func (s *testStringUtilSuite) TestGetLikePatternPrefix(c *C) {
+	defer testleak.AfterTest(c)()
+	tbl := []struct {
+		pattern    string
+		escape     byte
+		prefix     string
+		isExact    bool
+		oneFollows bool
+	}{
+		{``, '\\', ``, true, false},
+		{`%`, '\\', ``, false, false},
+		{`_a`, '\\', ``, false, true},
+		{`abc`, '\\', `abc`, true, false},
+		{`abc%`, '\\', `abc`, false, false},
+		{`abc%d_`, '\\', `abc`, false, false},
+		{`abc_`, '\\', `abc`, false, true},
+		{`a\%c%`, '\\', `a%c`, false, false},
+		{`a\bc%`, '\\', `a\bc`, false, false},
+		{`a|_c_`, '|', `a_c`, false, true},
+	}
+	for _, v := range tbl {
+		prefix, isExact, oneFollows := GetLikePatternPrefix(CompilePattern(v.pattern, v.escape))
+		c.Assert(string(prefix), Equals, v.prefix, Commentf("%v", v))
+		c.Assert(isExact, Equals, v.isExact, Commentf("%v", v))
+		c.Assert(oneFollows, Equals, v.oneFollows, Commentf("%v", v))
+	}
+}
+
 func BenchmarkMatchSpecial(b *testing.B) {
 	var (
 		pattern = `a%a%a%a%a%a%a%a%b`